@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/DeadlyParkour777/pr-service/internal/config"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+func runBackfillIssues(args []string) error {
+	flags := flag.NewFlagSet("backfill-issues", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return errors.New("usage: server backfill-issues")
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := store.NewStore(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	services := service.NewService(service.Dependencies{
+		TeamRepo:         store.Team(),
+		UserRepo:         store.User(),
+		PRRepo:           store.PR(),
+		IssueKeyPatterns: cfg.IssueKeyPatterns,
+	})
+
+	linked, err := services.PR.BackfillIssueKeys(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Linked %d issue keys.\n", linked)
+	return nil
+}
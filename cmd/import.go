@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/config"
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print the plan without applying it")
+	format := flags.String("format", "", "input format: yaml or csv (detected from the file extension by default)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("usage: server import [-dry-run] [-format yaml|csv] <file>")
+	}
+	path := flags.Arg(0)
+
+	importFormat := model.ImportFormat(*format)
+	if importFormat == "" {
+		importFormat = model.ImportFormatYAML
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			importFormat = model.ImportFormatCSV
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	org, err := service.ParseOrgImport(file, importFormat)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := store.NewStore(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	importService := service.NewImportService(store.Team(), store.User())
+	plan, err := importService.Import(context.Background(), *org, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	printImportPlan(os.Stdout, *plan, *dryRun)
+	return nil
+}
+
+func printImportPlan(w io.Writer, plan model.ImportPlan, dryRun bool) {
+	if plan.IsEmpty() {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+
+	for _, name := range plan.TeamsToCreate {
+		fmt.Fprintf(w, "+ team %s\n", name)
+	}
+	for _, c := range plan.UsersToAdd {
+		fmt.Fprintf(w, "+ user %s (%s) -> %s\n", c.User.ID, c.User.Username, c.TeamName)
+	}
+	for _, c := range plan.UsersToMove {
+		from := c.FromTeamName
+		if from == "" {
+			from = "(no team)"
+		}
+		fmt.Fprintf(w, "~ user %s (%s): %s -> %s\n", c.User.ID, c.User.Username, from, c.TeamName)
+	}
+	for _, c := range plan.UsersToUpdate {
+		fmt.Fprintf(w, "~ user %s (%s) updated, active=%t\n", c.User.ID, c.User.Username, c.User.IsActive)
+	}
+	for _, c := range plan.UsersToDeactivate {
+		fmt.Fprintf(w, "- user %s (%s) deactivated in %s\n", c.User.ID, c.User.Username, c.TeamName)
+	}
+
+	if dryRun {
+		fmt.Fprintln(w, "Dry run: nothing was applied.")
+	} else {
+		fmt.Fprintln(w, "Applied.")
+	}
+}
@@ -8,15 +8,37 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/DeadlyParkour777/pr-service/internal/config"
+	"github.com/DeadlyParkour777/pr-service/internal/github"
 	"github.com/DeadlyParkour777/pr-service/internal/handler"
+	"github.com/DeadlyParkour777/pr-service/internal/ical"
+	"github.com/DeadlyParkour777/pr-service/internal/jira"
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/netguard"
+	"github.com/DeadlyParkour777/pr-service/internal/notify"
+	"github.com/DeadlyParkour777/pr-service/internal/oidc"
+	"github.com/DeadlyParkour777/pr-service/internal/outbox"
 	"github.com/DeadlyParkour777/pr-service/internal/service"
 	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/internal/webhook"
 )
 
 func main() {
-	if err := run(); err != nil {
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "import":
+		err = runImport(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "seed-admin":
+		err = runSeedAdmin(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "backfill-issues":
+		err = runBackfillIssues(os.Args[2:])
+	default:
+		err = run()
+	}
+
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -34,14 +56,127 @@ func run() error {
 	defer store.Close()
 
 	deps := service.Dependencies{
-		TeamRepo:  store.Team(),
-		UserRepo:  store.User(),
-		PRRepo:    store.PR(),
-		StatsRepo: store.PR(),
+		TeamRepo:   store.Team(),
+		UserRepo:   store.User(),
+		PRRepo:     store.PR(),
+		StatsRepo:  store.PR(),
+		APIKeyRepo: store.APIKey(),
+		TokenRepo:  store.Token(),
+
+		IdentityRepo:        store.Identity(),
+		WebhookDeliveryRepo: store.WebhookDelivery(),
+
+		SubscriptionRepo: store.Subscription(),
+		WebhookSender:    webhook.NewSender(netguard.NewClient(10 * time.Second)),
+
+		OutboxRepo: store.Outbox(),
+
+		NotificationRepo: store.Notification(),
+		Notifiers: []service.Notifier{
+			notify.NewSlackNotifier(netguard.NewClient(10 * time.Second)),
+			notify.NewWebhookNotifier(netguard.NewClient(10 * time.Second)),
+		},
+		DigestRepo: store.Digest(),
+
+		CalendarRepo:         store.Calendar(),
+		CalendarFetcher:      ical.NewFetcher(netguard.NewClient(30 * time.Second)),
+		CalendarSyncInterval: cfg.CalendarSyncInterval,
+
+		IssueKeyPatterns: cfg.IssueKeyPatterns,
+
+		SigningKeyRepo:      store.SigningKey(cfg.JWTKeyEncryptionKey),
+		JWTAlgorithm:        cfg.JWTAlgorithm,
+		KeyRotationInterval: cfg.JWTKeyRotation,
+	}
+
+	if cfg.OutboxLogEvents {
+		deps.EventSinks = append(deps.EventSinks, outbox.NewLogSink(nil))
+	}
+
+	if cfg.SMTPHost != "" {
+		deps.Notifiers = append(deps.Notifiers, notify.NewEmailNotifier(notify.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}))
+	}
+
+	if cfg.GitHubToken != "" {
+		deps.CodeHostClient = github.NewClient(cfg.GitHubAPIURL, cfg.GitHubToken, &http.Client{Timeout: 10 * time.Second})
+		deps.ReviewerSyncRepo = store.ReviewerSync()
+		deps.ReconcileInterval = cfg.ReviewerReconcilePeriod
+	}
+
+	if cfg.JiraBaseURL != "" {
+		deps.IssueTracker = jira.NewClient(jira.Config{
+			BaseURL:         cfg.JiraBaseURL,
+			Email:           cfg.JiraEmail,
+			Token:           cfg.JiraAPIToken,
+			MergeTransition: cfg.JiraMergeTransition,
+		}, &http.Client{Timeout: 10 * time.Second})
+		deps.IssueTrackerRepo = store.IssueTracker()
+	}
+
+	if cfg.OIDCIssuerURL != "" {
+		discoveryCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		provider, err := oidc.NewProvider(discoveryCtx, oidc.Config{
+			IssuerURL:    cfg.OIDCIssuerURL,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       cfg.OIDCScopes,
+		}, &http.Client{Timeout: 10 * time.Second})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		deps.OIDCProvider = provider
+		deps.OIDCStateRepo = store.OIDCState()
+		deps.OIDC = service.OIDCSettings{
+			UserIDClaim:   cfg.OIDCUserIDClaim,
+			UsernameClaim: cfg.OIDCUsernameClaim,
+			DefaultTeam:   cfg.OIDCDefaultTeam,
+		}
 	}
 
 	service := service.NewService(deps)
-	handler := handler.NewHandler(service, cfg.JWTSecret, cfg.OpenAPISpecPath, store)
+
+	if cfg.AdminUserID != "" && cfg.AdminPassword != "" {
+		admin := model.User{ID: cfg.AdminUserID, Username: cfg.AdminUsername, IsActive: true}
+		if admin.Username == "" {
+			admin.Username = admin.ID
+		}
+		seeded, err := service.Auth.SeedAdmin(context.Background(), admin, cfg.AdminPassword)
+		if err != nil {
+			return err
+		}
+		if seeded {
+			log.Printf("Admin user %s seeded", admin.ID)
+		}
+	}
+	if err := service.Keys.Rotate(context.Background()); err != nil {
+		return err
+	}
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go service.Keys.RunRotation(backgroundCtx)
+	go service.Outbox.Run(backgroundCtx)
+	go service.Subscriptions.Run(backgroundCtx)
+	go service.Notifications.Run(backgroundCtx)
+	go service.Digests.Run(backgroundCtx)
+	go service.Calendars.Run(backgroundCtx)
+	if service.ReviewerSync != nil {
+		go service.ReviewerSync.Run(backgroundCtx)
+	}
+	if service.IssueTracker != nil {
+		go service.IssueTracker.Run(backgroundCtx)
+	}
+
+	handler := handler.NewHandler(service, cfg.JWTSecret, cfg.SCIMToken, handler.WebhookSecrets{GitHub: cfg.GitHubWebhookSecret, GitLab: cfg.GitLabWebhookToken, Slack: cfg.SlackSigningSecret}, cfg.OpenAPISpecPath, store)
 	router := handler.InitRoutes()
 
 	server := &http.Server{
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/config"
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+func runSeedAdmin(args []string) error {
+	flags := flag.NewFlagSet("seed-admin", flag.ContinueOnError)
+	userID := flags.String("user-id", "", "admin user id")
+	username := flags.String("username", "", "admin username (defaults to the user id)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *userID == "" || flags.NArg() != 0 {
+		return errors.New("usage: server seed-admin -user-id <id> [-username <name>] < password")
+	}
+
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return fmt.Errorf("failed to read password from stdin: %w", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := store.NewStore(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	admin := model.User{ID: *userID, Username: *username, IsActive: true}
+	if admin.Username == "" {
+		admin.Username = admin.ID
+	}
+
+	authService := service.NewAuthService(store.User())
+	seeded, err := authService.SeedAdmin(context.Background(), admin, password)
+	if err != nil {
+		return err
+	}
+
+	if !seeded {
+		fmt.Printf("User %s already has a password, nothing to do.\n", admin.ID)
+		return nil
+	}
+
+	fmt.Printf("Admin user %s seeded.\n", admin.ID)
+	return nil
+}
@@ -1,15 +1,62 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
 )
 
 type Config struct {
-	HTTP_PORT       string
-	DatabaseURL     string
-	JWTSecret       string
-	OpenAPISpecPath string
+	HTTP_PORT           string
+	DatabaseURL         string
+	JWTSecret           string
+	JWTAlgorithm        model.SigningAlgorithm
+	JWTKeyRotation      time.Duration
+	JWTKeyEncryptionKey []byte
+	SCIMToken           string
+	AdminUserID         string
+	AdminUsername       string
+	AdminPassword       string
+	OpenAPISpecPath     string
+
+	OIDCIssuerURL     string
+	OIDCClientID      string
+	OIDCClientSecret  string
+	OIDCRedirectURL   string
+	OIDCScopes        []string
+	OIDCUserIDClaim   string
+	OIDCUsernameClaim string
+	OIDCDefaultTeam   string
+
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+	SlackSigningSecret  string
+
+	GitHubToken             string
+	GitHubAPIURL            string
+	ReviewerReconcilePeriod time.Duration
+
+	OutboxLogEvents bool
+
+	CalendarSyncInterval time.Duration
+
+	IssueKeyPatterns    []*regexp.Regexp
+	JiraBaseURL         string
+	JiraEmail           string
+	JiraAPIToken        string
+	JiraMergeTransition string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
 }
 
 func NewConfig() (*Config, error) {
@@ -40,21 +87,158 @@ func NewConfig() (*Config, error) {
 	}
 	dbURL := DatabaseConnString(dbUser, dbPassword, dbHost, dbPort, dbName)
 
+	jwtAlgorithm := model.SigningAlgorithm(os.Getenv("JWT_ALGORITHM"))
+	if jwtAlgorithm == "" {
+		jwtAlgorithm = model.SigningHS256
+	}
+	if !jwtAlgorithm.IsValid() {
+		return nil, fmt.Errorf("JWT_ALGORITHM must be one of HS256, RS256, EdDSA")
+	}
+
 	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
+	if jwtSecret == "" && !jwtAlgorithm.IsAsymmetric() {
 		return nil, fmt.Errorf("JWT_SECRET environment variable is not set")
 	}
 
+	var jwtKeyRotation time.Duration
+	if raw := os.Getenv("JWT_KEY_ROTATION_INTERVAL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("JWT_KEY_ROTATION_INTERVAL must be a positive duration")
+		}
+		jwtKeyRotation = parsed
+	}
+
+	var jwtKeyEncryptionKey []byte
+	if raw := os.Getenv("JWT_KEY_ENCRYPTION_KEY"); raw != "" {
+		decoded, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil || len(decoded) != 32 {
+			return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY must be 32 random bytes encoded in base64")
+		}
+		jwtKeyEncryptionKey = decoded
+	} else if jwtAlgorithm.IsAsymmetric() {
+		return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY environment variable is not set")
+	}
+
 	specPath := os.Getenv("OPENAPI_SPEC_PATH")
 	if specPath == "" {
 		specPath = "./docs/openapi.yml"
 	}
 
+	oidcIssuer := os.Getenv("OIDC_ISSUER_URL")
+	oidcClientID := os.Getenv("OIDC_CLIENT_ID")
+	oidcRedirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if oidcIssuer != "" && (oidcClientID == "" || oidcRedirectURL == "") {
+		return nil, fmt.Errorf("OIDC_CLIENT_ID and OIDC_REDIRECT_URL must be set when OIDC_ISSUER_URL is set")
+	}
+
+	oidcScopes := strings.Fields(os.Getenv("OIDC_SCOPES"))
+	if len(oidcScopes) == 0 {
+		oidcScopes = []string{"openid", "profile", "email"}
+	}
+
+	var reconcilePeriod time.Duration
+	if raw := os.Getenv("REVIEWER_SYNC_RECONCILE_INTERVAL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("REVIEWER_SYNC_RECONCILE_INTERVAL must be a positive duration")
+		}
+		reconcilePeriod = parsed
+	}
+
+	var outboxLogEvents bool
+	if raw := os.Getenv("OUTBOX_LOG_EVENTS"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("OUTBOX_LOG_EVENTS must be a boolean")
+		}
+		outboxLogEvents = parsed
+	}
+
+	var calendarSyncInterval time.Duration
+	if raw := os.Getenv("CALENDAR_SYNC_INTERVAL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("CALENDAR_SYNC_INTERVAL must be a positive duration")
+		}
+		calendarSyncInterval = parsed
+	}
+
+	var issueKeyPatterns []*regexp.Regexp
+	for _, raw := range strings.Fields(os.Getenv("ISSUE_KEY_PATTERNS")) {
+		pattern, err := regexp.Compile(raw)
+		if err != nil {
+			return nil, fmt.Errorf("ISSUE_KEY_PATTERNS contains an invalid pattern %q: %w", raw, err)
+		}
+		issueKeyPatterns = append(issueKeyPatterns, pattern)
+	}
+
+	jiraBaseURL := os.Getenv("JIRA_BASE_URL")
+	jiraAPIToken := os.Getenv("JIRA_API_TOKEN")
+	if jiraBaseURL != "" && jiraAPIToken == "" {
+		return nil, fmt.Errorf("JIRA_API_TOKEN must be set when JIRA_BASE_URL is set")
+	}
+
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpFrom := os.Getenv("SMTP_FROM")
+	if smtpHost != "" && smtpFrom == "" {
+		return nil, fmt.Errorf("SMTP_FROM must be set when SMTP_HOST is set")
+	}
+
+	smtpPort := 587
+	if raw := os.Getenv("SMTP_PORT"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > 65535 {
+			return nil, fmt.Errorf("SMTP_PORT must be a valid port number")
+		}
+		smtpPort = parsed
+	}
+
 	return &Config{
-		HTTP_PORT:       port,
-		DatabaseURL:     dbURL,
-		JWTSecret:       jwtSecret,
-		OpenAPISpecPath: specPath,
+		HTTP_PORT:           port,
+		DatabaseURL:         dbURL,
+		JWTSecret:           jwtSecret,
+		JWTAlgorithm:        jwtAlgorithm,
+		JWTKeyRotation:      jwtKeyRotation,
+		JWTKeyEncryptionKey: jwtKeyEncryptionKey,
+		SCIMToken:           os.Getenv("SCIM_TOKEN"),
+		AdminUserID:         os.Getenv("ADMIN_USER_ID"),
+		AdminUsername:       os.Getenv("ADMIN_USERNAME"),
+		AdminPassword:       os.Getenv("ADMIN_PASSWORD"),
+		OpenAPISpecPath:     specPath,
+
+		OIDCIssuerURL:     oidcIssuer,
+		OIDCClientID:      oidcClientID,
+		OIDCClientSecret:  os.Getenv("OIDC_CLIENT_SECRET"),
+		OIDCRedirectURL:   oidcRedirectURL,
+		OIDCScopes:        oidcScopes,
+		OIDCUserIDClaim:   os.Getenv("OIDC_USER_ID_CLAIM"),
+		OIDCUsernameClaim: os.Getenv("OIDC_USERNAME_CLAIM"),
+		OIDCDefaultTeam:   os.Getenv("OIDC_DEFAULT_TEAM"),
+
+		GitHubWebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+		GitLabWebhookToken:  os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+		SlackSigningSecret:  os.Getenv("SLACK_SIGNING_SECRET"),
+
+		GitHubToken:             os.Getenv("GITHUB_TOKEN"),
+		GitHubAPIURL:            os.Getenv("GITHUB_API_URL"),
+		ReviewerReconcilePeriod: reconcilePeriod,
+
+		OutboxLogEvents: outboxLogEvents,
+
+		CalendarSyncInterval: calendarSyncInterval,
+
+		IssueKeyPatterns:    issueKeyPatterns,
+		JiraBaseURL:         jiraBaseURL,
+		JiraEmail:           os.Getenv("JIRA_EMAIL"),
+		JiraAPIToken:        jiraAPIToken,
+		JiraMergeTransition: os.Getenv("JIRA_MERGE_TRANSITION"),
+
+		SMTPHost:     smtpHost,
+		SMTPPort:     smtpPort,
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:     smtpFrom,
 	}, nil
 }
 
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+const (
+	DefaultBaseURL   = "https://api.github.com"
+	apiVersion       = "2022-11-28"
+	maxResponseBytes = 1 << 20
+)
+
+var repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+var (
+	ErrInvalidPullRequestID = errors.New("pull request id is not in owner/repo#number form")
+	ErrRequestFailed        = errors.New("github api request failed")
+)
+
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewClient(baseURL, token string, client *http.Client) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  client,
+	}
+}
+
+func (c *Client) Provider() model.IdentityProvider {
+	return model.ProviderGitHub
+}
+
+func (c *Client) Handles(prID string) bool {
+	_, err := reviewersPath(prID)
+	return err == nil
+}
+
+func (c *Client) RequestedReviewers(ctx context.Context, prID string) ([]string, error) {
+	path, err := reviewersPath(prID)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Users []struct {
+			Login string `json:"login"`
+		} `json:"users"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &body); err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, len(body.Users))
+	for i, user := range body.Users {
+		logins[i] = user.Login
+	}
+
+	return logins, nil
+}
+
+func (c *Client) RequestReviewers(ctx context.Context, prID string, logins []string) error {
+	path, err := reviewersPath(prID)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPost, path, map[string][]string{"reviewers": logins}, nil)
+}
+
+func (c *Client) RemoveReviewers(ctx context.Context, prID string, logins []string) error {
+	path, err := reviewersPath(prID)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, map[string][]string{"reviewers": logins}, nil)
+}
+
+func reviewersPath(prID string) (string, error) {
+	repo, number, ok := strings.Cut(prID, "#")
+	if !ok {
+		return "", ErrInvalidPullRequestID
+	}
+
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || !validRepoName(owner) || !validRepoName(name) {
+		return "", ErrInvalidPullRequestID
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return "", ErrInvalidPullRequestID
+	}
+
+	return fmt.Sprintf("/repos/%s/%s/pulls/%d/requested_reviewers", url.PathEscape(owner), url.PathEscape(name), n), nil
+}
+
+func validRepoName(name string) bool {
+	return name != "." && name != ".." && repoNamePattern.MatchString(name)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	reader := http.MaxBytesReader(nil, resp.Body, maxResponseBytes)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(reader).Decode(&apiErr)
+		return fmt.Errorf("%w: %s %s: status %d %s", ErrRequestFailed, method, path, resp.StatusCode, apiErr.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(reader).Decode(out); err != nil {
+		return fmt.Errorf("%w: invalid response: %v", ErrRequestFailed, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	Method    string
+	Path      string
+	Auth      string
+	Reviewers []string
+}
+
+type fakeGitHub struct {
+	mu        sync.Mutex
+	requested []string
+	requests  []recordedRequest
+	failures  int
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body struct {
+		Reviewers []string `json:"reviewers"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	f.requests = append(f.requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Auth: r.Header.Get("Authorization"), Reviewers: body.Reviewers})
+
+	if f.failures > 0 {
+		f.failures--
+		w.WriteHeader(http.StatusBadGateway)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "Server Error"})
+		return
+	}
+
+	if r.URL.Path != "/repos/acme/api/pulls/42/requested_reviewers" {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		f.requested = append(f.requested, body.Reviewers...)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"number": 42})
+		return
+	case http.MethodDelete:
+		kept := f.requested[:0]
+		for _, login := range f.requested {
+			if !contains(body.Reviewers, login) {
+				kept = append(kept, login)
+			}
+		}
+		f.requested = kept
+	}
+
+	users := make([]map[string]string, len(f.requested))
+	for i, login := range f.requested {
+		users[i] = map[string]string{"login": login}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"users": users, "teams": []any{}})
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestClient_RequestedReviewersRoundTrip(t *testing.T) {
+	fake := &fakeGitHub{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+	ctx := context.Background()
+
+	require.NoError(t, client.RequestReviewers(ctx, "acme/api#42", []string{"bob", "carol"}))
+	require.NoError(t, client.RemoveReviewers(ctx, "acme/api#42", []string{"bob"}))
+
+	logins, err := client.RequestedReviewers(ctx, "acme/api#42")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol"}, logins)
+
+	require.Len(t, fake.requests, 3)
+	assert.Equal(t, recordedRequest{Method: "POST", Path: "/repos/acme/api/pulls/42/requested_reviewers", Auth: "Bearer test-token", Reviewers: []string{"bob", "carol"}}, fake.requests[0])
+	assert.Equal(t, "DELETE", fake.requests[1].Method)
+	assert.Equal(t, []string{"bob"}, fake.requests[1].Reviewers)
+}
+
+func TestClient_ReportsAPIErrors(t *testing.T) {
+	fake := &fakeGitHub{failures: 1}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewClient(server.URL, "", server.Client())
+
+	err := client.RequestReviewers(context.Background(), "acme/api#42", []string{"bob"})
+	assert.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "502")
+
+	_, err = client.RequestedReviewers(context.Background(), "acme/other#1")
+	assert.ErrorIs(t, err, ErrRequestFailed)
+}
+
+func TestClient_Handles(t *testing.T) {
+	client := NewClient("", "", nil)
+
+	assert.True(t, client.Handles("acme/api#42"))
+	assert.False(t, client.Handles("pr-1001"))
+	assert.False(t, client.Handles("acme/billing!7"))
+	assert.False(t, client.Handles("acme#1"))
+	assert.False(t, client.Handles("acme/api#0"))
+	assert.False(t, client.Handles("acme/../../user#1"))
+	assert.False(t, client.Handles("../api#1"))
+	assert.False(t, client.Handles("acme/..#1"))
+	assert.False(t, client.Handles("acme/api?x=1#1"))
+	assert.False(t, client.Handles("acme/a%2fb#1"))
+	assert.True(t, client.Handles("acme-corp/api.v2_x#1"))
+
+	assert.ErrorIs(t, client.RequestReviewers(context.Background(), "pr-1001", []string{"bob"}), ErrInvalidPullRequestID)
+}
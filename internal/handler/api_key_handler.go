@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+func (h *Handler) issueAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req IssueAPIKeyRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	scopes := make([]model.Scope, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = model.Scope(scope)
+	}
+
+	issued, err := h.apiKeyService.Issue(r.Context(), req.UserID, req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, IssuedAPIKeyResponse{
+		APIKeyResponse: ConvertAPIKeyModelToDTO(issued.Key),
+		Key:            issued.Secret,
+	})
+}
+
+func (h *Handler) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyService.List(r.Context(), r.URL.Query().Get("user_id"))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		resp[i] = ConvertAPIKeyModelToDTO(key)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"api_keys": resp})
+}
+
+func (h *Handler) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req RevokeAPIKeyRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	key, err := h.apiKeyService.Revoke(r.Context(), req.KeyID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"api_key": ConvertAPIKeyModelToDTO(*key)})
+}
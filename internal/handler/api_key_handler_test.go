@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getAs(t *testing.T, token, path string) int {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", testServerURL+path, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+func TestAPIKey_E2E_IssueUseAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.User().Create(ctx, model.User{ID: "ci-bot", Username: "CI", IsActive: true})
+	require.NoError(t, err)
+
+	jwtToken := getTestTokenWithRole(t, "ci-bot", model.RoleBot)
+
+	payload, err := json.Marshal(IssueAPIKeyRequest{Name: "pipeline", Scopes: []string{"stats:read"}})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", testServerURL+"/apiKeys/issue", bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+jwtToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var issued IssuedAPIKeyResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&issued))
+	assert.Equal(t, "ci-bot", issued.UserID)
+	assert.Equal(t, []string{"stats:read"}, issued.Scopes)
+	require.NotEmpty(t, issued.Key)
+
+	assert.Equal(t, http.StatusOK, getAs(t, issued.Key, "/stats/user"))
+	assert.Equal(t, http.StatusUnauthorized, getAs(t, model.APIKeyPrefix+"bogus", "/stats/user"))
+
+	status, errResp := postAs(t, issued.Key, "/pullRequest/create", map[string]string{
+		"pull_request_id": "pr-1", "pull_request_name": "x", "author_id": "ci-bot",
+	})
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "INSUFFICIENT_SCOPE", errResp.Error.Code)
+
+	status, errResp = postAs(t, issued.Key, "/apiKeys/issue", IssueAPIKeyRequest{Name: "escalate", Scopes: []string{"pr:write"}})
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	status, _ = postAs(t, jwtToken, "/apiKeys/revoke", RevokeAPIKeyRequest{KeyID: issued.KeyID})
+	assert.Equal(t, http.StatusOK, status)
+
+	assert.Equal(t, http.StatusUnauthorized, getAs(t, issued.Key, "/stats/user"))
+}
+
+func TestAPIKey_E2E_InvalidScope(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	status, errResp := postAs(t, getTestToken(t, "admin"), "/apiKeys/issue", IssueAPIKeyRequest{
+		UserID: "admin", Name: "ci", Scopes: []string{"everything"},
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_SCOPE", errResp.Error.Code)
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+const maxCalendarSize = 10 << 20
+
+func (h *Handler) createCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	var req CreateCalendarFeedRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	feed, err := h.calendarService.Create(r.Context(), model.CalendarFeed{
+		UserID:     req.UserID,
+		TeamName:   req.TeamName,
+		URL:        req.URL,
+		Categories: req.Categories,
+	})
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, map[string]any{"feed": ConvertCalendarFeedModelToDTO(*feed)})
+}
+
+func (h *Handler) listCalendarFeeds(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	feeds, err := h.calendarService.List(r.Context(), model.CalendarFeedFilter{
+		UserID:   query.Get("user_id"),
+		TeamName: query.Get("team_name"),
+	})
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]CalendarFeedResponse, len(feeds))
+	for i, feed := range feeds {
+		resp[i] = ConvertCalendarFeedModelToDTO(feed)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"feeds": resp})
+}
+
+func (h *Handler) uploadCalendar(w http.ResponseWriter, r *http.Request) {
+	feedID, err := strconv.ParseInt(r.URL.Query().Get("feed_id"), 10, 64)
+	if err != nil || feedID <= 0 {
+		h.writeBadRequest(w, r, "invalid query parameter: feed_id")
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCalendarSize))
+	if err != nil {
+		h.writeBadRequest(w, r, "calendar is too large")
+		return
+	}
+
+	feed, err := h.calendarService.Upload(r.Context(), feedID, data)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"feed": ConvertCalendarFeedModelToDTO(*feed)})
+}
+
+func (h *Handler) syncCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	var req CalendarFeedIDRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	feed, err := h.calendarService.Sync(r.Context(), req.FeedID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"feed": ConvertCalendarFeedModelToDTO(*feed)})
+}
+
+func (h *Handler) deleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	var req CalendarFeedIDRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.calendarService.Delete(r.Context(), req.FeedID); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listAbsences(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var bounds [2]time.Time
+	for i, name := range []string{"from", "to"} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			h.writeBadRequest(w, r, "invalid query parameter: "+name)
+			return
+		}
+		bounds[i] = parsed
+	}
+
+	absences, err := h.calendarService.ListAbsences(r.Context(), query.Get("user_id"), bounds[0], bounds[1])
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]AbsenceResponse, len(absences))
+	for i, absence := range absences {
+		resp[i] = ConvertAbsenceModelToDTO(absence)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"absences": resp})
+}
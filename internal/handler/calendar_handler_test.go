@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uploadCalendarAs(t *testing.T, token string, feedID int64, body string) (int, APIErrorResponse) {
+	t.Helper()
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/calendars/upload?feed_id=%d", testServerURL, feedID), strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "text/calendar")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var errResp APIErrorResponse
+	if resp.StatusCode >= http.StatusBadRequest {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	}
+
+	return resp.StatusCode, errResp
+}
+
+func TestCalendars_E2E_UploadedAbsencesSkipReviewers(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+		{ID: "carol", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, testStore.User().SetRole(ctx, "alice", model.RoleTeamLead, ""))
+
+	aliceToken := getTestTokenWithRole(t, "alice", model.RoleTeamLead)
+	bobToken := getTestTokenWithRole(t, "bob", model.RoleMember)
+
+	status, errResp := postAs(t, bobToken, "/calendars/create", CreateCalendarFeedRequest{TeamName: "backend"})
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	var created struct {
+		Feed CalendarFeedResponse `json:"feed"`
+	}
+	status = doJSONAs(t, aliceToken, "POST", "/calendars/create", CreateCalendarFeedRequest{TeamName: "backend", Categories: []string{"vacation"}}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "backend", created.Feed.TeamName)
+	assert.Equal(t, []string{"VACATION"}, created.Feed.Categories)
+	assert.Nil(t, created.Feed.NextSyncAt)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:bob-vacation",
+		"SUMMARY:Bob: vacation",
+		"CATEGORIES:VACATION",
+		"DTSTART;VALUE=DATE:" + today.AddDate(0, 0, -1).Format("20060102"),
+		"DTEND;VALUE=DATE:" + today.AddDate(0, 0, 2).Format("20060102"),
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:carol-offsite",
+		"SUMMARY:Carol: offsite",
+		"CATEGORIES:MEETING",
+		"DTSTART;VALUE=DATE:" + today.Format("20060102"),
+		"DTEND;VALUE=DATE:" + today.AddDate(0, 0, 1).Format("20060102"),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	status, errResp = uploadCalendarAs(t, aliceToken, created.Feed.FeedID, "not a calendar")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_CALENDAR", errResp.Error.Code)
+
+	status, errResp = uploadCalendarAs(t, aliceToken, created.Feed.FeedID+1000, calendar)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
+
+	status, _ = uploadCalendarAs(t, aliceToken, created.Feed.FeedID, calendar)
+	require.Equal(t, http.StatusOK, status)
+
+	var absences struct {
+		Absences []AbsenceResponse `json:"absences"`
+	}
+	status = doJSONAs(t, bobToken, "GET", "/calendars/absences", nil, &absences)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, absences.Absences, 1)
+	assert.Equal(t, "Bob: vacation", absences.Absences[0].Summary)
+	assert.Equal(t, today.AddDate(0, 0, 2), absences.Absences[0].EndsAt.UTC())
+
+	status = doJSONAs(t, aliceToken, "GET", "/calendars/absences?user_id=carol", nil, &absences)
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, absences.Absences, "events outside the feed categories are ignored")
+	assert.Equal(t, http.StatusForbidden, getAs(t, bobToken, "/calendars/absences?user_id=carol"))
+
+	assert.Equal(t, http.StatusBadRequest, getAs(t, bobToken, "/calendars/absences?from=yesterday"))
+
+	var pr struct {
+		PR PullRequestResponse `json:"pr"`
+	}
+	status = doJSONAs(t, aliceToken, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Search", AuthorID: "alice"}, &pr)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, []string{"carol"}, pr.PR.AssignedReviewers)
+
+	var feeds struct {
+		Feeds []CalendarFeedResponse `json:"feeds"`
+	}
+	status = doJSONAs(t, aliceToken, "GET", "/calendars/list?team_name=backend", nil, &feeds)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, feeds.Feeds, 1)
+	assert.Equal(t, 1, feeds.Feeds[0].AbsenceCount)
+	assert.NotNil(t, feeds.Feeds[0].LastSyncedAt)
+
+	status, _ = postAs(t, aliceToken, "/calendars/delete", CalendarFeedIDRequest{FeedID: created.Feed.FeedID})
+	require.Equal(t, http.StatusNoContent, status)
+
+	status = doJSONAs(t, aliceToken, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-2", PullRequestName: "Index", AuthorID: "alice"}, &pr)
+	require.Equal(t, http.StatusCreated, status)
+	assert.ElementsMatch(t, []string{"bob", "carol"}, pr.PR.AssignedReviewers)
+}
+
+func TestCalendars_E2E_SubscribedFeedSync(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	aliceToken := getTestTokenWithRole(t, "alice", model.RoleMember)
+
+	status, errResp := postAs(t, aliceToken, "/calendars/create", CreateCalendarFeedRequest{URL: "ftp://calendar.example.com/team.ics"})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_CALENDAR", errResp.Error.Code)
+
+	for _, internalURL := range []string{"http://127.0.0.1:1/alice.ics", "http://169.254.169.254/latest/meta-data", "webcal://localhost/alice.ics"} {
+		status, errResp = postAs(t, aliceToken, "/calendars/create", CreateCalendarFeedRequest{URL: internalURL})
+		assert.Equal(t, http.StatusBadRequest, status, internalURL)
+		assert.Equal(t, "INVALID_CALENDAR", errResp.Error.Code)
+	}
+
+	var created struct {
+		Feed CalendarFeedResponse `json:"feed"`
+	}
+	status = doJSONAs(t, aliceToken, "POST", "/calendars/create", CreateCalendarFeedRequest{URL: "http://calendar.invalid/alice.ics"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "alice", created.Feed.UserID)
+	assert.Contains(t, created.Feed.LastError, "calendar fetch failed")
+	assert.Nil(t, created.Feed.LastSyncedAt)
+	require.NotNil(t, created.Feed.NextSyncAt)
+
+	status, errResp = uploadCalendarAs(t, aliceToken, created.Feed.FeedID, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_CALENDAR", errResp.Error.Code)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+const slackRequestMaxAge = 5 * time.Minute
+
+func verifySlackSignature(secret string, body []byte, timestamp, header string, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > slackRequestMaxAge || age < -slackRequestMaxAge {
+		return false
+	}
+
+	signature, ok := strings.CutPrefix(header, "v0=")
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func (h *Handler) slackCommand(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		h.writeBadRequest(w, r, "invalid request body")
+		return
+	}
+
+	if !verifySlackSignature(h.webhooks.Slack, body, r.Header.Get("X-Slack-Request-Timestamp"), r.Header.Get("X-Slack-Signature"), time.Now()) {
+		http.Error(w, "Invalid request signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil || form.Get("user_id") == "" {
+		h.writeBadRequest(w, r, "invalid slash command")
+		return
+	}
+
+	resp, err := h.chatOpsService.Execute(r.Context(), model.ChatCommand{
+		Provider:   model.ProviderSlack,
+		ExternalID: form.Get("user_id"),
+		Text:       form.Get("text"),
+	})
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ConvertChatResponseModelToDTO(resp))
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendSlackCommand(t *testing.T, secret, slackUserID, text string, sentAt time.Time) (int, ChatResponse) {
+	t.Helper()
+
+	body := url.Values{"command": {"/pr"}, "user_id": {slackUserID}, "text": {text}}.Encode()
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req, err := http.NewRequest("POST", testServerURL+"/chatops/slack", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result ChatResponse
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	}
+
+	return resp.StatusCode, result
+}
+
+func TestChatOps_E2E_SlashCommands(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+		{ID: "carol", Username: "Carol", IsActive: true},
+		{ID: "dave", Username: "Dave", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	adminToken := getTestToken(t, "admin")
+	for userID, slackID := range map[string]string{"alice": "UALICE", "bob": "UBOB"} {
+		status, _ := postAs(t, adminToken, "/identities/link", LinkIdentityRequest{Provider: "slack", ExternalID: slackID, UserID: userID})
+		require.Equal(t, http.StatusOK, status)
+	}
+
+	var created struct {
+		PR PullRequestResponse `json:"pr"`
+	}
+	status := doJSONAs(t, getTestToken(t, "alice"), "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Search", AuthorID: "alice"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	reviewer := created.PR.AssignedReviewers[0]
+	require.NoError(t, testStore.Identity().Link(ctx, model.ExternalIdentity{Provider: model.ProviderSlack, ExternalID: "UREVIEWER", UserID: reviewer}))
+
+	status, resp := sendSlackCommand(t, testSlackSecret, "UREVIEWER", "reviews", time.Now())
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ephemeral", resp.ResponseType)
+	assert.Contains(t, resp.Text, "• *pr-1* Search by `alice`")
+
+	status, resp = sendSlackCommand(t, testSlackSecret, "UREVIEWER", "reassign pr-1 me", time.Now())
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "in_channel", resp.ResponseType)
+	assert.Contains(t, resp.Text, "`"+reviewer+"` was replaced by")
+
+	status, resp = sendSlackCommand(t, testSlackSecret, "UBOB", "vacation on", time.Now())
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, resp.Text, "Vacation mode is on")
+	bob, err := testStore.User().GetByID(ctx, "bob")
+	require.NoError(t, err)
+	assert.True(t, bob.IsActive, "vacation does not deactivate the account")
+	available, err := testStore.User().GetActiveTeamMembers(ctx, bob.TeamID, "")
+	require.NoError(t, err)
+	for _, member := range available {
+		assert.NotEqual(t, "bob", member.ID, "users on vacation are not picked as reviewers")
+	}
+
+	status, resp = sendSlackCommand(t, testSlackSecret, "UBOB", "merge pr-1", time.Now())
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "You are not allowed to do that.", resp.Text)
+
+	status, resp = sendSlackCommand(t, testSlackSecret, "UALICE", "merge pr-1", time.Now())
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "in_channel", resp.ResponseType)
+	assert.Equal(t, "*pr-1* Search was merged by `alice`.", resp.Text)
+
+	status, resp = sendSlackCommand(t, testSlackSecret, "UNKNOWN", "stats", time.Now())
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, resp.Text, "is not linked")
+}
+
+func TestChatOps_E2E_RejectsBadSignatures(t *testing.T) {
+	status, _ := sendSlackCommand(t, "wrong-secret", "UBOB", "reviews", time.Now())
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = sendSlackCommand(t, testSlackSecret, "UBOB", "reviews", time.Now().Add(-10*time.Minute))
+	assert.Equal(t, http.StatusUnauthorized, status, "stale timestamps are replays")
+
+	status, resp := sendSlackCommand(t, testSlackSecret, "UBOB", "help", time.Now())
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, resp.Text, "Available commands")
+}
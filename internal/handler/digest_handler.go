@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+func (h *Handler) previewDigest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	digest, err := h.digestService.Preview(r.Context(),
+		query.Get("user_id"),
+		model.DigestKind(strings.ToUpper(query.Get("kind"))),
+		model.DigestFormat(strings.ToUpper(query.Get("format"))),
+	)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"digest": ConvertRenderedDigestModelToDTO(*digest)})
+}
+
+func (h *Handler) listDigestSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.digestService.ListSubscriptions(r.Context(), r.URL.Query().Get("user_id"))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]DigestSubscriptionResponse, len(subs))
+	for i, sub := range subs {
+		resp[i] = ConvertDigestSubscriptionModelToDTO(sub)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"subscriptions": resp})
+}
+
+func (h *Handler) subscribeDigest(w http.ResponseWriter, r *http.Request) {
+	var req SubscribeDigestRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	sub, err := h.digestService.Subscribe(r.Context(), model.DigestSubscription{
+		UserID: req.UserID,
+		Kind:   model.DigestKind(req.Kind),
+		Format: model.DigestFormat(req.Format),
+	})
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"subscription": ConvertDigestSubscriptionModelToDTO(*sub)})
+}
+
+func (h *Handler) unsubscribeDigest(w http.ResponseWriter, r *http.Request) {
+	var req UnsubscribeDigestRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.digestService.Unsubscribe(r.Context(), req.UserID, model.DigestKind(req.Kind)); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigests_E2E_PreviewSubscribeAndSend(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	var mu sync.Mutex
+	var slackTexts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		slackTexts = append(slackTexts, payload["text"])
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slack.Close()
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+		{ID: "carol", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, testStore.User().SetRole(ctx, "alice", model.RoleTeamLead, ""))
+
+	aliceToken := getTestTokenWithRole(t, "alice", model.RoleTeamLead)
+	bobToken := getTestTokenWithRole(t, "bob", model.RoleMember)
+	adminToken := getTestToken(t, "admin")
+
+	status := doJSONAs(t, getTestToken(t, "alice"), "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Search", AuthorID: "alice"}, nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	var preview struct {
+		Digest DigestPreviewResponse `json:"digest"`
+	}
+	status = doJSONAs(t, bobToken, "GET", "/digests/preview", nil, &preview)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "MARKDOWN", preview.Digest.Format)
+	assert.Equal(t, "Review digest: 1 to review, 0 waiting", preview.Digest.Subject)
+	assert.Contains(t, preview.Digest.Body, "- **pr-1** Search by alice, open ")
+
+	status = doJSONAs(t, aliceToken, "GET", "/digests/preview?kind=daily&format=text", nil, &preview)
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, preview.Digest.Body, "waiting for bob, carol")
+
+	status = doJSONAs(t, adminToken, "GET", "/digests/preview?user_id=alice&kind=WEEKLY&format=HTML", nil, &preview)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "text/html; charset=UTF-8", preview.Digest.ContentType)
+	assert.Contains(t, preview.Digest.Body, "<h2>backend</h2>")
+	assert.Contains(t, preview.Digest.Body, "<li>Opened: 1, merged: 0, closed: 0</li>")
+
+	assert.Equal(t, http.StatusForbidden, getAs(t, bobToken, "/digests/preview?user_id=alice"))
+
+	status, errResp := postAs(t, bobToken, "/digests/subscribe", SubscribeDigestRequest{Kind: "WEEKLY"})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_DIGEST", errResp.Error.Code)
+
+	status, errResp = postAs(t, bobToken, "/digests/subscribe", SubscribeDigestRequest{Kind: "DAILY"})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_DIGEST", errResp.Error.Code)
+
+	status = doJSONAs(t, bobToken, "POST", "/notifications/preferences/set", SetNotificationPreferenceRequest{Channel: "SLACK", Address: publicTestURL(slack, "slack.test")}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	var subscribed struct {
+		Subscription DigestSubscriptionResponse `json:"subscription"`
+	}
+	status = doJSONAs(t, bobToken, "POST", "/digests/subscribe", SubscribeDigestRequest{Kind: "DAILY", Format: "TEXT"}, &subscribed)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "TEXT", subscribed.Subscription.Format)
+	assert.True(t, subscribed.Subscription.NextRunAt.After(time.Now()))
+
+	require.NoError(t, testStore.Digest().Retry(ctx, "bob", model.DigestDaily, time.Now().Add(-time.Minute), ""))
+	processed, err := testService.Digests.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	mu.Lock()
+	require.Len(t, slackTexts, 1)
+	assert.Contains(t, slackTexts[0], "*Review digest: 1 to review, 0 waiting*")
+	assert.Contains(t, slackTexts[0], `* pr-1 "Search" by alice`)
+	mu.Unlock()
+
+	var list struct {
+		Subscriptions []DigestSubscriptionResponse `json:"subscriptions"`
+	}
+	status = doJSONAs(t, bobToken, "GET", "/digests/list", nil, &list)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, list.Subscriptions, 1)
+	assert.NotNil(t, list.Subscriptions[0].LastSentAt)
+	assert.True(t, list.Subscriptions[0].NextRunAt.After(time.Now()))
+
+	status = doJSONAs(t, bobToken, "POST", "/digests/unsubscribe", UnsubscribeDigestRequest{Kind: "DAILY"}, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+	status = doJSONAs(t, bobToken, "POST", "/digests/unsubscribe", UnsubscribeDigestRequest{Kind: "DAILY"}, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
@@ -1,12 +1,73 @@
 package handler
 
-import "github.com/DeadlyParkour777/pr-service/internal/model"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
 
 type CreateTeamRequest struct {
 	TeamName string          `json:"team_name" validate:"required"`
 	Members  []TeamMemberDTO `json:"members" validate:"dive"`
 }
 
+type LoginRequest struct {
+	UserID   string `json:"user_id" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	KID string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
+
+type SetPasswordRequest struct {
+	UserID          string `json:"user_id" validate:"required"`
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password" validate:"required"`
+}
+
+type SetRoleRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	Role   string `json:"role" validate:"required,oneof=admin team_lead member bot"`
+}
+
+type IssueAPIKeyRequest struct {
+	UserID    string     `json:"user_id"`
+	Name      string     `json:"name" validate:"required,max=255"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type RevokeAPIKeyRequest struct {
+	KeyID int `json:"key_id" validate:"required"`
+}
+
 type SetIsActiveRequest struct {
 	UserID   string `json:"user_id" validate:"required"`
 	IsActive bool   `json:"is_active"`
@@ -16,6 +77,7 @@ type CreatePullRequestRequest struct {
 	PullRequestID   string `json:"pull_request_id" validate:"required"`
 	PullRequestName string `json:"pull_request_name" validate:"required"`
 	AuthorID        string `json:"author_id" validate:"required"`
+	TeamName        string `json:"team_name"`
 }
 
 type ReassignReviewerRequest struct {
@@ -27,6 +89,140 @@ type MergePullRequestRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required"`
 }
 
+type ClosePullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+}
+
+type ReopenPullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+	ReviewerID    string `json:"reviewer_id" validate:"required"`
+	Verdict       string `json:"verdict" validate:"required,oneof=APPROVED CHANGES_REQUESTED COMMENTED"`
+}
+
+type LinkIdentityRequest struct {
+	Provider   string `json:"provider" validate:"required"`
+	ExternalID string `json:"external_id" validate:"required"`
+	UserID     string `json:"user_id" validate:"required"`
+}
+
+type UnlinkIdentityRequest struct {
+	Provider   string `json:"provider" validate:"required"`
+	ExternalID string `json:"external_id" validate:"required"`
+}
+
+type CreateSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+}
+
+type DeleteSubscriptionRequest struct {
+	SubscriptionID int `json:"subscription_id" validate:"required"`
+}
+
+type RedeliverRequest struct {
+	DeliveryID int64 `json:"delivery_id" validate:"required"`
+}
+
+type SetNotificationPreferenceRequest struct {
+	UserID          string `json:"user_id"`
+	Channel         string `json:"channel" validate:"required,oneof=EMAIL SLACK WEBHOOK"`
+	Address         string `json:"address" validate:"required"`
+	Mode            string `json:"mode" validate:"omitempty,oneof=IMMEDIATE DIGEST"`
+	Enabled         *bool  `json:"enabled"`
+	Timezone        string `json:"timezone"`
+	QuietHoursStart string `json:"quiet_hours_start"`
+	QuietHoursEnd   string `json:"quiet_hours_end"`
+	DigestTime      string `json:"digest_time"`
+}
+
+type SetNotificationTemplateRequest struct {
+	Kind    string `json:"kind" validate:"required"`
+	Subject string `json:"subject" validate:"required"`
+	Body    string `json:"body" validate:"required"`
+}
+
+type ResetNotificationTemplateRequest struct {
+	Kind string `json:"kind" validate:"required"`
+}
+
+type SubscribeDigestRequest struct {
+	UserID string `json:"user_id"`
+	Kind   string `json:"kind" validate:"required,oneof=DAILY WEEKLY"`
+	Format string `json:"format" validate:"omitempty,oneof=MARKDOWN HTML TEXT"`
+}
+
+type UnsubscribeDigestRequest struct {
+	UserID string `json:"user_id"`
+	Kind   string `json:"kind" validate:"required,oneof=DAILY WEEKLY"`
+}
+
+type CreateCalendarFeedRequest struct {
+	UserID     string   `json:"user_id" validate:"excluded_with=TeamName"`
+	TeamName   string   `json:"team_name"`
+	URL        string   `json:"url" validate:"omitempty,url"`
+	Categories []string `json:"categories" validate:"max=20,dive,required,max=100"`
+}
+
+type CalendarFeedIDRequest struct {
+	FeedID int64 `json:"feed_id" validate:"required"`
+}
+
+type AddTeamMemberRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	UserID   string `json:"user_id" validate:"required"`
+	Username string `json:"username" validate:"required"`
+	IsActive bool   `json:"is_active"`
+}
+
+type RemoveTeamMemberRequest struct {
+	TeamName    string `json:"team_name" validate:"required"`
+	UserID      string `json:"user_id" validate:"required"`
+	OpenReviews string `json:"open_reviews" validate:"omitempty,oneof=keep reassign"`
+}
+
+type MoveTeamMemberRequest struct {
+	UserID      string `json:"user_id" validate:"required"`
+	TeamName    string `json:"team_name" validate:"required"`
+	OpenReviews string `json:"open_reviews" validate:"omitempty,oneof=keep reassign"`
+	AuthoredPRs string `json:"authored_prs" validate:"omitempty,oneof=keep transfer"`
+}
+
+type SetSecondaryMemberRequest struct {
+	TeamName   string `json:"team_name" validate:"required"`
+	UserID     string `json:"user_id" validate:"required"`
+	Reviewable bool   `json:"reviewable"`
+}
+
+type SetTeamParentRequest struct {
+	TeamName       string `json:"team_name" validate:"required"`
+	ParentTeamName string `json:"parent_team_name"`
+}
+
+type SetTeamPolicyRequest struct {
+	TeamName         string `json:"team_name" validate:"required"`
+	ReviewerCount    *int   `json:"reviewer_count" validate:"omitempty,min=0,max=10"`
+	FallbackToParent *bool  `json:"fallback_to_parent"`
+}
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name" validate:"required"`
+	NewTeamName string `json:"new_team_name" validate:"required"`
+}
+
+type ArchiveTeamRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+}
+
+type DeleteTeamRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	Force    bool   `json:"force"`
+}
+
 type TeamMemberDTO struct {
 	UserID   string `json:"user_id" validate:"required"`
 	Username string `json:"username" validate:"required"`
@@ -38,19 +234,122 @@ type TeamResponse struct {
 	Members  []TeamMemberDTO `json:"members"`
 }
 
+type AssignmentPolicyResponse struct {
+	ReviewerCount    int  `json:"reviewer_count"`
+	FallbackToParent bool `json:"fallback_to_parent"`
+}
+
+type PolicyOverridesResponse struct {
+	ReviewerCount    *int  `json:"reviewer_count,omitempty"`
+	FallbackToParent *bool `json:"fallback_to_parent,omitempty"`
+}
+
+type TeamDetailsResponse struct {
+	TeamResponse
+	Parents          []string                 `json:"parents"`
+	Children         []string                 `json:"children"`
+	AssignmentPolicy AssignmentPolicyResponse `json:"assignment_policy"`
+	PolicyOverrides  PolicyOverridesResponse  `json:"policy_overrides"`
+	ArchivedAt       *time.Time               `json:"archived_at,omitempty"`
+	CreatedBy        string                   `json:"created_by,omitempty"`
+}
+
+type TeamDeletionReportResponse struct {
+	TeamName         string   `json:"team_name"`
+	IsArchived       bool     `json:"is_archived"`
+	Members          []string `json:"members"`
+	PrimaryMembers   []string `json:"primary_members"`
+	OpenPullRequests []string `json:"open_pull_requests"`
+	PullRequestCount int      `json:"pull_request_count"`
+	ChildTeams       []string `json:"child_teams"`
+}
+
+type CreateTeamResponse struct {
+	Team           TeamResponse `json:"team"`
+	CreatedMembers []string     `json:"created_members"`
+	UpdatedMembers []string     `json:"updated_members"`
+}
+
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID        string `json:"user_id"`
+	Username      string `json:"username"`
+	TeamName      string `json:"team_name"`
+	IsActive      bool   `json:"is_active"`
+	DeactivatedBy string `json:"deactivated_by,omitempty"`
+}
+
+type UserDetailsResponse struct {
+	UserResponse
+	OpenReviewCount int `json:"open_review_count"`
+}
+
+type TeamSummaryResponse struct {
+	TeamName          string `json:"team_name"`
+	ParentTeamName    string `json:"parent_team_name,omitempty"`
+	MemberCount       int    `json:"member_count"`
+	ActiveMemberCount int    `json:"active_member_count"`
+	IsArchived        bool   `json:"is_archived"`
+}
+
+type TeamListResponse struct {
+	Teams      []TeamSummaryResponse `json:"teams"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+type UserListResponse struct {
+	Users      []UserResponse `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type ImportUserChangeDTO struct {
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+	IsActive     bool   `json:"is_active"`
+	TeamName     string `json:"team_name"`
+	FromTeamName string `json:"from_team_name,omitempty"`
+}
+
+type ImportPlanResponse struct {
+	DryRun            bool                  `json:"dry_run"`
+	Applied           bool                  `json:"applied"`
+	TeamsToCreate     []string              `json:"teams_to_create"`
+	UsersToAdd        []ImportUserChangeDTO `json:"users_to_add"`
+	UsersToMove       []ImportUserChangeDTO `json:"users_to_move"`
+	UsersToUpdate     []ImportUserChangeDTO `json:"users_to_update"`
+	UsersToDeactivate []ImportUserChangeDTO `json:"users_to_deactivate"`
+}
+
+type ReviewReassignmentDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+}
+
+type MembershipChangeResponse struct {
+	User                    UserResponse            `json:"user"`
+	ReassignedReviews       []ReviewReassignmentDTO `json:"reassigned_reviews"`
+	TransferredPullRequests []string                `json:"transferred_pull_requests"`
+}
+
+type TeamMembershipResponse struct {
+	TeamName   string `json:"team_name"`
+	IsPrimary  bool   `json:"is_primary"`
+	Reviewable bool   `json:"reviewable"`
 }
 
 type PullRequestResponse struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	AuthorID          string            `json:"author_id"`
+	TeamName          string            `json:"team_name,omitempty"`
+	Status            string            `json:"status"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	AssignedBy        map[string]string `json:"assigned_by,omitempty"`
+	CreatedBy         string            `json:"created_by,omitempty"`
+	MergedBy          string            `json:"merged_by,omitempty"`
+	ClosedBy          string            `json:"closed_by,omitempty"`
+	Verdicts          map[string]string `json:"verdicts,omitempty"`
+	IssueKeys         []string          `json:"issue_keys,omitempty"`
 }
 
 type PullRequestShortResponse struct {
@@ -60,6 +359,153 @@ type PullRequestShortResponse struct {
 	Status          string `json:"status"`
 }
 
+type IdentityResponse struct {
+	Provider   string `json:"provider"`
+	ExternalID string `json:"external_id"`
+	UserID     string `json:"user_id"`
+}
+
+type ChatResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+type WebhookResultResponse struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type SubscriptionResponse struct {
+	SubscriptionID int       `json:"subscription_id"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"event_types"`
+	CreatedBy      string    `json:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CreatedSubscriptionResponse struct {
+	SubscriptionResponse
+	Secret string `json:"secret"`
+}
+
+type DeliveryAttemptResponse struct {
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+type DeliveryResponse struct {
+	DeliveryID     int64                     `json:"delivery_id"`
+	SubscriptionID int                       `json:"subscription_id"`
+	Event          string                    `json:"event"`
+	Status         string                    `json:"status"`
+	Attempts       int                       `json:"attempts"`
+	LastError      string                    `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time                `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time                `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	Payload        json.RawMessage           `json:"payload"`
+	AttemptLog     []DeliveryAttemptResponse `json:"attempt_log"`
+}
+
+type NotificationPreferenceResponse struct {
+	UserID          string    `json:"user_id"`
+	Channel         string    `json:"channel"`
+	Address         string    `json:"address"`
+	Mode            string    `json:"mode"`
+	Enabled         bool      `json:"enabled"`
+	Timezone        string    `json:"timezone"`
+	QuietHoursStart string    `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   string    `json:"quiet_hours_end,omitempty"`
+	DigestTime      string    `json:"digest_time"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type NotificationTemplateResponse struct {
+	Kind      string     `json:"kind"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Custom    bool       `json:"custom"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type NotificationResponse struct {
+	NotificationID int64      `json:"notification_id"`
+	Kind           string     `json:"kind"`
+	PullRequestID  string     `json:"pull_request_id"`
+	Channel        string     `json:"channel"`
+	Digest         bool       `json:"digest"`
+	Subject        string     `json:"subject"`
+	Body           string     `json:"body"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliverAfter   *time.Time `json:"deliver_after,omitempty"`
+	SentAt         *time.Time `json:"sent_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type DigestSubscriptionResponse struct {
+	UserID     string     `json:"user_id"`
+	Kind       string     `json:"kind"`
+	Format     string     `json:"format"`
+	NextRunAt  time.Time  `json:"next_run_at"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+}
+
+type DigestPreviewResponse struct {
+	UserID      string    `json:"user_id"`
+	Kind        string    `json:"kind"`
+	Format      string    `json:"format"`
+	ContentType string    `json:"content_type"`
+	Subject     string    `json:"subject"`
+	Body        string    `json:"body"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+type CalendarFeedResponse struct {
+	FeedID       int64      `json:"feed_id"`
+	UserID       string     `json:"user_id,omitempty"`
+	TeamName     string     `json:"team_name,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	Categories   []string   `json:"categories"`
+	CreatedBy    string     `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	NextSyncAt   *time.Time `json:"next_sync_at,omitempty"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	AbsenceCount int        `json:"absence_count"`
+}
+
+type AbsenceResponse struct {
+	FeedID   int64     `json:"feed_id"`
+	UserID   string    `json:"user_id"`
+	UID      string    `json:"uid"`
+	Summary  string    `json:"summary"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+type APIKeyResponse struct {
+	KeyID      int        `json:"key_id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type IssuedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
 func ConvertCreateTeamDTOToModels(dto CreateTeamRequest) (model.Team, []model.User) {
 	teamModel := model.Team{
 		Name: dto.TeamName,
@@ -93,22 +539,155 @@ func ConvertTeamModelsToDTO(team model.Team, members []model.User) TeamResponse
 	}
 }
 
+func ConvertTeamDetailsToDTO(team model.Team, members []model.User, hierarchy model.TeamHierarchy) TeamDetailsResponse {
+	parents := make([]string, len(hierarchy.Ancestors))
+	for i, t := range hierarchy.Ancestors {
+		parents[i] = t.Name
+	}
+
+	children := make([]string, len(hierarchy.Children))
+	for i, t := range hierarchy.Children {
+		children[i] = t.Name
+	}
+
+	return TeamDetailsResponse{
+		TeamResponse: ConvertTeamModelsToDTO(team, members),
+		Parents:      parents,
+		Children:     children,
+		AssignmentPolicy: AssignmentPolicyResponse{
+			ReviewerCount:    hierarchy.Policy.ReviewerCount,
+			FallbackToParent: hierarchy.Policy.FallbackToParent,
+		},
+		PolicyOverrides: PolicyOverridesResponse{
+			ReviewerCount:    team.Policy.ReviewerCount,
+			FallbackToParent: team.Policy.FallbackToParent,
+		},
+		ArchivedAt: team.ArchivedAt,
+		CreatedBy:  team.CreatedBy,
+	}
+}
+
+func ConvertDeletionReportToDTO(report model.TeamDeletionReport) TeamDeletionReportResponse {
+	return TeamDeletionReportResponse{
+		TeamName:         report.Team.Name,
+		IsArchived:       report.Team.ArchivedAt != nil,
+		Members:          report.MemberIDs,
+		PrimaryMembers:   report.PrimaryMemberIDs,
+		OpenPullRequests: report.OpenPullRequestIDs,
+		PullRequestCount: report.PullRequestCount,
+		ChildTeams:       report.ChildTeams,
+	}
+}
+
+func ConvertTeamUpsertToDTO(result model.TeamUpsert) CreateTeamResponse {
+	members := make([]model.User, 0, len(result.Created)+len(result.Updated))
+	createdIDs := make([]string, len(result.Created))
+	for i, m := range result.Created {
+		members = append(members, m)
+		createdIDs[i] = m.ID
+	}
+
+	updatedIDs := make([]string, len(result.Updated))
+	for i, m := range result.Updated {
+		members = append(members, m)
+		updatedIDs[i] = m.ID
+	}
+
+	return CreateTeamResponse{
+		Team:           ConvertTeamModelsToDTO(result.Team, members),
+		CreatedMembers: createdIDs,
+		UpdatedMembers: updatedIDs,
+	}
+}
+
 func ConvertFullUserModelToDTO(user model.FullUserInfo) UserResponse {
 	return UserResponse{
-		UserID:   user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		UserID:        user.ID,
+		Username:      user.Username,
+		TeamName:      user.TeamName,
+		IsActive:      user.IsActive,
+		DeactivatedBy: user.DeactivatedBy,
+	}
+}
+
+func ConvertTeamSummaryToDTO(summary model.TeamSummary) TeamSummaryResponse {
+	return TeamSummaryResponse{
+		TeamName:          summary.Team.Name,
+		ParentTeamName:    summary.ParentName,
+		MemberCount:       summary.MemberCount,
+		ActiveMemberCount: summary.ActiveMemberCount,
+		IsArchived:        summary.Team.ArchivedAt != nil,
+	}
+}
+
+func ConvertImportPlanToDTO(plan model.ImportPlan, dryRun bool) ImportPlanResponse {
+	convert := func(changes []model.ImportUserChange) []ImportUserChangeDTO {
+		dtos := make([]ImportUserChangeDTO, len(changes))
+		for i, c := range changes {
+			dtos[i] = ImportUserChangeDTO{
+				UserID:       c.User.ID,
+				Username:     c.User.Username,
+				IsActive:     c.User.IsActive,
+				TeamName:     c.TeamName,
+				FromTeamName: c.FromTeamName,
+			}
+		}
+		return dtos
+	}
+
+	teamsToCreate := plan.TeamsToCreate
+	if teamsToCreate == nil {
+		teamsToCreate = []string{}
+	}
+
+	return ImportPlanResponse{
+		DryRun:            dryRun,
+		Applied:           !dryRun && !plan.IsEmpty(),
+		TeamsToCreate:     teamsToCreate,
+		UsersToAdd:        convert(plan.UsersToAdd),
+		UsersToMove:       convert(plan.UsersToMove),
+		UsersToUpdate:     convert(plan.UsersToUpdate),
+		UsersToDeactivate: convert(plan.UsersToDeactivate),
 	}
 }
 
 func ConvertPRModelToDTO(pr model.PullRequest) PullRequestResponse {
+	var verdicts map[string]string
+	if len(pr.Verdicts) > 0 {
+		verdicts = make(map[string]string, len(pr.Verdicts))
+		for reviewerID, verdict := range pr.Verdicts {
+			verdicts[reviewerID] = string(verdict)
+		}
+	}
+
 	return PullRequestResponse{
 		PullRequestID:     pr.ID,
 		PullRequestName:   pr.Name,
 		AuthorID:          pr.AuthorID,
+		TeamName:          pr.TeamName,
 		Status:            string(pr.Status),
 		AssignedReviewers: pr.AssignedReviewers,
+		AssignedBy:        pr.AssignedBy,
+		CreatedBy:         pr.CreatedBy,
+		MergedBy:          pr.MergedBy,
+		ClosedBy:          pr.ClosedBy,
+		Verdicts:          verdicts,
+		IssueKeys:         pr.IssueKeys,
+	}
+}
+
+func ConvertChatResponseModelToDTO(resp model.ChatResponse) ChatResponse {
+	return ChatResponse{
+		ResponseType: string(resp.ResponseType),
+		Text:         resp.Text,
+	}
+}
+
+func ConvertIdentityModelToDTO(identity model.ExternalIdentity) IdentityResponse {
+	return IdentityResponse{
+		Provider:   string(identity.Provider),
+		ExternalID: identity.ExternalID,
+		UserID:     identity.UserID,
 	}
 }
 
@@ -120,3 +699,206 @@ func ConvertPRModelToShortDTO(pr model.PullRequest) PullRequestShortResponse {
 		Status:          string(pr.Status),
 	}
 }
+
+func ConvertMembershipChangeToDTO(change model.MembershipChange) MembershipChangeResponse {
+	reassigned := make([]ReviewReassignmentDTO, len(change.Reassignments))
+	for i, r := range change.Reassignments {
+		reassigned[i] = ReviewReassignmentDTO{
+			PullRequestID: r.PullRequestID,
+			OldReviewerID: r.OldReviewerID,
+			NewReviewerID: r.NewReviewerID,
+		}
+	}
+
+	transferred := change.TransferredPRs
+	if transferred == nil {
+		transferred = []string{}
+	}
+
+	return MembershipChangeResponse{
+		User:                    ConvertFullUserModelToDTO(change.User),
+		ReassignedReviews:       reassigned,
+		TransferredPullRequests: transferred,
+	}
+}
+
+func ConvertMembershipModelToDTO(m model.TeamMembership) TeamMembershipResponse {
+	return TeamMembershipResponse{
+		TeamName:   m.TeamName,
+		IsPrimary:  m.IsPrimary,
+		Reviewable: m.Reviewable,
+	}
+}
+
+func ConvertAPIKeyModelToDTO(key model.APIKey) APIKeyResponse {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return APIKeyResponse{
+		KeyID:      key.ID,
+		UserID:     key.UserID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func ConvertSubscriptionModelToDTO(sub model.WebhookSubscription) SubscriptionResponse {
+	eventTypes := make([]string, len(sub.EventTypes))
+	for i, eventType := range sub.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return SubscriptionResponse{
+		SubscriptionID: sub.ID,
+		URL:            sub.URL,
+		EventTypes:     eventTypes,
+		CreatedBy:      sub.CreatedBy,
+		CreatedAt:      sub.CreatedAt,
+	}
+}
+
+func ConvertDeliveryModelToDTO(delivery model.SubscriptionDelivery) DeliveryResponse {
+	attempts := make([]DeliveryAttemptResponse, len(delivery.AttemptLog))
+	for i, attempt := range delivery.AttemptLog {
+		attempts[i] = DeliveryAttemptResponse{
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+			AttemptedAt: attempt.AttemptedAt,
+		}
+	}
+
+	resp := DeliveryResponse{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		Event:          string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        json.RawMessage(delivery.Payload),
+		AttemptLog:     attempts,
+	}
+	if delivery.Status == model.DeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		resp.NextAttemptAt = &nextAttemptAt
+	}
+
+	return resp
+}
+
+func ConvertNotificationPreferenceModelToDTO(pref model.NotificationPreference) NotificationPreferenceResponse {
+	return NotificationPreferenceResponse{
+		UserID:          pref.UserID,
+		Channel:         string(pref.Channel),
+		Address:         pref.Address,
+		Mode:            string(pref.Mode),
+		Enabled:         pref.Enabled,
+		Timezone:        pref.Timezone,
+		QuietHoursStart: pref.QuietHoursStart,
+		QuietHoursEnd:   pref.QuietHoursEnd,
+		DigestTime:      pref.DigestTime,
+		UpdatedAt:       pref.UpdatedAt,
+	}
+}
+
+func ConvertNotificationTemplateModelToDTO(tmpl model.NotificationTemplate) NotificationTemplateResponse {
+	return NotificationTemplateResponse{
+		Kind:      string(tmpl.Kind),
+		Subject:   tmpl.Subject,
+		Body:      tmpl.Body,
+		Custom:    tmpl.Custom,
+		UpdatedBy: tmpl.UpdatedBy,
+		UpdatedAt: tmpl.UpdatedAt,
+	}
+}
+
+func ConvertNotificationModelToDTO(n model.Notification) NotificationResponse {
+	resp := NotificationResponse{
+		NotificationID: n.ID,
+		Kind:           string(n.Kind),
+		PullRequestID:  n.PullRequestID,
+		Channel:        string(n.Channel),
+		Digest:         n.Digest,
+		Subject:        n.Subject,
+		Body:           n.Body,
+		Status:         string(n.Status),
+		Attempts:       n.Attempts,
+		LastError:      n.LastError,
+		SentAt:         n.SentAt,
+		CreatedAt:      n.CreatedAt,
+	}
+	if n.Status == model.NotificationPending {
+		deliverAfter := n.DeliverAfter
+		resp.DeliverAfter = &deliverAfter
+	}
+
+	return resp
+}
+
+func ConvertDigestSubscriptionModelToDTO(sub model.DigestSubscription) DigestSubscriptionResponse {
+	return DigestSubscriptionResponse{
+		UserID:     sub.UserID,
+		Kind:       string(sub.Kind),
+		Format:     string(sub.Format),
+		NextRunAt:  sub.NextRunAt,
+		LastSentAt: sub.LastSentAt,
+		LastError:  sub.LastError,
+	}
+}
+
+func ConvertCalendarFeedModelToDTO(feed model.CalendarFeed) CalendarFeedResponse {
+	resp := CalendarFeedResponse{
+		FeedID:       feed.ID,
+		UserID:       feed.UserID,
+		TeamName:     feed.TeamName,
+		URL:          feed.URL,
+		Categories:   feed.Categories,
+		CreatedBy:    feed.CreatedBy,
+		CreatedAt:    feed.CreatedAt,
+		LastSyncedAt: feed.LastSyncedAt,
+		LastError:    feed.LastError,
+		AbsenceCount: feed.AbsenceCount,
+	}
+
+	if resp.Categories == nil {
+		resp.Categories = []string{}
+	}
+
+	if !feed.IsUpload() {
+		resp.NextSyncAt = &feed.NextSyncAt
+	}
+
+	return resp
+}
+
+func ConvertAbsenceModelToDTO(absence model.Absence) AbsenceResponse {
+	return AbsenceResponse{
+		FeedID:   absence.FeedID,
+		UserID:   absence.UserID,
+		UID:      absence.UID,
+		Summary:  absence.Summary,
+		StartsAt: absence.StartsAt,
+		EndsAt:   absence.EndsAt,
+	}
+}
+
+func ConvertRenderedDigestModelToDTO(digest model.RenderedDigest) DigestPreviewResponse {
+	return DigestPreviewResponse{
+		UserID:      digest.UserID,
+		Kind:        string(digest.Kind),
+		Format:      string(digest.Format),
+		ContentType: digest.Format.ContentType(),
+		Subject:     digest.Subject,
+		Body:        digest.Body,
+		GeneratedAt: digest.GeneratedAt,
+	}
+}
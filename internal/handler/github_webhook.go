@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+const maxWebhookBodyBytes = 5 << 20
+
+type githubAccount struct {
+	Login string `json:"login"`
+}
+
+type githubPullRequest struct {
+	Number int           `json:"number"`
+	Title  string        `json:"title"`
+	Merged bool          `json:"merged"`
+	User   githubAccount `json:"user"`
+}
+
+type githubWebhookPayload struct {
+	Action      string            `json:"action"`
+	PullRequest githubPullRequest `json:"pull_request"`
+	Review      struct {
+		State string        `json:"state"`
+		User  githubAccount `json:"user"`
+	} `json:"review"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender githubAccount `json:"sender"`
+}
+
+var githubReviewVerdicts = map[string]model.Verdict{
+	"approved":          model.VerdictApproved,
+	"changes_requested": model.VerdictChangesRequested,
+	"commented":         model.VerdictCommented,
+}
+
+func verifyGitHubSignature(secret string, body []byte, header string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func githubPullRequestID(payload githubWebhookPayload) string {
+	return fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.PullRequest.Number)
+}
+
+func (h *Handler) githubWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		h.writeBadRequest(w, r, "invalid request body")
+		return
+	}
+
+	if !verifyGitHubSignature(h.webhooks.GitHub, body, r.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "Invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	deliveryID := r.Header.Get("X-GitHub-Delivery")
+	if deliveryID == "" {
+		h.writeBadRequest(w, r, "X-GitHub-Delivery header required")
+		return
+	}
+
+	eventType := r.Header.Get("X-GitHub-Event")
+	if eventType == "ping" {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, WebhookResultResponse{Status: "pong"})
+		return
+	}
+
+	var payload githubWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	event := model.CodeHostEvent{
+		Provider:         model.ProviderGitHub,
+		DeliveryID:       deliveryID,
+		PullRequestID:    githubPullRequestID(payload),
+		Title:            payload.PullRequest.Title,
+		AuthorExternalID: payload.PullRequest.User.Login,
+		ActorExternalID:  payload.Sender.Login,
+	}
+
+	switch {
+	case eventType == "pull_request" && payload.Action == "opened":
+		event.Action = model.CodeHostOpened
+	case eventType == "pull_request" && payload.Action == "closed" && payload.PullRequest.Merged:
+		event.Action = model.CodeHostMerged
+	case eventType == "pull_request" && payload.Action == "closed":
+		event.Action = model.CodeHostClosed
+	case eventType == "pull_request_review" && payload.Action == "submitted":
+		event.Action = model.CodeHostReviewed
+		event.ActorExternalID = payload.Review.User.Login
+		event.Verdict = githubReviewVerdicts[strings.ToLower(payload.Review.State)]
+	default:
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, WebhookResultResponse{Status: string(model.OutcomeIgnored), Reason: "unsupported event"})
+		return
+	}
+
+	h.handleCodeHostEvent(w, r, event)
+}
+
+func (h *Handler) handleCodeHostEvent(w http.ResponseWriter, r *http.Request, event model.CodeHostEvent) {
+	result, err := h.codeHostService.Handle(r.Context(), event)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, WebhookResultResponse{Status: string(result.Outcome), Reason: result.Reason})
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendGitHubWebhook(t *testing.T, secret, event, deliveryID string, payload map[string]any) (int, WebhookResultResponse) {
+	t.Helper()
+
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req, err := http.NewRequest("POST", testServerURL+"/webhooks/github", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", deliveryID)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result WebhookResultResponse
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	}
+
+	return resp.StatusCode, result
+}
+
+func githubPullRequestPayload(action string, merged bool, sender string) map[string]any {
+	return map[string]any{
+		"action": action,
+		"pull_request": map[string]any{
+			"number": 42,
+			"title":  "Add webhook ingestion",
+			"merged": merged,
+			"user":   map[string]any{"login": "alice-gh"},
+		},
+		"repository": map[string]any{"full_name": "acme/api"},
+		"sender":     map[string]any{"login": sender},
+	}
+}
+
+func setupGitHubWebhookTestData(ctx context.Context, t *testing.T) {
+	t.Helper()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+		{ID: "carol", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	adminToken := getTestToken(t, "admin")
+	for userID, login := range map[string]string{"alice": "alice-gh", "bob": "bob-gh"} {
+		status, _ := postAs(t, adminToken, "/identities/link", LinkIdentityRequest{Provider: "github", ExternalID: login, UserID: userID})
+		require.Equal(t, http.StatusOK, status)
+	}
+}
+
+func TestGitHubWebhook_E2E_PullRequestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	setupGitHubWebhookTestData(ctx, t)
+
+	status, result := sendGitHubWebhook(t, testGitHubSecret, "pull_request", "delivery-1", githubPullRequestPayload("opened", false, "alice-gh"))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "processed", result.Status)
+
+	pr, err := testStore.PR().GetByID(ctx, "acme/api#42")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", pr.AuthorID)
+	assert.Equal(t, "Add webhook ingestion", pr.Name)
+	assert.ElementsMatch(t, []string{"bob", "carol"}, pr.AssignedReviewers)
+
+	status, result = sendGitHubWebhook(t, testGitHubSecret, "pull_request", "delivery-1", githubPullRequestPayload("opened", false, "alice-gh"))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "duplicate", result.Status)
+
+	review := githubPullRequestPayload("submitted", false, "bob-gh")
+	review["review"] = map[string]any{"state": "approved", "user": map[string]any{"login": "bob-gh"}}
+	status, result = sendGitHubWebhook(t, testGitHubSecret, "pull_request_review", "delivery-2", review)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "processed", result.Status)
+
+	status, result = sendGitHubWebhook(t, testGitHubSecret, "pull_request", "delivery-3", githubPullRequestPayload("closed", true, "alice-gh"))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "processed", result.Status)
+
+	pr, err = testStore.PR().GetByID(ctx, "acme/api#42")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusMerged, pr.Status)
+	assert.Equal(t, "alice", pr.MergedBy)
+	assert.Equal(t, map[string]model.Verdict{"bob": model.VerdictApproved}, pr.Verdicts)
+}
+
+func TestGitHubWebhook_E2E_ClosedWithoutMerge(t *testing.T) {
+	ctx := context.Background()
+	setupGitHubWebhookTestData(ctx, t)
+
+	status, _ := sendGitHubWebhook(t, testGitHubSecret, "pull_request", "delivery-1", githubPullRequestPayload("opened", false, "alice-gh"))
+	require.Equal(t, http.StatusOK, status)
+
+	status, result := sendGitHubWebhook(t, testGitHubSecret, "pull_request", "delivery-2", githubPullRequestPayload("closed", false, "unlinked-gh"))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "processed", result.Status)
+
+	pr, err := testStore.PR().GetByID(ctx, "acme/api#42")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusClosed, pr.Status)
+	assert.Equal(t, "github", pr.ClosedBy)
+
+	status, errResp := postAs(t, getTestToken(t, "admin"), "/pullRequest/merge", MergePullRequestRequest{PullRequestID: "acme/api#42"})
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "PR_CLOSED", errResp.Error.Code)
+}
+
+func TestGitHubWebhook_E2E_RejectsBadSignatureAndIgnoresUnknown(t *testing.T) {
+	ctx := context.Background()
+	setupGitHubWebhookTestData(ctx, t)
+
+	status, _ := sendGitHubWebhook(t, "wrong-secret", "pull_request", "delivery-1", githubPullRequestPayload("opened", false, "alice-gh"))
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	_, err := testStore.PR().GetByID(ctx, "acme/api#42")
+	assert.Error(t, err)
+
+	status, result := sendGitHubWebhook(t, testGitHubSecret, "pull_request", "delivery-2", githubPullRequestPayload("labeled", false, "alice-gh"))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ignored", result.Status)
+
+	status, result = sendGitHubWebhook(t, testGitHubSecret, "ping", "delivery-3", map[string]any{"zen": "Keep it logically awesome."})
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "pong", result.Status)
+}
+
+func TestPullRequest_E2E_CloseAndReview(t *testing.T) {
+	ctx := context.Background()
+	setupGitHubWebhookTestData(ctx, t)
+
+	status, _ := postAs(t, getTestToken(t, "admin"), "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Manual", AuthorID: "alice"})
+	require.Equal(t, http.StatusCreated, status)
+
+	bobToken := getTestTokenWithRole(t, "bob", model.RoleMember)
+	status, errResp := postAs(t, bobToken, "/pullRequest/review", SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "carol", Verdict: "APPROVED"})
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	status, _ = postAs(t, bobToken, "/pullRequest/review", SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "bob", Verdict: "CHANGES_REQUESTED"})
+	assert.Equal(t, http.StatusOK, status)
+
+	status, errResp = postAs(t, bobToken, "/pullRequest/close", ClosePullRequestRequest{PullRequestID: "pr-1"})
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	status, _ = postAs(t, getTestTokenWithRole(t, "alice", model.RoleMember), "/pullRequest/close", ClosePullRequestRequest{PullRequestID: "pr-1"})
+	assert.Equal(t, http.StatusOK, status)
+
+	status, errResp = postAs(t, bobToken, "/pullRequest/review", SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "bob", Verdict: "APPROVED"})
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "PR_CLOSED", errResp.Error.Code)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+const gitlabMergeRequestEvent = "Merge Request Hook"
+
+type gitlabWebhookPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID int64 `json:"id"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID      int    `json:"iid"`
+		Title    string `json:"title"`
+		AuthorID int64  `json:"author_id"`
+		Action   string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+var gitlabMergeRequestActions = map[string]model.CodeHostAction{
+	"open":     model.CodeHostOpened,
+	"merge":    model.CodeHostMerged,
+	"close":    model.CodeHostClosed,
+	"reopen":   model.CodeHostReopened,
+	"approved": model.CodeHostReviewed,
+	"approval": model.CodeHostReviewed,
+}
+
+func gitlabPullRequestID(payload gitlabWebhookPayload) string {
+	return fmt.Sprintf("%s!%d", payload.Project.PathWithNamespace, payload.ObjectAttributes.IID)
+}
+
+func gitlabUserID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
+
+func (h *Handler) gitlabWebhook(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Gitlab-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.webhooks.GitLab)) != 1 {
+		http.Error(w, "Invalid webhook token", http.StatusUnauthorized)
+		return
+	}
+
+	deliveryID := r.Header.Get("X-Gitlab-Event-UUID")
+	if deliveryID == "" {
+		h.writeBadRequest(w, r, "X-Gitlab-Event-UUID header required")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		h.writeBadRequest(w, r, "invalid request body")
+		return
+	}
+
+	var payload gitlabWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	action, ok := gitlabMergeRequestActions[payload.ObjectAttributes.Action]
+	if r.Header.Get("X-Gitlab-Event") != gitlabMergeRequestEvent || payload.ObjectKind != "merge_request" || !ok {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, WebhookResultResponse{Status: string(model.OutcomeIgnored), Reason: "unsupported event"})
+		return
+	}
+
+	event := model.CodeHostEvent{
+		Provider:         model.ProviderGitLab,
+		DeliveryID:       deliveryID,
+		Action:           action,
+		PullRequestID:    gitlabPullRequestID(payload),
+		Title:            payload.ObjectAttributes.Title,
+		AuthorExternalID: gitlabUserID(payload.ObjectAttributes.AuthorID),
+		ActorExternalID:  gitlabUserID(payload.User.ID),
+	}
+	if action == model.CodeHostReviewed {
+		event.Verdict = model.VerdictApproved
+	}
+
+	h.handleCodeHostEvent(w, r, event)
+}
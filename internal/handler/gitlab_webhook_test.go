@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gitlabFixturePRID = "acme/billing!7"
+
+func sendGitLabFixture(t *testing.T, token, fixture, deliveryID string) (int, WebhookResultResponse) {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "gitlab", fixture))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", testServerURL+"/webhooks/gitlab", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Event-UUID", deliveryID)
+	req.Header.Set("X-Gitlab-Token", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result WebhookResultResponse
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	}
+
+	return resp.StatusCode, result
+}
+
+func setupGitLabWebhookTestData(ctx context.Context, t *testing.T) {
+	t.Helper()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "billing"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	adminToken := getTestToken(t, "admin")
+	for userID, gitlabID := range map[string]string{"alice": "101", "bob": "102"} {
+		status, _ := postAs(t, adminToken, "/identities/link", LinkIdentityRequest{Provider: "gitlab", ExternalID: gitlabID, UserID: userID})
+		require.Equal(t, http.StatusOK, status)
+	}
+}
+
+func TestGitLabWebhook_E2E_MergeRequestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	setupGitLabWebhookTestData(ctx, t)
+
+	steps := []struct {
+		fixture string
+		status  model.PRStatus
+	}{
+		{"merge_request_open.json", model.StatusOpen},
+		{"merge_request_approved.json", model.StatusOpen},
+		{"merge_request_close.json", model.StatusClosed},
+		{"merge_request_reopen.json", model.StatusOpen},
+		{"merge_request_merge.json", model.StatusMerged},
+	}
+
+	for i, step := range steps {
+		status, result := sendGitLabFixture(t, testGitLabToken, step.fixture, step.fixture)
+		require.Equal(t, http.StatusOK, status, step.fixture)
+		assert.Equal(t, "processed", result.Status, "%s: %s", step.fixture, result.Reason)
+
+		pr, err := testStore.PR().GetByID(ctx, gitlabFixturePRID)
+		require.NoError(t, err, step.fixture)
+		assert.Equal(t, step.status, pr.Status, step.fixture)
+
+		if i == 0 {
+			assert.Equal(t, "alice", pr.AuthorID)
+			assert.Equal(t, "Retry invoice export", pr.Name)
+			assert.Equal(t, []string{"bob"}, pr.AssignedReviewers)
+		}
+	}
+
+	pr, err := testStore.PR().GetByID(ctx, gitlabFixturePRID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]model.Verdict{"bob": model.VerdictApproved}, pr.Verdicts)
+	assert.Equal(t, "alice", pr.MergedBy)
+	assert.Empty(t, pr.ClosedBy)
+}
+
+func TestGitLabWebhook_E2E_RejectsBadTokenAndDuplicates(t *testing.T) {
+	ctx := context.Background()
+	setupGitLabWebhookTestData(ctx, t)
+
+	status, _ := sendGitLabFixture(t, "wrong-token", "merge_request_open.json", "delivery-1")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, result := sendGitLabFixture(t, testGitLabToken, "merge_request_open.json", "delivery-1")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "processed", result.Status)
+
+	status, result = sendGitLabFixture(t, testGitLabToken, "merge_request_open.json", "delivery-1")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "duplicate", result.Status)
+
+	status, result = sendGitLabFixture(t, testGitLabToken, "merge_request_open.json", "delivery-2")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ignored", result.Status)
+}
+
+func TestGitLabWebhook_E2E_UnlinkedAuthorIsIgnored(t *testing.T) {
+	ctx := context.Background()
+	setupGitLabWebhookTestData(ctx, t)
+
+	status, _ := postAs(t, getTestToken(t, "admin"), "/identities/unlink", UnlinkIdentityRequest{Provider: "gitlab", ExternalID: "101"})
+	require.Equal(t, http.StatusNoContent, status)
+
+	status, result := sendGitLabFixture(t, testGitLabToken, "merge_request_open.json", "delivery-1")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ignored", result.Status)
+
+	_, err := testStore.PR().GetByID(ctx, gitlabFixturePRID)
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/DeadlyParkour777/pr-service/internal/service"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
@@ -15,12 +16,9 @@ import (
 	swgui "github.com/swaggest/swgui/v4"
 )
 
-type contextKey string
-
-const userContextKey = contextKey("user")
-
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID string     `json:"user_id"`
+	Role   model.Role `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -31,29 +29,86 @@ type APIErrorResponse struct {
 	} `json:"error"`
 }
 
+type WebhookSecrets struct {
+	GitHub string
+	GitLab string
+	Slack  string
+}
+
 type Handler struct {
-	teamService  TeamService
-	userService  UserService
-	prService    PullRequestService
-	statsService StatsService
+	teamService         TeamService
+	userService         UserService
+	prService           PullRequestService
+	statsService        StatsService
+	membershipService   MembershipService
+	importService       ImportService
+	provisioningService ProvisioningService
+	authService         AuthService
+	apiKeyService       APIKeyService
+	tokenService        TokenService
+	keyService          KeyService
+	oidcService         OIDCService
+	identityService     IdentityService
+	codeHostService     CodeHostService
+	chatOpsService      ChatOpsService
+	subscriptionService SubscriptionService
+	notificationService NotificationService
+	digestService       DigestService
+	calendarService     CalendarService
 
 	validate        *validator.Validate
 	jwtSecret       []byte
+	scimToken       []byte
+	webhooks        WebhookSecrets
 	openAPISpecPath string
 	dbPinger        DBPinger
 }
 
-func NewHandler(s *service.Service, jwtSecret string, openAPISpecPath string, pinger DBPinger) *Handler {
-	return &Handler{
-		teamService:     s.Team,
-		userService:     s.User,
-		prService:       s.PR,
-		statsService:    s.Stats,
-		validate:        validator.New(),
-		jwtSecret:       []byte(jwtSecret),
-		openAPISpecPath: openAPISpecPath,
-		dbPinger:        pinger,
+func NewHandler(s *service.Service, jwtSecret, scimToken string, webhooks WebhookSecrets, openAPISpecPath string, pinger DBPinger) *Handler {
+	h := &Handler{
+		teamService:         s.Team,
+		userService:         s.User,
+		prService:           s.PR,
+		statsService:        s.Stats,
+		membershipService:   s.Membership,
+		importService:       s.Import,
+		provisioningService: s.Provisioning,
+		authService:         s.Auth,
+		apiKeyService:       s.APIKey,
+		tokenService:        s.Token,
+		keyService:          s.Keys,
+		identityService:     s.Identity,
+		codeHostService:     s.CodeHost,
+		chatOpsService:      s.ChatOps,
+		validate:            validator.New(),
+		jwtSecret:           []byte(jwtSecret),
+		scimToken:           []byte(scimToken),
+		webhooks:            webhooks,
+		openAPISpecPath:     openAPISpecPath,
+		dbPinger:            pinger,
+	}
+
+	if s.OIDC != nil {
+		h.oidcService = s.OIDC
+	}
+
+	if s.Subscriptions != nil {
+		h.subscriptionService = s.Subscriptions
+	}
+
+	if s.Notifications != nil {
+		h.notificationService = s.Notifications
 	}
+
+	if s.Digests != nil {
+		h.digestService = s.Digests
+	}
+
+	if s.Calendars != nil {
+		h.calendarService = s.Calendars
+	}
+
+	return h
 }
 
 func (h *Handler) InitRoutes() http.Handler {
@@ -65,6 +120,13 @@ func (h *Handler) InitRoutes() http.Handler {
 	router.Use(render.SetContentType(render.ContentTypeJSON))
 
 	router.Post("/login", h.loginHandler)
+	router.Post("/token/refresh", h.refreshToken)
+	router.Get("/.well-known/jwks.json", h.getJWKS)
+
+	if h.oidcService != nil {
+		router.Get("/oidc/login", h.oidcLogin)
+		router.Get("/oidc/callback", h.oidcCallback)
+	}
 	docsHandler := swgui.NewHandler("PR Service API", "/docs/openapi.yml", "/docs/")
 	router.Route("/docs", func(r chi.Router) {
 		r.Mount("/", docsHandler)
@@ -73,27 +135,150 @@ func (h *Handler) InitRoutes() http.Handler {
 
 	router.Get("/health", h.healthCheckHandler)
 
+	if len(h.scimToken) > 0 {
+		router.Route(scimBasePath, h.scimRoutes)
+	}
+
+	if h.webhooks.GitHub != "" {
+		router.Post("/webhooks/github", h.githubWebhook)
+	}
+
+	if h.webhooks.GitLab != "" {
+		router.Post("/webhooks/gitlab", h.gitlabWebhook)
+	}
+
+	if h.webhooks.Slack != "" {
+		router.Post("/chatops/slack", h.slackCommand)
+	}
+
 	router.Group(func(r chi.Router) {
-		r.Use(h.jwtAuthMiddleware)
+		r.Use(h.authMiddleware)
+
+		r.Post("/logout", h.logout)
 
 		r.Route("/stats", func(r chi.Router) {
+			r.Use(h.requireScope(model.ScopeStatsRead))
 			r.Get("/user", h.getUserStats)
+			r.Get("/team", h.getTeamStats)
 		})
 
 		r.Route("/team", func(r chi.Router) {
-			r.Post("/add", h.createTeam)
-			r.Get("/get", h.getTeam)
+			r.With(h.requireScope(model.ScopeTeamsRead)).Get("/get", h.getTeam)
+			r.With(h.requireScope(model.ScopeTeamsRead)).Get("/list", h.listTeams)
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireRole(model.RoleAdmin, model.RoleTeamLead))
+				r.Use(h.requireScope(model.ScopeTeamsWrite))
+				r.Post("/addMember", h.addTeamMember)
+				r.Post("/removeMember", h.removeTeamMember)
+				r.Post("/moveMember", h.moveTeamMember)
+				r.Post("/setSecondaryMember", h.setSecondaryTeamMember)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireRole(model.RoleAdmin))
+				r.Use(h.requireScope(model.ScopeTeamsWrite))
+				r.Post("/add", h.createTeam)
+				r.Post("/setParent", h.setTeamParent)
+				r.Post("/setPolicy", h.setTeamPolicy)
+				r.Post("/rename", h.renameTeam)
+				r.Post("/archive", h.archiveTeam)
+				r.Post("/unarchive", h.unarchiveTeam)
+				r.Get("/deletePreview", h.previewTeamDeletion)
+				r.Post("/delete", h.deleteTeam)
+				r.Post("/import", h.importOrg)
+			})
 		})
 
 		r.Route("/users", func(r chi.Router) {
-			r.Post("/setIsActive", h.setUserIsActive)
-			r.Get("/getReview", h.getReviewsForUser)
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireScope(model.ScopeUsersWrite))
+				r.With(h.requireRole(model.RoleAdmin, model.RoleTeamLead)).Post("/setIsActive", h.setUserIsActive)
+				r.With(h.requireRole(model.RoleAdmin)).Post("/setRole", h.setUserRole)
+				r.Post("/setPassword", h.setUserPassword)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireScope(model.ScopeUsersRead))
+				r.Get("/get", h.getUser)
+				r.Get("/list", h.listUsers)
+				r.Get("/getTeams", h.getUserTeams)
+			})
+
+			r.With(h.requireScope(model.ScopePRRead)).Get("/getReview", h.getReviewsForUser)
 		})
 
 		r.Route("/pullRequest", func(r chi.Router) {
-			r.Post("/create", h.createPullRequest)
-			r.Post("/merge", h.mergePullRequest)
-			r.Post("/reassign", h.reassignReviewer)
+			r.With(h.requireScope(model.ScopePRRead)).Get("/byIssue", h.listPullRequestsByIssue)
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireScope(model.ScopePRWrite))
+				r.Post("/create", h.createPullRequest)
+				r.Post("/merge", h.mergePullRequest)
+				r.Post("/reassign", h.reassignReviewer)
+				r.Post("/close", h.closePullRequest)
+				r.Post("/reopen", h.reopenPullRequest)
+				r.Post("/review", h.submitReview)
+			})
+		})
+
+		r.Route("/identities", func(r chi.Router) {
+			r.Use(h.requireRole(model.RoleAdmin))
+			r.With(h.requireScope(model.ScopeUsersRead)).Get("/list", h.listIdentities)
+			r.With(h.requireScope(model.ScopeUsersWrite)).Post("/link", h.linkIdentity)
+			r.With(h.requireScope(model.ScopeUsersWrite)).Post("/unlink", h.unlinkIdentity)
+		})
+
+		if h.subscriptionService != nil {
+			r.Route("/webhookSubscriptions", func(r chi.Router) {
+				r.Use(h.requireRole(model.RoleAdmin))
+				r.With(h.requireScope(model.ScopeWebhooksRead)).Get("/list", h.listSubscriptions)
+				r.With(h.requireScope(model.ScopeWebhooksRead)).Get("/deliveries", h.listSubscriptionDeliveries)
+				r.With(h.requireScope(model.ScopeWebhooksWrite)).Post("/create", h.createSubscription)
+				r.With(h.requireScope(model.ScopeWebhooksWrite)).Post("/delete", h.deleteSubscription)
+				r.With(h.requireScope(model.ScopeWebhooksWrite)).Post("/redeliver", h.redeliverSubscriptionDelivery)
+			})
+		}
+
+		if h.notificationService != nil {
+			r.Route("/notifications", func(r chi.Router) {
+				r.With(h.requireScope(model.ScopeNotificationsRead)).Get("/list", h.listNotifications)
+				r.With(h.requireScope(model.ScopeNotificationsRead)).Get("/preferences/get", h.getNotificationPreference)
+				r.With(h.requireScope(model.ScopeNotificationsWrite)).Post("/preferences/set", h.setNotificationPreference)
+
+				r.Group(func(r chi.Router) {
+					r.Use(h.requireRole(model.RoleAdmin))
+					r.With(h.requireScope(model.ScopeNotificationsRead)).Get("/templates/list", h.listNotificationTemplates)
+					r.With(h.requireScope(model.ScopeNotificationsWrite)).Post("/templates/set", h.setNotificationTemplate)
+					r.With(h.requireScope(model.ScopeNotificationsWrite)).Post("/templates/reset", h.resetNotificationTemplate)
+				})
+			})
+		}
+
+		if h.digestService != nil {
+			r.Route("/digests", func(r chi.Router) {
+				r.With(h.requireScope(model.ScopeNotificationsRead)).Get("/preview", h.previewDigest)
+				r.With(h.requireScope(model.ScopeNotificationsRead)).Get("/list", h.listDigestSubscriptions)
+				r.With(h.requireScope(model.ScopeNotificationsWrite)).Post("/subscribe", h.subscribeDigest)
+				r.With(h.requireScope(model.ScopeNotificationsWrite)).Post("/unsubscribe", h.unsubscribeDigest)
+			})
+		}
+
+		if h.calendarService != nil {
+			r.Route("/calendars", func(r chi.Router) {
+				r.With(h.requireScope(model.ScopeUsersRead)).Get("/list", h.listCalendarFeeds)
+				r.With(h.requireScope(model.ScopeUsersRead)).Get("/absences", h.listAbsences)
+				r.With(h.requireScope(model.ScopeUsersWrite)).Post("/create", h.createCalendarFeed)
+				r.With(h.requireScope(model.ScopeUsersWrite)).Post("/upload", h.uploadCalendar)
+				r.With(h.requireScope(model.ScopeUsersWrite)).Post("/sync", h.syncCalendarFeed)
+				r.With(h.requireScope(model.ScopeUsersWrite)).Post("/delete", h.deleteCalendarFeed)
+			})
+		}
+
+		r.Route("/apiKeys", func(r chi.Router) {
+			r.Post("/issue", h.issueAPIKey)
+			r.Get("/list", h.listAPIKeys)
+			r.Post("/revoke", h.revokeAPIKey)
 		})
 	})
 
@@ -139,6 +324,151 @@ func (h *Handler) WriteError(w http.ResponseWriter, r *http.Request, err error)
 		resp.Error.Code = "NOT_ASSIGNED"
 		resp.Error.Message = "reviewer is not assigned to this PR"
 
+	case errors.Is(err, service.ErrDuplicateMember):
+		status = http.StatusBadRequest
+		resp.Error.Code = "DUPLICATE_MEMBER"
+		resp.Error.Message = "user is listed more than once"
+
+	case errors.Is(err, service.ErrUserInAnotherTeam):
+		status = http.StatusConflict
+		resp.Error.Code = "USER_IN_OTHER_TEAM"
+		resp.Error.Message = "user is a member of another team"
+
+	case errors.Is(err, service.ErrPrimaryTeam):
+		status = http.StatusConflict
+		resp.Error.Code = "PRIMARY_TEAM"
+		resp.Error.Message = "team is the user's primary team"
+
+	case errors.Is(err, service.ErrNotTeamMember):
+		status = http.StatusConflict
+		resp.Error.Code = "NOT_TEAM_MEMBER"
+		resp.Error.Message = "user is not a member of the team"
+
+	case errors.Is(err, service.ErrHierarchyCycle):
+		status = http.StatusConflict
+		resp.Error.Code = "HIERARCHY_CYCLE"
+		resp.Error.Message = "team cannot be nested under itself or its descendant"
+
+	case errors.Is(err, service.ErrTeamArchived):
+		status = http.StatusConflict
+		resp.Error.Code = "TEAM_ARCHIVED"
+		resp.Error.Message = "team is archived"
+
+	case errors.Is(err, service.ErrTeamNotArchived):
+		status = http.StatusConflict
+		resp.Error.Code = "TEAM_NOT_ARCHIVED"
+		resp.Error.Message = "team must be archived before deletion"
+
+	case errors.Is(err, service.ErrTeamNotEmpty):
+		status = http.StatusConflict
+		resp.Error.Code = "TEAM_NOT_EMPTY"
+		resp.Error.Message = "team still has members, open pull requests or child teams"
+
+	case errors.Is(err, service.ErrInvalidImport):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_IMPORT"
+		resp.Error.Message = err.Error()
+
+	case errors.Is(err, service.ErrInvalidCredentials):
+		status = http.StatusUnauthorized
+		resp.Error.Code = "INVALID_CREDENTIALS"
+		resp.Error.Message = "invalid user id or password"
+
+	case errors.Is(err, service.ErrAccountLocked):
+		status = http.StatusTooManyRequests
+		resp.Error.Code = "ACCOUNT_LOCKED"
+		resp.Error.Message = "too many failed login attempts, try again later"
+
+	case errors.Is(err, service.ErrWeakPassword):
+		status = http.StatusBadRequest
+		resp.Error.Code = "WEAK_PASSWORD"
+		resp.Error.Message = "password must be between 8 and 72 bytes"
+
+	case errors.Is(err, service.ErrForbidden):
+		status = http.StatusForbidden
+		resp.Error.Code = "FORBIDDEN"
+		resp.Error.Message = "operation is not permitted"
+
+	case errors.Is(err, service.ErrInvalidRole):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_ROLE"
+		resp.Error.Message = "role must be one of admin, team_lead, member, bot"
+
+	case errors.Is(err, service.ErrInvalidScope):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_SCOPE"
+		resp.Error.Message = "scopes must be a non-empty list of known scopes"
+
+	case errors.Is(err, service.ErrInvalidExpiry):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_EXPIRY"
+		resp.Error.Message = "expires_at must be in the future and at most one year ahead"
+
+	case errors.Is(err, service.ErrInsufficientScope):
+		status = http.StatusForbidden
+		resp.Error.Code = "INSUFFICIENT_SCOPE"
+		resp.Error.Message = "api key lacks the required scope"
+
+	case errors.Is(err, service.ErrInvalidRefreshToken):
+		status = http.StatusUnauthorized
+		resp.Error.Code = "INVALID_REFRESH_TOKEN"
+		resp.Error.Message = "refresh token is invalid, expired or revoked"
+
+	case errors.Is(err, service.ErrOIDCLoginFailed):
+		status = http.StatusUnauthorized
+		resp.Error.Code = "OIDC_LOGIN_FAILED"
+		resp.Error.Message = "oidc login failed"
+
+	case errors.Is(err, service.ErrOIDCUserNotProvisioned):
+		status = http.StatusForbidden
+		resp.Error.Code = "OIDC_USER_NOT_PROVISIONED"
+		resp.Error.Message = "no user matches the identity provider account"
+
+	case errors.Is(err, service.ErrPRClosed):
+		status = http.StatusConflict
+		resp.Error.Code = "PR_CLOSED"
+		resp.Error.Message = "cannot change closed PR"
+
+	case errors.Is(err, service.ErrInvalidVerdict):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_VERDICT"
+		resp.Error.Message = "verdict must be one of APPROVED, CHANGES_REQUESTED, COMMENTED"
+
+	case errors.Is(err, service.ErrInvalidIdentity):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_IDENTITY"
+		resp.Error.Message = "provider must be a known code host and ids must be non-empty"
+
+	case errors.Is(err, service.ErrInvalidSubscription):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_SUBSCRIPTION"
+		resp.Error.Message = "url must be an absolute http(s) url and event_types must list known events"
+
+	case errors.Is(err, service.ErrInvalidPreference):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_NOTIFICATION_PREFERENCE"
+		resp.Error.Message = "channel must be configured, address must match the channel, timezone and HH:MM times must be valid"
+
+	case errors.Is(err, service.ErrInvalidTemplate):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_TEMPLATE"
+		resp.Error.Message = err.Error()
+
+	case errors.Is(err, service.ErrInvalidDigest):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_DIGEST"
+		resp.Error.Message = err.Error()
+
+	case errors.Is(err, service.ErrInvalidIssueKey):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_ISSUE_KEY"
+		resp.Error.Message = err.Error()
+
+	case errors.Is(err, service.ErrInvalidCalendar):
+		status = http.StatusBadRequest
+		resp.Error.Code = "INVALID_CALENDAR"
+		resp.Error.Message = err.Error()
+
 	case errors.Is(err, service.ErrNoCandidates):
 		status = http.StatusConflict
 		resp.Error.Code = "NO_CANDIDATE"
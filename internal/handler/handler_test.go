@@ -4,15 +4,26 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sync"
 
 	"testing"
 	"time"
 
+	"github.com/DeadlyParkour777/pr-service/internal/ical"
+	"github.com/DeadlyParkour777/pr-service/internal/jira"
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/notify"
+	"github.com/DeadlyParkour777/pr-service/internal/notify/notifytest"
+	"github.com/DeadlyParkour777/pr-service/internal/outbox"
 	"github.com/DeadlyParkour777/pr-service/internal/service"
 	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/internal/webhook"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -25,7 +36,19 @@ import (
 var (
 	testServerURL string
 	testStore     *store.Store
+	testService   *service.Service
 	testSpecPath  string
+	testEvents    *outbox.ChannelSink
+	testSMTP      *notifytest.SMTPServer
+	testJira      *jiraStub
+	testHosts     sync.Map
+)
+
+const (
+	testSCIMToken    = "scim-test-token"
+	testGitHubSecret = "github-test-secret"
+	testGitLabToken  = "gitlab-test-token"
+	testSlackSecret  = "slack-test-secret"
 )
 
 func TestMain(m *testing.M) {
@@ -78,14 +101,47 @@ func TestMain(m *testing.M) {
 		log.Fatalf("failed to write temp spec file: %s", err)
 	}
 
+	testEvents = outbox.NewChannelSink(256)
+	testSMTP = notifytest.NewSMTPServer()
+	defer testSMTP.Close()
+	testJira = newJiraStub()
+	defer testJira.Close()
+
 	deps := service.Dependencies{
-		TeamRepo:  appStore.Team(),
-		UserRepo:  appStore.User(),
-		PRRepo:    appStore.PR(),
-		StatsRepo: appStore.PR(),
+		TeamRepo:   appStore.Team(),
+		UserRepo:   appStore.User(),
+		PRRepo:     appStore.PR(),
+		StatsRepo:  appStore.PR(),
+		APIKeyRepo: appStore.APIKey(),
+		TokenRepo:  appStore.Token(),
+
+		IdentityRepo:        appStore.Identity(),
+		WebhookDeliveryRepo: appStore.WebhookDelivery(),
+
+		SubscriptionRepo: appStore.Subscription(),
+		WebhookSender:    webhook.NewSender(testHostClient()),
+
+		OutboxRepo: appStore.Outbox(),
+		EventSinks: []service.EventSink{testEvents},
+
+		NotificationRepo: appStore.Notification(),
+		Notifiers: []service.Notifier{
+			notify.NewEmailNotifier(notify.SMTPConfig{Host: testSMTP.Host(), Port: testSMTP.Port(), From: "pr-service@example.com"}),
+			notify.NewSlackNotifier(testHostClient()),
+			notify.NewWebhookNotifier(testHostClient()),
+		},
+		DigestRepo: appStore.Digest(),
+
+		CalendarRepo:    appStore.Calendar(),
+		CalendarFetcher: ical.NewFetcher(nil),
+
+		IssueKeyPatterns: []*regexp.Regexp{service.DefaultIssueKeyPattern, regexp.MustCompile(`(?i)\bfixes #(\d+)`)},
+		IssueTracker:     jira.NewClient(jira.Config{BaseURL: testJira.URL, Token: "jira-test-token"}, nil),
+		IssueTrackerRepo: appStore.IssueTracker(),
 	}
 	appService := service.NewService(deps)
-	appHandler := NewHandler(appService, "123", testSpecPath, appStore)
+	testService = appService
+	appHandler := NewHandler(appService, "123", testSCIMToken, WebhookSecrets{GitHub: testGitHubSecret, GitLab: testGitLabToken, Slack: testSlackSecret}, testSpecPath, appStore)
 	router := appHandler.InitRoutes()
 
 	server := httptest.NewServer(router)
@@ -97,18 +153,54 @@ func TestMain(m *testing.M) {
 	os.Exit(exitCode)
 }
 
+func testHostClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		target, ok := testHosts.Load(host)
+		if !ok {
+			return nil, fmt.Errorf("unknown test host %s", host)
+		}
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, target.(string))
+	}
+
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
+
+func publicTestURL(server *httptest.Server, host string) string {
+	testHosts.Store(host, server.Listener.Addr().String())
+	return "http://" + host
+}
+
 func truncateTables(ctx context.Context) {
 	if err := testStore.TruncateAllTables(ctx); err != nil {
 		log.Fatalf("failed to truncate tables: %v", err)
 	}
+
+	for len(testEvents.Events()) > 0 {
+		<-testEvents.Events()
+	}
 }
 
 func getTestToken(t *testing.T, userID string) string {
 	t.Helper()
 
+	return getTestTokenWithRole(t, userID, model.RoleAdmin)
+}
+
+func getTestTokenWithRole(t *testing.T, userID string, role model.Role) string {
+	t.Helper()
+
 	claims := &Claims{
 		UserID: userID,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        fmt.Sprintf("%s-%d", userID, time.Now().UnixNano()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
 		},
 	}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+func (h *Handler) linkIdentity(w http.ResponseWriter, r *http.Request) {
+	var req LinkIdentityRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	identity := model.ExternalIdentity{
+		Provider:   model.IdentityProvider(req.Provider),
+		ExternalID: req.ExternalID,
+		UserID:     req.UserID,
+	}
+
+	if err := h.identityService.Link(r.Context(), identity); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"identity": ConvertIdentityModelToDTO(identity)})
+}
+
+func (h *Handler) unlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	var req UnlinkIdentityRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.identityService.Unlink(r.Context(), model.IdentityProvider(req.Provider), req.ExternalID); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listIdentities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	identities, err := h.identityService.List(r.Context(), model.IdentityProvider(query.Get("provider")), query.Get("user_id"))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]IdentityResponse, len(identities))
+	for i, identity := range identities {
+		resp[i] = ConvertIdentityModelToDTO(identity)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"identities": resp})
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/go-chi/render"
+)
+
+const maxImportSize = 5 << 20
+
+func (h *Handler) importOrg(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := model.ImportFormat(query.Get("format"))
+	if format == "" {
+		format = model.ImportFormatYAML
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType == "text/csv" {
+			format = model.ImportFormatCSV
+		}
+	}
+
+	dryRun := false
+	if raw := query.Get("dry_run"); raw != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			h.writeBadRequest(w, r, "invalid query parameter: dry_run")
+			return
+		}
+	}
+
+	org, err := service.ParseOrgImport(http.MaxBytesReader(w, r.Body, maxImportSize), format)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	plan, err := h.importService.Import(r.Context(), *org, dryRun)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ConvertImportPlanToDTO(*plan, dryRun))
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportHandler_E2E_ImportOrg(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+		{ID: "u9", Username: "Former", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	token := getTestToken(t, "test-user")
+	body := `
+teams:
+  - name: backend
+    members:
+      - user_id: u1
+        username: Alice
+      - user_id: u2
+        username: Bob
+  - name: frontend
+    members:
+      - user_id: u3
+        username: Carol
+`
+	doImport := func(query string) ImportPlanResponse {
+		req, err := http.NewRequest("POST", testServerURL+"/team/import"+query, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/yaml")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var plan ImportPlanResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&plan))
+		return plan
+	}
+
+	plan := doImport("?dry_run=true")
+	assert.False(t, plan.Applied)
+	assert.Equal(t, []string{"frontend"}, plan.TeamsToCreate)
+	assert.Len(t, plan.UsersToAdd, 2)
+	require.Len(t, plan.UsersToDeactivate, 1)
+	assert.Equal(t, "u9", plan.UsersToDeactivate[0].UserID)
+
+	_, _, err = testStore.Team().GetByName(ctx, "frontend")
+	require.Error(t, err)
+
+	plan = doImport("")
+	assert.True(t, plan.Applied)
+
+	user, err := testStore.User().GetByID(ctx, "u3")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName)
+
+	plan = doImport("")
+	assert.False(t, plan.Applied)
+	assert.Empty(t, plan.TeamsToCreate)
+	assert.Empty(t, plan.UsersToAdd)
+	assert.Empty(t, plan.UsersToDeactivate)
+}
+
+func TestImportHandler_E2E_ImportOrg_InvalidCSV(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	token := getTestToken(t, "test-user")
+	req, err := http.NewRequest("POST", testServerURL+"/team/import", strings.NewReader("team_name\nbackend\n"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "text/csv")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var errResp APIErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, "INVALID_IMPORT", errResp.Error.Code)
+}
@@ -2,18 +2,86 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/DeadlyParkour777/pr-service/internal/model"
 )
 
 type TeamService interface {
-	Create(ctx context.Context, team model.Team, members []model.User) (*model.Team, []model.User, error)
+	Create(ctx context.Context, team model.Team, members []model.User) (*model.TeamUpsert, error)
 	Get(ctx context.Context, name string) (*model.Team, []model.User, error)
+	GetHierarchy(ctx context.Context, team model.Team) (*model.TeamHierarchy, error)
+	SetParent(ctx context.Context, teamName, parentName string) (*model.Team, error)
+	SetPolicy(ctx context.Context, teamName string, policy model.AssignmentPolicy) (*model.Team, error)
+	Rename(ctx context.Context, teamName, newName string) (*model.Team, error)
+	SetArchived(ctx context.Context, teamName string, archived bool) (*model.Team, error)
+	GetDeletionReport(ctx context.Context, teamName string) (*model.TeamDeletionReport, error)
+	Delete(ctx context.Context, teamName string, force bool) (*model.TeamDeletionReport, error)
+	List(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, string, error)
+}
+
+type MembershipService interface {
+	AddMember(ctx context.Context, teamName string, member model.User) (*model.FullUserInfo, error)
+	RemoveMember(ctx context.Context, teamName, userID string, reviews model.ReviewPolicy) (*model.MembershipChange, error)
+	MoveMember(ctx context.Context, userID, teamName string, reviews model.ReviewPolicy, authored model.AuthoredPolicy) (*model.MembershipChange, error)
+	SetSecondaryMember(ctx context.Context, teamName, userID string, reviewable bool) (*model.TeamMembership, error)
+	GetMemberships(ctx context.Context, userID string) ([]model.TeamMembership, error)
+}
+
+type ImportService interface {
+	Import(ctx context.Context, org model.OrgImport, dryRun bool) (*model.ImportPlan, error)
+}
+
+type ProvisioningService interface {
+	ListUsers(ctx context.Context, filter model.UserFilter) ([]model.ProvisionedUser, int, error)
+	GetUser(ctx context.Context, userID string) (*model.ProvisionedUser, error)
+	CreateUser(ctx context.Context, user model.User) (*model.ProvisionedUser, error)
+	UpdateUser(ctx context.Context, userID string, patch model.UserPatch) (*model.ProvisionedUser, error)
+	DeprovisionUser(ctx context.Context, userID string) error
+	ListGroups(ctx context.Context, filter model.TeamFilter) ([]model.ProvisionedGroup, int, error)
+	GetGroup(ctx context.Context, teamID int) (*model.ProvisionedGroup, error)
+	CreateGroup(ctx context.Context, name string, memberIDs []string) (*model.ProvisionedGroup, error)
+	UpdateGroup(ctx context.Context, teamID int, patch model.GroupPatch) (*model.ProvisionedGroup, error)
+	DeleteGroup(ctx context.Context, teamID int) error
+}
+
+type AuthService interface {
+	Login(ctx context.Context, userID, password string) (*model.Principal, error)
+	ChangePassword(ctx context.Context, userID, currentPassword, password string) error
+	SetRole(ctx context.Context, userID string, role model.Role) error
+}
+
+type APIKeyService interface {
+	Issue(ctx context.Context, userID, name string, scopes []model.Scope, expiresAt *time.Time) (*model.IssuedAPIKey, error)
+	List(ctx context.Context, userID string) ([]model.APIKey, error)
+	Revoke(ctx context.Context, keyID int) (*model.APIKey, error)
+	Authenticate(ctx context.Context, secret string) (*model.Principal, error)
+}
+
+type TokenService interface {
+	IssueRefreshToken(ctx context.Context, userID string) (string, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	IsRevoked(ctx context.Context, jti string, expiresAt time.Time) (bool, error)
+}
+
+type KeyService interface {
+	Algorithm() model.SigningAlgorithm
+	SigningKey(ctx context.Context) (*model.SigningKey, error)
+	VerificationKey(ctx context.Context, kid string) (*model.SigningKey, error)
+	PublicKeys(ctx context.Context) ([]model.SigningKey, error)
+}
+
+type OIDCService interface {
+	Begin(ctx context.Context) (string, string, error)
+	Complete(ctx context.Context, state, code string) (*model.Principal, error)
 }
 
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*model.FullUserInfo, error)
 	GetReviewsForUser(ctx context.Context, userID string) ([]model.PullRequest, error)
+	Get(ctx context.Context, userID string) (*model.UserDetails, error)
+	List(ctx context.Context, filter model.UserFilter) ([]model.FullUserInfo, string, error)
 }
 
 type PullRequestService interface {
@@ -21,10 +89,61 @@ type PullRequestService interface {
 	Merge(ctx context.Context, prID string) (*model.PullRequest, error)
 	Reassign(ctx context.Context, prID, oldReviewerID string) (*model.PullRequest, string, error)
 	GetByID(ctx context.Context, prID string) (*model.PullRequest, error)
+	Close(ctx context.Context, prID string) (*model.PullRequest, error)
+	Reopen(ctx context.Context, prID string) (*model.PullRequest, error)
+	SubmitVerdict(ctx context.Context, prID, reviewerID string, verdict model.Verdict) (*model.PullRequest, error)
+	ListByIssueKey(ctx context.Context, issueKey string) ([]model.PullRequest, error)
+}
+
+type IdentityService interface {
+	Link(ctx context.Context, identity model.ExternalIdentity) error
+	Unlink(ctx context.Context, provider model.IdentityProvider, externalID string) error
+	List(ctx context.Context, provider model.IdentityProvider, userID string) ([]model.ExternalIdentity, error)
+}
+
+type CodeHostService interface {
+	Handle(ctx context.Context, event model.CodeHostEvent) (model.CodeHostResult, error)
+}
+
+type ChatOpsService interface {
+	Execute(ctx context.Context, cmd model.ChatCommand) (model.ChatResponse, error)
+}
+type SubscriptionService interface {
+	Create(ctx context.Context, sub model.WebhookSubscription) (*model.WebhookSubscription, error)
+	List(ctx context.Context) ([]model.WebhookSubscription, error)
+	Delete(ctx context.Context, id int) error
+	ListDeliveries(ctx context.Context, filter model.DeliveryFilter) ([]model.SubscriptionDelivery, error)
+	Redeliver(ctx context.Context, deliveryID int64) (*model.SubscriptionDelivery, error)
+}
+
+type NotificationService interface {
+	GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error)
+	SetPreference(ctx context.Context, pref model.NotificationPreference) (*model.NotificationPreference, error)
+	ListNotifications(ctx context.Context, userID string, limit int) ([]model.Notification, error)
+	ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error)
+	SetTemplate(ctx context.Context, tmpl model.NotificationTemplate) (*model.NotificationTemplate, error)
+	ResetTemplate(ctx context.Context, kind model.NotificationKind) (*model.NotificationTemplate, error)
+}
+
+type DigestService interface {
+	Preview(ctx context.Context, userID string, kind model.DigestKind, format model.DigestFormat) (*model.RenderedDigest, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]model.DigestSubscription, error)
+	Subscribe(ctx context.Context, sub model.DigestSubscription) (*model.DigestSubscription, error)
+	Unsubscribe(ctx context.Context, userID string, kind model.DigestKind) error
+}
+
+type CalendarService interface {
+	Create(ctx context.Context, feed model.CalendarFeed) (*model.CalendarFeed, error)
+	List(ctx context.Context, filter model.CalendarFeedFilter) ([]model.CalendarFeed, error)
+	Delete(ctx context.Context, feedID int64) error
+	Upload(ctx context.Context, feedID int64, data []byte) (*model.CalendarFeed, error)
+	Sync(ctx context.Context, feedID int64) (*model.CalendarFeed, error)
+	ListAbsences(ctx context.Context, userID string, from, to time.Time) ([]model.Absence, error)
 }
 
 type StatsService interface {
 	GetUserStats(ctx context.Context) ([]model.UserStats, error)
+	GetTeamStats(ctx context.Context, rootName string) ([]model.TeamStats, error)
 }
 
 type DBPinger interface {
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jiraStub struct {
+	*httptest.Server
+	mu       sync.Mutex
+	comments map[string][]string
+}
+
+func newJiraStub() *jiraStub {
+	stub := &jiraStub{comments: map[string][]string{}}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issueKey, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/"), "/comment")
+		if !ok || r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer jira-test-token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body struct {
+			Body string `json:"body"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		stub.mu.Lock()
+		stub.comments[issueKey] = append(stub.comments[issueKey], body.Body)
+		stub.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	return stub
+}
+
+func (s *jiraStub) Comments(issueKey string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.comments[issueKey]...)
+}
+
+func TestIssues_E2E_LinkListAndPushMerge(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	token := getTestToken(t, "alice")
+
+	var pr struct {
+		PR PullRequestResponse `json:"pr"`
+	}
+	status := doJSONAs(t, token, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "PROJ-123: fix login, fixes #42", AuthorID: "alice"}, &pr)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, []string{"42", "PROJ-123"}, pr.PR.IssueKeys)
+
+	status = doJSONAs(t, token, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-2", PullRequestName: "PROJ-123 follow-up", AuthorID: "alice"}, nil)
+	require.Equal(t, http.StatusCreated, status)
+	status = doJSONAs(t, token, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-3", PullRequestName: "Unrelated", AuthorID: "alice"}, nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	var linked struct {
+		IssueKey     string                     `json:"issue_key"`
+		PullRequests []PullRequestShortResponse `json:"pull_requests"`
+	}
+	status = doJSONAs(t, getTestTokenWithRole(t, "bob", model.RoleMember), "GET", "/pullRequest/byIssue?issue_key=proj-123", nil, &linked)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, linked.PullRequests, 2)
+	assert.Equal(t, "pr-1", linked.PullRequests[0].PullRequestID)
+	assert.Equal(t, "pr-2", linked.PullRequests[1].PullRequestID)
+
+	status = doJSONAs(t, token, "GET", "/pullRequest/byIssue?issue_key=NONE-1", nil, &linked)
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, linked.PullRequests)
+
+	assert.Equal(t, http.StatusBadRequest, getAs(t, token, "/pullRequest/byIssue"))
+
+	status = doJSONAs(t, token, "POST", "/pullRequest/merge", MergePullRequestRequest{PullRequestID: "pr-1"}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	relayOutbox(t, ctx)
+	receivedEvents()
+
+	processed, err := testService.IssueTracker.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []string{`Pull request pr-1 "PROJ-123: fix login, fixes #42" was merged by alice.`}, testJira.Comments("PROJ-123"))
+	assert.Len(t, testJira.Comments("42"), 1)
+
+	relayOutbox(t, ctx)
+	processed, err = testService.IssueTracker.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "relayed events are pushed once")
+}
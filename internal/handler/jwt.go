@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var errUnexpectedSigningMethod = errors.New("unexpected signing method")
+
+func signingMethod(algorithm model.SigningAlgorithm) jwt.SigningMethod {
+	switch algorithm {
+	case model.SigningRS256:
+		return jwt.SigningMethodRS256
+	case model.SigningEdDSA:
+		return jwt.SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodHS256
+}
+
+func (h *Handler) signToken(ctx context.Context, claims *Claims) (string, error) {
+	if !h.keyService.Algorithm().IsAsymmetric() {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(h.jwtSecret)
+	}
+
+	key, err := h.keyService.SigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.KID
+
+	return token.SignedString(key.PrivateKey)
+}
+
+func (h *Handler) parseToken(ctx context.Context, tokenStr string, claims *Claims) (*jwt.Token, error) {
+	algorithm := h.keyService.Algorithm()
+
+	return jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != string(algorithm) {
+			return nil, fmt.Errorf("%w: %s", errUnexpectedSigningMethod, token.Method.Alg())
+		}
+
+		if !algorithm.IsAsymmetric() {
+			return h.jwtSecret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, err := h.keyService.VerificationKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		if key.Algorithm != algorithm {
+			return nil, fmt.Errorf("%w: key %s is %s", errUnexpectedSigningMethod, kid, key.Algorithm)
+		}
+
+		return key.PublicKey(), nil
+	}, jwt.WithValidMethods([]string{string(algorithm)}))
+}
+
+func ConvertSigningKeyToJWK(key model.SigningKey) (JWK, bool) {
+	jwk := JWK{KID: key.KID, Alg: string(key.Algorithm), Use: "sig"}
+
+	switch public := key.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
+
+func (h *Handler) getJWKS(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.keyService.PublicKeys(r.Context())
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := JWKSResponse{Keys: make([]JWK, 0, len(keys))}
+	for _, key := range keys {
+		if jwk, ok := ConvertSigningKeyToJWK(key); ok {
+			resp.Keys = append(resp.Keys, jwk)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fetchJWKS(t *testing.T, baseURL string) JWKSResponse {
+	t.Helper()
+
+	resp, err := http.Get(baseURL + "/.well-known/jwks.json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var jwks JWKSResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	return jwks
+}
+
+func TestJWT_E2E_HS256PublishesNoKeys(t *testing.T) {
+	assert.Empty(t, fetchJWKS(t, testServerURL).Keys)
+}
+
+func TestJWT_E2E_AsymmetricSigningWithJWKS(t *testing.T) {
+	for _, algorithm := range []model.SigningAlgorithm{model.SigningRS256, model.SigningEdDSA} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			ctx := context.Background()
+			truncateTables(ctx)
+
+			appService := service.NewService(service.Dependencies{
+				TeamRepo:       testStore.Team(),
+				UserRepo:       testStore.User(),
+				PRRepo:         testStore.PR(),
+				StatsRepo:      testStore.PR(),
+				APIKeyRepo:     testStore.APIKey(),
+				TokenRepo:      testStore.Token(),
+				SigningKeyRepo: testStore.SigningKey([]byte("jwt-test-key-encryption-key-0032")),
+				JWTAlgorithm:   algorithm,
+			})
+			require.NoError(t, appService.Keys.Rotate(ctx))
+			_, err := appService.Auth.SeedAdmin(ctx, model.User{ID: "admin", Username: "Admin", IsActive: true}, "admin-password")
+			require.NoError(t, err)
+
+			server := httptest.NewServer(NewHandler(appService, "", "", WebhookSecrets{}, testSpecPath, testStore).InitRoutes())
+			defer server.Close()
+
+			jwks := fetchJWKS(t, server.URL)
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, string(algorithm), jwks.Keys[0].Alg)
+
+			body, _ := json.Marshal(LoginRequest{UserID: "admin", Password: "admin-password"})
+			resp, err := http.Post(server.URL+"/login", "application/json", bytes.NewReader(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var tokens TokenResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(tokens.Token, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, string(algorithm), parsed.Method.Alg())
+			assert.Equal(t, jwks.Keys[0].KID, parsed.Header["kid"])
+
+			req, err := http.NewRequest("GET", server.URL+"/users/list", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+tokens.Token)
+			listResp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			listResp.Body.Close()
+			assert.Equal(t, http.StatusOK, listResp.StatusCode)
+
+			assert.Equal(t, http.StatusUnauthorized, getAs(t, tokens.Token, "/users/list"))
+		})
+	}
+}
+
+func TestJWT_E2E_RejectsUnexpectedSigningMethod(t *testing.T) {
+	claims := &Claims{UserID: "admin", Role: model.RoleAdmin, RegisteredClaims: jwt.RegisteredClaims{ID: "none-token"}}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusUnauthorized, getAs(t, token, "/users/list"))
+}
@@ -1,33 +1,152 @@
 package handler
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"net/http"
 	"time"
 
+	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/go-chi/render"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const accessTokenTTL = 15 * time.Minute
+
 func (h *Handler) loginHandler(w http.ResponseWriter, r *http.Request) {
-	userID := "test-user"
+	var req LoginRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	principal, err := h.authService.Login(r.Context(), req.UserID, req.Password)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	refreshToken, err := h.tokenService.IssueRefreshToken(r.Context(), principal.UserID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	h.writeTokens(w, r, *principal, refreshToken)
+}
+
+func (h *Handler) refreshToken(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	pair, err := h.tokenService.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	h.writeTokens(w, r, pair.Principal, pair.RefreshToken)
+}
+
+func (h *Handler) logout(w http.ResponseWriter, r *http.Request) {
+	var req LogoutRequest
+	if r.ContentLength != 0 {
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			h.writeBadRequest(w, r, "invalid json request")
+			return
+		}
+	}
 
-	expirationTime := time.Now().Add(24 * time.Hour)
+	if err := h.tokenService.Logout(r.Context(), req.RefreshToken); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeTokens(w http.ResponseWriter, r *http.Request, principal model.Principal, refreshToken string) {
+	tokenID := make([]byte, 16)
+	if _, err := rand.Read(tokenID); err != nil {
+		http.Error(w, "Failed to create token", http.StatusInternalServerError)
+		return
+	}
 
+	now := time.Now()
 	claims := &Claims{
-		UserID: userID,
+		UserID: principal.UserID,
+		Role:   principal.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        hex.EncodeToString(tokenID),
+			Subject:   principal.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(h.jwtSecret)
+	tokenString, err := h.signToken(r.Context(), claims)
 	if err != nil {
 		http.Error(w, "Failed to create token", http.StatusInternalServerError)
 		return
 	}
 
-	render.JSON(w, r, map[string]string{
-		"token": tokenString,
+	render.JSON(w, r, TokenResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(accessTokenTTL.Seconds()),
 	})
 }
+
+func (h *Handler) setUserPassword(w http.ResponseWriter, r *http.Request) {
+	var req SetPasswordRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.authService.ChangePassword(r.Context(), req.UserID, req.CurrentPassword, req.Password); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) setUserRole(w http.ResponseWriter, r *http.Request) {
+	var req SetRoleRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.authService.SetRole(r.Context(), req.UserID, model.Role(req.Role)); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{"user_id": req.UserID, "role": req.Role})
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doLogin(t *testing.T, userID, password string) *http.Response {
+	t.Helper()
+
+	body, _ := json.Marshal(LoginRequest{UserID: userID, Password: password})
+	resp, err := http.Post(testServerURL+"/login", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestLoginHandler_E2E_Login(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	authService := service.NewAuthService(testStore.User())
+	_, err := authService.SeedAdmin(ctx, model.User{ID: "admin", Username: "Admin", IsActive: true}, "admin-password")
+	require.NoError(t, err)
+
+	resp := doLogin(t, "admin", "admin-password")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result TokenResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.NotEmpty(t, result.RefreshToken)
+	assert.Equal(t, int(accessTokenTTL.Seconds()), result.ExpiresIn)
+
+	claims := &Claims{}
+	_, err = jwt.ParseWithClaims(result.Token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("123"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", claims.UserID)
+	assert.Equal(t, model.RoleAdmin, claims.Role)
+	assert.NotEmpty(t, claims.ID)
+}
+
+func TestLoginHandler_E2E_LockoutAfterFailures(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	authService := service.NewAuthService(testStore.User())
+	_, err := authService.SeedAdmin(ctx, model.User{ID: "admin", Username: "Admin", IsActive: true}, "admin-password")
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		resp := doLogin(t, "admin", "wrong-password")
+		resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	resp := doLogin(t, "admin", "wrong-password")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	resp = doLogin(t, "admin", "admin-password")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	var errResp APIErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, "ACCOUNT_LOCKED", errResp.Error.Code)
+}
+
+func TestLoginHandler_E2E_SetPasswordOnlyForSelf(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+		{ID: "u2", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	setPassword := func(actor string, role model.Role, req SetPasswordRequest) int {
+		token := getTestTokenWithRole(t, actor, role)
+		body, _ := json.Marshal(req)
+		httpReq, err := http.NewRequest("POST", testServerURL+"/users/setPassword", bytes.NewReader(body))
+		require.NoError(t, err)
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(httpReq)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusNoContent, setPassword("admin", model.RoleAdmin, SetPasswordRequest{UserID: "u1", Password: "initial-password"}))
+	tokens := loginTokens(t, "u1", "initial-password")
+
+	assert.Equal(t, http.StatusForbidden, setPassword("u2", model.RoleMember, SetPasswordRequest{UserID: "u1", Password: "new-password"}))
+	assert.Equal(t, http.StatusUnauthorized, setPassword("u1", model.RoleMember, SetPasswordRequest{UserID: "u1", CurrentPassword: "wrong-password", Password: "new-password"}))
+	assert.Equal(t, http.StatusNoContent, setPassword("u1", model.RoleMember, SetPasswordRequest{UserID: "u1", CurrentPassword: "initial-password", Password: "new-password"}))
+
+	resp := doLogin(t, "u1", "new-password")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	status, _ := doRefresh(t, tokens.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status, "refresh tokens issued before the change are revoked")
+}
+
+func loginTokens(t *testing.T, userID, password string) TokenResponse {
+	t.Helper()
+
+	resp := doLogin(t, userID, password)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var tokens TokenResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+	return tokens
+}
+
+func doRefresh(t *testing.T, refreshToken string) (int, TokenResponse) {
+	t.Helper()
+
+	body, _ := json.Marshal(RefreshTokenRequest{RefreshToken: refreshToken})
+	resp, err := http.Post(testServerURL+"/token/refresh", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var tokens TokenResponse
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+	}
+	return resp.StatusCode, tokens
+}
+
+func TestLoginHandler_E2E_RefreshRotatesAndDetectsReplay(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	authService := service.NewAuthService(testStore.User())
+	_, err := authService.SeedAdmin(ctx, model.User{ID: "admin", Username: "Admin", IsActive: true}, "admin-password")
+	require.NoError(t, err)
+
+	first := loginTokens(t, "admin", "admin-password")
+
+	status, second := doRefresh(t, first.RefreshToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.Equal(t, http.StatusOK, getAs(t, second.Token, "/users/list"))
+
+	status, _ = doRefresh(t, first.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = doRefresh(t, second.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestLoginHandler_E2E_LogoutRevokesTokens(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	authService := service.NewAuthService(testStore.User())
+	_, err := authService.SeedAdmin(ctx, model.User{ID: "admin", Username: "Admin", IsActive: true}, "admin-password")
+	require.NoError(t, err)
+
+	tokens := loginTokens(t, "admin", "admin-password")
+	require.Equal(t, http.StatusOK, getAs(t, tokens.Token, "/users/list"))
+
+	status, _ := postAs(t, tokens.Token, "/logout", LogoutRequest{RefreshToken: tokens.RefreshToken})
+	require.Equal(t, http.StatusNoContent, status)
+
+	assert.Equal(t, http.StatusUnauthorized, getAs(t, tokens.Token, "/users/list"))
+
+	status, _ = doRefresh(t, tokens.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
@@ -1,14 +1,18 @@
 package handler
 
 import (
-	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
 
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func (h *Handler) jwtAuthMiddleware(next http.Handler) http.Handler {
+func (h *Handler) authMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
@@ -23,11 +27,26 @@ func (h *Handler) jwtAuthMiddleware(next http.Handler) http.Handler {
 		}
 
 		tokenStr := parts[1]
+
+		if strings.HasPrefix(tokenStr, model.APIKeyPrefix) {
+			principal, err := h.apiKeyService.Authenticate(r.Context(), tokenStr)
+			if err != nil {
+				if errors.Is(err, service.ErrInvalidCredentials) {
+					http.Error(w, "Invalid API key", http.StatusUnauthorized)
+					return
+				}
+				h.WriteError(w, r, err)
+				return
+			}
+
+			ctx := service.WithPrincipal(r.Context(), *principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		claims := &Claims{}
 
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-			return h.jwtSecret, nil
-		})
+		token, err := h.parseToken(r.Context(), tokenStr, claims)
 
 		if err != nil {
 			if err == jwt.ErrSignatureInvalid {
@@ -38,12 +57,73 @@ func (h *Handler) jwtAuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		if !token.Valid {
+		if !token.Valid || claims.ID == "" || claims.ExpiresAt == nil {
 			http.Error(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), userContextKey, claims.UserID)
+		revoked, err := h.tokenService.IsRevoked(r.Context(), claims.ID, claims.ExpiresAt.Time)
+		if err != nil {
+			h.WriteError(w, r, err)
+			return
+		}
+		if revoked {
+			http.Error(w, "Token has been revoked", http.StatusUnauthorized)
+			return
+		}
+
+		principal := model.Principal{
+			UserID:         claims.UserID,
+			Role:           claims.Role,
+			TokenID:        claims.ID,
+			TokenExpiresAt: claims.ExpiresAt.Time,
+		}
+		if principal.Role == "" {
+			principal.Role = model.RoleMember
+		}
+
+		ctx := service.WithPrincipal(r.Context(), principal)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+func (h *Handler) requireRole(roles ...model.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := service.PrincipalFromContext(r.Context())
+			if !ok || !slices.Contains(roles, principal.Role) {
+				h.WriteError(w, r, service.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (h *Handler) requireScope(scope model.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := service.PrincipalFromContext(r.Context())
+			if !ok || !principal.HasScope(scope) {
+				h.WriteError(w, r, service.ErrInsufficientScope)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (h *Handler) scimAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), h.scimToken) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeSCIMErrorStatus(w, http.StatusUnauthorized, "", "invalid provisioning token")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(service.WithPrincipal(r.Context(), service.SystemPrincipal)))
+	})
+}
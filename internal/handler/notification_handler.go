@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+func (h *Handler) getNotificationPreference(w http.ResponseWriter, r *http.Request) {
+	pref, err := h.notificationService.GetPreference(r.Context(), r.URL.Query().Get("user_id"))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"preference": ConvertNotificationPreferenceModelToDTO(*pref)})
+}
+
+func (h *Handler) setNotificationPreference(w http.ResponseWriter, r *http.Request) {
+	var req SetNotificationPreferenceRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	pref, err := h.notificationService.SetPreference(r.Context(), model.NotificationPreference{
+		UserID:          req.UserID,
+		Channel:         model.NotificationChannel(req.Channel),
+		Address:         req.Address,
+		Mode:            model.NotificationMode(req.Mode),
+		Enabled:         enabled,
+		Timezone:        req.Timezone,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		DigestTime:      req.DigestTime,
+	})
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"preference": ConvertNotificationPreferenceModelToDTO(*pref)})
+}
+
+func (h *Handler) listNotifications(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			h.writeBadRequest(w, r, "invalid query parameter: limit")
+			return
+		}
+		limit = parsed
+	}
+
+	notifications, err := h.notificationService.ListNotifications(r.Context(), query.Get("user_id"), limit)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		resp[i] = ConvertNotificationModelToDTO(n)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"notifications": resp})
+}
+
+func (h *Handler) listNotificationTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.notificationService.ListTemplates(r.Context())
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]NotificationTemplateResponse, len(templates))
+	for i, tmpl := range templates {
+		resp[i] = ConvertNotificationTemplateModelToDTO(tmpl)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"templates": resp})
+}
+
+func (h *Handler) setNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	var req SetNotificationTemplateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	tmpl, err := h.notificationService.SetTemplate(r.Context(), model.NotificationTemplate{
+		Kind:    model.NotificationKind(req.Kind),
+		Subject: req.Subject,
+		Body:    req.Body,
+	})
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"template": ConvertNotificationTemplateModelToDTO(*tmpl)})
+}
+
+func (h *Handler) resetNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	var req ResetNotificationTemplateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	tmpl, err := h.notificationService.ResetTemplate(r.Context(), model.NotificationKind(req.Kind))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"template": ConvertNotificationTemplateModelToDTO(*tmpl)})
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifications_E2E_ChannelsTemplatesAndDigest(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	var mu sync.Mutex
+	var slackTexts []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		slackTexts = append(slackTexts, payload["text"])
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slack.Close()
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+		{ID: "carol", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	aliceToken := getTestTokenWithRole(t, "alice", model.RoleMember)
+	bobToken := getTestTokenWithRole(t, "bob", model.RoleMember)
+	carolToken := getTestTokenWithRole(t, "carol", model.RoleMember)
+	adminToken := getTestToken(t, "admin")
+
+	status := doJSONAs(t, bobToken, "POST", "/notifications/preferences/set", SetNotificationPreferenceRequest{Channel: "EMAIL", Address: "bob@example.com"}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	slackURL := publicTestURL(slack, "slack.test")
+	status = doJSONAs(t, carolToken, "POST", "/notifications/preferences/set", SetNotificationPreferenceRequest{Channel: "SLACK", Address: slackURL}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	var alicePref struct {
+		Preference NotificationPreferenceResponse `json:"preference"`
+	}
+	status = doJSONAs(t, aliceToken, "POST", "/notifications/preferences/set", SetNotificationPreferenceRequest{
+		Channel: "SLACK", Address: slackURL, Mode: "DIGEST", Timezone: "Europe/Berlin", DigestTime: "08:30",
+	}, &alicePref)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "DIGEST", alicePref.Preference.Mode)
+	assert.True(t, alicePref.Preference.Enabled)
+
+	status = doJSONAs(t, adminToken, "POST", "/notifications/templates/set", SetNotificationTemplateRequest{
+		Kind:    "review_requested",
+		Subject: "[PR] {{.PullRequest.ID}} needs you",
+		Body:    "{{.Recipient.Username}}, please review {{.PullRequest.Name}}.",
+	}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	sentBefore := len(testSMTP.Messages())
+
+	status = doJSONAs(t, getTestToken(t, "alice"), "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Search", AuthorID: "alice"}, nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	relayOutbox(t, ctx)
+	_, err = testService.Notifications.ProcessDue(ctx)
+	require.NoError(t, err)
+
+	emails := testSMTP.Messages()[sentBefore:]
+	require.Len(t, emails, 1)
+	assert.Equal(t, []string{"bob@example.com"}, emails[0].To)
+	assert.Equal(t, "[PR] pr-1 needs you", emails[0].Subject)
+	assert.Contains(t, emails[0].Body, "Bob, please review Search.")
+
+	mu.Lock()
+	require.Len(t, slackTexts, 1)
+	assert.Equal(t, "*[PR] pr-1 needs you*\nCarol, please review Search.", slackTexts[0])
+	mu.Unlock()
+
+	status = doJSONAs(t, bobToken, "POST", "/pullRequest/review", SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "bob", Verdict: "APPROVED"}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	relayOutbox(t, ctx)
+	processed, err := testService.Notifications.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "digest notifications wait for the digest time")
+
+	var inbox struct {
+		Notifications []NotificationResponse `json:"notifications"`
+	}
+	status = doJSONAs(t, aliceToken, "GET", "/notifications/list", nil, &inbox)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, inbox.Notifications, 1)
+	assert.Equal(t, "review_submitted", inbox.Notifications[0].Kind)
+	assert.True(t, inbox.Notifications[0].Digest)
+	assert.Equal(t, "PENDING", inbox.Notifications[0].Status)
+	require.NotNil(t, inbox.Notifications[0].DeliverAfter)
+	assert.Equal(t, 30, inbox.Notifications[0].DeliverAfter.Minute())
+
+	status = doJSONAs(t, bobToken, "GET", "/notifications/list?user_id=alice", nil, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestNotifications_E2E_Validation(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "bob", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	bobToken := getTestTokenWithRole(t, "bob", model.RoleMember)
+	adminToken := getTestToken(t, "admin")
+
+	assert.Equal(t, http.StatusNotFound, getAs(t, bobToken, "/notifications/preferences/get"))
+
+	status, errResp := postAs(t, bobToken, "/notifications/preferences/set", SetNotificationPreferenceRequest{Channel: "EMAIL", Address: "not-an-email"})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_NOTIFICATION_PREFERENCE", errResp.Error.Code)
+
+	status, _ = postAs(t, bobToken, "/notifications/preferences/set", SetNotificationPreferenceRequest{UserID: "admin", Channel: "EMAIL", Address: "bob@example.com"})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, _ = postAs(t, adminToken, "/notifications/preferences/set", SetNotificationPreferenceRequest{UserID: "ghost", Channel: "EMAIL", Address: "ghost@example.com"})
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = postAs(t, bobToken, "/notifications/templates/set", SetNotificationTemplateRequest{Kind: "digest", Subject: "x", Body: "x"})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, errResp = postAs(t, adminToken, "/notifications/templates/set", SetNotificationTemplateRequest{Kind: "digest", Subject: "{{.Nope}}", Body: "x"})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_TEMPLATE", errResp.Error.Code)
+
+	var reset struct {
+		Template NotificationTemplateResponse `json:"template"`
+	}
+	status = doJSONAs(t, adminToken, "POST", "/notifications/templates/reset", ResetNotificationTemplateRequest{Kind: "digest"}, &reset)
+	require.Equal(t, http.StatusOK, status)
+	assert.False(t, reset.Template.Custom)
+	assert.NotEmpty(t, reset.Template.Body)
+}
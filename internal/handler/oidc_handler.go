@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+)
+
+const (
+	oidcStateCookie    = "oidc_state"
+	oidcStateCookieTTL = 10 * time.Minute
+)
+
+func (h *Handler) oidcLogin(w http.ResponseWriter, r *http.Request) {
+	authURL, state, err := h.oidcService.Begin(r.Context())
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, oidcStateCookieFor(r, state, int(oidcStateCookieTTL.Seconds())))
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+func (h *Handler) oidcCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		h.WriteError(w, r, fmt.Errorf("%w: %s", service.ErrOIDCLoginFailed, providerErr))
+		return
+	}
+
+	state := query.Get("state")
+	if state == "" || query.Get("code") == "" {
+		h.writeBadRequest(w, r, "state and code are required")
+		return
+	}
+
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		h.WriteError(w, r, fmt.Errorf("%w: state does not belong to this browser", service.ErrOIDCLoginFailed))
+		return
+	}
+	http.SetCookie(w, oidcStateCookieFor(r, "", -1))
+
+	principal, err := h.oidcService.Complete(r.Context(), state, query.Get("code"))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	refreshToken, err := h.tokenService.IssueRefreshToken(r.Context(), principal.UserID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	h.writeTokens(w, r, *principal, refreshToken)
+}
+
+func oidcStateCookieFor(r *http.Request, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     "/oidc/callback",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/oidc"
+	"github.com/DeadlyParkour777/pr-service/internal/oidc/oidctest"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOIDCTestServer(t *testing.T, settings service.OIDCSettings) (*httptest.Server, *oidctest.Provider) {
+	t.Helper()
+
+	idp := oidctest.NewProvider("pr-service")
+	t.Cleanup(idp.Close)
+
+	var router http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		IssuerURL:   idp.Issuer(),
+		ClientID:    "pr-service",
+		RedirectURL: server.URL + "/oidc/callback",
+		Scopes:      []string{"openid", "profile"},
+	}, http.DefaultClient)
+	require.NoError(t, err)
+
+	appService := service.NewService(service.Dependencies{
+		TeamRepo:      testStore.Team(),
+		UserRepo:      testStore.User(),
+		PRRepo:        testStore.PR(),
+		StatsRepo:     testStore.PR(),
+		APIKeyRepo:    testStore.APIKey(),
+		TokenRepo:     testStore.Token(),
+		OIDCProvider:  provider,
+		OIDCStateRepo: testStore.OIDCState(),
+		OIDC:          settings,
+	})
+	router = NewHandler(appService, "test-secret", "", WebhookSecrets{}, testSpecPath, testStore).InitRoutes()
+
+	return server, idp
+}
+
+func newBrowser(t *testing.T) *http.Client {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	return &http.Client{Jar: jar}
+}
+
+func oidcLogin(t *testing.T, baseURL string) (int, TokenResponse) {
+	t.Helper()
+
+	resp, err := newBrowser(t).Get(baseURL + "/oidc/login")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var tokens TokenResponse
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+	}
+
+	return resp.StatusCode, tokens
+}
+
+func TestOIDC_E2E_LoginMapsExistingUser(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{{ID: "u1", Username: "Alice", IsActive: true}})
+	require.NoError(t, err)
+
+	server, idp := newOIDCTestServer(t, service.OIDCSettings{UserIDClaim: "email"})
+	idp.SetClaims(map[string]any{"sub": "idp-123", "email": "u1"})
+
+	status, tokens := oidcLogin(t, server.URL)
+	require.Equal(t, http.StatusOK, status)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	claims := &Claims{}
+	_, err = jwt.ParseWithClaims(tokens.Token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("test-secret"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "u1", claims.UserID)
+	assert.Equal(t, model.RoleMember, claims.Role)
+}
+
+func TestOIDC_E2E_ProvisionsIntoDefaultTeam(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, nil)
+	require.NoError(t, err)
+
+	server, idp := newOIDCTestServer(t, service.OIDCSettings{DefaultTeam: "backend"})
+	idp.SetClaims(map[string]any{"sub": "newcomer", "preferred_username": "Newcomer"})
+
+	status, _ := oidcLogin(t, server.URL)
+	require.Equal(t, http.StatusOK, status)
+
+	user, err := testStore.User().GetByID(ctx, "newcomer")
+	require.NoError(t, err)
+	assert.Equal(t, "Newcomer", user.Username)
+	assert.Equal(t, "backend", user.TeamName)
+
+	creds, err := testStore.User().GetCredentials(ctx, "newcomer")
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleMember, creds.Role)
+}
+
+func TestOIDC_E2E_RejectsUnknownUserAndBadState(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	server, idp := newOIDCTestServer(t, service.OIDCSettings{})
+	idp.SetClaims(map[string]any{"sub": "stranger"})
+
+	status, _ := oidcLogin(t, server.URL)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	resp, err := http.Get(server.URL + "/oidc/callback?state=forged&code=whatever")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/oidc/callback?error=access_denied")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestOIDC_E2E_RejectsCallbackFromAnotherBrowser(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{{ID: "u1", Username: "Alice", IsActive: true}})
+	require.NoError(t, err)
+
+	server, idp := newOIDCTestServer(t, service.OIDCSettings{UserIDClaim: "email"})
+	idp.SetClaims(map[string]any{"sub": "idp-123", "email": "u1"})
+
+	attacker := newBrowser(t)
+	attacker.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Path == "/oidc/callback" {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+	resp, err := attacker.Get(server.URL + "/oidc/login")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	callbackURL := resp.Header.Get("Location")
+
+	resp, err = newBrowser(t).Get(callbackURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "a callback without the state cookie is rejected")
+
+	resp, err = attacker.Get(callbackURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestOIDC_E2E_DisabledWithoutProvider(t *testing.T) {
+	resp, err := http.Get(testServerURL + "/oidc/login")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func relayOutbox(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	for {
+		processed, err := testService.Outbox.ProcessDue(ctx)
+		require.NoError(t, err)
+		if processed == 0 {
+			return
+		}
+	}
+}
+
+func receivedEvents() []model.Event {
+	var events []model.Event
+	for len(testEvents.Events()) > 0 {
+		events = append(events, <-testEvents.Events())
+	}
+
+	return events
+}
+
+func TestOutbox_E2E_RelaysEventsInOrderPerPullRequest(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+		{ID: "carol", Username: "Carol", IsActive: true},
+		{ID: "dave", Username: "Dave", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	token := getTestToken(t, "alice")
+
+	var pr struct {
+		PR PullRequestResponse `json:"pr"`
+	}
+	status := doJSONAs(t, token, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Outbox", AuthorID: "alice"}, &pr)
+	require.Equal(t, http.StatusCreated, status)
+	require.NotEmpty(t, pr.PR.AssignedReviewers)
+
+	status = doJSONAs(t, token, "POST", "/pullRequest/reassign", ReassignReviewerRequest{PullRequestID: "pr-1", OldUserID: pr.PR.AssignedReviewers[0]}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	status = doJSONAs(t, token, "POST", "/pullRequest/merge", MergePullRequestRequest{PullRequestID: "pr-1"}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	status = doJSONAs(t, token, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-2", PullRequestName: "Other", AuthorID: "bob"}, nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	assert.Empty(t, receivedEvents(), "nothing is published before the relay runs")
+
+	relayOutbox(t, ctx)
+
+	var forPR1 []model.EventType
+	var lastID int64
+	for _, event := range receivedEvents() {
+		assert.NotZero(t, event.ID)
+		assert.Equal(t, "alice", event.ActorID)
+		if event.PullRequest.ID != "pr-1" {
+			continue
+		}
+
+		assert.Greater(t, event.ID, lastID)
+		lastID = event.ID
+		forPR1 = append(forPR1, event.Type)
+	}
+
+	assert.Equal(t, []model.EventType{model.EventPRCreated, model.EventReviewerReassigned, model.EventPRMerged}, forPR1)
+
+	relayOutbox(t, ctx)
+	assert.Empty(t, receivedEvents(), "published events are not relayed twice")
+}
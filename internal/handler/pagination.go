@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+func parsePageQuery(query url.Values) (string, int, error) {
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return "", 0, errors.New("invalid query parameter: limit")
+		}
+		limit = parsed
+	}
+
+	after, err := base64.RawURLEncoding.DecodeString(query.Get("cursor"))
+	if err != nil {
+		return "", 0, errors.New("invalid query parameter: cursor")
+	}
+
+	return string(after), limit, nil
+}
+
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
@@ -23,6 +23,7 @@ func (h *Handler) createPullRequest(w http.ResponseWriter, r *http.Request) {
 		ID:       req.PullRequestID,
 		Name:     req.PullRequestName,
 		AuthorID: req.AuthorID,
+		TeamName: req.TeamName,
 	}
 
 	createdPR, err := h.prService.Create(r.Context(), prModel)
@@ -84,3 +85,94 @@ func (h *Handler) reassignReviewer(w http.ResponseWriter, r *http.Request) {
 		"replaced_by": newReviewerID,
 	})
 }
+
+func (h *Handler) closePullRequest(w http.ResponseWriter, r *http.Request) {
+	var req ClosePullRequestRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	closedPR, err := h.prService.Close(r.Context(), req.PullRequestID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response := ConvertPRModelToDTO(*closedPR)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"pr": response})
+}
+
+func (h *Handler) reopenPullRequest(w http.ResponseWriter, r *http.Request) {
+	var req ReopenPullRequestRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	reopenedPR, err := h.prService.Reopen(r.Context(), req.PullRequestID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response := ConvertPRModelToDTO(*reopenedPR)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"pr": response})
+}
+
+func (h *Handler) submitReview(w http.ResponseWriter, r *http.Request) {
+	var req SubmitReviewRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	reviewedPR, err := h.prService.SubmitVerdict(r.Context(), req.PullRequestID, req.ReviewerID, model.Verdict(req.Verdict))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response := ConvertPRModelToDTO(*reviewedPR)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"pr": response})
+}
+
+func (h *Handler) listPullRequestsByIssue(w http.ResponseWriter, r *http.Request) {
+	issueKey := r.URL.Query().Get("issue_key")
+	if issueKey == "" {
+		h.writeBadRequest(w, r, "missing required query parameter: issue_key")
+		return
+	}
+
+	prs, err := h.prService.ListByIssueKey(r.Context(), issueKey)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	prDTOs := make([]PullRequestShortResponse, len(prs))
+	for i, pr := range prs {
+		prDTOs[i] = ConvertPRModelToShortDTO(pr)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"issue_key": issueKey, "pull_requests": prDTOs})
+}
@@ -23,7 +23,7 @@ func TestPullRequestHandler_E2E_Create(t *testing.T) {
 		{ID: "pr-author", Username: "PR Author", IsActive: true},
 		{ID: "pr-reviewer", Username: "PR Reviewer", IsActive: true},
 	}
-	_, _, err := appService.Team.Create(ctx, teamModel, users)
+	_, err := appService.Team.Create(ctx, teamModel, users)
 	require.NoError(t, err)
 
 	token := getTestToken(t, "pr-author")
@@ -66,7 +66,7 @@ func TestPullRequestHandler_E2E_Merge(t *testing.T) {
 	appService := service.NewService(service.Dependencies{TeamRepo: testStore.Team(), UserRepo: testStore.User(), PRRepo: testStore.PR(), StatsRepo: testStore.PR()})
 	teamModel := model.Team{Name: "merge-team"}
 	userModel := model.User{ID: "merge-author", Username: "Merge Author", IsActive: true}
-	_, _, err := appService.Team.Create(ctx, teamModel, []model.User{userModel})
+	_, err := appService.Team.Create(ctx, teamModel, []model.User{userModel})
 	require.NoError(t, err)
 
 	pr := model.PullRequest{ID: "pr-to-merge", Name: "Test Merge", AuthorID: "merge-author", Status: model.StatusOpen}
@@ -123,7 +123,7 @@ func TestPullRequestHandler_E2E_Reassign(t *testing.T) {
 		{ID: "reviewer-B", Username: "Reviewer B", IsActive: true},
 		{ID: "candidate-C", Username: "Candidate C", IsActive: true},
 	}
-	_, _, err := appService.Team.Create(ctx, teamModel, users)
+	_, err := appService.Team.Create(ctx, teamModel, users)
 	require.NoError(t, err)
 
 	prModel := model.PullRequest{ID: "pr-to-reassign", Name: "Test Reassign", AuthorID: "reassign-author"}
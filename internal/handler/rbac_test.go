@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postAs(t *testing.T, token, path string, body any) (int, APIErrorResponse) {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", testServerURL+path, bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var errResp APIErrorResponse
+	if resp.StatusCode >= http.StatusBadRequest {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	}
+
+	return resp.StatusCode, errResp
+}
+
+func TestRBAC_E2E_OnlyAdminsCreateTeams(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	body := CreateTeamRequest{TeamName: "backend"}
+
+	status, errResp := postAs(t, getTestTokenWithRole(t, "lead", model.RoleTeamLead), "/team/add", body)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	status, _ = postAs(t, getTestTokenWithRole(t, "admin", model.RoleAdmin), "/team/add", body)
+	assert.Equal(t, http.StatusCreated, status)
+}
+
+func TestRBAC_E2E_LeadsChangeOnlyTheirOwnTeam(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "lead", Username: "Lead", IsActive: true},
+	})
+	require.NoError(t, err)
+	_, err = testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "frontend"}, nil)
+	require.NoError(t, err)
+
+	leadToken := getTestTokenWithRole(t, "lead", model.RoleTeamLead)
+
+	status, _ := postAs(t, leadToken, "/team/addMember", AddTeamMemberRequest{
+		TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true,
+	})
+	assert.Equal(t, http.StatusOK, status)
+
+	status, errResp := postAs(t, leadToken, "/team/addMember", AddTeamMemberRequest{
+		TeamName: "frontend", UserID: "u2", Username: "Bob", IsActive: true,
+	})
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	memberToken := getTestTokenWithRole(t, "u1", model.RoleMember)
+	status, _ = postAs(t, memberToken, "/team/removeMember", RemoveTeamMemberRequest{TeamName: "backend", UserID: "lead"})
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestRBAC_E2E_OnlyAuthorOrAdminMerges(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "author", Username: "Author", IsActive: true},
+		{ID: "reviewer", Username: "Reviewer", IsActive: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "author"}))
+
+	body := MergePullRequestRequest{PullRequestID: "pr-1"}
+
+	status, errResp := postAs(t, getTestTokenWithRole(t, "reviewer", model.RoleMember), "/pullRequest/merge", body)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	status, _ = postAs(t, getTestTokenWithRole(t, "author", model.RoleMember), "/pullRequest/merge", body)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestRBAC_E2E_ReassignAcrossTeamsRequiresLeadOfPRTeam(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "author", Username: "Author", IsActive: true},
+		{ID: "reviewer", Username: "Reviewer", IsActive: true},
+		{ID: "candidate", Username: "Candidate", IsActive: true},
+	})
+	require.NoError(t, err)
+	_, err = testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "frontend"}, []model.User{
+		{ID: "outsider", Username: "Outsider", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	backend, _, err := testStore.Team().GetByName(ctx, "backend")
+	require.NoError(t, err)
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{
+		ID: "pr-1", Name: "Feature", AuthorID: "author", TeamID: backend.ID, AssignedReviewers: []string{"reviewer"},
+	}))
+
+	body := ReassignReviewerRequest{PullRequestID: "pr-1", OldUserID: "reviewer"}
+
+	status, errResp := postAs(t, getTestTokenWithRole(t, "outsider", model.RoleMember), "/pullRequest/reassign", body)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	outsiderLead := getTestTokenWithRole(t, "outsider", model.RoleTeamLead)
+	status, errResp = postAs(t, outsiderLead, "/pullRequest/reassign", body)
+	assert.Equal(t, http.StatusForbidden, status, "a lead of an unrelated team cannot reassign")
+	assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+
+	_, err = testStore.Team().SetSecondaryMember(ctx, "backend", "outsider", false, "")
+	require.NoError(t, err)
+
+	status, _ = postAs(t, outsiderLead, "/pullRequest/reassign", body)
+	assert.Equal(t, http.StatusOK, status)
+
+	pr, err := testStore.PR().GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"candidate": "outsider"}, pr.AssignedBy)
+}
+
+func TestRBAC_E2E_MutationsRecordActor(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	status, _ := postAs(t, getTestToken(t, "admin"), "/team/add", CreateTeamRequest{
+		TeamName: "backend",
+		Members: []TeamMemberDTO{
+			{UserID: "author", Username: "Author", IsActive: true},
+			{UserID: "reviewer", Username: "Reviewer", IsActive: true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, status)
+
+	team, _, err := testStore.Team().GetByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", team.CreatedBy)
+
+	status, _ = postAs(t, getTestTokenWithRole(t, "author", model.RoleMember), "/pullRequest/create", CreatePullRequestRequest{
+		PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "author",
+	})
+	require.Equal(t, http.StatusCreated, status)
+
+	status, _ = postAs(t, getTestTokenWithRole(t, "author", model.RoleMember), "/pullRequest/merge", MergePullRequestRequest{PullRequestID: "pr-1"})
+	require.Equal(t, http.StatusOK, status)
+
+	pr, err := testStore.PR().GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "author", pr.CreatedBy)
+	assert.Equal(t, "author", pr.MergedBy)
+	assert.Equal(t, map[string]string{"reviewer": "author"}, pr.AssignedBy)
+
+	status, _ = postAs(t, getTestToken(t, "admin"), "/users/setIsActive", SetIsActiveRequest{UserID: "reviewer", IsActive: false})
+	require.Equal(t, http.StatusOK, status)
+
+	user, err := testStore.User().GetByID(ctx, "reviewer")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", user.DeactivatedBy)
+}
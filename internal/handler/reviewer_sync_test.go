@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/github"
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGitHubReviewers struct {
+	mu        sync.Mutex
+	requested map[string][]string
+}
+
+func (f *fakeGitHubReviewers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body struct {
+		Reviewers []string `json:"reviewers"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	path := r.URL.Path
+	switch r.Method {
+	case http.MethodPost:
+		f.requested[path] = append(f.requested[path], body.Reviewers...)
+	case http.MethodDelete:
+		f.requested[path] = slices.DeleteFunc(f.requested[path], func(login string) bool {
+			return slices.Contains(body.Reviewers, login)
+		})
+	}
+
+	users := []map[string]string{}
+	for _, login := range f.requested[path] {
+		users = append(users, map[string]string{"login": login})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"users": users})
+}
+
+func (f *fakeGitHubReviewers) logins(path string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.requested[path])
+}
+
+func (f *fakeGitHubReviewers) set(path string, logins []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requested[path] = logins
+}
+
+func newReviewerSyncTestServer(t *testing.T) (*httptest.Server, *service.Service, *fakeGitHubReviewers) {
+	t.Helper()
+
+	fake := &fakeGitHubReviewers{requested: map[string][]string{}}
+	githubAPI := httptest.NewServer(fake)
+	t.Cleanup(githubAPI.Close)
+
+	appService := service.NewService(service.Dependencies{
+		TeamRepo:         testStore.Team(),
+		UserRepo:         testStore.User(),
+		PRRepo:           testStore.PR(),
+		StatsRepo:        testStore.PR(),
+		APIKeyRepo:       testStore.APIKey(),
+		TokenRepo:        testStore.Token(),
+		IdentityRepo:     testStore.Identity(),
+		CodeHostClient:   github.NewClient(githubAPI.URL, "test-token", githubAPI.Client()),
+		ReviewerSyncRepo: testStore.ReviewerSync(),
+	})
+	server := httptest.NewServer(NewHandler(appService, "123", "", WebhookSecrets{}, testSpecPath, testStore).InitRoutes())
+	t.Cleanup(server.Close)
+
+	return server, appService, fake
+}
+
+func TestReviewerSync_E2E_PushesAssignmentsToGitHub(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	server, appService, fake := newReviewerSyncTestServer(t)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+		{ID: "carol", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+	for userID, login := range map[string]string{"alice": "alice-gh", "bob": "bob-gh", "carol": "carol-gh"} {
+		require.NoError(t, testStore.Identity().Link(ctx, model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalID: login, UserID: userID}))
+	}
+	reviewerCount := 1
+	_, err = testStore.Team().SetPolicy(ctx, "backend", model.AssignmentPolicy{ReviewerCount: &reviewerCount}, "")
+	require.NoError(t, err)
+
+	adminToken := getTestToken(t, "admin")
+	payload, err := json.Marshal(CreatePullRequestRequest{PullRequestID: "acme/api#42", PullRequestName: "Sync", AuthorID: "alice"})
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", server.URL+"/pullRequest/create", bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	processed, err := appService.ReviewerSync.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	const reviewersPath = "/repos/acme/api/pulls/42/requested_reviewers"
+	pr, err := testStore.PR().GetByID(ctx, "acme/api#42")
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 1)
+	first := pr.AssignedReviewers[0]
+	assert.Equal(t, []string{first + "-gh"}, fake.logins(reviewersPath))
+
+	_, _, err = appService.PR.Reassign(service.WithPrincipal(ctx, service.SystemPrincipal), "acme/api#42", first)
+	require.NoError(t, err)
+	_, err = appService.ReviewerSync.ProcessDue(ctx)
+	require.NoError(t, err)
+
+	pr, err = testStore.PR().GetByID(ctx, "acme/api#42")
+	require.NoError(t, err)
+	second := pr.AssignedReviewers[0]
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, []string{second + "-gh"}, fake.logins(reviewersPath))
+
+	fake.set(reviewersPath, []string{first + "-gh"})
+	require.NoError(t, appService.ReviewerSync.Reconcile(ctx))
+	_, err = appService.ReviewerSync.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{second + "-gh"}, fake.logins(reviewersPath))
+}
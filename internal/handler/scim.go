@@ -0,0 +1,447 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+)
+
+const (
+	scimUserSchema     = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema    = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimListSchema     = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimPatchSchema    = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimErrorSchema    = "urn:ietf:params:scim:api:messages:2.0:Error"
+	scimProviderSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	scimContentType = "application/scim+json"
+	scimBasePath    = "/scim/v2"
+)
+
+var (
+	errSCIMInvalidFilter = errors.New("invalid filter")
+	errSCIMInvalidValue  = errors.New("invalid value")
+	errSCIMInvalidPath   = errors.New("invalid path")
+)
+
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+type SCIMGroupRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type SCIMMemberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type SCIMUser struct {
+	Schemas    []string       `json:"schemas"`
+	ID         string         `json:"id,omitempty"`
+	ExternalID string         `json:"externalId,omitempty"`
+	UserName   string         `json:"userName"`
+	Active     *bool          `json:"active,omitempty"`
+	Groups     []SCIMGroupRef `json:"groups,omitempty"`
+	Meta       *SCIMMeta      `json:"meta,omitempty"`
+}
+
+type SCIMGroup struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	DisplayName string          `json:"displayName"`
+	Members     []SCIMMemberRef `json:"members"`
+	Meta        *SCIMMeta       `json:"meta,omitempty"`
+}
+
+type SCIMListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    any      `json:"Resources"`
+}
+
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type SCIMErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+type scimFilterTerm struct {
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+func ConvertProvisionedUserToSCIM(user model.ProvisionedUser) SCIMUser {
+	active := user.User.IsActive
+	groups := make([]SCIMGroupRef, len(user.Memberships))
+	for i, m := range user.Memberships {
+		groups[i] = SCIMGroupRef{Value: strconv.Itoa(m.TeamID), Display: m.TeamName}
+	}
+
+	return SCIMUser{
+		Schemas:    []string{scimUserSchema},
+		ID:         user.User.ID,
+		ExternalID: user.User.ID,
+		UserName:   user.User.Username,
+		Active:     &active,
+		Groups:     groups,
+		Meta: &SCIMMeta{
+			ResourceType: "User",
+			Location:     scimBasePath + "/Users/" + url.PathEscape(user.User.ID),
+		},
+	}
+}
+
+func ConvertProvisionedGroupToSCIM(group model.ProvisionedGroup) SCIMGroup {
+	id := strconv.Itoa(group.Team.ID)
+	members := make([]SCIMMemberRef, len(group.Members))
+	for i, member := range group.Members {
+		members[i] = SCIMMemberRef{Value: member.ID, Display: member.Username}
+	}
+
+	return SCIMGroup{
+		Schemas:     []string{scimGroupSchema},
+		ID:          id,
+		DisplayName: group.Team.Name,
+		Members:     members,
+		Meta: &SCIMMeta{
+			ResourceType: "Group",
+			Location:     scimBasePath + "/Groups/" + id,
+		},
+	}
+}
+
+func writeSCIM(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeSCIMErrorStatus(w http.ResponseWriter, status int, scimType, detail string) {
+	writeSCIM(w, status, SCIMErrorResponse{
+		Schemas:  []string{scimErrorSchema},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+func (h *Handler) writeSCIMError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errSCIMInvalidFilter):
+		writeSCIMErrorStatus(w, http.StatusBadRequest, "invalidFilter", err.Error())
+	case errors.Is(err, errSCIMInvalidPath):
+		writeSCIMErrorStatus(w, http.StatusBadRequest, "invalidPath", err.Error())
+	case errors.Is(err, errSCIMInvalidValue):
+		writeSCIMErrorStatus(w, http.StatusBadRequest, "invalidValue", err.Error())
+	case errors.Is(err, service.ErrNotFound):
+		writeSCIMErrorStatus(w, http.StatusNotFound, "", "resource not found")
+	case errors.Is(err, service.ErrUserExists):
+		writeSCIMErrorStatus(w, http.StatusConflict, "uniqueness", "user already exists")
+	case errors.Is(err, service.ErrTeamExists):
+		writeSCIMErrorStatus(w, http.StatusConflict, "uniqueness", "group display name already exists")
+	case errors.Is(err, service.ErrTeamArchived):
+		writeSCIMErrorStatus(w, http.StatusConflict, "mutability", "group is archived")
+	default:
+		writeSCIMErrorStatus(w, http.StatusInternalServerError, "", "internal server error")
+	}
+}
+
+func parseSCIMFilter(filter string) ([]scimFilterTerm, error) {
+	var terms []scimFilterTerm
+	rest := strings.TrimSpace(filter)
+	for rest != "" {
+		var term scimFilterTerm
+		var ok bool
+
+		term.Attribute, rest, ok = strings.Cut(rest, " ")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errSCIMInvalidFilter, filter)
+		}
+		term.Operator, rest, ok = strings.Cut(strings.TrimLeft(rest, " "), " ")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errSCIMInvalidFilter, filter)
+		}
+		term.Operator = strings.ToLower(term.Operator)
+		rest = strings.TrimLeft(rest, " ")
+
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated string", errSCIMInvalidFilter)
+			}
+			term.Value, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			term.Value, rest, _ = strings.Cut(rest, " ")
+		}
+		terms = append(terms, term)
+
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			break
+		}
+
+		var conj string
+		conj, rest, _ = strings.Cut(rest, " ")
+		if !strings.EqualFold(conj, "and") {
+			return nil, fmt.Errorf("%w: only 'and' is supported", errSCIMInvalidFilter)
+		}
+	}
+
+	return terms, nil
+}
+
+func parseSCIMUserFilter(filter string) (model.UserFilter, error) {
+	var result model.UserFilter
+	terms, err := parseSCIMFilter(filter)
+	if err != nil {
+		return result, err
+	}
+
+	for _, term := range terms {
+		switch {
+		case strings.EqualFold(term.Attribute, "userName") && term.Operator == "eq":
+			result.Username = term.Value
+		case strings.EqualFold(term.Attribute, "userName") && term.Operator == "sw":
+			result.NamePrefix = term.Value
+		case strings.EqualFold(term.Attribute, "active") && term.Operator == "eq":
+			active, err := strconv.ParseBool(term.Value)
+			if err != nil {
+				return result, fmt.Errorf("%w: active must be a boolean", errSCIMInvalidFilter)
+			}
+			result.IsActive = &active
+		case strings.EqualFold(term.Attribute, "groups.display") && term.Operator == "eq":
+			result.TeamName = term.Value
+		default:
+			return result, fmt.Errorf("%w: unsupported expression %s %s", errSCIMInvalidFilter, term.Attribute, term.Operator)
+		}
+	}
+
+	return result, nil
+}
+
+func parseSCIMGroupFilter(filter string) (model.TeamFilter, error) {
+	var result model.TeamFilter
+	terms, err := parseSCIMFilter(filter)
+	if err != nil {
+		return result, err
+	}
+
+	for _, term := range terms {
+		switch {
+		case strings.EqualFold(term.Attribute, "displayName") && term.Operator == "eq":
+			result.Name = term.Value
+		case strings.EqualFold(term.Attribute, "displayName") && term.Operator == "sw":
+			result.NamePrefix = term.Value
+		default:
+			return result, fmt.Errorf("%w: unsupported expression %s %s", errSCIMInvalidFilter, term.Attribute, term.Operator)
+		}
+	}
+
+	return result, nil
+}
+
+func parseSCIMPage(query url.Values) (int, int, error) {
+	startIndex := 1
+	if raw := query.Get("startIndex"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: startIndex", errSCIMInvalidValue)
+		}
+		startIndex = max(parsed, 1)
+	}
+
+	count := 0
+	if raw := query.Get("count"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return 0, 0, fmt.Errorf("%w: count", errSCIMInvalidValue)
+		}
+		count = parsed
+	}
+
+	return startIndex, count, nil
+}
+
+func parseSCIMBool(raw json.RawMessage) (bool, error) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if parsed, err := strconv.ParseBool(text); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return false, fmt.Errorf("%w: expected boolean", errSCIMInvalidValue)
+}
+
+func parseSCIMUserPatch(req SCIMPatchRequest) (model.UserPatch, error) {
+	var patch model.UserPatch
+	for _, op := range req.Operations {
+		if !strings.EqualFold(op.Op, "add") && !strings.EqualFold(op.Op, "replace") {
+			return patch, fmt.Errorf("%w: unsupported op %q for users", errSCIMInvalidValue, op.Op)
+		}
+
+		values := map[string]json.RawMessage{}
+		if op.Path == "" {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return patch, fmt.Errorf("%w: expected object", errSCIMInvalidValue)
+			}
+		} else {
+			values[op.Path] = op.Value
+		}
+
+		for attr, raw := range values {
+			switch {
+			case strings.EqualFold(attr, "active"):
+				active, err := parseSCIMBool(raw)
+				if err != nil {
+					return patch, err
+				}
+				patch.IsActive = &active
+			case strings.EqualFold(attr, "userName"):
+				var username string
+				if err := json.Unmarshal(raw, &username); err != nil || username == "" {
+					return patch, fmt.Errorf("%w: userName must be a non-empty string", errSCIMInvalidValue)
+				}
+				patch.Username = &username
+			default:
+				return patch, fmt.Errorf("%w: %s", errSCIMInvalidPath, attr)
+			}
+		}
+	}
+
+	return patch, nil
+}
+
+func parseSCIMMemberValues(raw json.RawMessage) ([]string, error) {
+	var refs []SCIMMemberRef
+	if err := json.Unmarshal(raw, &refs); err != nil {
+		return nil, fmt.Errorf("%w: members must be a list", errSCIMInvalidValue)
+	}
+
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.Value
+	}
+
+	return ids, nil
+}
+
+func parseSCIMGroupPatch(req SCIMPatchRequest) (model.GroupPatch, error) {
+	var patch model.GroupPatch
+	for _, op := range req.Operations {
+		kind := strings.ToLower(op.Op)
+		path := op.Path
+
+		if kind == "remove" {
+			if memberID, ok := parseSCIMMemberPath(path); ok {
+				patch.RemoveMembers = append(patch.RemoveMembers, memberID)
+				continue
+			}
+			if !strings.EqualFold(path, "members") {
+				return patch, fmt.Errorf("%w: %s", errSCIMInvalidPath, path)
+			}
+			if len(op.Value) == 0 {
+				patch.ReplaceMembers = true
+				patch.Members = nil
+				continue
+			}
+
+			ids, err := parseSCIMMemberValues(op.Value)
+			if err != nil {
+				return patch, err
+			}
+			patch.RemoveMembers = append(patch.RemoveMembers, ids...)
+			continue
+		}
+
+		if kind != "add" && kind != "replace" {
+			return patch, fmt.Errorf("%w: unsupported op %q", errSCIMInvalidValue, op.Op)
+		}
+
+		values := map[string]json.RawMessage{}
+		if path == "" {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return patch, fmt.Errorf("%w: expected object", errSCIMInvalidValue)
+			}
+		} else {
+			values[path] = op.Value
+		}
+
+		for attr, raw := range values {
+			switch {
+			case strings.EqualFold(attr, "displayName"):
+				var name string
+				if err := json.Unmarshal(raw, &name); err != nil || name == "" {
+					return patch, fmt.Errorf("%w: displayName must be a non-empty string", errSCIMInvalidValue)
+				}
+				patch.Name = &name
+			case strings.EqualFold(attr, "members"):
+				ids, err := parseSCIMMemberValues(raw)
+				if err != nil {
+					return patch, err
+				}
+				if kind == "replace" {
+					patch.ReplaceMembers = true
+					patch.Members = ids
+				} else if patch.ReplaceMembers {
+					patch.Members = append(patch.Members, ids...)
+				} else {
+					patch.AddMembers = append(patch.AddMembers, ids...)
+				}
+			default:
+				return patch, fmt.Errorf("%w: %s", errSCIMInvalidPath, attr)
+			}
+		}
+	}
+
+	return patch, nil
+}
+
+func parseSCIMMemberPath(path string) (string, bool) {
+	inner, ok := strings.CutPrefix(path, "members[")
+	if !ok {
+		return "", false
+	}
+	inner, ok = strings.CutSuffix(inner, "]")
+	if !ok {
+		return "", false
+	}
+
+	terms, err := parseSCIMFilter(inner)
+	if err != nil || len(terms) != 1 || terms[0].Attribute != "value" || terms[0].Operator != "eq" {
+		return "", false
+	}
+
+	return terms[0].Value, true
+}
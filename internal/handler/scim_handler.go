@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) scimRoutes(r chi.Router) {
+	r.Use(h.scimAuthMiddleware)
+
+	r.Get("/ServiceProviderConfig", h.getSCIMServiceProviderConfig)
+
+	r.Route("/Users", func(r chi.Router) {
+		r.Get("/", h.listSCIMUsers)
+		r.Post("/", h.createSCIMUser)
+		r.Get("/{id}", h.getSCIMUser)
+		r.Put("/{id}", h.replaceSCIMUser)
+		r.Patch("/{id}", h.patchSCIMUser)
+		r.Delete("/{id}", h.deleteSCIMUser)
+	})
+
+	r.Route("/Groups", func(r chi.Router) {
+		r.Get("/", h.listSCIMGroups)
+		r.Post("/", h.createSCIMGroup)
+		r.Get("/{id}", h.getSCIMGroup)
+		r.Put("/{id}", h.replaceSCIMGroup)
+		r.Patch("/{id}", h.patchSCIMGroup)
+		r.Delete("/{id}", h.deleteSCIMGroup)
+	})
+}
+
+func (h *Handler) getSCIMServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeSCIM(w, http.StatusOK, map[string]any{
+		"schemas":        []string{scimProviderSchema},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": 100},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]string{{
+			"type": "oauthbearertoken",
+			"name": "Bearer Token",
+		}},
+	})
+}
+
+func decodeSCIM(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid json request", errSCIMInvalidValue)
+	}
+
+	return nil
+}
+
+func (h *Handler) listSCIMUsers(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, err := parseSCIMPage(r.URL.Query())
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	filter, err := parseSCIMUserFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+	filter.Offset = startIndex - 1
+	filter.Limit = count
+
+	users, total, err := h.provisioningService.ListUsers(r.Context(), filter)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	resources := make([]SCIMUser, len(users))
+	for i, user := range users {
+		resources[i] = ConvertProvisionedUserToSCIM(user)
+	}
+
+	writeSCIM(w, http.StatusOK, SCIMListResponse{
+		Schemas:      []string{scimListSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) getSCIMUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.provisioningService.GetUser(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, ConvertProvisionedUserToSCIM(*user))
+}
+
+func (h *Handler) createSCIMUser(w http.ResponseWriter, r *http.Request) {
+	var req SCIMUser
+	if err := decodeSCIM(r, &req); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	if req.UserName == "" {
+		h.writeSCIMError(w, fmt.Errorf("%w: userName is required", errSCIMInvalidValue))
+		return
+	}
+
+	user := model.User{ID: req.ExternalID, Username: req.UserName, IsActive: true}
+	if user.ID == "" {
+		user.ID = req.UserName
+	}
+	if req.Active != nil {
+		user.IsActive = *req.Active
+	}
+
+	created, err := h.provisioningService.CreateUser(r.Context(), user)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusCreated, ConvertProvisionedUserToSCIM(*created))
+}
+
+func (h *Handler) replaceSCIMUser(w http.ResponseWriter, r *http.Request) {
+	var req SCIMUser
+	if err := decodeSCIM(r, &req); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	if req.UserName == "" {
+		h.writeSCIMError(w, fmt.Errorf("%w: userName is required", errSCIMInvalidValue))
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	patch := model.UserPatch{Username: &req.UserName, IsActive: &active}
+	h.updateSCIMUser(w, r, patch)
+}
+
+func (h *Handler) patchSCIMUser(w http.ResponseWriter, r *http.Request) {
+	var req SCIMPatchRequest
+	if err := decodeSCIM(r, &req); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	patch, err := parseSCIMUserPatch(req)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	h.updateSCIMUser(w, r, patch)
+}
+
+func (h *Handler) updateSCIMUser(w http.ResponseWriter, r *http.Request, patch model.UserPatch) {
+	user, err := h.provisioningService.UpdateUser(r.Context(), chi.URLParam(r, "id"), patch)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, ConvertProvisionedUserToSCIM(*user))
+}
+
+func (h *Handler) deleteSCIMUser(w http.ResponseWriter, r *http.Request) {
+	if err := h.provisioningService.DeprovisionUser(r.Context(), chi.URLParam(r, "id")); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listSCIMGroups(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, err := parseSCIMPage(r.URL.Query())
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	filter, err := parseSCIMGroupFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+	filter.Offset = startIndex - 1
+	filter.Limit = count
+
+	groups, total, err := h.provisioningService.ListGroups(r.Context(), filter)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	resources := make([]SCIMGroup, len(groups))
+	for i, group := range groups {
+		resources[i] = ConvertProvisionedGroupToSCIM(group)
+	}
+
+	writeSCIM(w, http.StatusOK, SCIMListResponse{
+		Schemas:      []string{scimListSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func parseSCIMGroupID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, service.ErrNotFound
+	}
+
+	return id, nil
+}
+
+func (h *Handler) getSCIMGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := parseSCIMGroupID(r)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	group, err := h.provisioningService.GetGroup(r.Context(), id)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, ConvertProvisionedGroupToSCIM(*group))
+}
+
+func (h *Handler) createSCIMGroup(w http.ResponseWriter, r *http.Request) {
+	var req SCIMGroup
+	if err := decodeSCIM(r, &req); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	if req.DisplayName == "" {
+		h.writeSCIMError(w, fmt.Errorf("%w: displayName is required", errSCIMInvalidValue))
+		return
+	}
+
+	memberIDs := make([]string, len(req.Members))
+	for i, member := range req.Members {
+		memberIDs[i] = member.Value
+	}
+
+	group, err := h.provisioningService.CreateGroup(r.Context(), req.DisplayName, memberIDs)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusCreated, ConvertProvisionedGroupToSCIM(*group))
+}
+
+func (h *Handler) replaceSCIMGroup(w http.ResponseWriter, r *http.Request) {
+	var req SCIMGroup
+	if err := decodeSCIM(r, &req); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	if req.DisplayName == "" {
+		h.writeSCIMError(w, fmt.Errorf("%w: displayName is required", errSCIMInvalidValue))
+		return
+	}
+
+	patch := model.GroupPatch{Name: &req.DisplayName, ReplaceMembers: true}
+	for _, member := range req.Members {
+		patch.Members = append(patch.Members, member.Value)
+	}
+
+	h.updateSCIMGroup(w, r, patch)
+}
+
+func (h *Handler) patchSCIMGroup(w http.ResponseWriter, r *http.Request) {
+	var req SCIMPatchRequest
+	if err := decodeSCIM(r, &req); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	patch, err := parseSCIMGroupPatch(req)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	h.updateSCIMGroup(w, r, patch)
+}
+
+func (h *Handler) updateSCIMGroup(w http.ResponseWriter, r *http.Request, patch model.GroupPatch) {
+	id, err := parseSCIMGroupID(r)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	group, err := h.provisioningService.UpdateGroup(r.Context(), id, patch)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, ConvertProvisionedGroupToSCIM(*group))
+}
+
+func (h *Handler) deleteSCIMGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := parseSCIMGroupID(r)
+	if err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	if err := h.provisioningService.DeleteGroup(r.Context(), id); err != nil {
+		h.writeSCIMError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doSCIMRequest(t *testing.T, method, path, token, body string) *http.Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, testServerURL+"/scim/v2"+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", scimContentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestSCIMHandler_E2E_RejectsUserJWT(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	resp := doSCIMRequest(t, "GET", "/Users", getTestToken(t, "test-user"), "")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	var scimErr SCIMErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&scimErr))
+	assert.Equal(t, "401", scimErr.Status)
+}
+
+func TestSCIMHandler_E2E_UserAndGroupLifecycle(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "author", Username: "Author", IsActive: true},
+		{ID: "u2", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	resp := doSCIMRequest(t, "POST", "/Users", testSCIMToken,
+		`{"schemas":["`+scimUserSchema+`"],"externalId":"u1","userName":"alice","active":true}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var user SCIMUser
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+	assert.Equal(t, "u1", user.ID)
+
+	resp = doSCIMRequest(t, "POST", "/Users", testSCIMToken, `{"externalId":"u1","userName":"alice"}`)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	team, _, err := testStore.Team().GetByName(ctx, "backend")
+	require.NoError(t, err)
+	groupPath := "/Groups/" + strconv.Itoa(team.ID)
+
+	resp = doSCIMRequest(t, "PATCH", groupPath, testSCIMToken,
+		`{"schemas":["`+scimPatchSchema+`"],"Operations":[{"op":"add","path":"members","value":[{"value":"u1"}]}]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var group SCIMGroup
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&group))
+	resp.Body.Close()
+	assert.Len(t, group.Members, 3)
+
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{
+		ID: "pr-1", Name: "Feature", AuthorID: "author", TeamID: team.ID, AssignedReviewers: []string{"u1"},
+	}))
+
+	resp = doSCIMRequest(t, "PATCH", "/Users/u1", testSCIMToken,
+		`{"schemas":["`+scimPatchSchema+`"],"Operations":[{"op":"replace","path":"active","value":"False"}]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&user))
+	resp.Body.Close()
+	require.NotNil(t, user.Active)
+	assert.False(t, *user.Active)
+
+	pr, err := testStore.PR().GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+
+	filter := url.QueryEscape(`userName eq "alice" and active eq false`)
+	resp = doSCIMRequest(t, "GET", "/Users?filter="+filter, testSCIMToken, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var list struct {
+		TotalResults int        `json:"totalResults"`
+		Resources    []SCIMUser `json:"Resources"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	assert.Equal(t, 1, list.TotalResults)
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "u1", list.Resources[0].ID)
+	require.Len(t, list.Resources[0].Groups, 1)
+	assert.Equal(t, "backend", list.Resources[0].Groups[0].Display)
+
+	resp = doSCIMRequest(t, "PATCH", groupPath, testSCIMToken,
+		`{"schemas":["`+scimPatchSchema+`"],"Operations":[{"op":"remove","path":"members[value eq \"u1\"]"},{"op":"replace","path":"displayName","value":"core"}]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&group))
+	resp.Body.Close()
+	assert.Equal(t, "core", group.DisplayName)
+	assert.Len(t, group.Members, 2)
+
+	resp = doSCIMRequest(t, "DELETE", groupPath, testSCIMToken, "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = doSCIMRequest(t, "GET", groupPath, testSCIMToken, "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSCIMHandler_E2E_CreateGroupAndFilter(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	resp := doSCIMRequest(t, "POST", "/Users", testSCIMToken, `{"userName":"carol"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = doSCIMRequest(t, "POST", "/Groups", testSCIMToken,
+		`{"schemas":["`+scimGroupSchema+`"],"displayName":"frontend","members":[{"value":"carol"}]}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	user, err := testStore.User().GetByID(ctx, "carol")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName)
+
+	filter := url.QueryEscape(`displayName eq "frontend"`)
+	resp = doSCIMRequest(t, "GET", "/Groups?filter="+filter, testSCIMToken, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var list struct {
+		TotalResults int         `json:"totalResults"`
+		Resources    []SCIMGroup `json:"Resources"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	assert.Equal(t, 1, list.TotalResults)
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "carol", list.Resources[0].Members[0].Value)
+
+	filter = url.QueryEscape(`displayName co "front"`)
+	resp = doSCIMRequest(t, "GET", "/Groups?filter="+filter, testSCIMToken, "")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var scimErr SCIMErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&scimErr))
+	assert.Equal(t, "invalidFilter", scimErr.SCIMType)
+}
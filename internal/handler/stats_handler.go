@@ -16,3 +16,14 @@ func (h *Handler) getUserStats(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, map[string]any{"user_stats": stats})
 }
+
+func (h *Handler) getTeamStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.statsService.GetTeamStats(r.Context(), r.URL.Query().Get("team_name"))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"team_stats": stats})
+}
@@ -101,3 +101,52 @@ func TestGetUserStats(t *testing.T) {
 		require.Len(t, result.UserStats, 0)
 	})
 }
+
+func TestGetTeamStats(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - rolls up review counts by org unit", func(t *testing.T) {
+		truncateTables(ctx)
+
+		_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "engineering"}, nil)
+		require.NoError(t, err)
+		backend, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+			{ID: "author", Username: "Author", IsActive: true},
+			{ID: "user1", Username: "User One", IsActive: true},
+			{ID: "user2", Username: "User Two", IsActive: true},
+		})
+		require.NoError(t, err)
+		_, err = testStore.Team().SetParent(ctx, "backend", "engineering", "")
+		require.NoError(t, err)
+
+		err = testStore.PR().Create(ctx, model.PullRequest{
+			ID: "pr1", Name: "PR One", AuthorID: "author", TeamID: backend.Team.ID, AssignedReviewers: []string{"user1", "user2"},
+		})
+		require.NoError(t, err)
+
+		token := getTestToken(t, "test-user")
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServerURL+"/stats/team?team_name=engineering", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			TeamStats []model.TeamStats `json:"team_stats"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		require.NoError(t, err)
+
+		require.Len(t, result.TeamStats, 1)
+		require.Equal(t, "engineering", result.TeamStats[0].TeamName)
+		require.Equal(t, 0, result.TeamStats[0].ReviewCount)
+		require.Equal(t, 2, result.TeamStats[0].TotalReviewCount)
+		require.Len(t, result.TeamStats[0].Children, 1)
+		require.Equal(t, "backend", result.TeamStats[0].Children[0].TeamName)
+	})
+}
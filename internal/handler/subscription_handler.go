@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/go-chi/render"
+)
+
+func (h *Handler) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var req CreateSubscriptionRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	eventTypes := make([]model.EventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = model.EventType(eventType)
+	}
+
+	sub, err := h.subscriptionService.Create(r.Context(), model.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, CreatedSubscriptionResponse{
+		SubscriptionResponse: ConvertSubscriptionModelToDTO(*sub),
+		Secret:               sub.Secret,
+	})
+}
+
+func (h *Handler) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.subscriptionService.List(r.Context())
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]SubscriptionResponse, len(subs))
+	for i, sub := range subs {
+		resp[i] = ConvertSubscriptionModelToDTO(sub)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"subscriptions": resp})
+}
+
+func (h *Handler) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	var req DeleteSubscriptionRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.subscriptionService.Delete(r.Context(), req.SubscriptionID); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listSubscriptionDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := model.DeliveryFilter{Status: model.DeliveryStatus(query.Get("status"))}
+
+	if raw := query.Get("subscription_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			h.writeBadRequest(w, r, "invalid query parameter: subscription_id")
+			return
+		}
+		filter.SubscriptionID = id
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		h.writeBadRequest(w, r, "invalid query parameter: status")
+		return
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			h.writeBadRequest(w, r, "invalid query parameter: limit")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	deliveries, err := h.subscriptionService.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]DeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		resp[i] = ConvertDeliveryModelToDTO(delivery)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"deliveries": resp})
+}
+
+func (h *Handler) redeliverSubscriptionDelivery(w http.ResponseWriter, r *http.Request) {
+	var req RedeliverRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	delivery, err := h.subscriptionService.Redeliver(r.Context(), req.DeliveryID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, map[string]any{"delivery": ConvertDeliveryModelToDTO(*delivery)})
+}
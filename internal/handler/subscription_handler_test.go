@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	bodies   [][]byte
+	headers  []http.Header
+	received int
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	rcv.bodies = append(rcv.bodies, body)
+	rcv.headers = append(rcv.headers, r.Header.Clone())
+	rcv.received++
+	w.WriteHeader(rcv.status)
+}
+
+func (rcv *webhookReceiver) respondWith(status int) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	rcv.status = status
+}
+
+func doJSONAs(t *testing.T, token, method, path string, body, out any) int {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, testServerURL+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < http.StatusBadRequest {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+
+	return resp.StatusCode
+}
+
+func TestSubscriptions_E2E_SignedDeliveryRetryAndRedeliver(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	receiverServer := httptest.NewServer(receiver)
+	defer receiverServer.Close()
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice", IsActive: true},
+		{ID: "bob", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	adminToken := getTestToken(t, "admin")
+
+	var created CreatedSubscriptionResponse
+	status := doJSONAs(t, adminToken, "POST", "/webhookSubscriptions/create", CreateSubscriptionRequest{
+		URL:        publicTestURL(receiverServer, "receiver.test"),
+		Secret:     "receiver-shared-secret",
+		EventTypes: []string{string(model.EventPRCreated), string(model.EventPRMerged)},
+	}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "receiver-shared-secret", created.Secret)
+
+	status = doJSONAs(t, adminToken, "POST", "/pullRequest/create", CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Hooks", AuthorID: "alice"}, nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	relayOutbox(t, ctx)
+	processed, err := testService.Subscriptions.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	require.Len(t, receiver.bodies, 1)
+	assert.Equal(t, webhook.Sign("receiver-shared-secret", receiver.bodies[0]), receiver.headers[0].Get(webhook.SignatureHeader))
+	assert.Equal(t, string(model.EventPRCreated), receiver.headers[0].Get(webhook.EventHeader))
+
+	var event model.Event
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &event))
+	assert.Equal(t, "pr-1", event.PullRequest.ID)
+	assert.Equal(t, []string{"bob"}, event.PullRequest.AssignedReviewers)
+	assert.Equal(t, "admin", event.ActorID)
+
+	receiver.respondWith(http.StatusInternalServerError)
+	status = doJSONAs(t, adminToken, "POST", "/pullRequest/merge", MergePullRequestRequest{PullRequestID: "pr-1"}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	relayOutbox(t, ctx)
+	_, err = testService.Subscriptions.ProcessDue(ctx)
+	require.NoError(t, err)
+
+	var log struct {
+		Deliveries []DeliveryResponse `json:"deliveries"`
+	}
+	status = doJSONAs(t, adminToken, "GET", fmt.Sprintf("/webhookSubscriptions/deliveries?subscription_id=%d", created.SubscriptionID), nil, &log)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, log.Deliveries, 2)
+
+	failed := log.Deliveries[0]
+	assert.Equal(t, string(model.EventPRMerged), failed.Event)
+	assert.Equal(t, string(model.DeliveryPending), failed.Status)
+	assert.NotNil(t, failed.NextAttemptAt)
+	require.Len(t, failed.AttemptLog, 1)
+	assert.Equal(t, http.StatusInternalServerError, failed.AttemptLog[0].StatusCode)
+
+	delivered := log.Deliveries[1]
+	assert.Equal(t, string(model.DeliveryDelivered), delivered.Status)
+	require.Len(t, delivered.AttemptLog, 1)
+	assert.Equal(t, http.StatusOK, delivered.AttemptLog[0].StatusCode)
+
+	processed, err = testService.Subscriptions.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed, "failed delivery waits for its backoff")
+
+	receiver.respondWith(http.StatusNoContent)
+	status = doJSONAs(t, adminToken, "POST", "/webhookSubscriptions/redeliver", RedeliverRequest{DeliveryID: failed.DeliveryID}, nil)
+	require.Equal(t, http.StatusAccepted, status)
+
+	processed, err = testService.Subscriptions.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	status = doJSONAs(t, adminToken, "GET", "/webhookSubscriptions/deliveries?status=DELIVERED", nil, &log)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, log.Deliveries, 2)
+	assert.Equal(t, failed.DeliveryID, log.Deliveries[0].DeliveryID)
+	require.Len(t, log.Deliveries[0].AttemptLog, 2)
+	assert.Equal(t, http.StatusNoContent, log.Deliveries[0].AttemptLog[1].StatusCode)
+	assert.Equal(t, 3, receiver.received)
+}
+
+func TestSubscriptions_E2E_AdminOnlyAndValidation(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	memberToken := getTestTokenWithRole(t, "bob", model.RoleMember)
+	status, _ := postAs(t, memberToken, "/webhookSubscriptions/create", CreateSubscriptionRequest{URL: "https://ci.example.com", EventTypes: []string{"pull_request.merged"}})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	adminToken := getTestToken(t, "admin")
+	status, errResp := postAs(t, adminToken, "/webhookSubscriptions/create", CreateSubscriptionRequest{URL: "https://ci.example.com", EventTypes: []string{"pull_request.deleted"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_SUBSCRIPTION", errResp.Error.Code)
+
+	status, _ = postAs(t, adminToken, "/webhookSubscriptions/delete", DeleteSubscriptionRequest{SubscriptionID: 999})
+	assert.Equal(t, http.StatusNotFound, status)
+
+	assert.Equal(t, http.StatusBadRequest, getAs(t, adminToken, "/webhookSubscriptions/deliveries?status=LOST"))
+}
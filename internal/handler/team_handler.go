@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 
+	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/go-chi/render"
 )
 
@@ -21,15 +24,15 @@ func (h *Handler) createTeam(w http.ResponseWriter, r *http.Request) {
 
 	teamModel, userModels := ConvertCreateTeamDTOToModels(req)
 
-	createdTeam, createdMembers, err := h.teamService.Create(r.Context(), teamModel, userModels)
+	result, err := h.teamService.Create(r.Context(), teamModel, userModels)
 	if err != nil {
 		h.WriteError(w, r, err)
 		return
 	}
 
-	response := ConvertTeamModelsToDTO(*createdTeam, createdMembers)
+	response := ConvertTeamUpsertToDTO(*result)
 	render.Status(r, http.StatusCreated)
-	render.JSON(w, r, map[string]any{"team": response})
+	render.JSON(w, r, response)
 }
 
 func (h *Handler) getTeam(w http.ResponseWriter, r *http.Request) {
@@ -39,13 +42,335 @@ func (h *Handler) getTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	team, members, err := h.teamService.Get(r.Context(), teamName)
+	response, err := h.getTeamDetails(r.Context(), teamName)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *Handler) listTeams(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	after, limit, err := parsePageQuery(query)
+	if err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	includeArchived := false
+	if raw := query.Get("include_archived"); raw != "" {
+		includeArchived, err = strconv.ParseBool(raw)
+		if err != nil {
+			h.writeBadRequest(w, r, "invalid query parameter: include_archived")
+			return
+		}
+	}
+
+	filter := model.TeamFilter{
+		NamePrefix:      query.Get("name_prefix"),
+		ParentName:      query.Get("parent_team_name"),
+		IncludeArchived: includeArchived,
+		After:           after,
+		Limit:           limit,
+	}
+
+	teams, next, err := h.teamService.List(r.Context(), filter)
 	if err != nil {
 		h.WriteError(w, r, err)
 		return
 	}
 
-	response := ConvertTeamModelsToDTO(*team, members)
+	response := TeamListResponse{Teams: make([]TeamSummaryResponse, len(teams))}
+	for i, team := range teams {
+		response.Teams[i] = ConvertTeamSummaryToDTO(team)
+	}
+	if next != "" {
+		response.NextCursor = encodeCursor(next)
+	}
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, response)
 }
+
+func (h *Handler) addTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req AddTeamMemberRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	member := model.User{
+		ID:       req.UserID,
+		Username: req.Username,
+		IsActive: req.IsActive,
+	}
+
+	user, err := h.membershipService.AddMember(r.Context(), req.TeamName, member)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response := ConvertFullUserModelToDTO(*user)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"user": response})
+}
+
+func (h *Handler) removeTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req RemoveTeamMemberRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	change, err := h.membershipService.RemoveMember(r.Context(), req.TeamName, req.UserID, model.ReviewPolicy(req.OpenReviews))
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ConvertMembershipChangeToDTO(*change))
+}
+
+func (h *Handler) moveTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req MoveTeamMemberRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	change, err := h.membershipService.MoveMember(
+		r.Context(),
+		req.UserID,
+		req.TeamName,
+		model.ReviewPolicy(req.OpenReviews),
+		model.AuthoredPolicy(req.AuthoredPRs),
+	)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ConvertMembershipChangeToDTO(*change))
+}
+
+func (h *Handler) setSecondaryTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req SetSecondaryMemberRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	membership, err := h.membershipService.SetSecondaryMember(r.Context(), req.TeamName, req.UserID, req.Reviewable)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{
+		"user_id":    membership.UserID,
+		"membership": ConvertMembershipModelToDTO(*membership),
+	})
+}
+
+func (h *Handler) setTeamParent(w http.ResponseWriter, r *http.Request) {
+	var req SetTeamParentRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if _, err := h.teamService.SetParent(r.Context(), req.TeamName, req.ParentTeamName); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response, err := h.getTeamDetails(r.Context(), req.TeamName)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *Handler) setTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	var req SetTeamPolicyRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	policy := model.AssignmentPolicy{
+		ReviewerCount:    req.ReviewerCount,
+		FallbackToParent: req.FallbackToParent,
+	}
+
+	if _, err := h.teamService.SetPolicy(r.Context(), req.TeamName, policy); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response, err := h.getTeamDetails(r.Context(), req.TeamName)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *Handler) renameTeam(w http.ResponseWriter, r *http.Request) {
+	var req RenameTeamRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	team, err := h.teamService.Rename(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response, err := h.getTeamDetails(r.Context(), team.Name)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *Handler) archiveTeam(w http.ResponseWriter, r *http.Request) {
+	h.setTeamArchived(w, r, true)
+}
+
+func (h *Handler) unarchiveTeam(w http.ResponseWriter, r *http.Request) {
+	h.setTeamArchived(w, r, false)
+}
+
+func (h *Handler) setTeamArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	var req ArchiveTeamRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	if _, err := h.teamService.SetArchived(r.Context(), req.TeamName, archived); err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response, err := h.getTeamDetails(r.Context(), req.TeamName)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *Handler) previewTeamDeletion(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		h.writeBadRequest(w, r, "missing required query parameter: team_name")
+		return
+	}
+
+	report, err := h.teamService.GetDeletionReport(r.Context(), teamName)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ConvertDeletionReportToDTO(*report))
+}
+
+func (h *Handler) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	var req DeleteTeamRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.writeBadRequest(w, r, "invalid json request")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	report, err := h.teamService.Delete(r.Context(), req.TeamName, req.Force)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ConvertDeletionReportToDTO(*report))
+}
+
+func (h *Handler) getTeamDetails(ctx context.Context, teamName string) (*TeamDetailsResponse, error) {
+	team, members, err := h.teamService.Get(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	hierarchy, err := h.teamService.GetHierarchy(ctx, *team)
+	if err != nil {
+		return nil, err
+	}
+
+	response := ConvertTeamDetailsToDTO(*team, members, *hierarchy)
+	return &response, nil
+}
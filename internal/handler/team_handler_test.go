@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -198,3 +199,308 @@ func TestTeamHandler_E2E_GetTeam_MissingQueryParam(t *testing.T) {
 	assert.Equal(t, "BAD_REQUEST", errResp.Error.Code)
 	assert.Contains(t, errResp.Error.Message, "missing required query parameter: team_name")
 }
+
+func TestTeamHandler_E2E_MoveMember(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "move-from"}, []model.User{
+		{ID: "mover", Username: "Mover", IsActive: true},
+		{ID: "stayer", Username: "Stayer", IsActive: true},
+	})
+	require.NoError(t, err)
+	_, err = testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "move-to"}, nil)
+	require.NoError(t, err)
+
+	token := getTestToken(t, "test-user")
+	moveBody := `{"user_id": "mover", "team_name": "move-to", "open_reviews": "reassign", "authored_prs": "transfer"}`
+
+	req, err := http.NewRequest("POST", testServerURL+"/team/moveMember", strings.NewReader(moveBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var moveResp MembershipChangeResponse
+	err = json.NewDecoder(resp.Body).Decode(&moveResp)
+	require.NoError(t, err)
+	assert.Equal(t, "move-to", moveResp.User.TeamName)
+
+	addBody := `{"team_name": "move-from", "user_id": "mover", "username": "Mover", "is_active": true}`
+
+	req, err = http.NewRequest("POST", testServerURL+"/team/addMember", strings.NewReader(addBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	var errResp APIErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&errResp)
+	require.NoError(t, err)
+	assert.Equal(t, "USER_IN_OTHER_TEAM", errResp.Error.Code)
+}
+
+func TestTeamHandler_E2E_CreateTeam_UpsertsExistingMembers(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "old-team"}, []model.User{
+		{ID: "existing-user", Username: "Old Name", IsActive: false},
+	})
+	require.NoError(t, err)
+
+	token := getTestToken(t, "test-user")
+	createBody := `
+	{
+		"team_name": "new-team",
+		"members": [
+			{"user_id": "existing-user", "username": "New Name", "is_active": true},
+			{"user_id": "fresh-user", "username": "Fresh", "is_active": true}
+		]
+	}`
+
+	req, err := http.NewRequest("POST", testServerURL+"/team/add", strings.NewReader(createBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var createResp CreateTeamResponse
+	err = json.NewDecoder(resp.Body).Decode(&createResp)
+	require.NoError(t, err)
+
+	assert.Equal(t, "new-team", createResp.Team.TeamName)
+	assert.Len(t, createResp.Team.Members, 2)
+	assert.Equal(t, []string{"fresh-user"}, createResp.CreatedMembers)
+	assert.Equal(t, []string{"existing-user"}, createResp.UpdatedMembers)
+}
+
+func TestTeamHandler_E2E_CreateTeam_DuplicateMember(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	token := getTestToken(t, "test-user")
+	createBody := `
+	{
+		"team_name": "dup-team",
+		"members": [
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u1", "username": "Alice Again", "is_active": true}
+		]
+	}`
+
+	req, err := http.NewRequest("POST", testServerURL+"/team/add", strings.NewReader(createBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var errResp APIErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&errResp)
+	require.NoError(t, err)
+	assert.Equal(t, "DUPLICATE_MEMBER", errResp.Error.Code)
+}
+
+func TestTeamHandler_E2E_TeamHierarchy(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	for _, name := range []string{"engineering", "backend", "payments"} {
+		_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: name}, nil)
+		require.NoError(t, err)
+	}
+	_, err := testStore.Team().SetParent(ctx, "payments", "backend", "")
+	require.NoError(t, err)
+
+	token := getTestToken(t, "test-user")
+	post := func(path, body string) *http.Response {
+		req, err := http.NewRequest("POST", testServerURL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := post("/team/setParent", `{"team_name": "backend", "parent_team_name": "engineering"}`)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = post("/team/setPolicy", `{"team_name": "engineering", "reviewer_count": 1, "fallback_to_parent": true}`)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req, err := http.NewRequest("GET", testServerURL+"/team/get?team_name=backend", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var details TeamDetailsResponse
+	err = json.NewDecoder(resp.Body).Decode(&details)
+	require.NoError(t, err)
+
+	assert.Equal(t, "backend", details.TeamName)
+	assert.Equal(t, []string{"engineering"}, details.Parents)
+	assert.Equal(t, []string{"payments"}, details.Children)
+	assert.Equal(t, AssignmentPolicyResponse{ReviewerCount: 1, FallbackToParent: true}, details.AssignmentPolicy)
+	assert.Nil(t, details.PolicyOverrides.ReviewerCount)
+
+	cycleResp := post("/team/setParent", `{"team_name": "engineering", "parent_team_name": "payments"}`)
+	defer cycleResp.Body.Close()
+	assert.Equal(t, http.StatusConflict, cycleResp.StatusCode)
+
+	var errResp APIErrorResponse
+	err = json.NewDecoder(cycleResp.Body).Decode(&errResp)
+	require.NoError(t, err)
+	assert.Equal(t, "HIERARCHY_CYCLE", errResp.Error.Code)
+}
+
+func TestTeamHandler_E2E_ArchiveAndDeleteTeam(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "legacy"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	token := getTestToken(t, "test-user")
+	post := func(path, body string) *http.Response {
+		req, err := http.NewRequest("POST", testServerURL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	errorCode := func(resp *http.Response) string {
+		defer resp.Body.Close()
+		var errResp APIErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+		return errResp.Error.Code
+	}
+
+	resp := post("/team/delete", `{"team_name": "legacy", "force": true}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "TEAM_NOT_ARCHIVED", errorCode(resp))
+
+	resp = post("/team/archive", `{"team_name": "legacy"}`)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = post("/team/addMember", `{"team_name": "legacy", "user_id": "u2", "username": "Bob", "is_active": true}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "TEAM_ARCHIVED", errorCode(resp))
+
+	resp = post("/team/delete", `{"team_name": "legacy"}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "TEAM_NOT_EMPTY", errorCode(resp))
+
+	req, err := http.NewRequest("GET", testServerURL+"/team/deletePreview?team_name=legacy", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	previewResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer previewResp.Body.Close()
+	require.Equal(t, http.StatusOK, previewResp.StatusCode)
+
+	var preview TeamDeletionReportResponse
+	require.NoError(t, json.NewDecoder(previewResp.Body).Decode(&preview))
+	assert.True(t, preview.IsArchived)
+	assert.Equal(t, []string{"u1"}, preview.PrimaryMembers)
+
+	resp = post("/team/delete", `{"team_name": "legacy", "force": true}`)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	user, err := testStore.User().GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, user.TeamName)
+}
+
+func TestTeamHandler_E2E_RenameTeam_Conflict(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	for _, name := range []string{"backend", "frontend"} {
+		_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: name}, nil)
+		require.NoError(t, err)
+	}
+
+	token := getTestToken(t, "test-user")
+	req, err := http.NewRequest("POST", testServerURL+"/team/rename", strings.NewReader(`{"team_name": "backend", "new_team_name": "frontend"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var errResp APIErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, "TEAM_EXISTS", errResp.Error.Code)
+}
+
+func TestTeamHandler_E2E_ListTeams(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+	})
+	require.NoError(t, err)
+	for _, name := range []string{"frontend", "legacy"} {
+		_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: name}, nil)
+		require.NoError(t, err)
+	}
+	_, err = testStore.Team().SetArchived(ctx, "legacy", true, "")
+	require.NoError(t, err)
+
+	token := getTestToken(t, "test-user")
+	req, err := http.NewRequest("GET", testServerURL+"/team/list", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list TeamListResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Teams, 2)
+	assert.Equal(t, TeamSummaryResponse{TeamName: "backend", MemberCount: 1, ActiveMemberCount: 1}, list.Teams[0])
+	assert.Equal(t, "frontend", list.Teams[1].TeamName)
+	assert.Empty(t, list.NextCursor)
+}
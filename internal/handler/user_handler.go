@@ -2,7 +2,9 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/go-chi/render"
 )
 
@@ -50,3 +52,88 @@ func (h *Handler) getReviewsForUser(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, map[string]any{"user_id": userID, "pull_requests": prDTOs})
 }
+
+func (h *Handler) getUserTeams(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		h.writeBadRequest(w, r, "missing required query parameter: user_id")
+		return
+	}
+
+	memberships, err := h.membershipService.GetMemberships(r.Context(), userID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	teamDTOs := make([]TeamMembershipResponse, len(memberships))
+	for i, m := range memberships {
+		teamDTOs[i] = ConvertMembershipModelToDTO(m)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"user_id": userID, "teams": teamDTOs})
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		h.writeBadRequest(w, r, "missing required query parameter: user_id")
+		return
+	}
+
+	user, err := h.userService.Get(r.Context(), userID)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response := UserDetailsResponse{
+		UserResponse:    ConvertFullUserModelToDTO(user.FullUserInfo),
+		OpenReviewCount: user.OpenReviewCount,
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]any{"user": response})
+}
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	after, limit, err := parsePageQuery(query)
+	if err != nil {
+		h.writeBadRequest(w, r, err.Error())
+		return
+	}
+
+	filter := model.UserFilter{
+		TeamName:   query.Get("team_name"),
+		NamePrefix: query.Get("name_prefix"),
+		After:      after,
+		Limit:      limit,
+	}
+
+	if raw := query.Get("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			h.writeBadRequest(w, r, "invalid query parameter: is_active")
+			return
+		}
+		filter.IsActive = &isActive
+	}
+
+	users, next, err := h.userService.List(r.Context(), filter)
+	if err != nil {
+		h.WriteError(w, r, err)
+		return
+	}
+
+	response := UserListResponse{Users: make([]UserResponse, len(users))}
+	for i, user := range users {
+		response.Users[i] = ConvertFullUserModelToDTO(user)
+	}
+	if next != "" {
+		response.NextCursor = encodeCursor(next)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
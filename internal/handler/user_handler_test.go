@@ -20,7 +20,7 @@ func TestUserHandler_E2E_SetUserIsActive(t *testing.T) {
 	appService := service.NewService(service.Dependencies{TeamRepo: testStore.Team(), UserRepo: testStore.User(), PRRepo: testStore.PR(), StatsRepo: testStore.PR()})
 	teamModel := model.Team{Name: "e2e-user-team"}
 	userModels := []model.User{{ID: "e2e-user-active", Username: "E2E User", IsActive: true}}
-	_, _, err := appService.Team.Create(ctx, teamModel, userModels)
+	_, err := appService.Team.Create(ctx, teamModel, userModels)
 	require.NoError(t, err)
 
 	token := getTestToken(t, "test-user")
@@ -96,3 +96,105 @@ func TestUserHandler_E2E_SetUserIsActive_ValidationFailure(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "BAD_REQUEST", errResp.Error.Code)
 }
+
+func TestUserHandler_E2E_GetTeams(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "feature"}, []model.User{
+		{ID: "multi-user", Username: "Multi", IsActive: true},
+	})
+	require.NoError(t, err)
+	_, err = testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "guild"}, nil)
+	require.NoError(t, err)
+
+	token := getTestToken(t, "test-user")
+	setBody := `{"team_name": "guild", "user_id": "multi-user", "reviewable": false}`
+
+	req, err := http.NewRequest("POST", testServerURL+"/team/setSecondaryMember", strings.NewReader(setBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req, err = http.NewRequest("GET", testServerURL+"/users/getTeams?user_id=multi-user", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var teamsResp struct {
+		UserID string                   `json:"user_id"`
+		Teams  []TeamMembershipResponse `json:"teams"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&teamsResp)
+	require.NoError(t, err)
+
+	assert.Equal(t, []TeamMembershipResponse{
+		{TeamName: "feature", IsPrimary: true, Reviewable: true},
+		{TeamName: "guild", IsPrimary: false, Reviewable: false},
+	}, teamsResp.Teams)
+}
+
+func TestUserHandler_E2E_ListAndGetUsers(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	_, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+		{ID: "u2", Username: "Bob", IsActive: true},
+		{ID: "u3", Username: "Carol", IsActive: false},
+	})
+	require.NoError(t, err)
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{ID: "pr-1", Name: "PR", AuthorID: "u1", AssignedReviewers: []string{"u2"}}))
+
+	token := getTestToken(t, "test-user")
+	get := func(path string) *http.Response {
+		req, err := http.NewRequest("GET", testServerURL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := get("/users/list?is_active=true&team_name=backend&limit=1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var page UserListResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	resp.Body.Close()
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, "u1", page.Users[0].UserID)
+	require.NotEmpty(t, page.NextCursor)
+
+	resp = get("/users/list?is_active=true&team_name=backend&limit=1&cursor=" + page.NextCursor)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	page = UserListResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	resp.Body.Close()
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, "u2", page.Users[0].UserID)
+	assert.Empty(t, page.NextCursor)
+
+	resp = get("/users/get?user_id=u2")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var userResp struct {
+		User UserDetailsResponse `json:"user"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&userResp))
+	resp.Body.Close()
+	assert.Equal(t, "backend", userResp.User.TeamName)
+	assert.Equal(t, 1, userResp.User.OpenReviewCount)
+
+	resp = get("/users/list?cursor=not-base64!")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,319 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Occurrence struct {
+	UID        string
+	Summary    string
+	Categories []string
+	Attendees  []string
+	Start      time.Time
+	End        time.Time
+	AllDay     bool
+}
+
+type zone struct {
+	loc         *time.Location
+	observances []observance
+}
+
+type observance struct {
+	start      time.Time
+	offsetFrom int
+	offsetTo   int
+	rule       *Rule
+}
+
+func parseTimezone(c *component) (string, *zone, error) {
+	var id, location string
+	for _, prop := range c.props {
+		switch prop.name {
+		case "TZID":
+			id = prop.value
+		case "X-LIC-LOCATION":
+			location = prop.value
+		}
+	}
+
+	if id == "" {
+		return "", nil, fmt.Errorf("%w: VTIMEZONE without TZID", ErrInvalidCalendar)
+	}
+
+	for _, name := range []string{id, location} {
+		if loc, err := time.LoadLocation(name); name != "" && err == nil {
+			return id, &zone{loc: loc}, nil
+		}
+	}
+
+	z := &zone{}
+	for _, child := range c.components {
+		if child.name != "STANDARD" && child.name != "DAYLIGHT" {
+			continue
+		}
+
+		var obs observance
+		var err error
+		for _, prop := range child.props {
+			switch prop.name {
+			case "DTSTART":
+				var start DateTime
+				start, err = parseDateTime(prop)
+				obs.start = start.Wall
+			case "TZOFFSETFROM":
+				obs.offsetFrom, err = parseOffset(prop.value)
+			case "TZOFFSETTO":
+				obs.offsetTo, err = parseOffset(prop.value)
+			case "RRULE":
+				obs.rule, err = parseRule(prop.value)
+			}
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		z.observances = append(z.observances, obs)
+	}
+
+	if len(z.observances) == 0 {
+		return "", nil, fmt.Errorf("%w: VTIMEZONE %s has no observances", ErrInvalidCalendar, id)
+	}
+
+	sort.Slice(z.observances, func(i, j int) bool { return z.observances[i].start.Before(z.observances[j].start) })
+
+	return id, z, nil
+}
+
+func parseOffset(value string) (int, error) {
+	if len(value) != 5 && len(value) != 7 {
+		return 0, fmt.Errorf("%w: bad UTC offset %q", ErrInvalidCalendar, value)
+	}
+
+	sign := 1
+	switch value[0] {
+	case '-':
+		sign = -1
+	case '+':
+	default:
+		return 0, fmt.Errorf("%w: bad UTC offset %q", ErrInvalidCalendar, value)
+	}
+
+	offset := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(value) {
+			break
+		}
+		n, err := strconv.Atoi(value[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, fmt.Errorf("%w: bad UTC offset %q", ErrInvalidCalendar, value)
+		}
+		offset += n * unit
+	}
+
+	return sign * offset, nil
+}
+
+func (z *zone) resolve(wall time.Time) time.Time {
+	if z.loc != nil {
+		return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, z.loc)
+	}
+
+	offset := z.observances[0].offsetFrom
+	var onset time.Time
+	for _, obs := range z.observances {
+		if obs.start.After(wall) {
+			continue
+		}
+
+		last := obs.start
+		if obs.rule != nil {
+			starts := obs.rule.expand(obs.start, func(t time.Time) bool {
+				return obs.rule.Until != nil && t.Add(-time.Duration(obs.offsetFrom)*time.Second).After(obs.rule.Until.Wall)
+			}, func(t time.Time) bool {
+				return t.After(wall)
+			})
+			last = starts[len(starts)-1]
+		}
+
+		if !last.Before(onset) {
+			onset = last
+			offset = obs.offsetTo
+		}
+	}
+
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, time.FixedZone("", offset))
+}
+
+func (c *Calendar) zoneFor(dt DateTime) *zone {
+	if dt.UTC {
+		return &zone{loc: time.UTC}
+	}
+
+	if dt.TZID != "" && !dt.AllDay {
+		if z, ok := c.zones[dt.TZID]; ok {
+			return z
+		}
+
+		if loc, err := time.LoadLocation(dt.TZID); err == nil {
+			c.zones[dt.TZID] = &zone{loc: loc}
+			return c.zones[dt.TZID]
+		}
+	}
+
+	if c.defaultZone != nil {
+		return c.defaultZone
+	}
+
+	return &zone{loc: time.UTC}
+}
+
+func (c *Calendar) resolve(dt DateTime) time.Time {
+	return c.zoneFor(dt).resolve(dt.Wall)
+}
+
+type instantSet struct {
+	dates    map[string]bool
+	instants map[int64]bool
+}
+
+func newInstantSet() instantSet {
+	return instantSet{dates: map[string]bool{}, instants: map[int64]bool{}}
+}
+
+func (s instantSet) add(c *Calendar, dt DateTime) {
+	if dt.AllDay {
+		s.dates[dt.Wall.Format("20060102")] = true
+		return
+	}
+
+	s.instants[c.resolve(dt).Unix()] = true
+}
+
+func (s instantSet) has(wall, instant time.Time) bool {
+	return s.dates[wall.Format("20060102")] || s.instants[instant.Unix()]
+}
+
+func (c *Calendar) Expand(from, to time.Time) []Occurrence {
+	var occurrences []Occurrence
+	keep := func(occurrence Occurrence) {
+		if occurrence.End.After(from) && occurrence.Start.Before(to) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	overridden := map[string]instantSet{}
+	for _, event := range c.Events {
+		if event.RecurrenceID == nil {
+			continue
+		}
+
+		if _, ok := overridden[event.UID]; !ok {
+			overridden[event.UID] = newInstantSet()
+		}
+		overridden[event.UID].add(c, *event.RecurrenceID)
+
+		if event.Status != "CANCELLED" {
+			keep(c.occurrence(event, event.Start.Wall))
+		}
+	}
+
+	for _, event := range c.Events {
+		if event.RecurrenceID != nil || event.Status == "CANCELLED" {
+			continue
+		}
+
+		z := c.zoneFor(event.Start)
+		starts := []time.Time{event.Start.Wall}
+		if event.Rule != nil {
+			starts = event.Rule.expand(event.Start.Wall, func(wall time.Time) bool {
+				return c.afterUntil(event.Rule.Until, z, wall)
+			}, func(wall time.Time) bool {
+				return !z.resolve(wall).Before(to)
+			})
+		}
+
+		excluded := newInstantSet()
+		for _, exdate := range event.ExDates {
+			excluded.add(c, exdate)
+		}
+
+		overrides, hasOverrides := overridden[event.UID]
+		for _, start := range starts {
+			instant := z.resolve(start)
+			if excluded.has(start, instant) || (hasOverrides && overrides.has(start, instant)) {
+				continue
+			}
+			keep(c.occurrence(event, start))
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if !occurrences[i].Start.Equal(occurrences[j].Start) {
+			return occurrences[i].Start.Before(occurrences[j].Start)
+		}
+		return occurrences[i].UID < occurrences[j].UID
+	})
+
+	return occurrences
+}
+
+func (c *Calendar) afterUntil(until *DateTime, z *zone, wall time.Time) bool {
+	if until == nil {
+		return false
+	}
+
+	if until.AllDay {
+		return wall.Format("20060102") > until.Wall.Format("20060102")
+	}
+
+	return z.resolve(wall).After(c.resolve(*until))
+}
+
+func (c *Calendar) occurrence(event Event, startWall time.Time) Occurrence {
+	z := c.zoneFor(event.Start)
+	start := z.resolve(startWall)
+
+	var end time.Time
+	switch {
+	case event.End != nil && event.End.AllDay == event.Start.AllDay && event.End.TZID == event.Start.TZID && event.End.UTC == event.Start.UTC:
+		end = z.resolve(startWall.Add(event.End.Wall.Sub(event.Start.Wall)))
+	case event.End != nil:
+		end = start.Add(c.resolve(*event.End).Sub(c.resolve(event.Start)))
+	case event.Duration != nil:
+		end = z.resolve(startWall.AddDate(0, 0, event.Duration.Days)).Add(event.Duration.Clock)
+	case event.Start.AllDay:
+		end = z.resolve(startWall.AddDate(0, 0, 1))
+	default:
+		end = start
+	}
+
+	return Occurrence{
+		UID:        event.UID,
+		Summary:    event.Summary,
+		Categories: event.Categories,
+		Attendees:  event.Attendees,
+		Start:      start,
+		End:        end,
+		AllDay:     event.Start.AllDay,
+	}
+}
+
+func (o Occurrence) HasCategory(categories []string) bool {
+	if len(categories) == 0 {
+		return true
+	}
+
+	for _, category := range o.Categories {
+		for _, want := range categories {
+			if strings.EqualFold(strings.TrimSpace(category), strings.TrimSpace(want)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
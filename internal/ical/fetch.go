@@ -0,0 +1,66 @@
+package ical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/netguard"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	maxFeedBytes   = 10 << 20
+)
+
+var ErrFetchFailed = errors.New("calendar fetch failed")
+
+type Fetcher struct {
+	client *http.Client
+}
+
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = netguard.NewClient(defaultTimeout)
+	}
+
+	return &Fetcher{client: client}
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	if rest, ok := strings.CutPrefix(url, "webcal://"); ok {
+		url = "https://" + rest
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+	req.Header.Set("User-Agent", "pr-service-calendar")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: status %d", ErrFetchFailed, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+
+	if len(body) > maxFeedBytes {
+		return nil, fmt.Errorf("%w: feed is larger than %d bytes", ErrFetchFailed, maxFeedBytes)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,150 @@
+package ical
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadCalendar(t *testing.T, name string) *Calendar {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	cal, err := Parse(data)
+	require.NoError(t, err)
+
+	return cal
+}
+
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestParse_Properties(t *testing.T) {
+	cal := loadCalendar(t, "team.ics")
+
+	assert.Equal(t, 1, cal.Skipped, "unsupported RRULE frequency")
+	require.Len(t, cal.Events, 6)
+
+	alice := cal.Events[0]
+	assert.Equal(t, "alice-vacation@acme", alice.UID)
+	assert.Equal(t, "Alice: vacation, Spain", alice.Summary)
+	assert.Equal(t, []string{"Vacation", "Travel"}, alice.Categories)
+	assert.Equal(t, []string{"Alice", "alice@acme.test", "alice"}, alice.Attendees)
+	assert.True(t, alice.Start.AllDay)
+
+	carol := cal.Events[2]
+	assert.Equal(t, "Carol remote day off", carol.Summary, "folded lines are joined")
+	assert.Equal(t, "Europe/Berlin", carol.Start.TZID)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"BEGIN:VEVENT\r\nEND:VEVENT\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n",
+		"BEGIN:VCALENDAR\r\nnot a property\r\nEND:VCALENDAR\r\n",
+	} {
+		_, err := Parse([]byte(data))
+		assert.ErrorIs(t, err, ErrInvalidCalendar, data)
+	}
+}
+
+func TestExpand_RecurrenceAndTimezones(t *testing.T) {
+	cal := loadCalendar(t, "team.ics")
+
+	occurrences := cal.Expand(utc(2025, 10, 1, 0, 0), utc(2026, 2, 1, 0, 0))
+
+	type span struct {
+		uid        string
+		start, end time.Time
+	}
+	var got []span
+	for _, o := range occurrences {
+		got = append(got, span{o.UID, o.Start.UTC(), o.End.UTC()})
+	}
+
+	assert.Equal(t, []span{
+		{"bob-gym@acme", utc(2025, 10, 20, 15, 0), utc(2025, 10, 20, 16, 30)},
+		{"bob-gym@acme", utc(2025, 10, 27, 16, 0), utc(2025, 10, 27, 17, 30)},
+		{"bob-gym@acme", utc(2025, 10, 29, 16, 0), utc(2025, 10, 29, 17, 30)},
+		{"carol-friday@acme", utc(2025, 10, 31, 8, 0), utc(2025, 10, 31, 17, 0)},
+		{"bob-gym@acme", utc(2025, 11, 3, 16, 0), utc(2025, 11, 3, 17, 30)},
+		{"bob-gym@acme", utc(2025, 11, 5, 16, 0), utc(2025, 11, 5, 17, 30)},
+		{"alice-vacation@acme", utc(2025, 11, 9, 23, 0), utc(2025, 11, 14, 23, 0)},
+		{"carol-friday@acme", utc(2025, 11, 27, 8, 0), utc(2025, 11, 27, 17, 0)},
+		{"carol-friday@acme", utc(2026, 1, 30, 8, 0), utc(2026, 1, 30, 17, 0)},
+	}, got)
+
+	assert.True(t, occurrences[6].AllDay)
+	assert.Len(t, cal.Expand(utc(2025, 11, 12, 0, 0), utc(2025, 11, 13, 0, 0)), 1)
+}
+
+func TestExpand_CustomTimezoneDefinition(t *testing.T) {
+	cal := loadCalendar(t, "custom_timezone.ics")
+
+	occurrences := cal.Expand(utc(2025, 1, 1, 0, 0), utc(2026, 1, 1, 0, 0))
+	require.Len(t, occurrences, 7)
+
+	assert.Equal(t, utc(2025, 10, 30, 14, 0), occurrences[0].Start.UTC())
+	assert.Equal(t, utc(2025, 11, 1, 22, 0), occurrences[1].End.UTC())
+	assert.Equal(t, utc(2025, 11, 3, 15, 0), occurrences[2].Start.UTC(), "standard time starts on the first Sunday of November")
+	assert.Equal(t, utc(2025, 11, 11, 15, 0), occurrences[6].Start.UTC())
+}
+
+func TestRule_MonthlyAndYearly(t *testing.T) {
+	expand := func(value string, start time.Time, limit time.Time) []time.Time {
+		rule, err := parseRule(value)
+		require.NoError(t, err)
+		return rule.expand(start, func(time.Time) bool { return false }, func(wall time.Time) bool { return wall.After(limit) })
+	}
+
+	start := utc(2025, 1, 31, 9, 0)
+	assert.Equal(t, []time.Time{start, utc(2025, 3, 31, 9, 0), utc(2025, 5, 31, 9, 0)},
+		expand("FREQ=MONTHLY;COUNT=3", start, utc(2030, 1, 1, 0, 0)), "months without the day are skipped")
+
+	assert.Equal(t, []time.Time{utc(2025, 1, 31, 9, 0), utc(2025, 2, 28, 9, 0), utc(2025, 3, 31, 9, 0)},
+		expand("FREQ=MONTHLY;BYMONTHDAY=-1", start, utc(2025, 4, 1, 0, 0)))
+
+	thanksgiving := utc(2025, 11, 27, 0, 0)
+	assert.Equal(t, []time.Time{thanksgiving, utc(2026, 11, 26, 0, 0), utc(2027, 11, 25, 0, 0)},
+		expand("FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=3", thanksgiving, utc(2030, 1, 1, 0, 0)))
+
+	sprint := utc(2025, 11, 3, 0, 0)
+	assert.Equal(t, []time.Time{sprint, utc(2025, 11, 4, 0, 0), utc(2025, 11, 17, 0, 0), utc(2025, 11, 18, 0, 0)},
+		expand("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TU", sprint, utc(2025, 11, 20, 0, 0)))
+
+	_, err := parseRule("FREQ=MONTHLY;BYSETPOS=-1;BYDAY=MO,TU,WE,TH,FR")
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/team.ics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "text/calendar", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(server.Client())
+
+	body, err := fetcher.Fetch(context.Background(), server.URL+"/team.ics")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), "BEGIN:VCALENDAR"))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing.ics")
+	assert.ErrorIs(t, err, ErrFetchFailed)
+}
@@ -0,0 +1,412 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCalendar = errors.New("invalid icalendar data")
+
+type Calendar struct {
+	Events  []Event
+	Skipped int
+
+	zones       map[string]*zone
+	defaultZone *zone
+}
+
+type DateTime struct {
+	Wall   time.Time
+	AllDay bool
+	UTC    bool
+	TZID   string
+}
+
+type Event struct {
+	UID          string
+	Summary      string
+	Status       string
+	Categories   []string
+	Attendees    []string
+	Start        DateTime
+	End          *DateTime
+	Duration     *Duration
+	Rule         *Rule
+	ExDates      []DateTime
+	RecurrenceID *DateTime
+}
+
+type Duration struct {
+	Days  int
+	Clock time.Duration
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+type component struct {
+	name       string
+	props      []property
+	components []*component
+}
+
+func Parse(data []byte) (*Calendar, error) {
+	root, err := parseComponents(unfold(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if root.name != "VCALENDAR" {
+		return nil, fmt.Errorf("%w: VCALENDAR is missing", ErrInvalidCalendar)
+	}
+
+	cal := &Calendar{zones: map[string]*zone{}}
+
+	for _, prop := range root.props {
+		if prop.name == "X-WR-TIMEZONE" {
+			if loc, err := time.LoadLocation(prop.value); err == nil {
+				cal.defaultZone = &zone{loc: loc}
+			}
+		}
+	}
+
+	for _, child := range root.components {
+		if child.name != "VTIMEZONE" {
+			continue
+		}
+
+		id, z, err := parseTimezone(child)
+		if err != nil {
+			continue
+		}
+		cal.zones[id] = z
+	}
+
+	for _, child := range root.components {
+		if child.name != "VEVENT" {
+			continue
+		}
+
+		event, err := parseEvent(child)
+		if err != nil {
+			cal.Skipped++
+			continue
+		}
+		cal.Events = append(cal.Events, *event)
+	}
+
+	return cal, nil
+}
+
+func unfold(data []byte) []string {
+	var lines []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+func parseComponents(lines []string) (*component, error) {
+	var stack []*component
+	var root *component
+
+	for _, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			c := &component{name: strings.ToUpper(prop.value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.components = append(parent.components, c)
+			} else if root == nil {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != strings.ToUpper(prop.value) {
+				return nil, fmt.Errorf("%w: unexpected END:%s", ErrInvalidCalendar, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: property outside of a component", ErrInvalidCalendar)
+			}
+			current := stack[len(stack)-1]
+			current.props = append(current.props, prop)
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("%w: no components", ErrInvalidCalendar)
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: %s is not closed", ErrInvalidCalendar, stack[len(stack)-1].name)
+	}
+
+	return root, nil
+}
+
+func parseProperty(line string) (property, error) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+
+	if colon < 0 {
+		return property{}, fmt.Errorf("%w: malformed line %q", ErrInvalidCalendar, line)
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := splitOutsideQuotes(head, ';')
+
+	prop := property{name: strings.ToUpper(parts[0]), params: map[string]string{}, value: value}
+	for _, part := range parts[1:] {
+		key, val, _ := strings.Cut(part, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+
+	return prop, nil
+}
+
+func splitOutsideQuotes(s string, sep rune) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+func unescapeText(value string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+	return replacer.Replace(value)
+}
+
+func splitTextList(value string) []string {
+	var items []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			items = append(items, unescapeText(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	return append(items, unescapeText(current.String()))
+}
+
+func parseEvent(c *component) (*Event, error) {
+	event := &Event{}
+	hasStart := false
+
+	for _, prop := range c.props {
+		switch prop.name {
+		case "UID":
+			event.UID = prop.value
+		case "SUMMARY":
+			event.Summary = unescapeText(prop.value)
+		case "STATUS":
+			event.Status = strings.ToUpper(prop.value)
+		case "CATEGORIES":
+			for _, category := range splitTextList(prop.value) {
+				if category = strings.TrimSpace(category); category != "" {
+					event.Categories = append(event.Categories, category)
+				}
+			}
+		case "ATTENDEE", "ORGANIZER":
+			event.Attendees = append(event.Attendees, attendeeNames(prop)...)
+		case "DTSTART":
+			start, err := parseDateTime(prop)
+			if err != nil {
+				return nil, err
+			}
+			event.Start = start
+			hasStart = true
+		case "DTEND":
+			end, err := parseDateTime(prop)
+			if err != nil {
+				return nil, err
+			}
+			event.End = &end
+		case "DURATION":
+			duration, err := parseDuration(prop.value)
+			if err != nil {
+				return nil, err
+			}
+			event.Duration = &duration
+		case "RRULE":
+			rule, err := parseRule(prop.value)
+			if err != nil {
+				return nil, err
+			}
+			event.Rule = rule
+		case "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				exdate, err := parseDateTime(property{params: prop.params, value: value})
+				if err != nil {
+					return nil, err
+				}
+				event.ExDates = append(event.ExDates, exdate)
+			}
+		case "RECURRENCE-ID":
+			recurrenceID, err := parseDateTime(prop)
+			if err != nil {
+				return nil, err
+			}
+			event.RecurrenceID = &recurrenceID
+		}
+	}
+
+	if !hasStart {
+		return nil, fmt.Errorf("%w: event %q has no DTSTART", ErrInvalidCalendar, event.UID)
+	}
+
+	return event, nil
+}
+
+func attendeeNames(prop property) []string {
+	var names []string
+	if cn := prop.params["CN"]; cn != "" {
+		names = append(names, cn)
+	}
+
+	address := prop.value
+	if len(address) >= 7 && strings.EqualFold(address[:7], "mailto:") {
+		address = address[7:]
+	}
+	if local, _, ok := strings.Cut(address, "@"); ok && local != "" {
+		names = append(names, address, local)
+	}
+
+	return names
+}
+
+func parseDateTime(prop property) (DateTime, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == 8 {
+		wall, err := time.Parse("20060102", value)
+		if err != nil {
+			return DateTime{}, fmt.Errorf("%w: bad date %q", ErrInvalidCalendar, value)
+		}
+
+		return DateTime{Wall: wall, AllDay: true}, nil
+	}
+
+	utc := strings.HasSuffix(value, "Z")
+	wall, err := time.Parse("20060102T150405", strings.TrimSuffix(value, "Z"))
+	if err != nil {
+		return DateTime{}, fmt.Errorf("%w: bad date-time %q", ErrInvalidCalendar, value)
+	}
+
+	dt := DateTime{Wall: wall, UTC: utc}
+	if !utc {
+		dt.TZID = prop.params["TZID"]
+	}
+
+	return dt, nil
+}
+
+func parseDuration(value string) (Duration, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(value, "+"), "-")
+	negative := strings.HasPrefix(value, "-")
+
+	rest, ok := strings.CutPrefix(rest, "P")
+	if !ok || rest == "" {
+		return Duration{}, fmt.Errorf("%w: bad duration %q", ErrInvalidCalendar, value)
+	}
+
+	var d Duration
+	inTime := false
+	number := ""
+	for _, r := range rest {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return Duration{}, fmt.Errorf("%w: bad duration %q", ErrInvalidCalendar, value)
+		}
+		number = ""
+
+		switch {
+		case r == 'W' && !inTime:
+			d.Days += 7 * n
+		case r == 'D' && !inTime:
+			d.Days += n
+		case r == 'H' && inTime:
+			d.Clock += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			d.Clock += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			d.Clock += time.Duration(n) * time.Second
+		default:
+			return Duration{}, fmt.Errorf("%w: bad duration %q", ErrInvalidCalendar, value)
+		}
+	}
+
+	if number != "" {
+		return Duration{}, fmt.Errorf("%w: bad duration %q", ErrInvalidCalendar, value)
+	}
+
+	if negative {
+		d.Days, d.Clock = -d.Days, -d.Clock
+	}
+
+	return d, nil
+}
@@ -0,0 +1,322 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxRulePeriods = 100000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+type weekdayNum struct {
+	ordinal int
+	weekday time.Weekday
+}
+
+type Rule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    *DateTime
+
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+	weekStart  time.Weekday
+}
+
+func parseRule(value string) (*Rule, error) {
+	rule := &Rule{Interval: 1, weekStart: time.Monday}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		key = strings.ToUpper(key)
+		val = strings.ToUpper(val)
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = val
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = fmt.Errorf("interval must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			var until DateTime
+			until, err = parseDateTime(property{params: map[string]string{}, value: val})
+			rule.Until = &until
+		case "WKST":
+			weekday, ok := weekdays[val]
+			if !ok {
+				err = fmt.Errorf("unknown weekday %q", val)
+			}
+			rule.weekStart = weekday
+		case "BYDAY":
+			rule.byDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseInts(val, -31, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(val, 1, 12)
+			for _, month := range months {
+				rule.byMonth = append(rule.byMonth, time.Month(month))
+			}
+		default:
+			err = fmt.Errorf("%s is not supported", key)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: RRULE %s: %v", ErrInvalidCalendar, value, err)
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("%w: RRULE frequency %q is not supported", ErrInvalidCalendar, rule.Freq)
+	}
+
+	return rule, nil
+}
+
+func parseByDay(value string) ([]weekdayNum, error) {
+	var days []weekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("bad BYDAY %q", item)
+		}
+
+		weekday, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("bad BYDAY %q", item)
+		}
+
+		day := weekdayNum{weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 || ordinal < -53 || ordinal > 53 {
+				return nil, fmt.Errorf("bad BYDAY %q", item)
+			}
+			day.ordinal = ordinal
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func parseInts(value string, min, max int) ([]int, error) {
+	var numbers []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("bad value %q", item)
+		}
+		numbers = append(numbers, n)
+	}
+
+	return numbers, nil
+}
+
+func (r *Rule) expand(start time.Time, afterUntil, pastWindow func(time.Time) bool) []time.Time {
+	occurrences := []time.Time{start}
+	if r.Count == 1 {
+		return occurrences
+	}
+
+	for k := 0; k < maxRulePeriods; k++ {
+		anchor, candidates := r.period(start, k)
+		if pastWindow(anchor) {
+			break
+		}
+
+		for _, candidate := range candidates {
+			if !candidate.After(start) {
+				continue
+			}
+
+			if afterUntil(candidate) || pastWindow(candidate) {
+				return occurrences
+			}
+
+			occurrences = append(occurrences, candidate)
+			if r.Count > 0 && len(occurrences) >= r.Count {
+				return occurrences
+			}
+		}
+	}
+
+	return occurrences
+}
+
+func (r *Rule) period(start time.Time, k int) (time.Time, []time.Time) {
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	clock := start.Sub(day)
+	step := k * r.Interval
+
+	var anchor time.Time
+	var days []time.Time
+
+	switch r.Freq {
+	case "DAILY":
+		anchor = day.AddDate(0, 0, step)
+		if r.matchesDay(anchor) {
+			days = []time.Time{anchor}
+		}
+	case "WEEKLY":
+		anchor = day.AddDate(0, 0, -((int(day.Weekday())-int(r.weekStart)+7)%7)+7*step)
+		for i := 0; i < 7; i++ {
+			d := anchor.AddDate(0, 0, i)
+			if len(r.byDay) == 0 && d.Weekday() != start.Weekday() {
+				continue
+			}
+			if r.matchesDay(d) {
+				days = append(days, d)
+			}
+		}
+	case "MONTHLY":
+		anchor = time.Date(day.Year(), day.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		if len(r.byMonth) == 0 || containsMonth(r.byMonth, anchor.Month()) {
+			days = r.daysInSpan(anchor, anchor.AddDate(0, 1, -1), start.Day())
+		}
+	case "YEARLY":
+		anchor = time.Date(day.Year()+step, time.January, 1, 0, 0, 0, 0, time.UTC)
+		switch {
+		case len(r.byMonth) > 0:
+			for _, month := range r.byMonth {
+				first := time.Date(anchor.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+				days = append(days, r.daysInSpan(first, first.AddDate(0, 1, -1), start.Day())...)
+			}
+		case len(r.byDay) > 0 && len(r.byMonthDay) == 0:
+			days = r.byDayIn(anchor, anchor.AddDate(1, 0, -1))
+		default:
+			first := time.Date(anchor.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+			days = r.daysInSpan(first, first.AddDate(0, 1, -1), start.Day())
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	occurrences := make([]time.Time, len(days))
+	for i, d := range days {
+		occurrences[i] = d.Add(clock)
+	}
+
+	return anchor, occurrences
+}
+
+func (r *Rule) matchesDay(d time.Time) bool {
+	if len(r.byMonth) > 0 && !containsMonth(r.byMonth, d.Month()) {
+		return false
+	}
+
+	if len(r.byMonthDay) > 0 && !r.matchesMonthDay(d) {
+		return false
+	}
+
+	if len(r.byDay) == 0 {
+		return true
+	}
+
+	for _, day := range r.byDay {
+		if day.weekday == d.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Rule) matchesMonthDay(d time.Time) bool {
+	last := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, monthDay := range r.byMonthDay {
+		if monthDay == d.Day() || last+1+monthDay == d.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Rule) daysInSpan(first, last time.Time, defaultDay int) []time.Time {
+	switch {
+	case len(r.byDay) > 0:
+		var days []time.Time
+		for _, d := range r.byDayIn(first, last) {
+			if len(r.byMonthDay) == 0 || r.matchesMonthDay(d) {
+				days = append(days, d)
+			}
+		}
+		return days
+	case len(r.byMonthDay) > 0:
+		var days []time.Time
+		for i := 0; !first.AddDate(0, 0, i).After(last); i++ {
+			if d := first.AddDate(0, 0, i); r.matchesMonthDay(d) {
+				days = append(days, d)
+			}
+		}
+		return days
+	case defaultDay <= last.Day():
+		return []time.Time{first.AddDate(0, 0, defaultDay-1)}
+	}
+
+	return nil
+}
+
+func (r *Rule) byDayIn(first, last time.Time) []time.Time {
+	seen := map[time.Time]bool{}
+	var days []time.Time
+
+	for _, day := range r.byDay {
+		var matches []time.Time
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			if d.Weekday() == day.weekday {
+				matches = append(matches, d)
+			}
+		}
+
+		switch {
+		case day.ordinal == 0:
+		case day.ordinal > 0 && day.ordinal <= len(matches):
+			matches = matches[day.ordinal-1 : day.ordinal]
+		case day.ordinal < 0 && -day.ordinal <= len(matches):
+			matches = matches[len(matches)+day.ordinal : len(matches)+day.ordinal+1]
+		default:
+			matches = nil
+		}
+
+		for _, d := range matches {
+			if !seen[d] {
+				seen[d] = true
+				days = append(days, d)
+			}
+		}
+	}
+
+	return days
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+
+	return false
+}
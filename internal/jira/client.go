@@ -0,0 +1,153 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+const maxResponseBytes = 1 << 20
+
+var ErrRequestFailed = errors.New("jira api request failed")
+
+type Config struct {
+	BaseURL         string
+	Email           string
+	Token           string
+	MergeTransition string
+}
+
+type Client struct {
+	baseURL    string
+	email      string
+	token      string
+	transition string
+	client     *http.Client
+}
+
+func NewClient(cfg Config, client *http.Client) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		email:      cfg.Email,
+		token:      cfg.Token,
+		transition: cfg.MergeTransition,
+		client:     client,
+	}
+}
+
+func (c *Client) PullRequestMerged(ctx context.Context, issueKey string, event model.Event) error {
+	if c.transition != "" {
+		if err := c.Transition(ctx, issueKey, c.transition); err != nil {
+			return err
+		}
+	}
+
+	return c.AddComment(ctx, issueKey, mergeComment(event))
+}
+
+func (c *Client) AddComment(ctx context.Context, issueKey, body string) error {
+	return c.do(ctx, http.MethodPost, issuePath(issueKey, "comment"), map[string]string{"body": body}, nil)
+}
+
+func (c *Client) Transition(ctx context.Context, issueKey, name string) error {
+	path := issuePath(issueKey, "transitions")
+
+	var available struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &available); err != nil {
+		return err
+	}
+
+	for _, transition := range available.Transitions {
+		if strings.EqualFold(transition.Name, name) || strings.EqualFold(transition.To.Name, name) {
+			payload := map[string]map[string]string{"transition": {"id": transition.ID}}
+			return c.do(ctx, http.MethodPost, path, payload, nil)
+		}
+	}
+
+	return nil
+}
+
+func mergeComment(event model.Event) string {
+	pr := event.PullRequest
+
+	text := fmt.Sprintf("Pull request %s %q was merged", pr.ID, pr.Name)
+	if event.ActorID != "" {
+		text += " by " + event.ActorID
+	}
+
+	return text + "."
+}
+
+func issuePath(issueKey, resource string) string {
+	return fmt.Sprintf("/rest/api/2/issue/%s/%s", url.PathEscape(issueKey), resource)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.email != "" {
+		req.SetBasicAuth(c.email, c.token)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	reader := http.MaxBytesReader(nil, resp.Body, maxResponseBytes)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			ErrorMessages []string `json:"errorMessages"`
+		}
+		_ = json.NewDecoder(reader).Decode(&apiErr)
+		return fmt.Errorf("%w: %s %s: status %d %s", ErrRequestFailed, method, path, resp.StatusCode, strings.Join(apiErr.ErrorMessages, "; "))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(reader).Decode(out); err != nil {
+		return fmt.Errorf("%w: invalid response: %v", ErrRequestFailed, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Auth   string
+	Body   map[string]any
+}
+
+type fakeJira struct {
+	mu       sync.Mutex
+	status   map[string]string
+	requests []recordedRequest
+	failures int
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]any
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	f.requests = append(f.requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Auth: r.Header.Get("Authorization"), Body: body})
+
+	if f.failures > 0 {
+		f.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var issueKey, resource string
+	for key := range f.status {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/" + key + "/comment":
+			issueKey, resource = key, "comment"
+		case "/rest/api/2/issue/" + key + "/transitions":
+			issueKey, resource = key, "transitions"
+		}
+	}
+
+	if issueKey == "" {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errorMessages": []string{"Issue does not exist or you do not have permission to see it."}})
+		return
+	}
+
+	switch {
+	case resource == "comment" && r.Method == http.MethodPost:
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "10000"})
+	case resource == "transitions" && r.Method == http.MethodGet:
+		transitions := []map[string]any{}
+		if f.status[issueKey] != "Done" {
+			transitions = append(transitions, map[string]any{"id": "31", "name": "Resolve", "to": map[string]string{"name": "Done"}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"transitions": transitions})
+	case resource == "transitions" && r.Method == http.MethodPost:
+		f.status[issueKey] = "Done"
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func mergedEvent() model.Event {
+	return model.NewPullRequestEvent(model.EventPRMerged, "alice", model.PullRequest{
+		ID:        "acme/api#42",
+		Name:      "PROJ-123: fix login",
+		Status:    model.StatusMerged,
+		IssueKeys: []string{"PROJ-123"},
+	})
+}
+
+func TestClient_PullRequestMerged_TransitionsAndComments(t *testing.T) {
+	fake := &fakeJira{status: map[string]string{"PROJ-123": "In Review"}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL + "/", Email: "bot@acme.test", Token: "secret", MergeTransition: "done"}, server.Client())
+
+	require.NoError(t, client.PullRequestMerged(context.Background(), "PROJ-123", mergedEvent()))
+
+	require.Len(t, fake.requests, 3)
+	assert.Equal(t, http.MethodGet, fake.requests[0].Method)
+	assert.Equal(t, "/rest/api/2/issue/PROJ-123/transitions", fake.requests[1].Path)
+	assert.Equal(t, map[string]any{"transition": map[string]any{"id": "31"}}, fake.requests[1].Body)
+	assert.Equal(t, "/rest/api/2/issue/PROJ-123/comment", fake.requests[2].Path)
+	assert.Equal(t, `Pull request acme/api#42 "PROJ-123: fix login" was merged by alice.`, fake.requests[2].Body["body"])
+	assert.Equal(t, "Basic Ym90QGFjbWUudGVzdDpzZWNyZXQ=", fake.requests[2].Auth)
+	assert.Equal(t, "Done", fake.status["PROJ-123"])
+
+	fake.requests = nil
+	require.NoError(t, client.PullRequestMerged(context.Background(), "PROJ-123", mergedEvent()))
+	require.Len(t, fake.requests, 2, "issues already in the target status are only commented")
+	assert.Equal(t, http.MethodPost, fake.requests[1].Method)
+}
+
+func TestClient_PullRequestMerged_CommentOnlyWithBearerToken(t *testing.T) {
+	fake := &fakeJira{status: map[string]string{"PROJ-123": "In Review"}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, Token: "pat"}, server.Client())
+
+	require.NoError(t, client.PullRequestMerged(context.Background(), "PROJ-123", mergedEvent()))
+
+	require.Len(t, fake.requests, 1)
+	assert.Equal(t, "/rest/api/2/issue/PROJ-123/comment", fake.requests[0].Path)
+	assert.Equal(t, "Bearer pat", fake.requests[0].Auth)
+	assert.Equal(t, "In Review", fake.status["PROJ-123"])
+}
+
+func TestClient_PullRequestMerged_Errors(t *testing.T) {
+	fake := &fakeJira{status: map[string]string{"PROJ-123": "In Review"}, failures: 1}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, Token: "pat", MergeTransition: "Done"}, server.Client())
+
+	err := client.PullRequestMerged(context.Background(), "PROJ-123", mergedEvent())
+	assert.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "status 503")
+
+	err = client.PullRequestMerged(context.Background(), "NOPE-1", mergedEvent())
+	assert.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "Issue does not exist")
+}
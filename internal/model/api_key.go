@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+const APIKeyPrefix = "prs_"
+
+type Scope string
+
+const (
+	ScopeStatsRead  Scope = "stats:read"
+	ScopeTeamsRead  Scope = "teams:read"
+	ScopeTeamsWrite Scope = "teams:write"
+	ScopeUsersRead  Scope = "users:read"
+	ScopeUsersWrite Scope = "users:write"
+	ScopePRRead     Scope = "pr:read"
+	ScopePRWrite    Scope = "pr:write"
+
+	ScopeWebhooksRead  Scope = "webhooks:read"
+	ScopeWebhooksWrite Scope = "webhooks:write"
+
+	ScopeNotificationsRead  Scope = "notifications:read"
+	ScopeNotificationsWrite Scope = "notifications:write"
+)
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeStatsRead, ScopeTeamsRead, ScopeTeamsWrite, ScopeUsersRead, ScopeUsersWrite, ScopePRRead, ScopePRWrite, ScopeWebhooksRead, ScopeWebhooksWrite, ScopeNotificationsRead, ScopeNotificationsWrite:
+		return true
+	}
+
+	return false
+}
+
+type APIKey struct {
+	ID         int
+	UserID     string
+	Name       string
+	Prefix     string
+	Scopes     []Scope
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type IssuedAPIKey struct {
+	Key    APIKey
+	Secret string
+}
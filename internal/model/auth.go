@@ -0,0 +1,46 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team_lead"
+	RoleMember   Role = "member"
+	RoleBot      Role = "bot"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleTeamLead, RoleMember, RoleBot:
+		return true
+	}
+
+	return false
+}
+
+type Principal struct {
+	UserID   string
+	Role     Role
+	APIKeyID int
+	Scopes   []Scope
+
+	TokenID        string
+	TokenExpiresAt time.Time
+}
+
+func (p Principal) HasScope(scope Scope) bool {
+	return p.APIKeyID == 0 || slices.Contains(p.Scopes, scope)
+}
+
+type Credentials struct {
+	UserID         string
+	Role           Role
+	PasswordHash   string
+	FailedAttempts int
+	LockedUntil    *time.Time
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type CalendarFeed struct {
+	ID           int64
+	UserID       string
+	TeamID       int
+	TeamName     string
+	URL          string
+	Categories   []string
+	CreatedBy    string
+	CreatedAt    time.Time
+	NextSyncAt   time.Time
+	LastSyncedAt *time.Time
+	LastError    string
+	AbsenceCount int
+}
+
+func (f CalendarFeed) IsUpload() bool {
+	return f.URL == ""
+}
+
+type CalendarFeedFilter struct {
+	UserID   string
+	TeamName string
+}
+
+type Absence struct {
+	FeedID   int64
+	UserID   string
+	UID      string
+	Summary  string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
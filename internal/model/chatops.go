@@ -0,0 +1,19 @@
+package model
+
+type ChatCommand struct {
+	Provider   IdentityProvider
+	ExternalID string
+	Text       string
+}
+
+type ChatResponseType string
+
+const (
+	ChatEphemeral ChatResponseType = "ephemeral"
+	ChatInChannel ChatResponseType = "in_channel"
+)
+
+type ChatResponse struct {
+	ResponseType ChatResponseType
+	Text         string
+}
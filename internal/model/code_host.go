@@ -0,0 +1,58 @@
+package model
+
+type IdentityProvider string
+
+const (
+	ProviderGitHub IdentityProvider = "github"
+	ProviderGitLab IdentityProvider = "gitlab"
+	ProviderSlack  IdentityProvider = "slack"
+)
+
+func (p IdentityProvider) IsValid() bool {
+	switch p {
+	case ProviderGitHub, ProviderGitLab, ProviderSlack:
+		return true
+	}
+
+	return false
+}
+
+type ExternalIdentity struct {
+	Provider   IdentityProvider
+	ExternalID string
+	UserID     string
+}
+
+type CodeHostAction string
+
+const (
+	CodeHostOpened   CodeHostAction = "opened"
+	CodeHostMerged   CodeHostAction = "merged"
+	CodeHostClosed   CodeHostAction = "closed"
+	CodeHostReopened CodeHostAction = "reopened"
+	CodeHostReviewed CodeHostAction = "reviewed"
+)
+
+type CodeHostEvent struct {
+	Provider         IdentityProvider
+	DeliveryID       string
+	Action           CodeHostAction
+	PullRequestID    string
+	Title            string
+	AuthorExternalID string
+	ActorExternalID  string
+	Verdict          Verdict
+}
+
+type CodeHostOutcome string
+
+const (
+	OutcomeProcessed CodeHostOutcome = "processed"
+	OutcomeDuplicate CodeHostOutcome = "duplicate"
+	OutcomeIgnored   CodeHostOutcome = "ignored"
+)
+
+type CodeHostResult struct {
+	Outcome CodeHostOutcome
+	Reason  string
+}
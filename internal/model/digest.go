@@ -0,0 +1,106 @@
+package model
+
+import "time"
+
+type DigestKind string
+
+const (
+	DigestDaily  DigestKind = "DAILY"
+	DigestWeekly DigestKind = "WEEKLY"
+)
+
+func (k DigestKind) IsValid() bool {
+	switch k {
+	case DigestDaily, DigestWeekly:
+		return true
+	}
+
+	return false
+}
+
+type DigestFormat string
+
+const (
+	DigestMarkdown DigestFormat = "MARKDOWN"
+	DigestHTML     DigestFormat = "HTML"
+	DigestText     DigestFormat = "TEXT"
+)
+
+func (f DigestFormat) IsValid() bool {
+	switch f {
+	case DigestMarkdown, DigestHTML, DigestText:
+		return true
+	}
+
+	return false
+}
+
+func (f DigestFormat) ContentType() string {
+	switch f {
+	case DigestMarkdown:
+		return "text/markdown; charset=UTF-8"
+	case DigestHTML:
+		return "text/html; charset=UTF-8"
+	}
+
+	return "text/plain; charset=UTF-8"
+}
+
+type DigestSubscription struct {
+	UserID     string
+	Kind       DigestKind
+	Format     DigestFormat
+	NextRunAt  time.Time
+	LastSentAt *time.Time
+	LastError  string
+	CreatedAt  time.Time
+}
+
+type DigestPullRequest struct {
+	ID               string
+	Name             string
+	AuthorID         string
+	TeamName         string
+	CreatedAt        time.Time
+	Verdict          Verdict
+	PendingReviewers []string
+}
+
+type ReviewerLoad struct {
+	UserID      string
+	Username    string
+	OpenReviews int
+}
+
+type TeamDigest struct {
+	TeamName           string
+	Opened             int
+	Merged             int
+	Closed             int
+	AverageTimeToMerge time.Duration
+	OpenPullRequests   []DigestPullRequest
+	ReviewerLoad       []ReviewerLoad
+}
+
+type Digest struct {
+	Kind            DigestKind
+	User            User
+	Since           time.Time
+	Until           time.Time
+	AssignedReviews []DigestPullRequest
+	AwaitingReview  []DigestPullRequest
+	Teams           []TeamDigest
+}
+
+func (d Digest) IsEmpty() bool {
+	return len(d.AssignedReviews) == 0 && len(d.AwaitingReview) == 0 && len(d.Teams) == 0
+}
+
+type RenderedDigest struct {
+	UserID      string
+	Kind        DigestKind
+	Format      DigestFormat
+	Subject     string
+	Body        string
+	GeneratedAt time.Time
+}
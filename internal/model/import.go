@@ -0,0 +1,39 @@
+package model
+
+type ImportFormat string
+
+const (
+	ImportFormatYAML ImportFormat = "yaml"
+	ImportFormatCSV  ImportFormat = "csv"
+)
+
+type OrgImport struct {
+	Teams []ImportTeam
+}
+
+type ImportTeam struct {
+	Name    string
+	Members []User
+}
+
+type ImportUserChange struct {
+	User         User
+	TeamName     string
+	FromTeamName string
+}
+
+type ImportPlan struct {
+	TeamsToCreate     []string
+	UsersToAdd        []ImportUserChange
+	UsersToMove       []ImportUserChange
+	UsersToUpdate     []ImportUserChange
+	UsersToDeactivate []ImportUserChange
+}
+
+func (p ImportPlan) IsEmpty() bool {
+	return len(p.TeamsToCreate) == 0 &&
+		len(p.UsersToAdd) == 0 &&
+		len(p.UsersToMove) == 0 &&
+		len(p.UsersToUpdate) == 0 &&
+		len(p.UsersToDeactivate) == 0
+}
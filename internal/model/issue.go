@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type IssueTrackerJob struct {
+	ID            int64
+	IssueKey      string
+	Event         Event
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+}
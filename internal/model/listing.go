@@ -0,0 +1,28 @@
+package model
+
+type TeamFilter struct {
+	Name            string
+	NamePrefix      string
+	ParentName      string
+	IncludeArchived bool
+	After           string
+	Offset          int
+	Limit           int
+}
+
+type UserFilter struct {
+	IsActive   *bool
+	TeamName   string
+	Username   string
+	NamePrefix string
+	After      string
+	Offset     int
+	Limit      int
+}
+
+type TeamSummary struct {
+	Team              Team
+	ParentName        string
+	MemberCount       int
+	ActiveMemberCount int
+}
@@ -0,0 +1,41 @@
+package model
+
+type ReviewPolicy string
+
+const (
+	ReviewPolicyKeep     ReviewPolicy = "keep"
+	ReviewPolicyReassign ReviewPolicy = "reassign"
+)
+
+type AuthoredPolicy string
+
+const (
+	AuthoredPolicyKeep     AuthoredPolicy = "keep"
+	AuthoredPolicyTransfer AuthoredPolicy = "transfer"
+)
+
+type ReviewReassignment struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+}
+
+type MembershipChange struct {
+	User           FullUserInfo
+	Reassignments  []ReviewReassignment
+	TransferredPRs []string
+}
+
+type PullRequestTransfer struct {
+	PullRequestID string
+	ReviewerIDs   []string
+}
+
+type MembershipPlan struct {
+	UserID        string
+	Deactivate    bool
+	LeaveTeamID   int
+	JoinTeamID    int
+	Reassignments []ReviewReassignment
+	Transfers     []PullRequestTransfer
+}
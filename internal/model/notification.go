@@ -0,0 +1,118 @@
+package model
+
+import "time"
+
+type NotificationChannel string
+
+const (
+	ChannelEmail   NotificationChannel = "EMAIL"
+	ChannelSlack   NotificationChannel = "SLACK"
+	ChannelWebhook NotificationChannel = "WEBHOOK"
+)
+
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case ChannelEmail, ChannelSlack, ChannelWebhook:
+		return true
+	}
+
+	return false
+}
+
+type NotificationMode string
+
+const (
+	NotifyImmediately NotificationMode = "IMMEDIATE"
+	NotifyInDigest    NotificationMode = "DIGEST"
+)
+
+func (m NotificationMode) IsValid() bool {
+	return m == NotifyImmediately || m == NotifyInDigest
+}
+
+type NotificationKind string
+
+const (
+	NotificationReviewRequested  NotificationKind = "review_requested"
+	NotificationReviewReassigned NotificationKind = "review_reassigned"
+	NotificationReviewSubmitted  NotificationKind = "review_submitted"
+	NotificationPRMerged         NotificationKind = "pull_request_merged"
+	NotificationDigest           NotificationKind = "digest"
+)
+
+var NotificationKinds = []NotificationKind{
+	NotificationReviewRequested,
+	NotificationReviewReassigned,
+	NotificationReviewSubmitted,
+	NotificationPRMerged,
+	NotificationDigest,
+}
+
+func (k NotificationKind) IsValid() bool {
+	for _, kind := range NotificationKinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "PENDING"
+	NotificationSent    NotificationStatus = "SENT"
+	NotificationFailed  NotificationStatus = "FAILED"
+)
+
+type NotificationPreference struct {
+	UserID          string
+	Channel         NotificationChannel
+	Address         string
+	Mode            NotificationMode
+	Enabled         bool
+	Timezone        string
+	QuietHoursStart string
+	QuietHoursEnd   string
+	DigestTime      string
+	UpdatedAt       time.Time
+}
+
+type NotificationTemplate struct {
+	Kind      NotificationKind
+	Subject   string
+	Body      string
+	Custom    bool
+	UpdatedBy string
+	UpdatedAt *time.Time
+}
+
+type Notification struct {
+	ID            int64
+	EventID       int64
+	UserID        string
+	Kind          NotificationKind
+	PullRequestID string
+	Channel       NotificationChannel
+	Address       string
+	Digest        bool
+	Subject       string
+	Body          string
+	Status        NotificationStatus
+	Attempts      int
+	LastError     string
+	DeliverAfter  time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+}
+
+type NotificationMessage struct {
+	UserID        string
+	Channel       NotificationChannel
+	Address       string
+	Subject       string
+	Body          string
+	ContentType   string
+	Notifications []Notification
+}
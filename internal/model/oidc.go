@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type OIDCState struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
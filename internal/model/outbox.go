@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type OutboxRecord struct {
+	ID          int64
+	AggregateID string
+	EventType   EventType
+	Payload     []byte
+	Attempts    int
+	CreatedAt   time.Time
+}
@@ -0,0 +1,24 @@
+package model
+
+type ProvisionedUser struct {
+	User        FullUserInfo
+	Memberships []TeamMembership
+}
+
+type ProvisionedGroup struct {
+	Team    Team
+	Members []User
+}
+
+type UserPatch struct {
+	Username *string
+	IsActive *bool
+}
+
+type GroupPatch struct {
+	Name           *string
+	ReplaceMembers bool
+	Members        []string
+	AddMembers     []string
+	RemoveMembers  []string
+}
@@ -7,14 +7,41 @@ type PRStatus string
 const (
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED"
 )
 
+type Verdict string
+
+const (
+	VerdictApproved         Verdict = "APPROVED"
+	VerdictChangesRequested Verdict = "CHANGES_REQUESTED"
+	VerdictCommented        Verdict = "COMMENTED"
+)
+
+func (v Verdict) IsValid() bool {
+	switch v {
+	case VerdictApproved, VerdictChangesRequested, VerdictCommented:
+		return true
+	}
+
+	return false
+}
+
 type PullRequest struct {
 	ID                string
 	Name              string
 	AuthorID          string
+	TeamID            int
+	TeamName          string
 	Status            PRStatus
 	AssignedReviewers []string
+	AssignedBy        map[string]string
+	Verdicts          map[string]Verdict
+	IssueKeys         []string
+	CreatedBy         string
+	MergedBy          string
+	ClosedBy          string
 	CreatedAt         time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
 }
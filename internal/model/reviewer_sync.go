@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type ReviewerSyncJob struct {
+	PullRequestID string
+	Generation    int
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"crypto"
+	"time"
+)
+
+type SigningAlgorithm string
+
+const (
+	SigningHS256 SigningAlgorithm = "HS256"
+	SigningRS256 SigningAlgorithm = "RS256"
+	SigningEdDSA SigningAlgorithm = "EdDSA"
+)
+
+func (a SigningAlgorithm) IsValid() bool {
+	switch a {
+	case SigningHS256, SigningRS256, SigningEdDSA:
+		return true
+	}
+
+	return false
+}
+
+func (a SigningAlgorithm) IsAsymmetric() bool {
+	return a == SigningRS256 || a == SigningEdDSA
+}
+
+type SigningKey struct {
+	KID         string
+	Algorithm   SigningAlgorithm
+	PrivateKey  crypto.Signer
+	ActivatesAt time.Time
+	RetiresAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (k SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
@@ -4,3 +4,17 @@ type UserStats struct {
 	UserID      string `json:"user_id"`
 	ReviewCount int    `json:"review_count"`
 }
+
+type TeamReviewCount struct {
+	TeamID      int
+	ParentID    int
+	TeamName    string
+	ReviewCount int
+}
+
+type TeamStats struct {
+	TeamName         string      `json:"team_name"`
+	ReviewCount      int         `json:"review_count"`
+	TotalReviewCount int         `json:"total_review_count"`
+	Children         []TeamStats `json:"children"`
+}
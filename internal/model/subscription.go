@@ -0,0 +1,131 @@
+package model
+
+import "time"
+
+type EventType string
+
+const (
+	EventPRCreated          EventType = "pull_request.created"
+	EventReviewerReassigned EventType = "pull_request.reviewer_reassigned"
+	EventReviewerRemoved    EventType = "pull_request.reviewer_removed"
+	EventPRTransferred      EventType = "pull_request.transferred"
+	EventPRMerged           EventType = "pull_request.merged"
+	EventPRClosed           EventType = "pull_request.closed"
+	EventPRReopened         EventType = "pull_request.reopened"
+	EventPRReviewed         EventType = "pull_request.reviewed"
+)
+
+func (e EventType) IsValid() bool {
+	switch e {
+	case EventPRCreated, EventReviewerReassigned, EventReviewerRemoved, EventPRTransferred, EventPRMerged, EventPRClosed, EventPRReopened, EventPRReviewed:
+		return true
+	}
+
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryDead      DeliveryStatus = "DEAD"
+)
+
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryPending, DeliveryDelivered, DeliveryDead:
+		return true
+	}
+
+	return false
+}
+
+type WebhookSubscription struct {
+	ID         int
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	CreatedBy  string
+	CreatedAt  time.Time
+}
+
+type EventPullRequest struct {
+	ID                string   `json:"pull_request_id"`
+	Name              string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	TeamName          string   `json:"team_name,omitempty"`
+	Status            PRStatus `json:"status"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	IssueKeys         []string `json:"issue_keys,omitempty"`
+}
+
+type EventReassignment struct {
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+}
+
+type EventReview struct {
+	ReviewerID string  `json:"reviewer_id"`
+	Verdict    Verdict `json:"verdict"`
+}
+
+type Event struct {
+	ID           int64              `json:"id,omitempty"`
+	Type         EventType          `json:"event"`
+	OccurredAt   time.Time          `json:"occurred_at"`
+	ActorID      string             `json:"actor_id,omitempty"`
+	PullRequest  EventPullRequest   `json:"pull_request"`
+	Reassignment *EventReassignment `json:"reassignment,omitempty"`
+	Review       *EventReview       `json:"review,omitempty"`
+}
+
+func NewPullRequestEvent(eventType EventType, actorID string, pr PullRequest) Event {
+	reviewers := pr.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+
+	return Event{
+		Type:    eventType,
+		ActorID: actorID,
+		PullRequest: EventPullRequest{
+			ID:                pr.ID,
+			Name:              pr.Name,
+			AuthorID:          pr.AuthorID,
+			TeamName:          pr.TeamName,
+			Status:            pr.Status,
+			AssignedReviewers: reviewers,
+			IssueKeys:         pr.IssueKeys,
+		},
+	}
+}
+
+type SubscriptionDelivery struct {
+	ID             int64
+	SubscriptionID int
+	URL            string
+	Secret         string
+	EventType      EventType
+	Payload        []byte
+	Status         DeliveryStatus
+	Attempts       int
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	AttemptLog     []DeliveryAttempt
+}
+
+type DeliveryAttempt struct {
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+type DeliveryFilter struct {
+	SubscriptionID int
+	Status         DeliveryStatus
+	Limit          int
+}
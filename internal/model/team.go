@@ -1,6 +1,80 @@
 package model
 
+import "time"
+
+const DefaultReviewerCount = 2
+
+type AssignmentPolicy struct {
+	ReviewerCount    *int
+	FallbackToParent *bool
+}
+
 type Team struct {
-	ID   int
-	Name string
+	ID         int
+	Name       string
+	ParentID   int
+	Policy     AssignmentPolicy
+	ArchivedAt *time.Time
+	CreatedBy  string
+}
+
+type TeamUpsert struct {
+	Team    Team
+	Created []User
+	Updated []User
+}
+
+type TeamMembership struct {
+	TeamID     int
+	TeamName   string
+	UserID     string
+	IsPrimary  bool
+	Reviewable bool
+}
+
+type TeamDeletionReport struct {
+	Team               Team
+	MemberIDs          []string
+	PrimaryMemberIDs   []string
+	OpenPullRequestIDs []string
+	PullRequestCount   int
+	ChildTeams         []string
+}
+
+func (r TeamDeletionReport) IsEmpty() bool {
+	return len(r.MemberIDs) == 0 && len(r.OpenPullRequestIDs) == 0 && len(r.ChildTeams) == 0
+}
+
+type ResolvedPolicy struct {
+	ReviewerCount    int
+	FallbackToParent bool
+}
+
+type TeamHierarchy struct {
+	Ancestors []Team
+	Children  []Team
+	Policy    ResolvedPolicy
+}
+
+func ResolvePolicy(chain []Team) ResolvedPolicy {
+	var reviewerCount *int
+	var fallback *bool
+	for _, team := range chain {
+		if reviewerCount == nil {
+			reviewerCount = team.Policy.ReviewerCount
+		}
+		if fallback == nil {
+			fallback = team.Policy.FallbackToParent
+		}
+	}
+
+	policy := ResolvedPolicy{ReviewerCount: DefaultReviewerCount}
+	if reviewerCount != nil {
+		policy.ReviewerCount = *reviewerCount
+	}
+	if fallback != nil {
+		policy.FallbackToParent = *fallback
+	}
+
+	return policy
 }
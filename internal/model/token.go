@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type RefreshToken struct {
+	ID        int
+	UserID    string
+	FamilyID  string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+type TokenPair struct {
+	Principal    Principal
+	RefreshToken string
+}
@@ -9,5 +9,11 @@ type User struct {
 
 type FullUserInfo struct {
 	User
-	TeamName string
+	TeamName      string
+	DeactivatedBy string
+}
+
+type UserDetails struct {
+	FullUserInfo
+	OpenReviewCount int
 }
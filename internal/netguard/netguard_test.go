@@ -0,0 +1,60 @@
+package netguard
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1::1", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.100.100.200", false},
+		{"0.0.0.0", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPublic(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestIsPublicHost(t *testing.T) {
+	assert.True(t, IsPublicHost("calendar.example.com"))
+	assert.False(t, IsPublicHost("localhost"))
+	assert.False(t, IsPublicHost("api.LOCALHOST."))
+	assert.False(t, IsPublicHost("127.0.0.1"))
+	assert.False(t, IsPublicHost("[::1]"))
+}
+
+func TestNewClient_BlocksPrivateTargets(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer internal.Close()
+
+	client := NewClient(time.Second)
+
+	_, err := client.Get(internal.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrBlockedAddress)
+}
@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type EmailNotifier struct {
+	config SMTPConfig
+	dialer *net.Dialer
+	now    func() time.Time
+}
+
+func NewEmailNotifier(config SMTPConfig) *EmailNotifier {
+	if config.Port == 0 {
+		config.Port = 587
+	}
+
+	return &EmailNotifier{
+		config: config,
+		dialer: &net.Dialer{Timeout: defaultTimeout},
+		now:    time.Now,
+	}
+}
+
+func (n *EmailNotifier) Channel() model.NotificationChannel {
+	return model.ChannelEmail
+}
+
+func (n *EmailNotifier) Send(ctx context.Context, message model.NotificationMessage) error {
+	if err := n.send(ctx, message.Address, n.compose(message)); err != nil {
+		return fmt.Errorf("%w: %v", ErrSendFailed, err)
+	}
+
+	return nil
+}
+
+func (n *EmailNotifier) compose(message model.NotificationMessage) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", message.Address)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s\r\n", contentType(message))
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	return msg.Bytes()
+}
+
+func (n *EmailNotifier) send(ctx context.Context, to string, msg []byte) error {
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+
+	conn, err := n.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host}); err != nil {
+			return err
+		}
+	}
+
+	if n.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/notify/notifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailNotifier_Send(t *testing.T) {
+	sink := notifytest.NewSMTPServer()
+	defer sink.Close()
+
+	notifier := NewEmailNotifier(SMTPConfig{Host: sink.Host(), Port: sink.Port(), From: "pr-service@example.com"})
+	err := notifier.Send(context.Background(), model.NotificationMessage{
+		Address: "bob@example.com",
+		Subject: "Review requested: Äpfel",
+		Body:    "Line one\nLine two",
+	})
+	require.NoError(t, err)
+
+	messages := sink.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "pr-service@example.com", messages[0].From)
+	assert.Equal(t, []string{"bob@example.com"}, messages[0].To)
+	assert.Equal(t, "Review requested: Äpfel", messages[0].Subject)
+	assert.Equal(t, "Line one\nLine two\n", messages[0].Body)
+	assert.Equal(t, "text/plain; charset=UTF-8", messages[0].Header.Get("Content-Type"))
+}
+
+func TestEmailNotifier_Send_HTML(t *testing.T) {
+	sink := notifytest.NewSMTPServer()
+	defer sink.Close()
+
+	notifier := NewEmailNotifier(SMTPConfig{Host: sink.Host(), Port: sink.Port(), From: "pr-service@example.com"})
+	err := notifier.Send(context.Background(), model.NotificationMessage{
+		Address:     "bob@example.com",
+		Subject:     "Review digest",
+		Body:        "<h1>Review digest</h1>",
+		ContentType: model.DigestHTML.ContentType(),
+	})
+	require.NoError(t, err)
+
+	messages := sink.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "text/html; charset=UTF-8", messages[0].Header.Get("Content-Type"))
+	assert.Equal(t, "<h1>Review digest</h1>\n", messages[0].Body)
+}
+
+func TestEmailNotifier_Send_Rejected(t *testing.T) {
+	sink := notifytest.NewSMTPServer()
+	defer sink.Close()
+	sink.SetFailing(true)
+
+	notifier := NewEmailNotifier(SMTPConfig{Host: sink.Host(), Port: sink.Port(), From: "pr-service@example.com"})
+	err := notifier.Send(context.Background(), model.NotificationMessage{Address: "bob@example.com", Subject: "s", Body: "b"})
+	assert.ErrorIs(t, err, ErrSendFailed)
+	assert.Empty(t, sink.Messages())
+}
+
+func TestSlackNotifier_Send(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.Client()).Send(context.Background(), model.NotificationMessage{
+		Address: server.URL,
+		Subject: "Merged: Add search",
+		Body:    "pr-1 was merged by alice.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"text": "*Merged: Add search*\npr-1 was merged by alice."}, got)
+}
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var got webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.Client()).Send(context.Background(), model.NotificationMessage{
+		UserID:  "bob",
+		Address: server.URL,
+		Subject: "2 pull request update(s)",
+		Body:    "digest",
+		Notifications: []model.Notification{
+			{ID: 1, EventID: 10, Kind: model.NotificationReviewRequested, PullRequestID: "pr-1", Subject: "Review requested: A"},
+			{ID: 2, EventID: 11, Kind: model.NotificationPRMerged, PullRequestID: "pr-2", Subject: "Merged: B"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bob", got.UserID)
+	assert.Equal(t, "text/plain; charset=UTF-8", got.ContentType)
+	require.Len(t, got.Notifications, 2)
+	assert.Equal(t, "review_requested", got.Notifications[0].Kind)
+	assert.Equal(t, int64(11), got.Notifications[1].EventID)
+}
+
+func TestWebhookNotifier_Send_NonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.Client()).Send(context.Background(), model.NotificationMessage{Address: server.URL})
+	assert.ErrorIs(t, err, ErrSendFailed)
+}
+
+func TestWebhookNotifier_Send_DefaultClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal address must not be reached")
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(nil).Send(context.Background(), model.NotificationMessage{Address: server.URL})
+	assert.ErrorIs(t, err, ErrSendFailed)
+	assert.ErrorContains(t, err, "not allowed")
+}
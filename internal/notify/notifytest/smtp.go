@@ -0,0 +1,172 @@
+package notifytest
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	Header  mail.Header
+}
+
+type SMTPServer struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+	failing  bool
+}
+
+func NewSMTPServer() *SMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	s := &SMTPServer{listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s
+}
+
+func (s *SMTPServer) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+func (s *SMTPServer) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *SMTPServer) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+func (s *SMTPServer) SetFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failing = failing
+}
+
+func (s *SMTPServer) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *SMTPServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *SMTPServer) handle(conn *textproto.Conn) {
+	reply := func(code int, text string) bool {
+		return conn.PrintfLine("%d %s", code, text) == nil
+	}
+
+	if !reply(220, "notifytest ESMTP") {
+		return
+	}
+
+	var current Message
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			if conn.PrintfLine("250-notifytest") != nil || !reply(250, "8BITMIME") {
+				return
+			}
+		case "HELO", "NOOP":
+			reply(250, "OK")
+		case "RSET":
+			current = Message{}
+			reply(250, "OK")
+		case "MAIL":
+			current = Message{From: extractAddress(arg)}
+			reply(250, "OK")
+		case "RCPT":
+			current.To = append(current.To, extractAddress(arg))
+			reply(250, "OK")
+		case "DATA":
+			if !reply(354, "end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			failing := s.failing
+			s.mu.Unlock()
+			if failing {
+				reply(451, "try again later")
+				continue
+			}
+
+			if parsed, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(data)))); err == nil {
+				body, _ := io.ReadAll(parsed.Body)
+				current.Header = parsed.Header
+				current.Subject = decodeHeader(parsed.Header.Get("Subject"))
+				current.Body = strings.ReplaceAll(string(body), "\r\n", "\n")
+			}
+
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, "command not implemented")
+		}
+	}
+}
+
+func extractAddress(arg string) string {
+	_, address, _ := strings.Cut(arg, ":")
+	address, _, _ = strings.Cut(strings.TrimSpace(address), " ")
+	return strings.Trim(address, "<>")
+}
+
+func decodeHeader(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+
+	return decoded
+}
@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/netguard"
+)
+
+type SlackNotifier struct {
+	client *http.Client
+}
+
+func NewSlackNotifier(client *http.Client) *SlackNotifier {
+	if client == nil {
+		client = netguard.NewClient(defaultTimeout)
+	}
+
+	return &SlackNotifier{client: client}
+}
+
+func (n *SlackNotifier) Channel() model.NotificationChannel {
+	return model.ChannelSlack
+}
+
+func (n *SlackNotifier) Send(ctx context.Context, message model.NotificationMessage) error {
+	return postJSON(ctx, n.client, message.Address, map[string]string{
+		"text": "*" + message.Subject + "*\n" + message.Body,
+	})
+}
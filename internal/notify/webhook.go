@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/netguard"
+)
+
+type webhookNotification struct {
+	ID            int64     `json:"id"`
+	EventID       int64     `json:"event_id"`
+	Kind          string    `json:"kind"`
+	PullRequestID string    `json:"pull_request_id"`
+	Subject       string    `json:"subject"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type webhookPayload struct {
+	UserID        string                `json:"user_id"`
+	Subject       string                `json:"subject"`
+	Body          string                `json:"body"`
+	ContentType   string                `json:"content_type"`
+	Notifications []webhookNotification `json:"notifications"`
+}
+
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+func NewWebhookNotifier(client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = netguard.NewClient(defaultTimeout)
+	}
+
+	return &WebhookNotifier{client: client}
+}
+
+func (n *WebhookNotifier) Channel() model.NotificationChannel {
+	return model.ChannelWebhook
+}
+
+func (n *WebhookNotifier) Send(ctx context.Context, message model.NotificationMessage) error {
+	payload := webhookPayload{
+		UserID:        message.UserID,
+		Subject:       message.Subject,
+		Body:          message.Body,
+		ContentType:   contentType(message),
+		Notifications: make([]webhookNotification, len(message.Notifications)),
+	}
+	for i, notification := range message.Notifications {
+		payload.Notifications[i] = webhookNotification{
+			ID:            notification.ID,
+			EventID:       notification.EventID,
+			Kind:          string(notification.Kind),
+			PullRequestID: notification.PullRequestID,
+			Subject:       notification.Subject,
+			Body:          notification.Body,
+			CreatedAt:     notification.CreatedAt,
+		}
+	}
+
+	return postJSON(ctx, n.client, message.Address, payload)
+}
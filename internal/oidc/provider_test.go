@@ -0,0 +1,115 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID    = "pr-service"
+	testRedirectURL = "http://localhost/oidc/callback"
+	testVerifier    = "test-code-verifier-with-enough-entropy-0123456789"
+)
+
+func newTestProvider(t *testing.T, fake *oidctest.Provider) *Provider {
+	t.Helper()
+
+	provider, err := NewProvider(context.Background(), Config{
+		IssuerURL:   fake.Issuer(),
+		ClientID:    testClientID,
+		RedirectURL: testRedirectURL,
+		Scopes:      []string{"openid", "profile"},
+	}, nil)
+	require.NoError(t, err)
+
+	return provider
+}
+
+func authorize(t *testing.T, provider *Provider, state, nonce string) string {
+	t.Helper()
+
+	sum := sha256.Sum256([]byte(testVerifier))
+	authURL := provider.AuthCodeURL(state, nonce, base64.RawURLEncoding.EncodeToString(sum[:]))
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, state, location.Query().Get("state"))
+
+	return location.Query().Get("code")
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	fake := oidctest.NewProvider(testClientID)
+	defer fake.Close()
+	fake.SetClaims(map[string]any{"sub": "123", "preferred_username": "alice"})
+
+	provider := newTestProvider(t, fake)
+	code := authorize(t, provider, "state-1", "nonce-1")
+
+	claims, err := provider.Exchange(context.Background(), code, testVerifier)
+
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims["preferred_username"])
+	assert.Equal(t, "nonce-1", claims["nonce"])
+
+	_, err = provider.Exchange(context.Background(), code, testVerifier)
+	assert.ErrorIs(t, err, ErrTokenExchange)
+}
+
+func TestProvider_RejectsWrongVerifier(t *testing.T) {
+	fake := oidctest.NewProvider(testClientID)
+	defer fake.Close()
+
+	provider := newTestProvider(t, fake)
+	code := authorize(t, provider, "state-1", "nonce-1")
+
+	_, err := provider.Exchange(context.Background(), code, "another-verifier")
+
+	assert.ErrorIs(t, err, ErrTokenExchange)
+}
+
+func TestProvider_RejectsInvalidIDToken(t *testing.T) {
+	fake := oidctest.NewProvider(testClientID)
+	defer fake.Close()
+
+	provider := newTestProvider(t, fake)
+
+	for name, claims := range map[string]map[string]any{
+		"audience": {"sub": "123", "aud": "someone-else"},
+		"issuer":   {"sub": "123", "iss": "https://evil.example"},
+		"expired":  {"sub": "123", "exp": time.Now().Add(-time.Minute).Unix()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake.SetClaims(claims)
+			code := authorize(t, provider, "state", "nonce")
+
+			_, err := provider.Exchange(context.Background(), code, testVerifier)
+
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestNewProvider_IssuerMismatch(t *testing.T) {
+	fake := oidctest.NewProvider(testClientID)
+	defer fake.Close()
+
+	_, err := NewProvider(context.Background(), Config{IssuerURL: fake.Issuer() + "/", ClientID: testClientID}, nil)
+
+	assert.ErrorIs(t, err, ErrDiscovery)
+}
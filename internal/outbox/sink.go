@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+type LogSink struct {
+	logger *log.Logger
+}
+
+func NewLogSink(logger *log.Logger) *LogSink {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Publish(ctx context.Context, event model.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Printf("event %d %s %s: %s", event.ID, event.Type, event.PullRequest.ID, payload)
+	return nil
+}
+
+type ChannelSink struct {
+	events chan model.Event
+}
+
+func NewChannelSink(buffer int) *ChannelSink {
+	return &ChannelSink{events: make(chan model.Event, buffer)}
+}
+
+func (s *ChannelSink) Events() <-chan model.Event {
+	return s.events
+}
+
+func (s *ChannelSink) Publish(ctx context.Context, event model.Event) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
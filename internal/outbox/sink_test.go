@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogSink_Publish(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewLogSink(log.New(&buf, "", 0))
+
+	event := model.NewPullRequestEvent(model.EventPRMerged, "alice", model.PullRequest{ID: "pr-1", Status: model.StatusMerged})
+	event.ID = 42
+	require.NoError(t, sink.Publish(context.Background(), event))
+
+	assert.Contains(t, buf.String(), "event 42 pull_request.merged pr-1")
+	assert.Contains(t, buf.String(), `"actor_id":"alice"`)
+}
+
+func TestChannelSink_Publish(t *testing.T) {
+	sink := NewChannelSink(1)
+
+	require.NoError(t, sink.Publish(context.Background(), model.Event{ID: 1, Type: model.EventPRCreated}))
+	assert.Equal(t, int64(1), (<-sink.Events()).ID)
+
+	require.NoError(t, sink.Publish(context.Background(), model.Event{ID: 2}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, sink.Publish(ctx, model.Event{ID: 3}), context.Canceled)
+}
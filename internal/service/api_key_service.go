@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	apiKeySecretBytes   = 32
+	apiKeyDisplayLength = len(model.APIKeyPrefix) + 8
+	defaultAPIKeyTTL    = 90 * 24 * time.Hour
+	maxAPIKeyTTL        = 365 * 24 * time.Hour
+)
+
+type APIKeyService struct {
+	apiKeyRepo APIKeyRepository
+	now        func() time.Time
+}
+
+func NewAPIKeyService(apiKeyRepo APIKeyRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		now:        time.Now,
+	}
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func authorizeKeyOwner(ctx context.Context, userID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.APIKeyID != 0 {
+		return ErrForbidden
+	}
+
+	if principal.Role != model.RoleAdmin && principal.UserID != userID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func (s *APIKeyService) Issue(ctx context.Context, userID, name string, scopes []model.Scope, expiresAt *time.Time) (*model.IssuedAPIKey, error) {
+	if userID == "" {
+		principal, _ := PrincipalFromContext(ctx)
+		userID = principal.UserID
+	}
+
+	if err := authorizeKeyOwner(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if len(scopes) == 0 {
+		return nil, ErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, ErrInvalidScope
+		}
+	}
+
+	now := s.now()
+	expiry := now.Add(defaultAPIKeyTTL)
+	if expiresAt != nil {
+		expiry = *expiresAt
+	}
+	if !expiry.After(now) || expiry.After(now.Add(maxAPIKeyTTL)) {
+		return nil, ErrInvalidExpiry
+	}
+
+	random, err := randomToken(apiKeySecretBytes)
+	if err != nil {
+		return nil, err
+	}
+	secret := model.APIKeyPrefix + random
+
+	key, err := s.apiKeyRepo.Create(ctx, model.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    secret[:apiKeyDisplayLength],
+		Scopes:    scopes,
+		ExpiresAt: expiry,
+	}, hashSecret(secret))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &model.IssuedAPIKey{Key: *key, Secret: secret}, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, userID string) ([]model.APIKey, error) {
+	if userID == "" {
+		principal, _ := PrincipalFromContext(ctx)
+		userID = principal.UserID
+	}
+
+	if err := authorizeKeyOwner(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, keyID int) (*model.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if err := authorizeKeyOwner(ctx, key.UserID); err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.apiKeyRepo.Revoke(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return revoked, nil
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*model.Principal, error) {
+	if !strings.HasPrefix(secret, model.APIKeyPrefix) {
+		return nil, ErrInvalidCredentials
+	}
+
+	key, role, err := s.apiKeyRepo.Authenticate(ctx, hashSecret(secret))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, err
+	}
+
+	if role == "" {
+		role = model.RoleMember
+	}
+
+	return &model.Principal{
+		UserID:   key.UserID,
+		Role:     role,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}, nil
+}
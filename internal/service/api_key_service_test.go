@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyService_Issue_StoresOnlyHash(t *testing.T) {
+	mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+
+	var storedHash string
+	mockAPIKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("model.APIKey"), mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { storedHash = args.String(2) }).
+		Return(func(_ context.Context, key model.APIKey, _ string) *model.APIKey {
+			key.ID = 1
+			return &key
+		}, nil)
+
+	apiKeyService := NewAPIKeyService(mockAPIKeyRepo)
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleMember})
+
+	issued, err := apiKeyService.Issue(ctx, "", "ci", []model.Scope{model.ScopePRWrite}, nil)
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(issued.Secret, model.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(issued.Secret, issued.Key.Prefix))
+	assert.Equal(t, "u1", issued.Key.UserID)
+	assert.Equal(t, hashSecret(issued.Secret), storedHash)
+	assert.NotContains(t, storedHash, issued.Secret)
+	assert.WithinDuration(t, time.Now().Add(defaultAPIKeyTTL), issued.Key.ExpiresAt, time.Minute)
+}
+
+func TestAPIKeyService_Issue_Validation(t *testing.T) {
+	apiKeyService := NewAPIKeyService(mocks.NewAPIKeyRepository(t))
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleMember})
+	viaKey := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleAdmin, APIKeyID: 7})
+	past := time.Now().Add(-time.Hour)
+	tooFar := time.Now().Add(2 * maxAPIKeyTTL)
+
+	_, err := apiKeyService.Issue(member, "u2", "ci", []model.Scope{model.ScopePRRead}, nil)
+	assert.Equal(t, ErrForbidden, err)
+
+	_, err = apiKeyService.Issue(viaKey, "u1", "ci", []model.Scope{model.ScopePRRead}, nil)
+	assert.Equal(t, ErrForbidden, err)
+
+	_, err = apiKeyService.Issue(member, "u1", "ci", nil, nil)
+	assert.Equal(t, ErrInvalidScope, err)
+
+	_, err = apiKeyService.Issue(member, "u1", "ci", []model.Scope{"pr:delete"}, nil)
+	assert.Equal(t, ErrInvalidScope, err)
+
+	_, err = apiKeyService.Issue(member, "u1", "ci", []model.Scope{model.ScopePRRead}, &past)
+	assert.Equal(t, ErrInvalidExpiry, err)
+
+	_, err = apiKeyService.Issue(member, "u1", "ci", []model.Scope{model.ScopePRRead}, &tooFar)
+	assert.Equal(t, ErrInvalidExpiry, err)
+}
+
+func TestAPIKeyService_Revoke_OwnerOrAdmin(t *testing.T) {
+	mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+
+	key := &model.APIKey{ID: 3, UserID: "u1"}
+	mockAPIKeyRepo.On("GetByID", mock.Anything, 3).Return(key, nil)
+	mockAPIKeyRepo.On("Revoke", mock.Anything, 3).Return(key, nil).Once()
+
+	apiKeyService := NewAPIKeyService(mockAPIKeyRepo)
+	other := WithPrincipal(context.Background(), model.Principal{UserID: "u2", Role: model.RoleMember})
+
+	_, err := apiKeyService.Revoke(other, 3)
+	assert.Equal(t, ErrForbidden, err)
+
+	_, err = apiKeyService.Revoke(testAdminContext(), 3)
+	assert.NoError(t, err)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+
+	secret := model.APIKeyPrefix + "secret"
+	key := &model.APIKey{ID: 5, UserID: "bot", Scopes: []model.Scope{model.ScopeStatsRead}}
+	mockAPIKeyRepo.On("Authenticate", mock.Anything, hashSecret(secret)).Return(key, model.RoleBot, nil)
+	mockAPIKeyRepo.On("Authenticate", mock.Anything, hashSecret(model.APIKeyPrefix+"revoked")).Return(nil, model.Role(""), store.ErrNotFound)
+
+	apiKeyService := NewAPIKeyService(mockAPIKeyRepo)
+
+	principal, err := apiKeyService.Authenticate(context.Background(), secret)
+	require.NoError(t, err)
+	assert.Equal(t, model.Principal{UserID: "bot", Role: model.RoleBot, APIKeyID: 5, Scopes: key.Scopes}, *principal)
+	assert.True(t, principal.HasScope(model.ScopeStatsRead))
+	assert.False(t, principal.HasScope(model.ScopePRWrite))
+
+	_, err = apiKeyService.Authenticate(context.Background(), model.APIKeyPrefix+"revoked")
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	_, err = apiKeyService.Authenticate(context.Background(), "not-a-key")
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	maxFailedLogins   = 5
+	loginLockout      = 15 * time.Minute
+	minPasswordLength = 8
+)
+
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+type AuthService struct {
+	userRepo     UserRepository
+	passwordCost int
+	now          func() time.Time
+}
+
+func NewAuthService(userRepo UserRepository) *AuthService {
+	return &AuthService{
+		userRepo:     userRepo,
+		passwordCost: bcrypt.DefaultCost,
+		now:          time.Now,
+	}
+}
+
+func (s *AuthService) Login(ctx context.Context, userID, password string) (*model.Principal, error) {
+	creds, err := s.verifyPassword(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, ErrInvalidCredentials
+	}
+
+	if creds.FailedAttempts > 0 || creds.LockedUntil != nil {
+		if err := s.userRepo.ResetLoginFailures(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	role := creds.Role
+	if role == "" {
+		role = model.RoleMember
+	}
+
+	return &model.Principal{UserID: user.ID, Role: role}, nil
+}
+
+func (s *AuthService) verifyPassword(ctx context.Context, userID, password string) (*model.Credentials, error) {
+	creds, err := s.userRepo.GetCredentials(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, err
+	}
+
+	if creds.LockedUntil != nil && creds.LockedUntil.After(s.now()) {
+		return nil, ErrAccountLocked
+	}
+
+	if creds.PasswordHash == "" {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(password)); err != nil {
+		updated, err := s.userRepo.RecordLoginFailure(ctx, userID, maxFailedLogins, loginLockout)
+		if err != nil {
+			return nil, err
+		}
+
+		if updated.LockedUntil != nil && updated.LockedUntil.After(s.now()) {
+			return nil, ErrAccountLocked
+		}
+
+		return nil, ErrInvalidCredentials
+	}
+
+	return creds, nil
+}
+
+func (s *AuthService) ChangePassword(ctx context.Context, userID, currentPassword, password string) error {
+	if err := authorizeKeyOwner(ctx, userID); err != nil {
+		return err
+	}
+
+	if principal, _ := PrincipalFromContext(ctx); principal.Role != model.RoleAdmin {
+		if _, err := s.verifyPassword(ctx, userID, currentPassword); err != nil {
+			return err
+		}
+	}
+
+	return s.SetPassword(ctx, userID, password)
+}
+
+func (s *AuthService) SetRole(ctx context.Context, userID string, role model.Role) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+
+	if err := s.userRepo.SetRole(ctx, userID, role, actorID(ctx)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *AuthService) SetPassword(ctx context.Context, userID, password string) error {
+	if len(password) < minPasswordLength {
+		return ErrWeakPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.passwordCost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return ErrWeakPassword
+		}
+
+		return err
+	}
+
+	if err := s.userRepo.SetPasswordHash(ctx, userID, string(hash), actorID(ctx)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *AuthService) SeedAdmin(ctx context.Context, admin model.User, password string) (bool, error) {
+	creds, err := s.userRepo.GetCredentials(ctx, admin.ID)
+	if errors.Is(err, store.ErrNotFound) {
+		_, err = s.userRepo.Create(ctx, admin)
+		if errors.Is(err, store.ErrUserExists) {
+			return false, nil
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if creds != nil && creds.PasswordHash != "" {
+		return false, nil
+	}
+
+	if err := s.SetPassword(ctx, admin.ID, password); err != nil {
+		return false, err
+	}
+
+	if err := s.userRepo.SetRole(ctx, admin.ID, model.RoleAdmin, actorID(ctx)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
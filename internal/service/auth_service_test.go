@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestAuthService(userRepo UserRepository) *AuthService {
+	s := NewAuthService(userRepo)
+	s.passwordCost = bcrypt.MinCost
+	return s
+}
+
+func testPasswordHash(t *testing.T, password string) string {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+func TestAuthService_Login_Success(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	creds := &model.Credentials{UserID: "u1", Role: model.RoleMember, PasswordHash: testPasswordHash(t, "correct-horse"), FailedAttempts: 2}
+	user := &model.FullUserInfo{User: model.User{ID: "u1", IsActive: true}}
+	mockUserRepo.On("GetCredentials", mock.Anything, "u1").Return(creds, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockUserRepo.On("ResetLoginFailures", mock.Anything, "u1").Return(nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	result, err := authService.Login(context.Background(), "u1", "correct-horse")
+
+	require.NoError(t, err)
+	assert.Equal(t, model.Principal{UserID: "u1", Role: model.RoleMember}, *result)
+}
+
+func TestAuthService_Login_WrongPasswordRecordsFailure(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	creds := &model.Credentials{UserID: "u1", PasswordHash: testPasswordHash(t, "correct-horse")}
+	mockUserRepo.On("GetCredentials", mock.Anything, "u1").Return(creds, nil)
+	mockUserRepo.On("RecordLoginFailure", mock.Anything, "u1", maxFailedLogins, loginLockout).
+		Return(&model.Credentials{UserID: "u1", FailedAttempts: 1}, nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	_, err := authService.Login(context.Background(), "u1", "wrong")
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+	mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_LocksAfterRepeatedFailures(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	lockedUntil := time.Now().Add(loginLockout)
+	creds := &model.Credentials{UserID: "u1", PasswordHash: testPasswordHash(t, "correct-horse"), FailedAttempts: maxFailedLogins - 1}
+	mockUserRepo.On("GetCredentials", mock.Anything, "u1").Return(creds, nil)
+	mockUserRepo.On("RecordLoginFailure", mock.Anything, "u1", maxFailedLogins, loginLockout).
+		Return(&model.Credentials{UserID: "u1", LockedUntil: &lockedUntil}, nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	_, err := authService.Login(context.Background(), "u1", "wrong")
+
+	assert.Equal(t, ErrAccountLocked, err)
+}
+
+func TestAuthService_Login_RejectsLockedAccountWithoutCheckingPassword(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	lockedUntil := time.Now().Add(time.Minute)
+	creds := &model.Credentials{UserID: "u1", PasswordHash: testPasswordHash(t, "correct-horse"), LockedUntil: &lockedUntil}
+	mockUserRepo.On("GetCredentials", mock.Anything, "u1").Return(creds, nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	_, err := authService.Login(context.Background(), "u1", "correct-horse")
+
+	assert.Equal(t, ErrAccountLocked, err)
+}
+
+func TestAuthService_Login_UnknownUserOrInactive(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	mockUserRepo.On("GetCredentials", mock.Anything, "ghost").Return(nil, store.ErrNotFound)
+	mockUserRepo.On("GetCredentials", mock.Anything, "u2").Return(&model.Credentials{
+		UserID: "u2", PasswordHash: testPasswordHash(t, "correct-horse"),
+	}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u2").Return(&model.FullUserInfo{User: model.User{ID: "u2", IsActive: false}}, nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	_, err := authService.Login(context.Background(), "ghost", "whatever")
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	_, err = authService.Login(context.Background(), "u2", "correct-horse")
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	mockUserRepo.On("GetCredentials", mock.Anything, "u1").Return(&model.Credentials{UserID: "u1", PasswordHash: testPasswordHash(t, "current-password")}, nil)
+	mockUserRepo.On("RecordLoginFailure", mock.Anything, "u1", maxFailedLogins, loginLockout).Return(&model.Credentials{UserID: "u1", FailedAttempts: 1}, nil)
+	mockUserRepo.On("SetPasswordHash", mock.Anything, "u1", mock.AnythingOfType("string"), mock.Anything).Return(nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	otherMember := WithPrincipal(context.Background(), model.Principal{UserID: "u2", Role: model.RoleMember})
+	err := authService.ChangePassword(otherMember, "u1", "current-password", "long-enough")
+	assert.Equal(t, ErrForbidden, err)
+
+	apiKey := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleMember, APIKeyID: 7})
+	err = authService.ChangePassword(apiKey, "u1", "current-password", "long-enough")
+	assert.Equal(t, ErrForbidden, err)
+
+	self := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleMember})
+	err = authService.ChangePassword(self, "u1", "wrong-password", "long-enough")
+	assert.Equal(t, ErrInvalidCredentials, err)
+	mockUserRepo.AssertNumberOfCalls(t, "RecordLoginFailure", 1)
+
+	err = authService.ChangePassword(self, "u1", "current-password", "short")
+	assert.Equal(t, ErrWeakPassword, err)
+
+	err = authService.ChangePassword(self, "u1", "current-password", "long-enough")
+	require.NoError(t, err)
+
+	err = authService.ChangePassword(testAdminContext(), "u1", "", "long-enough")
+	require.NoError(t, err)
+	mockUserRepo.AssertNumberOfCalls(t, "SetPasswordHash", 2)
+}
+
+func TestAuthService_SetRole_RequiresAdmin(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	mockUserRepo.On("SetRole", mock.Anything, "u1", model.RoleTeamLead, "admin").Return(nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	lead := WithPrincipal(context.Background(), model.Principal{UserID: "lead", Role: model.RoleTeamLead})
+	assert.Equal(t, ErrForbidden, authService.SetRole(lead, "u1", model.RoleTeamLead))
+	assert.Equal(t, ErrInvalidRole, authService.SetRole(testAdminContext(), "u1", "owner"))
+	require.NoError(t, authService.SetRole(testAdminContext(), "u1", model.RoleTeamLead))
+}
+
+func TestAuthService_SeedAdmin_CreatesMissingUser(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	admin := model.User{ID: "admin", Username: "admin", IsActive: true}
+	mockUserRepo.On("GetCredentials", mock.Anything, "admin").Return(nil, store.ErrNotFound)
+	mockUserRepo.On("Create", mock.Anything, admin).Return(&model.FullUserInfo{User: admin}, nil)
+	mockUserRepo.On("SetPasswordHash", mock.Anything, "admin", mock.AnythingOfType("string"), "").Return(nil)
+	mockUserRepo.On("SetRole", mock.Anything, "admin", model.RoleAdmin, "").Return(nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	seeded, err := authService.SeedAdmin(context.Background(), admin, "admin-password")
+
+	require.NoError(t, err)
+	assert.True(t, seeded)
+}
+
+func TestAuthService_SeedAdmin_SetsPasswordOfExistingUserWithoutOne(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	admin := model.User{ID: "admin", Username: "admin", IsActive: true}
+	mockUserRepo.On("GetCredentials", mock.Anything, "admin").Return(&model.Credentials{UserID: "admin", Role: model.RoleMember}, nil)
+	mockUserRepo.On("SetPasswordHash", mock.Anything, "admin", mock.AnythingOfType("string"), "").Return(nil)
+	mockUserRepo.On("SetRole", mock.Anything, "admin", model.RoleAdmin, "").Return(nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	seeded, err := authService.SeedAdmin(context.Background(), admin, "admin-password")
+
+	require.NoError(t, err)
+	assert.True(t, seeded)
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_SeedAdmin_KeepsExistingPassword(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	admin := model.User{ID: "admin", Username: "admin", IsActive: true}
+	mockUserRepo.On("GetCredentials", mock.Anything, "admin").Return(&model.Credentials{UserID: "admin", Role: model.RoleMember, PasswordHash: testPasswordHash(t, "changed-password")}, nil)
+
+	authService := newTestAuthService(mockUserRepo)
+
+	seeded, err := authService.SeedAdmin(context.Background(), admin, "admin-password")
+
+	require.NoError(t, err)
+	assert.False(t, seeded)
+	mockUserRepo.AssertNotCalled(t, "SetPasswordHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "SetRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
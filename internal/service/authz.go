@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+var SystemPrincipal = model.Principal{UserID: "system", Role: model.RoleAdmin}
+
+type principalContextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal model.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (model.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(model.Principal)
+	return principal, ok
+}
+
+func actorID(ctx context.Context) string {
+	principal, _ := PrincipalFromContext(ctx)
+	return principal.UserID
+}
+
+func requireAdmin(ctx context.Context) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.Role != model.RoleAdmin {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func authorizeTeamChange(ctx context.Context, userRepo UserRepository, teamNames ...string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+
+	if principal.Role == model.RoleAdmin {
+		return nil
+	}
+
+	if principal.Role != model.RoleTeamLead {
+		return ErrForbidden
+	}
+
+	memberships, err := userRepo.GetMemberships(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	led := make(map[string]struct{}, len(memberships))
+	for _, m := range memberships {
+		led[m.TeamName] = struct{}{}
+	}
+
+	for _, name := range teamNames {
+		if _, ok := led[name]; !ok {
+			return ErrForbidden
+		}
+	}
+
+	return nil
+}
+
+func resolveSelfOrAdmin(ctx context.Context, userID string) (string, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return "", ErrForbidden
+	}
+
+	if userID == "" {
+		return principal.UserID, nil
+	}
+
+	if principal.Role != model.RoleAdmin && principal.UserID != userID {
+		return "", ErrForbidden
+	}
+
+	return userID, nil
+}
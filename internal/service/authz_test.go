@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testAdminContext() context.Context {
+	return WithPrincipal(context.Background(), model.Principal{UserID: "admin", Role: model.RoleAdmin})
+}
+
+func TestAuthorizeTeamChange(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	mockUserRepo.On("GetMemberships", mock.Anything, "lead").Return([]model.TeamMembership{
+		{TeamID: 1, TeamName: "backend", UserID: "lead", IsPrimary: true},
+	}, nil)
+
+	lead := WithPrincipal(context.Background(), model.Principal{UserID: "lead", Role: model.RoleTeamLead})
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "member", Role: model.RoleMember})
+
+	assert.NoError(t, authorizeTeamChange(testAdminContext(), mockUserRepo, "anything"))
+	assert.NoError(t, authorizeTeamChange(lead, mockUserRepo, "backend"))
+	assert.Equal(t, ErrForbidden, authorizeTeamChange(lead, mockUserRepo, "backend", "frontend"))
+	assert.Equal(t, ErrForbidden, authorizeTeamChange(member, mockUserRepo, "backend"))
+	assert.Equal(t, ErrForbidden, authorizeTeamChange(context.Background(), mockUserRepo, "backend"))
+}
@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/ical"
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/netguard"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	calendarBatch               = 10
+	calendarLease               = 5 * time.Minute
+	calendarPoll                = time.Minute
+	calendarRetryDelay          = 15 * time.Minute
+	calendarLookback            = 7 * 24 * time.Hour
+	calendarHorizon             = 365 * 24 * time.Hour
+	defaultCalendarSyncInterval = time.Hour
+	defaultAbsenceWindow        = 30 * 24 * time.Hour
+)
+
+type CalendarService struct {
+	calendarRepo CalendarRepository
+	userRepo     UserRepository
+	teamRepo     TeamRepository
+	fetcher      CalendarFetcher
+	interval     time.Duration
+	now          func() time.Time
+}
+
+func NewCalendarService(calendarRepo CalendarRepository, userRepo UserRepository, teamRepo TeamRepository, fetcher CalendarFetcher, syncInterval time.Duration) *CalendarService {
+	if syncInterval <= 0 {
+		syncInterval = defaultCalendarSyncInterval
+	}
+
+	return &CalendarService{
+		calendarRepo: calendarRepo,
+		userRepo:     userRepo,
+		teamRepo:     teamRepo,
+		fetcher:      fetcher,
+		interval:     syncInterval,
+		now:          time.Now,
+	}
+}
+
+func (s *CalendarService) Create(ctx context.Context, feed model.CalendarFeed) (*model.CalendarFeed, error) {
+	if feed.UserID != "" && feed.TeamName != "" {
+		return nil, fmt.Errorf("%w: set either user_id or team_name", ErrInvalidCalendar)
+	}
+
+	if feed.URL != "" {
+		if err := validateFeedURL(feed.URL); err != nil {
+			return nil, err
+		}
+		if s.fetcher == nil {
+			return nil, fmt.Errorf("%w: calendar subscriptions are not configured", ErrInvalidCalendar)
+		}
+	}
+
+	if feed.TeamName != "" {
+		if err := authorizeTeamChange(ctx, s.userRepo, feed.TeamName); err != nil {
+			return nil, err
+		}
+
+		team, _, err := s.teamRepo.GetByName(ctx, feed.TeamName)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrNotFound
+			}
+
+			return nil, err
+		}
+		feed.TeamID = team.ID
+	} else {
+		userID, err := resolveSelfOrAdmin(ctx, feed.UserID)
+		if err != nil {
+			return nil, err
+		}
+		feed.UserID = userID
+	}
+
+	feed.Categories = normalizeCategories(feed.Categories)
+	feed.CreatedBy = actorID(ctx)
+
+	created, err := s.calendarRepo.CreateFeed(ctx, feed)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if created.IsUpload() {
+		return created, nil
+	}
+
+	return s.sync(ctx, *created)
+}
+
+func (s *CalendarService) List(ctx context.Context, filter model.CalendarFeedFilter) ([]model.CalendarFeed, error) {
+	switch {
+	case filter.TeamName != "":
+		if err := authorizeTeamChange(ctx, s.userRepo, filter.TeamName); err != nil {
+			return nil, err
+		}
+	case filter.UserID != "" || requireAdmin(ctx) != nil:
+		userID, err := resolveSelfOrAdmin(ctx, filter.UserID)
+		if err != nil {
+			return nil, err
+		}
+		filter.UserID = userID
+	}
+
+	return s.calendarRepo.ListFeeds(ctx, filter)
+}
+
+func (s *CalendarService) Delete(ctx context.Context, feedID int64) error {
+	if _, err := s.authorizedFeed(ctx, feedID); err != nil {
+		return err
+	}
+
+	if err := s.calendarRepo.DeleteFeed(ctx, feedID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *CalendarService) Upload(ctx context.Context, feedID int64, data []byte) (*model.CalendarFeed, error) {
+	feed, err := s.authorizedFeed(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !feed.IsUpload() {
+		return nil, fmt.Errorf("%w: feed is synced from its url", ErrInvalidCalendar)
+	}
+
+	if err := s.apply(ctx, *feed, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.calendarRepo.MarkSynced(ctx, feed.ID, s.now(), ""); err != nil {
+		return nil, err
+	}
+
+	return s.calendarRepo.GetFeed(ctx, feed.ID)
+}
+
+func (s *CalendarService) Sync(ctx context.Context, feedID int64) (*model.CalendarFeed, error) {
+	feed, err := s.authorizedFeed(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if feed.IsUpload() {
+		return nil, fmt.Errorf("%w: uploaded feeds have no url to sync", ErrInvalidCalendar)
+	}
+
+	return s.sync(ctx, *feed)
+}
+
+func (s *CalendarService) ListAbsences(ctx context.Context, userID string, from, to time.Time) ([]model.Absence, error) {
+	resolved, err := resolveSelfOrAdmin(ctx, userID)
+	if errors.Is(err, ErrForbidden) && userID != "" {
+		resolved, err = userID, s.authorizeTeamLeadOf(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	userID = resolved
+
+	if from.IsZero() {
+		from = s.now()
+	}
+	if to.IsZero() {
+		to = from.Add(defaultAbsenceWindow)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidCalendar)
+	}
+
+	return s.calendarRepo.ListAbsences(ctx, userID, from, to)
+}
+
+func (s *CalendarService) authorizedFeed(ctx context.Context, feedID int64) (*model.CalendarFeed, error) {
+	feed, err := s.calendarRepo.GetFeed(ctx, feedID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if feed.TeamName != "" {
+		err = authorizeTeamChange(ctx, s.userRepo, feed.TeamName)
+	} else {
+		_, err = resolveSelfOrAdmin(ctx, feed.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+func (s *CalendarService) authorizeTeamLeadOf(ctx context.Context, userID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.Role != model.RoleTeamLead {
+		return ErrForbidden
+	}
+
+	leadMemberships, err := s.userRepo.GetMemberships(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	userMemberships, err := s.userRepo.GetMemberships(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, lead := range leadMemberships {
+		for _, member := range userMemberships {
+			if lead.TeamID == member.TeamID {
+				return nil
+			}
+		}
+	}
+
+	return ErrForbidden
+}
+
+func (s *CalendarService) sync(ctx context.Context, feed model.CalendarFeed) (*model.CalendarFeed, error) {
+	now := s.now()
+
+	data, err := s.fetcher.Fetch(ctx, feed.URL)
+	if err != nil {
+		log.Printf("Failed to fetch calendar feed %d: %v", feed.ID, err)
+		if err := s.calendarRepo.MarkSynced(ctx, feed.ID, now.Add(calendarRetryDelay), err.Error()); err != nil {
+			return nil, err
+		}
+
+		return s.calendarRepo.GetFeed(ctx, feed.ID)
+	}
+
+	lastError := ""
+	if err := s.apply(ctx, feed, data); err != nil {
+		if !errors.Is(err, ErrInvalidCalendar) {
+			return nil, err
+		}
+		lastError = err.Error()
+	}
+
+	if err := s.calendarRepo.MarkSynced(ctx, feed.ID, now.Add(s.interval), lastError); err != nil {
+		return nil, err
+	}
+
+	return s.calendarRepo.GetFeed(ctx, feed.ID)
+}
+
+func (s *CalendarService) apply(ctx context.Context, feed model.CalendarFeed, data []byte) error {
+	cal, err := ical.Parse(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCalendar, err)
+	}
+
+	if cal.Skipped > 0 {
+		log.Printf("Calendar feed %d: skipped %d unsupported events", feed.ID, cal.Skipped)
+	}
+
+	var members []model.User
+	if feed.TeamID != 0 {
+		_, members, err = s.teamRepo.GetByID(ctx, feed.TeamID)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := s.now()
+	var absences []model.Absence
+	for _, occurrence := range cal.Expand(now.Add(-calendarLookback), now.Add(calendarHorizon)) {
+		if !occurrence.HasCategory(feed.Categories) || !occurrence.End.After(occurrence.Start) {
+			continue
+		}
+
+		userIDs := []string{feed.UserID}
+		if feed.TeamID != 0 {
+			userIDs = absentMembers(occurrence, members)
+		}
+
+		for _, userID := range userIDs {
+			absences = append(absences, model.Absence{
+				FeedID:   feed.ID,
+				UserID:   userID,
+				UID:      occurrence.UID,
+				Summary:  occurrence.Summary,
+				StartsAt: occurrence.Start.UTC(),
+				EndsAt:   occurrence.End.UTC(),
+			})
+		}
+	}
+
+	return s.calendarRepo.ReplaceAbsences(ctx, feed.ID, absences)
+}
+
+func absentMembers(occurrence ical.Occurrence, members []model.User) []string {
+	names := append([]string{}, occurrence.Attendees...)
+	for _, sep := range []string{":", " - "} {
+		if owner, _, ok := strings.Cut(occurrence.Summary, sep); ok {
+			names = append(names, strings.TrimSpace(owner))
+		}
+	}
+
+	var userIDs []string
+	for _, member := range members {
+		for _, name := range names {
+			if strings.EqualFold(name, member.ID) || strings.EqualFold(name, member.Username) {
+				userIDs = append(userIDs, member.ID)
+				break
+			}
+		}
+	}
+
+	return userIDs
+}
+
+func validateFeedURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be absolute", ErrInvalidCalendar)
+	}
+
+	switch parsed.Scheme {
+	case "http", "https", "webcal":
+	default:
+		return fmt.Errorf("%w: url scheme must be http, https or webcal", ErrInvalidCalendar)
+	}
+
+	if !netguard.IsPublicHost(parsed.Hostname()) {
+		return fmt.Errorf("%w: url must point to a public host", ErrInvalidCalendar)
+	}
+
+	return nil
+}
+
+func normalizeCategories(categories []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, category := range categories {
+		category = strings.ToUpper(strings.TrimSpace(category))
+		if category != "" && !seen[category] {
+			seen[category] = true
+			normalized = append(normalized, category)
+		}
+	}
+
+	return normalized
+}
+
+func (s *CalendarService) ProcessDue(ctx context.Context) (int, error) {
+	feeds, err := s.calendarRepo.ClaimDue(ctx, calendarBatch, calendarLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, feed := range feeds {
+		if _, err := s.sync(ctx, feed); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(feeds), nil
+}
+
+func (s *CalendarService) Run(ctx context.Context) {
+	poll := time.NewTicker(calendarPoll)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		}
+
+		for {
+			processed, err := s.ProcessDue(ctx)
+			if err != nil {
+				log.Printf("Calendar sync failed: %v", err)
+			}
+			if err != nil || processed < calendarBatch {
+				break
+			}
+		}
+	}
+}
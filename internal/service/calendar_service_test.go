@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const teamCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:alice-1
+SUMMARY:Alice: vacation
+CATEGORIES:Vacation
+DTSTART;VALUE=DATE:20251110
+DTEND;VALUE=DATE:20251115
+END:VEVENT
+BEGIN:VEVENT
+UID:bob-1
+SUMMARY:Conference
+CATEGORIES:out of office
+ATTENDEE;CN=Bob Builder:mailto:bob@acme.test
+DTSTART:20251105T080000Z
+DTEND:20251105T170000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:carol-1
+SUMMARY:Carol: dentist
+CATEGORIES:Personal
+DTSTART:20251104T080000Z
+DTEND:20251104T090000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:old-1
+SUMMARY:Alice: last year
+CATEGORIES:Vacation
+DTSTART;VALUE=DATE:20241110
+END:VEVENT
+END:VCALENDAR
+`
+
+type calendarTestDeps struct {
+	repo    *mocks.CalendarRepository
+	users   *mocks.UserRepository
+	teams   *mocks.TeamRepository
+	fetcher *mocks.CalendarFetcher
+}
+
+func newTestCalendarService(t *testing.T) (*CalendarService, calendarTestDeps) {
+	deps := calendarTestDeps{
+		repo:    mocks.NewCalendarRepository(t),
+		users:   mocks.NewUserRepository(t),
+		teams:   mocks.NewTeamRepository(t),
+		fetcher: mocks.NewCalendarFetcher(t),
+	}
+
+	calendarService := NewCalendarService(deps.repo, deps.users, deps.teams, deps.fetcher, 0)
+	calendarService.now = func() time.Time { return notificationTestNow }
+
+	return calendarService, deps
+}
+
+func TestCalendarService_Create_UserFeedSyncsImmediately(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "alice", Role: model.RoleMember})
+
+	deps.repo.On("CreateFeed", mock.Anything, model.CalendarFeed{
+		UserID: "alice", URL: "webcal://calendar.test/alice.ics", Categories: []string{"VACATION"}, CreatedBy: "alice",
+	}).Return(&model.CalendarFeed{ID: 1, UserID: "alice", URL: "webcal://calendar.test/alice.ics", Categories: []string{"VACATION"}}, nil)
+	deps.fetcher.On("Fetch", mock.Anything, "webcal://calendar.test/alice.ics").Return([]byte(teamCalendar), nil)
+	deps.repo.On("ReplaceAbsences", mock.Anything, int64(1), []model.Absence{{
+		FeedID: 1, UserID: "alice", UID: "alice-1", Summary: "Alice: vacation",
+		StartsAt: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC),
+	}}).Return(nil)
+	deps.repo.On("MarkSynced", mock.Anything, int64(1), notificationTestNow.Add(time.Hour), "").Return(nil)
+	deps.repo.On("GetFeed", mock.Anything, int64(1)).Return(&model.CalendarFeed{ID: 1, UserID: "alice", AbsenceCount: 1}, nil)
+
+	feed, err := calendarService.Create(ctx, model.CalendarFeed{URL: "webcal://calendar.test/alice.ics", Categories: []string{" vacation ", "Vacation"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, feed.AbsenceCount)
+}
+
+func TestCalendarService_Create_TeamFeedMatchesMembers(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+
+	deps.teams.On("GetByName", mock.Anything, "backend").Return(&model.Team{ID: 7, Name: "backend"}, nil, nil)
+	deps.repo.On("CreateFeed", mock.Anything, model.CalendarFeed{
+		TeamID: 7, TeamName: "backend", URL: "https://calendar.test/team.ics", Categories: []string{"VACATION", "OUT OF OFFICE"}, CreatedBy: "admin",
+	}).Return(&model.CalendarFeed{ID: 2, TeamID: 7, TeamName: "backend", URL: "https://calendar.test/team.ics", Categories: []string{"VACATION", "OUT OF OFFICE"}}, nil)
+	deps.fetcher.On("Fetch", mock.Anything, "https://calendar.test/team.ics").Return([]byte(teamCalendar), nil)
+	deps.teams.On("GetByID", mock.Anything, 7).Return(&model.Team{ID: 7, Name: "backend"}, []model.User{
+		{ID: "alice", Username: "Alice"},
+		{ID: "bob", Username: "Bob Builder"},
+		{ID: "carol", Username: "Carol"},
+	}, nil)
+	deps.repo.On("ReplaceAbsences", mock.Anything, int64(2), mock.MatchedBy(func(absences []model.Absence) bool {
+		var users []string
+		for _, absence := range absences {
+			users = append(users, absence.UserID+"/"+absence.UID)
+		}
+		return strings.Join(users, ",") == "bob/bob-1,alice/alice-1"
+	})).Return(nil)
+	deps.repo.On("MarkSynced", mock.Anything, int64(2), notificationTestNow.Add(time.Hour), "").Return(nil)
+	deps.repo.On("GetFeed", mock.Anything, int64(2)).Return(&model.CalendarFeed{ID: 2, TeamID: 7, AbsenceCount: 2}, nil)
+
+	feed, err := calendarService.Create(testAdminContext(), model.CalendarFeed{
+		TeamName: "backend", URL: "https://calendar.test/team.ics", Categories: []string{"Vacation", "Out of office"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, feed.AbsenceCount)
+}
+
+func TestCalendarService_Create_Validation(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	_, err := calendarService.Create(member, model.CalendarFeed{UserID: "bob", TeamName: "backend"})
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+
+	_, err = calendarService.Create(member, model.CalendarFeed{URL: "ftp://calendar.test/bob.ics"})
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+
+	_, err = calendarService.Create(member, model.CalendarFeed{URL: "/bob.ics"})
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+
+	_, err = calendarService.Create(member, model.CalendarFeed{URL: "http://10.0.0.5/bob.ics"})
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+
+	_, err = calendarService.Create(member, model.CalendarFeed{UserID: "alice"})
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	_, err = calendarService.Create(member, model.CalendarFeed{TeamName: "backend"})
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	deps.repo.On("CreateFeed", mock.Anything, model.CalendarFeed{UserID: "bob", Categories: []string{}, CreatedBy: "bob"}).
+		Return(&model.CalendarFeed{ID: 3, UserID: "bob"}, nil)
+	feed, err := calendarService.Create(member, model.CalendarFeed{})
+	require.NoError(t, err)
+	assert.True(t, feed.IsUpload(), "uploaded feeds are not fetched")
+}
+
+func TestCalendarService_Sync_RecordsFailures(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+	feed := &model.CalendarFeed{ID: 4, UserID: "alice", URL: "https://calendar.test/alice.ics"}
+
+	deps.repo.On("GetFeed", mock.Anything, int64(4)).Return(feed, nil)
+	deps.fetcher.On("Fetch", mock.Anything, feed.URL).Return(nil, errors.New("calendar fetch failed: status 404")).Once()
+	deps.repo.On("MarkSynced", mock.Anything, int64(4), notificationTestNow.Add(calendarRetryDelay), "calendar fetch failed: status 404").Return(nil)
+
+	_, err := calendarService.Sync(testAdminContext(), 4)
+	require.NoError(t, err)
+
+	deps.fetcher.On("Fetch", mock.Anything, feed.URL).Return([]byte("<html>not a calendar</html>"), nil)
+	deps.repo.On("MarkSynced", mock.Anything, int64(4), notificationTestNow.Add(time.Hour), mock.MatchedBy(func(lastError string) bool {
+		return strings.HasPrefix(lastError, "invalid calendar feed: invalid icalendar data")
+	})).Return(nil)
+
+	_, err = calendarService.Sync(testAdminContext(), 4)
+	require.NoError(t, err, "a broken feed keeps its previous absences")
+
+	_, err = calendarService.Upload(testAdminContext(), 4, []byte(teamCalendar))
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+	_, err = calendarService.Sync(member, 4)
+	assert.ErrorIs(t, err, ErrForbidden)
+}
+
+func TestCalendarService_Upload(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "carol", Role: model.RoleMember})
+
+	deps.repo.On("GetFeed", mock.Anything, int64(5)).Return(&model.CalendarFeed{ID: 5, UserID: "carol"}, nil)
+
+	_, err := calendarService.Upload(ctx, 5, []byte("BEGIN:VEVENT\nEND:VEVENT\n"))
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+
+	deps.repo.On("ReplaceAbsences", mock.Anything, int64(5), mock.MatchedBy(func(absences []model.Absence) bool {
+		return len(absences) == 3
+	})).Return(nil)
+	deps.repo.On("MarkSynced", mock.Anything, int64(5), notificationTestNow, "").Return(nil)
+
+	_, err = calendarService.Upload(ctx, 5, []byte(teamCalendar))
+	require.NoError(t, err)
+
+	deps.repo.On("DeleteFeed", mock.Anything, int64(5)).Return(nil)
+	require.NoError(t, calendarService.Delete(ctx, 5))
+
+	deps.repo.On("GetFeed", mock.Anything, int64(99)).Return(nil, store.ErrNotFound)
+	assert.ErrorIs(t, calendarService.Delete(ctx, 99), ErrNotFound)
+}
+
+func TestCalendarService_ListAbsences(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	deps.repo.On("ListAbsences", mock.Anything, "bob", notificationTestNow, notificationTestNow.Add(defaultAbsenceWindow)).Return([]model.Absence{}, nil)
+	_, err := calendarService.ListAbsences(ctx, "", time.Time{}, time.Time{})
+	require.NoError(t, err)
+
+	_, err = calendarService.ListAbsences(ctx, "alice", notificationTestNow, notificationTestNow)
+	assert.ErrorIs(t, err, ErrForbidden, "members only see their own absences")
+
+	leadCtx := WithPrincipal(context.Background(), model.Principal{UserID: "alice", Role: model.RoleTeamLead})
+	deps.users.On("GetMemberships", mock.Anything, "alice").Return([]model.TeamMembership{{TeamID: 1, TeamName: "backend"}}, nil)
+	deps.users.On("GetMemberships", mock.Anything, "bob").Return([]model.TeamMembership{{TeamID: 1, TeamName: "backend"}}, nil)
+	deps.users.On("GetMemberships", mock.Anything, "mallory").Return([]model.TeamMembership{{TeamID: 2, TeamName: "payments"}}, nil)
+
+	_, err = calendarService.ListAbsences(leadCtx, "bob", notificationTestNow, notificationTestNow)
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+
+	_, err = calendarService.ListAbsences(leadCtx, "mallory", time.Time{}, time.Time{})
+	assert.ErrorIs(t, err, ErrForbidden, "leads only see absences of their own teams")
+}
+
+func TestCalendarService_ProcessDue(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+
+	deps.repo.On("ClaimDue", mock.Anything, calendarBatch, calendarLease).Return([]model.CalendarFeed{
+		{ID: 6, UserID: "dave", URL: "https://calendar.test/dave.ics"},
+	}, nil)
+	deps.fetcher.On("Fetch", mock.Anything, "https://calendar.test/dave.ics").Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil)
+	deps.repo.On("ReplaceAbsences", mock.Anything, int64(6), []model.Absence(nil)).Return(nil)
+	deps.repo.On("MarkSynced", mock.Anything, int64(6), notificationTestNow.Add(time.Hour), "").Return(nil)
+	deps.repo.On("GetFeed", mock.Anything, int64(6)).Return(&model.CalendarFeed{ID: 6}, nil)
+
+	processed, err := calendarService.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
+
+func TestCalendarService_List(t *testing.T) {
+	calendarService, deps := newTestCalendarService(t)
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	deps.repo.On("ListFeeds", mock.Anything, model.CalendarFeedFilter{UserID: "bob"}).Return([]model.CalendarFeed{}, nil)
+	_, err := calendarService.List(member, model.CalendarFeedFilter{})
+	require.NoError(t, err)
+
+	deps.repo.On("ListFeeds", mock.Anything, model.CalendarFeedFilter{}).Return([]model.CalendarFeed{}, nil)
+	_, err = calendarService.List(testAdminContext(), model.CalendarFeedFilter{})
+	require.NoError(t, err)
+
+	_, err = calendarService.List(member, model.CalendarFeedFilter{TeamName: "backend"})
+	assert.ErrorIs(t, err, ErrForbidden)
+}
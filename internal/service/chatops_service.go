@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const chatStatsLimit = 10
+
+const chatHelp = "Available commands:\n" +
+	"• `reviews` list pull requests waiting for your review\n" +
+	"• `reassign <pr> [me|@user|<user_id>]` replace a reviewer (you by default)\n" +
+	"• `merge <pr>` merge your pull request\n" +
+	"• `vacation on|off` stop or resume getting review assignments\n" +
+	"• `stats` show who reviews the most"
+
+type ChatOpsService struct {
+	identities   *IdentityService
+	userRepo     UserRepository
+	userService  *UserService
+	prService    *PullRequestService
+	statsService *StatsService
+}
+
+func NewChatOpsService(identities *IdentityService, userRepo UserRepository, userService *UserService, prService *PullRequestService, statsService *StatsService) *ChatOpsService {
+	return &ChatOpsService{
+		identities:   identities,
+		userRepo:     userRepo,
+		userService:  userService,
+		prService:    prService,
+		statsService: statsService,
+	}
+}
+
+func (s *ChatOpsService) Execute(ctx context.Context, cmd model.ChatCommand) (model.ChatResponse, error) {
+	args := strings.Fields(cmd.Text)
+	if len(args) == 0 || strings.EqualFold(args[0], "help") {
+		return chatReply(chatHelp), nil
+	}
+
+	userID, err := s.identities.Resolve(ctx, cmd.Provider, cmd.ExternalID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return chatReply(fmt.Sprintf("Your %s account `%s` is not linked to a PR service user. Ask an admin to link it via `/identities/link`.", cmd.Provider, cmd.ExternalID)), nil
+		}
+
+		return model.ChatResponse{}, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return chatReply(fmt.Sprintf("User `%s` no longer exists.", userID)), nil
+		}
+
+		return model.ChatResponse{}, err
+	}
+
+	if !user.IsActive {
+		return chatReply(fmt.Sprintf("User `%s` is deactivated. Ask an admin to reactivate it.", userID)), nil
+	}
+
+	creds, err := s.userRepo.GetCredentials(ctx, userID)
+	if err != nil {
+		return model.ChatResponse{}, err
+	}
+
+	role := creds.Role
+	if role == "" {
+		role = model.RoleMember
+	}
+	ctx = WithPrincipal(ctx, model.Principal{UserID: userID, Role: role})
+
+	resp, err := s.dispatch(ctx, cmd.Provider, userID, strings.ToLower(args[0]), args[1:])
+	if err != nil {
+		if text, ok := chatErrorText(err); ok {
+			return chatReply(text), nil
+		}
+
+		return model.ChatResponse{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *ChatOpsService) dispatch(ctx context.Context, provider model.IdentityProvider, userID, command string, args []string) (model.ChatResponse, error) {
+	switch command {
+	case "reviews":
+		if len(args) != 0 {
+			break
+		}
+
+		return s.reviews(ctx, userID)
+	case "reassign":
+		if len(args) != 1 && len(args) != 2 {
+			break
+		}
+
+		reviewerID := userID
+		if len(args) == 2 {
+			target, err := s.resolveTarget(ctx, provider, userID, args[1])
+			if err != nil {
+				return model.ChatResponse{}, err
+			}
+			reviewerID = target
+		}
+
+		pr, replacedBy, err := s.prService.Reassign(ctx, args[0], reviewerID)
+		if err != nil {
+			return model.ChatResponse{}, err
+		}
+
+		return chatAnnounce(fmt.Sprintf("*%s* %s: `%s` was replaced by `%s`.", pr.ID, pr.Name, reviewerID, replacedBy)), nil
+	case "merge":
+		if len(args) != 1 {
+			break
+		}
+
+		pr, err := s.prService.Merge(ctx, args[0])
+		if err != nil {
+			return model.ChatResponse{}, err
+		}
+
+		return chatAnnounce(fmt.Sprintf("*%s* %s was merged by `%s`.", pr.ID, pr.Name, userID)), nil
+	case "vacation":
+		if len(args) != 1 {
+			break
+		}
+
+		switch strings.ToLower(args[0]) {
+		case "on":
+			return s.setVacation(ctx, userID, true)
+		case "off":
+			return s.setVacation(ctx, userID, false)
+		}
+	case "stats":
+		if len(args) != 0 {
+			break
+		}
+
+		return s.stats(ctx, userID)
+	}
+
+	return chatReply(fmt.Sprintf("Unknown command `%s`.\n%s", strings.TrimSpace(command+" "+strings.Join(args, " ")), chatHelp)), nil
+}
+
+func (s *ChatOpsService) resolveTarget(ctx context.Context, provider model.IdentityProvider, userID, arg string) (string, error) {
+	if strings.EqualFold(arg, "me") {
+		return userID, nil
+	}
+
+	mention, ok := strings.CutPrefix(arg, "<@")
+	if !ok {
+		return strings.TrimPrefix(arg, "@"), nil
+	}
+
+	externalID, _, _ := strings.Cut(strings.TrimSuffix(mention, ">"), "|")
+	return s.identities.Resolve(ctx, provider, externalID)
+}
+
+func (s *ChatOpsService) reviews(ctx context.Context, userID string) (model.ChatResponse, error) {
+	prs, err := s.userService.GetReviewsForUser(ctx, userID)
+	if err != nil {
+		return model.ChatResponse{}, err
+	}
+
+	var lines []string
+	for _, pr := range prs {
+		if pr.Status == model.StatusOpen {
+			lines = append(lines, fmt.Sprintf("• *%s* %s by `%s`", pr.ID, pr.Name, pr.AuthorID))
+		}
+	}
+
+	if len(lines) == 0 {
+		return chatReply("Nothing is waiting for your review."), nil
+	}
+
+	return chatReply(fmt.Sprintf("Pull requests waiting for your review (%d):\n%s", len(lines), strings.Join(lines, "\n"))), nil
+}
+
+func (s *ChatOpsService) setVacation(ctx context.Context, userID string, away bool) (model.ChatResponse, error) {
+	if err := s.userRepo.SetVacation(ctx, userID, away); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return model.ChatResponse{}, ErrNotFound
+		}
+
+		return model.ChatResponse{}, err
+	}
+
+	if away {
+		return chatReply("Vacation mode is on: you will not be assigned new reviews. Existing assignments stay with you, use `reassign` to hand them over."), nil
+	}
+
+	return chatReply("Welcome back: you will be assigned reviews again."), nil
+}
+
+func (s *ChatOpsService) stats(ctx context.Context, userID string) (model.ChatResponse, error) {
+	stats, err := s.statsService.GetUserStats(ctx)
+	if err != nil {
+		return model.ChatResponse{}, err
+	}
+
+	if len(stats) == 0 {
+		return chatReply("No reviews have been assigned yet."), nil
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].ReviewCount > stats[j].ReviewCount
+	})
+
+	lines := make([]string, 0, chatStatsLimit+1)
+	own := -1
+	for i, stat := range stats {
+		if stat.UserID == userID {
+			own = i
+		}
+		if i < chatStatsLimit {
+			lines = append(lines, fmt.Sprintf("%d. `%s` %d", i+1, stat.UserID, stat.ReviewCount))
+		}
+	}
+
+	if own >= chatStatsLimit {
+		lines = append(lines, fmt.Sprintf("…\n%d. `%s` %d", own+1, userID, stats[own].ReviewCount))
+	}
+
+	return chatReply("Review assignments:\n" + strings.Join(lines, "\n")), nil
+}
+
+func chatErrorText(err error) (string, bool) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return "Pull request or user not found.", true
+	case errors.Is(err, ErrForbidden):
+		return "You are not allowed to do that.", true
+	case errors.Is(err, ErrPRMerged):
+		return "The pull request is already merged.", true
+	case errors.Is(err, ErrPRClosed):
+		return "The pull request is closed.", true
+	case errors.Is(err, ErrNotAssigned):
+		return "That user is not a reviewer of the pull request.", true
+	case errors.Is(err, ErrNoCandidates):
+		return "There is no active reviewer to take over.", true
+	}
+
+	return "", false
+}
+
+func chatReply(text string) model.ChatResponse {
+	return model.ChatResponse{ResponseType: model.ChatEphemeral, Text: text}
+}
+
+func chatAnnounce(text string) model.ChatResponse {
+	return model.ChatResponse{ResponseType: model.ChatInChannel, Text: text}
+}
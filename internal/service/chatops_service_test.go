@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type chatOpsTestDeps struct {
+	identities *mocks.IdentityRepository
+	users      *mocks.UserRepository
+	prs        *mocks.PullRequestRepository
+	stats      *mocks.StatsRepository
+}
+
+func newTestChatOpsService(t *testing.T) (*ChatOpsService, chatOpsTestDeps) {
+	deps := chatOpsTestDeps{
+		identities: mocks.NewIdentityRepository(t),
+		users:      mocks.NewUserRepository(t),
+		prs:        mocks.NewPullRequestRepository(t),
+		stats:      mocks.NewStatsRepository(t),
+	}
+
+	chatOps := NewChatOpsService(
+		NewIdentityService(deps.identities),
+		deps.users,
+		NewUserService(deps.users, deps.prs),
+		NewPullRequestService(deps.prs, deps.users, nil),
+		NewStatsService(deps.stats),
+	)
+
+	return chatOps, deps
+}
+
+func linkChatUser(deps chatOpsTestDeps, externalID, userID string, role model.Role) {
+	deps.identities.On("Resolve", mock.Anything, model.ProviderSlack, externalID).Return(userID, nil)
+	deps.users.On("GetByID", mock.Anything, userID).Return(&model.FullUserInfo{User: model.User{ID: userID, IsActive: true}}, nil)
+	deps.users.On("GetCredentials", mock.Anything, userID).Return(&model.Credentials{UserID: userID, Role: role}, nil)
+}
+
+func runChat(t *testing.T, chatOps *ChatOpsService, text string) model.ChatResponse {
+	t.Helper()
+
+	resp, err := chatOps.Execute(context.Background(), model.ChatCommand{Provider: model.ProviderSlack, ExternalID: "U1", Text: text})
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestChatOpsService_HelpAndUnknownCommands(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+
+	resp := runChat(t, chatOps, "  ")
+	assert.Equal(t, model.ChatEphemeral, resp.ResponseType)
+	assert.Equal(t, chatHelp, resp.Text)
+
+	linkChatUser(deps, "U1", "bob", model.RoleMember)
+
+	resp = runChat(t, chatOps, "deploy prod")
+	assert.Equal(t, model.ChatEphemeral, resp.ResponseType)
+	assert.Contains(t, resp.Text, "Unknown command `deploy prod`.")
+
+	resp = runChat(t, chatOps, "vacation maybe")
+	assert.Contains(t, resp.Text, "Unknown command `vacation maybe`.")
+
+	resp = runChat(t, chatOps, "merge")
+	assert.Contains(t, resp.Text, "Unknown command `merge`.")
+}
+
+func TestChatOpsService_UnlinkedUser(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+
+	deps.identities.On("Resolve", mock.Anything, model.ProviderSlack, "U1").Return("", store.ErrNotFound)
+
+	resp := runChat(t, chatOps, "reviews")
+	assert.Equal(t, model.ChatEphemeral, resp.ResponseType)
+	assert.Contains(t, resp.Text, "Your slack account `U1` is not linked")
+}
+
+func TestChatOpsService_Reviews(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+	linkChatUser(deps, "U1", "bob", model.RoleMember)
+
+	deps.prs.On("GetByReviewerID", mock.Anything, "bob").Return([]model.PullRequest{
+		{ID: "pr-1", Name: "Add search", AuthorID: "alice", Status: model.StatusOpen},
+		{ID: "pr-2", Name: "Old", AuthorID: "alice", Status: model.StatusMerged},
+	}, nil).Once()
+
+	resp := runChat(t, chatOps, "Reviews")
+	assert.Equal(t, "Pull requests waiting for your review (1):\n• *pr-1* Add search by `alice`", resp.Text)
+
+	deps.prs.On("GetByReviewerID", mock.Anything, "bob").Return([]model.PullRequest{}, nil)
+	resp = runChat(t, chatOps, "reviews")
+	assert.Equal(t, "Nothing is waiting for your review.", resp.Text)
+}
+
+func TestChatOpsService_Merge(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+	linkChatUser(deps, "U1", "alice", model.RoleMember)
+
+	open := &model.PullRequest{ID: "pr-1", Name: "Add search", AuthorID: "alice", Status: model.StatusOpen}
+	merged := &model.PullRequest{ID: "pr-1", Name: "Add search", AuthorID: "alice", Status: model.StatusMerged}
+	deps.prs.On("GetByID", mock.Anything, "pr-1").Return(open, nil).Once()
+	deps.prs.On("Merge", mock.Anything, "pr-1", "alice").Return(nil)
+	deps.prs.On("GetByID", mock.Anything, "pr-1").Return(merged, nil).Once()
+
+	resp := runChat(t, chatOps, "merge pr-1")
+	assert.Equal(t, model.ChatInChannel, resp.ResponseType)
+	assert.Equal(t, "*pr-1* Add search was merged by `alice`.", resp.Text)
+
+	deps.prs.On("GetByID", mock.Anything, "pr-2").Return(&model.PullRequest{ID: "pr-2", AuthorID: "carol", Status: model.StatusOpen}, nil)
+	resp = runChat(t, chatOps, "merge pr-2")
+	assert.Equal(t, model.ChatEphemeral, resp.ResponseType)
+	assert.Equal(t, "You are not allowed to do that.", resp.Text)
+
+	deps.prs.On("GetByID", mock.Anything, "pr-404").Return(nil, store.ErrNotFound)
+	resp = runChat(t, chatOps, "merge pr-404")
+	assert.Equal(t, "Pull request or user not found.", resp.Text)
+}
+
+func TestChatOpsService_ReassignResolvesMentions(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+	linkChatUser(deps, "U1", "alice", model.RoleTeamLead)
+
+	deps.identities.On("Resolve", mock.Anything, model.ProviderSlack, "U2").Return("carol", nil)
+	deps.prs.On("GetByID", mock.Anything, "pr-1").Return(&model.PullRequest{
+		ID: "pr-1", AuthorID: "dave", Status: model.StatusOpen, AssignedReviewers: []string{"bob"},
+	}, nil)
+
+	resp := runChat(t, chatOps, "reassign pr-1 <@U2|carol>")
+	assert.Equal(t, "That user is not a reviewer of the pull request.", resp.Text)
+
+	resp = runChat(t, chatOps, "reassign pr-1 me")
+	assert.Equal(t, "That user is not a reviewer of the pull request.", resp.Text)
+
+	deps.identities.On("Resolve", mock.Anything, model.ProviderSlack, "U3").Return("", store.ErrNotFound)
+	resp = runChat(t, chatOps, "reassign pr-1 <@U3>")
+	assert.Equal(t, "Pull request or user not found.", resp.Text)
+}
+
+func TestChatOpsService_Vacation(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+	linkChatUser(deps, "U1", "bob", model.RoleMember)
+
+	deps.users.On("SetVacation", mock.Anything, "bob", true).Return(nil)
+	deps.users.On("SetVacation", mock.Anything, "bob", false).Return(nil)
+
+	resp := runChat(t, chatOps, "vacation ON")
+	assert.Equal(t, model.ChatEphemeral, resp.ResponseType)
+	assert.Contains(t, resp.Text, "Vacation mode is on")
+
+	resp = runChat(t, chatOps, "vacation off")
+	assert.Contains(t, resp.Text, "Welcome back")
+}
+
+func TestChatOpsService_RejectsInactiveUsers(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+
+	deps.identities.On("Resolve", mock.Anything, model.ProviderSlack, "U1").Return("bob", nil)
+	deps.users.On("GetByID", mock.Anything, "bob").Return(&model.FullUserInfo{User: model.User{ID: "bob", IsActive: false}}, nil)
+
+	resp := runChat(t, chatOps, "vacation off")
+	assert.Equal(t, model.ChatEphemeral, resp.ResponseType)
+	assert.Contains(t, resp.Text, "is deactivated")
+}
+
+func TestChatOpsService_Stats(t *testing.T) {
+	chatOps, deps := newTestChatOpsService(t)
+	linkChatUser(deps, "U1", "bob", model.RoleMember)
+
+	counts := map[string]int{"bob": 1}
+	for _, id := range []string{"u01", "u02", "u03", "u04", "u05", "u06", "u07", "u08", "u09", "u10"} {
+		counts[id] = 5
+	}
+	counts["u11"] = 9
+	deps.stats.On("GetReviewCountsByUser", mock.Anything).Return(counts, nil)
+
+	resp := runChat(t, chatOps, "stats")
+	assert.Equal(t, "Review assignments:\n"+
+		"1. `u11` 9\n2. `u01` 5\n3. `u02` 5\n4. `u03` 5\n5. `u04` 5\n"+
+		"6. `u05` 5\n7. `u06` 5\n8. `u07` 5\n9. `u08` 5\n10. `u09` 5\n"+
+		"…\n12. `bob` 1", resp.Text)
+}
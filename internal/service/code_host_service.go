@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+const webhookDeliveryRetention = 7 * 24 * time.Hour
+
+type CodeHostService struct {
+	deliveryRepo WebhookDeliveryRepository
+	identities   *IdentityService
+	prService    *PullRequestService
+}
+
+func NewCodeHostService(deliveryRepo WebhookDeliveryRepository, identities *IdentityService, prService *PullRequestService) *CodeHostService {
+	return &CodeHostService{
+		deliveryRepo: deliveryRepo,
+		identities:   identities,
+		prService:    prService,
+	}
+}
+
+func (s *CodeHostService) Handle(ctx context.Context, event model.CodeHostEvent) (model.CodeHostResult, error) {
+	claimed, err := s.deliveryRepo.Claim(ctx, event.Provider, event.DeliveryID, webhookDeliveryRetention)
+	if err != nil {
+		return model.CodeHostResult{}, err
+	}
+
+	if !claimed {
+		return model.CodeHostResult{Outcome: model.OutcomeDuplicate}, nil
+	}
+
+	result, err := s.dispatch(ctx, event)
+	if err != nil {
+		if releaseErr := s.deliveryRepo.Release(ctx, event.Provider, event.DeliveryID); releaseErr != nil {
+			return model.CodeHostResult{}, errors.Join(err, releaseErr)
+		}
+
+		return model.CodeHostResult{}, err
+	}
+
+	return result, nil
+}
+
+func (s *CodeHostService) dispatch(ctx context.Context, event model.CodeHostEvent) (model.CodeHostResult, error) {
+	actor, err := s.identities.Resolve(ctx, event.Provider, event.ActorExternalID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return model.CodeHostResult{}, err
+	}
+	linked := err == nil
+
+	principal := model.Principal{UserID: string(event.Provider), Role: model.RoleAdmin}
+	if linked {
+		principal.UserID = actor
+	}
+	ctx = WithPrincipal(ctx, principal)
+
+	switch event.Action {
+	case model.CodeHostOpened:
+		authorID, err := s.identities.Resolve(ctx, event.Provider, event.AuthorExternalID)
+		if err != nil {
+			return ignoreOn(err, ErrNotFound)
+		}
+
+		_, err = s.prService.Create(ctx, model.PullRequest{ID: event.PullRequestID, Name: event.Title, AuthorID: authorID})
+		return ignoreOn(err, ErrPRExists, ErrNotFound, ErrNotTeamMember)
+	case model.CodeHostMerged:
+		_, err := s.prService.Merge(ctx, event.PullRequestID)
+		return ignoreOn(err, ErrNotFound, ErrPRClosed)
+	case model.CodeHostClosed:
+		_, err := s.prService.Close(ctx, event.PullRequestID)
+		return ignoreOn(err, ErrNotFound, ErrPRMerged)
+	case model.CodeHostReopened:
+		_, err := s.prService.Reopen(ctx, event.PullRequestID)
+		return ignoreOn(err, ErrNotFound, ErrPRMerged)
+	case model.CodeHostReviewed:
+		if !linked {
+			return model.CodeHostResult{Outcome: model.OutcomeIgnored, Reason: "reviewer is not linked"}, nil
+		}
+
+		_, err := s.prService.SubmitVerdict(ctx, event.PullRequestID, actor, event.Verdict)
+		return ignoreOn(err, ErrNotFound, ErrNotAssigned, ErrPRMerged, ErrPRClosed, ErrInvalidVerdict)
+	}
+
+	return model.CodeHostResult{Outcome: model.OutcomeIgnored, Reason: "unsupported action"}, nil
+}
+
+func ignoreOn(err error, expected ...error) (model.CodeHostResult, error) {
+	if err == nil {
+		return model.CodeHostResult{Outcome: model.OutcomeProcessed}, nil
+	}
+
+	for _, target := range expected {
+		if errors.Is(err, target) {
+			return model.CodeHostResult{Outcome: model.OutcomeIgnored, Reason: err.Error()}, nil
+		}
+	}
+
+	return model.CodeHostResult{}, err
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type codeHostTestRepos struct {
+	deliveries *mocks.WebhookDeliveryRepository
+	identities *mocks.IdentityRepository
+	prs        *mocks.PullRequestRepository
+	users      *mocks.UserRepository
+	teams      *mocks.TeamRepository
+}
+
+func newTestCodeHostService(t *testing.T) (*CodeHostService, codeHostTestRepos) {
+	repos := codeHostTestRepos{
+		deliveries: mocks.NewWebhookDeliveryRepository(t),
+		identities: mocks.NewIdentityRepository(t),
+		prs:        mocks.NewPullRequestRepository(t),
+		users:      mocks.NewUserRepository(t),
+		teams:      mocks.NewTeamRepository(t),
+	}
+
+	prService := NewPullRequestService(repos.prs, repos.users, repos.teams)
+	return NewCodeHostService(repos.deliveries, NewIdentityService(repos.identities), prService), repos
+}
+
+func TestCodeHostService_Handle_SkipsDuplicateDelivery(t *testing.T) {
+	codeHostService, repos := newTestCodeHostService(t)
+
+	repos.deliveries.On("Claim", mock.Anything, model.ProviderGitHub, "d-1", webhookDeliveryRetention).Return(false, nil)
+
+	result, err := codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:      model.ProviderGitHub,
+		DeliveryID:    "d-1",
+		Action:        model.CodeHostMerged,
+		PullRequestID: "org/repo#1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.OutcomeDuplicate, result.Outcome)
+}
+
+func TestCodeHostService_Handle_OpenedCreatesPRForMappedAuthor(t *testing.T) {
+	codeHostService, repos := newTestCodeHostService(t)
+
+	author := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 7}}
+
+	repos.deliveries.On("Claim", mock.Anything, model.ProviderGitHub, "d-1", webhookDeliveryRetention).Return(true, nil)
+	repos.identities.On("Resolve", mock.Anything, model.ProviderGitHub, "octocat").Return("u1", nil)
+	repos.users.On("GetByID", mock.Anything, "u1").Return(author, nil)
+	repos.teams.On("GetAncestors", mock.Anything, 7).Return([]model.Team{{ID: 7}}, nil)
+	repos.users.On("GetActiveTeamMembers", mock.Anything, 7, "u1").Return([]model.User{{ID: "u2", TeamID: 7}}, nil)
+	repos.prs.On("Create", mock.Anything, mock.MatchedBy(func(pr model.PullRequest) bool {
+		return pr.ID == "org/repo#1" && pr.Name == "Add feature" && pr.AuthorID == "u1" && pr.CreatedBy == "u1"
+	})).Return(nil)
+	repos.prs.On("GetByID", mock.Anything, "org/repo#1").Return(&model.PullRequest{ID: "org/repo#1"}, nil)
+
+	result, err := codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:         model.ProviderGitHub,
+		DeliveryID:       "d-1",
+		Action:           model.CodeHostOpened,
+		PullRequestID:    "org/repo#1",
+		Title:            "Add feature",
+		AuthorExternalID: "octocat",
+		ActorExternalID:  "octocat",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.OutcomeProcessed, result.Outcome)
+}
+
+func TestCodeHostService_Handle_IgnoresUnlinkedAndUnknown(t *testing.T) {
+	codeHostService, repos := newTestCodeHostService(t)
+
+	repos.deliveries.On("Claim", mock.Anything, model.ProviderGitHub, mock.Anything, webhookDeliveryRetention).Return(true, nil)
+	repos.identities.On("Resolve", mock.Anything, model.ProviderGitHub, "stranger").Return("", store.ErrNotFound)
+	repos.prs.On("GetByID", mock.Anything, "org/repo#404").Return(nil, store.ErrNotFound)
+
+	result, err := codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:         model.ProviderGitHub,
+		DeliveryID:       "d-1",
+		Action:           model.CodeHostOpened,
+		PullRequestID:    "org/repo#1",
+		AuthorExternalID: "stranger",
+		ActorExternalID:  "stranger",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.OutcomeIgnored, result.Outcome)
+
+	result, err = codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:        model.ProviderGitHub,
+		DeliveryID:      "d-2",
+		Action:          model.CodeHostMerged,
+		PullRequestID:   "org/repo#404",
+		ActorExternalID: "stranger",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.OutcomeIgnored, result.Outcome)
+
+	result, err = codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:        model.ProviderGitHub,
+		DeliveryID:      "d-3",
+		Action:          model.CodeHostReviewed,
+		PullRequestID:   "org/repo#1",
+		ActorExternalID: "stranger",
+		Verdict:         model.VerdictApproved,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.OutcomeIgnored, result.Outcome)
+	assert.Equal(t, "reviewer is not linked", result.Reason)
+}
+
+func TestCodeHostService_Handle_ReviewSetsVerdictForMappedReviewer(t *testing.T) {
+	codeHostService, repos := newTestCodeHostService(t)
+
+	openPR := &model.PullRequest{ID: "org/repo#1", Status: model.StatusOpen, AssignedReviewers: []string{"u2"}}
+
+	repos.deliveries.On("Claim", mock.Anything, model.ProviderGitHub, "d-1", webhookDeliveryRetention).Return(true, nil)
+	repos.identities.On("Resolve", mock.Anything, model.ProviderGitHub, "reviewer").Return("u2", nil)
+	repos.prs.On("GetByID", mock.Anything, "org/repo#1").Return(openPR, nil)
+	repos.prs.On("SetVerdict", mock.Anything, "org/repo#1", "u2", model.VerdictChangesRequested, mock.Anything).Return(nil)
+
+	result, err := codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:        model.ProviderGitHub,
+		DeliveryID:      "d-1",
+		Action:          model.CodeHostReviewed,
+		PullRequestID:   "org/repo#1",
+		ActorExternalID: "reviewer",
+		Verdict:         model.VerdictChangesRequested,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.OutcomeProcessed, result.Outcome)
+}
+
+func TestCodeHostService_Handle_ReleasesDeliveryOnFailure(t *testing.T) {
+	codeHostService, repos := newTestCodeHostService(t)
+
+	dbErr := errors.New("db is down")
+
+	repos.deliveries.On("Claim", mock.Anything, model.ProviderGitHub, "d-1", webhookDeliveryRetention).Return(true, nil)
+	repos.identities.On("Resolve", mock.Anything, model.ProviderGitHub, "octocat").Return("u1", nil)
+	repos.prs.On("GetByID", mock.Anything, "org/repo#1").Return(nil, dbErr)
+	repos.deliveries.On("Release", mock.Anything, model.ProviderGitHub, "d-1").Return(nil)
+
+	_, err := codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:        model.ProviderGitHub,
+		DeliveryID:      "d-1",
+		Action:          model.CodeHostClosed,
+		PullRequestID:   "org/repo#1",
+		ActorExternalID: "octocat",
+	})
+
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestCodeHostService_Handle_ReopenRestoresClosedPR(t *testing.T) {
+	codeHostService, repos := newTestCodeHostService(t)
+
+	closedPR := &model.PullRequest{ID: "acme/billing!7", AuthorID: "u1", Status: model.StatusClosed}
+	openPR := &model.PullRequest{ID: "acme/billing!7", AuthorID: "u1", Status: model.StatusOpen}
+
+	repos.deliveries.On("Claim", mock.Anything, model.ProviderGitLab, "d-1", webhookDeliveryRetention).Return(true, nil)
+	repos.identities.On("Resolve", mock.Anything, model.ProviderGitLab, "101").Return("u1", nil)
+	repos.prs.On("GetByID", mock.Anything, "acme/billing!7").Return(closedPR, nil).Once()
+	repos.prs.On("Reopen", mock.Anything, "acme/billing!7", mock.Anything).Return(nil)
+	repos.prs.On("GetByID", mock.Anything, "acme/billing!7").Return(openPR, nil).Once()
+
+	result, err := codeHostService.Handle(context.Background(), model.CodeHostEvent{
+		Provider:        model.ProviderGitLab,
+		DeliveryID:      "d-1",
+		Action:          model.CodeHostReopened,
+		PullRequestID:   "acme/billing!7",
+		ActorExternalID: "101",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.OutcomeProcessed, result.Outcome)
+}
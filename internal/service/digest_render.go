@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+const digestMarkdownTemplate = `
+{{- define "pr"}}**{{.ID}}** {{.Name}} by {{.AuthorID}}, open {{age .CreatedAt}}{{end}}
+{{- define "DAILY"}}# Review digest for {{.User.Username}}
+
+## Reviews assigned to you ({{len .AssignedReviews}})
+{{range .AssignedReviews}}
+- {{template "pr" .}}{{if .Verdict}}, your verdict: {{.Verdict}}{{end}}
+{{- else}}
+Nothing to review.
+{{- end}}
+
+## Your pull requests waiting for review ({{len .AwaitingReview}})
+{{range .AwaitingReview}}
+- {{template "pr" .}}, {{if .PendingReviewers}}waiting for {{join .PendingReviewers ", "}}{{else}}no pending reviewers{{end}}
+{{- else}}
+Nothing is waiting.
+{{- end}}
+{{end}}
+{{- define "WEEKLY"}}# Weekly team summary
+
+{{day .Since}} – {{day .Until}}
+{{range .Teams}}
+## {{.TeamName}}
+
+- Opened: {{.Opened}}, merged: {{.Merged}}, closed: {{.Closed}}
+- Average time to merge: {{if .Merged}}{{duration .AverageTimeToMerge}}{{else}}n/a{{end}}
+
+### Open pull requests ({{len .OpenPullRequests}})
+{{range .OpenPullRequests}}
+- {{template "pr" .}}{{if .PendingReviewers}}, waiting for {{join .PendingReviewers ", "}}{{end}}
+{{- else}}
+None.
+{{- end}}
+
+### Reviewer load
+{{range .ReviewerLoad}}
+- {{.Username}} ({{.UserID}}): {{.OpenReviews}} open review(s)
+{{- end}}
+{{else}}
+You are not a member of any team.
+{{end}}
+{{- end}}`
+
+const digestTextTemplate = `
+{{- define "pr"}}{{.ID}} "{{.Name}}" by {{.AuthorID}}, open {{age .CreatedAt}}{{end}}
+{{- define "DAILY"}}Review digest for {{.User.Username}}
+
+Reviews assigned to you ({{len .AssignedReviews}}):
+{{- range .AssignedReviews}}
+  * {{template "pr" .}}{{if .Verdict}}, your verdict: {{.Verdict}}{{end}}
+{{- else}}
+  Nothing to review.
+{{- end}}
+
+Your pull requests waiting for review ({{len .AwaitingReview}}):
+{{- range .AwaitingReview}}
+  * {{template "pr" .}}, {{if .PendingReviewers}}waiting for {{join .PendingReviewers ", "}}{{else}}no pending reviewers{{end}}
+{{- else}}
+  Nothing is waiting.
+{{- end}}
+{{end}}
+{{- define "WEEKLY"}}Weekly team summary, {{day .Since}} – {{day .Until}}
+{{range .Teams}}
+{{.TeamName}}
+  Opened: {{.Opened}}, merged: {{.Merged}}, closed: {{.Closed}}
+  Average time to merge: {{if .Merged}}{{duration .AverageTimeToMerge}}{{else}}n/a{{end}}
+  Open pull requests ({{len .OpenPullRequests}}):
+{{- range .OpenPullRequests}}
+    * {{template "pr" .}}{{if .PendingReviewers}}, waiting for {{join .PendingReviewers ", "}}{{end}}
+{{- else}}
+    None.
+{{- end}}
+  Reviewer load:
+{{- range .ReviewerLoad}}
+    * {{.Username}} ({{.UserID}}): {{.OpenReviews}} open review(s)
+{{- end}}
+{{else}}
+You are not a member of any team.
+{{end}}
+{{- end}}`
+
+const digestHTMLTemplate = `
+{{- define "pr"}}<strong>{{.ID}}</strong> {{.Name}} by {{.AuthorID}}, open {{age .CreatedAt}}{{end}}
+{{- define "DAILY"}}<h1>Review digest for {{.User.Username}}</h1>
+<h2>Reviews assigned to you ({{len .AssignedReviews}})</h2>
+{{if .AssignedReviews}}<ul>
+{{- range .AssignedReviews}}
+<li>{{template "pr" .}}{{if .Verdict}}, your verdict: {{.Verdict}}{{end}}</li>
+{{- end}}
+</ul>{{else}}<p>Nothing to review.</p>{{end}}
+<h2>Your pull requests waiting for review ({{len .AwaitingReview}})</h2>
+{{if .AwaitingReview}}<ul>
+{{- range .AwaitingReview}}
+<li>{{template "pr" .}}, {{if .PendingReviewers}}waiting for {{join .PendingReviewers ", "}}{{else}}no pending reviewers{{end}}</li>
+{{- end}}
+</ul>{{else}}<p>Nothing is waiting.</p>{{end}}
+{{end}}
+{{- define "WEEKLY"}}<h1>Weekly team summary</h1>
+<p>{{day .Since}} – {{day .Until}}</p>
+{{range .Teams}}<h2>{{.TeamName}}</h2>
+<ul>
+<li>Opened: {{.Opened}}, merged: {{.Merged}}, closed: {{.Closed}}</li>
+<li>Average time to merge: {{if .Merged}}{{duration .AverageTimeToMerge}}{{else}}n/a{{end}}</li>
+</ul>
+<h3>Open pull requests ({{len .OpenPullRequests}})</h3>
+{{if .OpenPullRequests}}<ul>
+{{- range .OpenPullRequests}}
+<li>{{template "pr" .}}{{if .PendingReviewers}}, waiting for {{join .PendingReviewers ", "}}{{end}}</li>
+{{- end}}
+</ul>{{else}}<p>None.</p>{{end}}
+<h3>Reviewer load</h3>
+<ul>
+{{- range .ReviewerLoad}}
+<li>{{.Username}} ({{.UserID}}): {{.OpenReviews}} open review(s)</li>
+{{- end}}
+</ul>
+{{else}}<p>You are not a member of any team.</p>
+{{end}}
+{{- end}}`
+
+type digestTemplate interface {
+	ExecuteTemplate(w io.Writer, name string, data any) error
+}
+
+func digestFuncs(until time.Time) map[string]any {
+	return map[string]any{
+		"age":      func(since time.Time) string { return formatDigestDuration(until.Sub(since)) },
+		"duration": formatDigestDuration,
+		"day":      func(t time.Time) string { return t.Format("Mon, 2 Jan 2006") },
+		"join":     strings.Join,
+	}
+}
+
+func digestTemplateFor(format model.DigestFormat, until time.Time) digestTemplate {
+	funcs := digestFuncs(until)
+
+	switch format {
+	case model.DigestHTML:
+		return htmltemplate.Must(htmltemplate.New("digest").Funcs(funcs).Parse(digestHTMLTemplate))
+	case model.DigestText:
+		return template.Must(template.New("digest").Funcs(funcs).Parse(digestTextTemplate))
+	}
+
+	return template.Must(template.New("digest").Funcs(funcs).Parse(digestMarkdownTemplate))
+}
+
+func formatDigestDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", d/(24*time.Hour), d%(24*time.Hour)/time.Hour)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", d/time.Hour, d%time.Hour/time.Minute)
+	}
+
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+func digestSubject(digest model.Digest) string {
+	if digest.Kind == model.DigestWeekly {
+		return fmt.Sprintf("Weekly team summary: %s – %s", digest.Since.Format("2 Jan"), digest.Until.Format("2 Jan"))
+	}
+
+	return fmt.Sprintf("Review digest: %d to review, %d waiting", len(digest.AssignedReviews), len(digest.AwaitingReview))
+}
+
+func renderDigest(digest model.Digest, format model.DigestFormat) (*model.RenderedDigest, error) {
+	var body bytes.Buffer
+	if err := digestTemplateFor(format, digest.Until).ExecuteTemplate(&body, string(digest.Kind), digest); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	return &model.RenderedDigest{
+		UserID:      digest.User.ID,
+		Kind:        digest.Kind,
+		Format:      format,
+		Subject:     digestSubject(digest),
+		Body:        strings.TrimSpace(body.String()) + "\n",
+		GeneratedAt: digest.Until,
+	}, nil
+}
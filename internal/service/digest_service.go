@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	digestBatch      = 20
+	digestLease      = 5 * time.Minute
+	digestPoll       = time.Minute
+	digestRetryDelay = 15 * time.Minute
+	digestWeekday    = time.Monday
+	digestWeek       = 7 * 24 * time.Hour
+)
+
+type DigestService struct {
+	digestRepo       DigestRepository
+	userRepo         UserRepository
+	notificationRepo NotificationRepository
+	notifiers        map[model.NotificationChannel]Notifier
+	now              func() time.Time
+}
+
+func NewDigestService(digestRepo DigestRepository, userRepo UserRepository, notificationRepo NotificationRepository, notifiers ...Notifier) *DigestService {
+	byChannel := make(map[model.NotificationChannel]Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		byChannel[notifier.Channel()] = notifier
+	}
+
+	return &DigestService{
+		digestRepo:       digestRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		notifiers:        byChannel,
+		now:              time.Now,
+	}
+}
+
+func (s *DigestService) Preview(ctx context.Context, userID string, kind model.DigestKind, format model.DigestFormat) (*model.RenderedDigest, error) {
+	userID, err := resolveSelfOrAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == "" {
+		kind = model.DigestDaily
+	}
+	if format == "" {
+		format = model.DigestMarkdown
+	}
+	if !kind.IsValid() || !format.IsValid() {
+		return nil, ErrInvalidDigest
+	}
+
+	location := time.UTC
+	if pref, err := s.notificationRepo.GetPreference(ctx, userID); err == nil {
+		location = preferenceLocation(*pref)
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	digest, err := s.build(ctx, userID, kind, s.now().In(location))
+	if err != nil {
+		return nil, err
+	}
+
+	return renderDigest(*digest, format)
+}
+
+func (s *DigestService) build(ctx context.Context, userID string, kind model.DigestKind, now time.Time) (*model.Digest, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	digest := &model.Digest{Kind: kind, User: user.User, Since: now.Add(-24 * time.Hour), Until: now}
+
+	if kind == model.DigestDaily {
+		if digest.AssignedReviews, err = s.digestRepo.ListAssignedReviews(ctx, userID); err != nil {
+			return nil, err
+		}
+		if digest.AwaitingReview, err = s.digestRepo.ListAwaitingReview(ctx, userID); err != nil {
+			return nil, err
+		}
+
+		return digest, nil
+	}
+
+	digest.Since = now.Add(-digestWeek)
+
+	memberships, err := s.userRepo.GetMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, membership := range memberships {
+		team, err := s.digestRepo.GetTeamDigest(ctx, membership.TeamID, digest.Since, digest.Until)
+		if err != nil {
+			return nil, err
+		}
+		team.TeamName = membership.TeamName
+		digest.Teams = append(digest.Teams, *team)
+	}
+
+	return digest, nil
+}
+
+func (s *DigestService) ListSubscriptions(ctx context.Context, userID string) ([]model.DigestSubscription, error) {
+	userID, err := resolveSelfOrAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.digestRepo.ListSubscriptions(ctx, userID)
+}
+
+func (s *DigestService) Subscribe(ctx context.Context, sub model.DigestSubscription) (*model.DigestSubscription, error) {
+	userID, err := resolveSelfOrAdmin(ctx, sub.UserID)
+	if err != nil {
+		return nil, err
+	}
+	sub.UserID = userID
+
+	if sub.Format == "" {
+		sub.Format = model.DigestMarkdown
+	}
+	if !sub.Kind.IsValid() || !sub.Format.IsValid() {
+		return nil, ErrInvalidDigest
+	}
+
+	if sub.Kind == model.DigestWeekly {
+		creds, err := s.userRepo.GetCredentials(ctx, userID)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrNotFound
+			}
+
+			return nil, err
+		}
+		if creds.Role != model.RoleTeamLead && creds.Role != model.RoleAdmin {
+			return nil, fmt.Errorf("%w: weekly team summaries are available to team leads", ErrInvalidDigest)
+		}
+	}
+
+	pref, err := s.notificationRepo.GetPreference(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("%w: set a notification channel first", ErrInvalidDigest)
+		}
+
+		return nil, err
+	}
+	sub.NextRunAt = nextDigestRun(*pref, sub.Kind, s.now())
+
+	saved, err := s.digestRepo.SetSubscription(ctx, sub)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s *DigestService) Unsubscribe(ctx context.Context, userID string, kind model.DigestKind) error {
+	userID, err := resolveSelfOrAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !kind.IsValid() {
+		return ErrInvalidDigest
+	}
+
+	if err := s.digestRepo.DeleteSubscription(ctx, userID, kind); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func preferenceLocation(pref model.NotificationPreference) *time.Location {
+	location, err := time.LoadLocation(pref.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+func nextDigestRun(pref model.NotificationPreference, kind model.DigestKind, now time.Time) time.Time {
+	location := preferenceLocation(pref)
+
+	next := nextClockTime(now.In(location), pref.DigestTime).In(location)
+	if kind == model.DigestWeekly {
+		for next.Weekday() != digestWeekday {
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+
+	return next.UTC()
+}
+
+func (s *DigestService) ProcessDue(ctx context.Context) (int, error) {
+	subs, err := s.digestRepo.ClaimDue(ctx, digestBatch, digestLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, sub := range subs {
+		if err := s.deliver(ctx, sub); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(subs), nil
+}
+
+func (s *DigestService) deliver(ctx context.Context, sub model.DigestSubscription) error {
+	now := s.now()
+
+	pref, err := s.notificationRepo.GetPreference(ctx, sub.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return s.digestRepo.Retry(ctx, sub.UserID, sub.Kind, now.Add(24*time.Hour), "notification channel is not configured")
+		}
+
+		return err
+	}
+
+	next := nextDigestRun(*pref, sub.Kind, now)
+	if !pref.Enabled {
+		return s.digestRepo.Retry(ctx, sub.UserID, sub.Kind, next, "")
+	}
+
+	digest, err := s.build(ctx, sub.UserID, sub.Kind, now.In(preferenceLocation(*pref)))
+	if err != nil {
+		return err
+	}
+	if digest.IsEmpty() {
+		return s.digestRepo.Retry(ctx, sub.UserID, sub.Kind, next, "")
+	}
+
+	rendered, err := renderDigest(*digest, sub.Format)
+	if err != nil {
+		return err
+	}
+
+	notifier, ok := s.notifiers[pref.Channel]
+	if !ok {
+		return s.digestRepo.Retry(ctx, sub.UserID, sub.Kind, next, fmt.Sprintf("%s notifications are not configured", pref.Channel))
+	}
+
+	sendErr := notifier.Send(ctx, model.NotificationMessage{
+		UserID:      sub.UserID,
+		Channel:     pref.Channel,
+		Address:     pref.Address,
+		Subject:     rendered.Subject,
+		Body:        rendered.Body,
+		ContentType: sub.Format.ContentType(),
+	})
+	if sendErr != nil {
+		log.Printf("Failed to send %s digest to %s: %v", sub.Kind, sub.UserID, sendErr)
+
+		retryAt := now.Add(digestRetryDelay)
+		if retryAt.After(next) {
+			retryAt = next
+		}
+		return s.digestRepo.Retry(ctx, sub.UserID, sub.Kind, retryAt, sendErr.Error())
+	}
+
+	return s.digestRepo.MarkSent(ctx, sub.UserID, sub.Kind, next)
+}
+
+func (s *DigestService) Run(ctx context.Context) {
+	poll := time.NewTicker(digestPoll)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		}
+
+		for {
+			processed, err := s.ProcessDue(ctx)
+			if err != nil {
+				log.Printf("Digest delivery failed: %v", err)
+			}
+			if err != nil || processed < digestBatch {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type digestTestDeps struct {
+	repo          *mocks.DigestRepository
+	users         *mocks.UserRepository
+	notifications *mocks.NotificationRepository
+	slack         *mocks.Notifier
+}
+
+func newTestDigestService(t *testing.T) (*DigestService, digestTestDeps) {
+	deps := digestTestDeps{
+		repo:          mocks.NewDigestRepository(t),
+		users:         mocks.NewUserRepository(t),
+		notifications: mocks.NewNotificationRepository(t),
+		slack:         mocks.NewNotifier(t),
+	}
+	deps.slack.On("Channel").Return(model.ChannelSlack)
+
+	digestService := NewDigestService(deps.repo, deps.users, deps.notifications, deps.slack)
+	digestService.now = func() time.Time { return notificationTestNow }
+
+	return digestService, deps
+}
+
+func TestNextDigestRun(t *testing.T) {
+	berlin := model.NotificationPreference{Timezone: "Europe/Berlin", DigestTime: "08:30"}
+
+	assert.Equal(t, time.Date(2025, 11, 4, 7, 30, 0, 0, time.UTC), nextDigestRun(berlin, model.DigestDaily, notificationTestNow))
+	assert.Equal(t, time.Date(2025, 11, 10, 7, 30, 0, 0, time.UTC), nextDigestRun(berlin, model.DigestWeekly, notificationTestNow))
+
+	utc := model.NotificationPreference{Timezone: "UTC", DigestTime: "09:00"}
+	early := time.Date(2025, 11, 5, 6, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC), nextDigestRun(utc, model.DigestDaily, early))
+	assert.Equal(t, time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC), nextDigestRun(utc, model.DigestWeekly, early))
+}
+
+func TestDigestService_Preview_DailyMarkdown(t *testing.T) {
+	digestService, deps := newTestDigestService(t)
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	deps.notifications.On("GetPreference", mock.Anything, "bob").Return(nil, store.ErrNotFound)
+	deps.users.On("GetByID", mock.Anything, "bob").Return(testUser("bob", "Bob"), nil)
+	deps.repo.On("ListAssignedReviews", mock.Anything, "bob").Return([]model.DigestPullRequest{
+		{ID: "pr-1", Name: "Add search", AuthorID: "alice", CreatedAt: notificationTestNow.Add(-50 * time.Hour), Verdict: model.VerdictChangesRequested},
+		{ID: "pr-2", Name: "Fix login", AuthorID: "carol", CreatedAt: notificationTestNow.Add(-90 * time.Minute)},
+	}, nil)
+	deps.repo.On("ListAwaitingReview", mock.Anything, "bob").Return([]model.DigestPullRequest{
+		{ID: "pr-3", Name: "Drop cache", AuthorID: "bob", CreatedAt: notificationTestNow.Add(-20 * time.Minute), PendingReviewers: []string{"alice", "carol"}},
+	}, nil)
+
+	rendered, err := digestService.Preview(ctx, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", rendered.UserID)
+	assert.Equal(t, model.DigestMarkdown, rendered.Format)
+	assert.Equal(t, "Review digest: 2 to review, 1 waiting", rendered.Subject)
+	assert.Equal(t, `# Review digest for Bob
+
+## Reviews assigned to you (2)
+
+- **pr-1** Add search by alice, open 2d 2h, your verdict: CHANGES_REQUESTED
+- **pr-2** Fix login by carol, open 1h 30m
+
+## Your pull requests waiting for review (1)
+
+- **pr-3** Drop cache by bob, open 20m, waiting for alice, carol
+`, rendered.Body)
+}
+
+func TestDigestService_Preview_WeeklyFormats(t *testing.T) {
+	digestService, deps := newTestDigestService(t)
+
+	deps.notifications.On("GetPreference", mock.Anything, "lead").Return(&model.NotificationPreference{UserID: "lead", Timezone: "Europe/Berlin"}, nil)
+	deps.users.On("GetByID", mock.Anything, "lead").Return(testUser("lead", "Lead"), nil)
+	deps.users.On("GetMemberships", mock.Anything, "lead").Return([]model.TeamMembership{{TeamID: 7, TeamName: "backend"}}, nil)
+	sameTime := func(want time.Time) any {
+		return mock.MatchedBy(func(got time.Time) bool { return got.Equal(want) })
+	}
+	deps.repo.On("GetTeamDigest", mock.Anything, 7, sameTime(notificationTestNow.Add(-digestWeek)), sameTime(notificationTestNow)).Return(&model.TeamDigest{
+		Opened: 3, Merged: 2, Closed: 1, AverageTimeToMerge: 26 * time.Hour,
+		OpenPullRequests: []model.DigestPullRequest{
+			{ID: "pr-9", Name: "<b>Refactor</b>", AuthorID: "bob", CreatedAt: notificationTestNow.Add(-72 * time.Hour), PendingReviewers: []string{"carol"}},
+		},
+		ReviewerLoad: []model.ReviewerLoad{{UserID: "carol", Username: "Carol", OpenReviews: 4}},
+	}, nil)
+
+	html, err := digestService.Preview(testAdminContext(), "lead", model.DigestWeekly, model.DigestHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly team summary: 27 Oct – 3 Nov", html.Subject)
+	assert.Contains(t, html.Body, "<h2>backend</h2>")
+	assert.Contains(t, html.Body, "<li>Average time to merge: 1d 2h</li>")
+	assert.Contains(t, html.Body, "<strong>pr-9</strong> &lt;b&gt;Refactor&lt;/b&gt; by bob, open 3d 0h, waiting for carol")
+	assert.Contains(t, html.Body, "<li>Carol (carol): 4 open review(s)</li>")
+
+	text, err := digestService.Preview(testAdminContext(), "lead", model.DigestWeekly, model.DigestText)
+	require.NoError(t, err)
+	assert.Equal(t, `Weekly team summary, Mon, 27 Oct 2025 – Mon, 3 Nov 2025
+
+backend
+  Opened: 3, merged: 2, closed: 1
+  Average time to merge: 1d 2h
+  Open pull requests (1):
+    * pr-9 "<b>Refactor</b>" by bob, open 3d 0h, waiting for carol
+  Reviewer load:
+    * Carol (carol): 4 open review(s)
+`, text.Body)
+}
+
+func TestDigestService_Preview_Errors(t *testing.T) {
+	digestService, _ := newTestDigestService(t)
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	_, err := digestService.Preview(member, "alice", model.DigestDaily, model.DigestMarkdown)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	_, err = digestService.Preview(member, "", model.DigestDaily, "PDF")
+	assert.ErrorIs(t, err, ErrInvalidDigest)
+
+	_, err = digestService.Preview(member, "", "MONTHLY", model.DigestText)
+	assert.ErrorIs(t, err, ErrInvalidDigest)
+}
+
+func TestDigestService_Subscribe(t *testing.T) {
+	digestService, deps := newTestDigestService(t)
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	deps.users.On("GetCredentials", mock.Anything, "bob").Return(&model.Credentials{UserID: "bob", Role: model.RoleMember}, nil)
+	_, err := digestService.Subscribe(member, model.DigestSubscription{Kind: model.DigestWeekly})
+	assert.ErrorIs(t, err, ErrInvalidDigest)
+
+	deps.notifications.On("GetPreference", mock.Anything, "bob").Return(nil, store.ErrNotFound).Once()
+	_, err = digestService.Subscribe(member, model.DigestSubscription{Kind: model.DigestDaily})
+	assert.ErrorIs(t, err, ErrInvalidDigest)
+
+	deps.notifications.On("GetPreference", mock.Anything, "bob").Return(&model.NotificationPreference{
+		UserID: "bob", Channel: model.ChannelSlack, Timezone: "UTC", DigestTime: "09:00", Enabled: true,
+	}, nil)
+	want := model.DigestSubscription{UserID: "bob", Kind: model.DigestDaily, Format: model.DigestMarkdown, NextRunAt: time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC)}
+	deps.repo.On("SetSubscription", mock.Anything, want).Return(&want, nil)
+
+	saved, err := digestService.Subscribe(member, model.DigestSubscription{Kind: model.DigestDaily})
+	require.NoError(t, err)
+	assert.Equal(t, want, *saved)
+}
+
+func TestDigestService_ProcessDue(t *testing.T) {
+	digestService, deps := newTestDigestService(t)
+
+	deps.repo.On("ClaimDue", mock.Anything, digestBatch, digestLease).Return([]model.DigestSubscription{
+		{UserID: "bob", Kind: model.DigestDaily, Format: model.DigestHTML},
+		{UserID: "carol", Kind: model.DigestDaily, Format: model.DigestText},
+		{UserID: "dave", Kind: model.DigestDaily, Format: model.DigestText},
+	}, nil)
+
+	for _, id := range []string{"bob", "carol", "dave"} {
+		deps.notifications.On("GetPreference", mock.Anything, id).Return(&model.NotificationPreference{
+			UserID: id, Channel: model.ChannelSlack, Address: "https://hooks.slack.test/" + id, Timezone: "UTC", DigestTime: "09:00", Enabled: true,
+		}, nil)
+		deps.users.On("GetByID", mock.Anything, id).Return(testUser(id, id), nil)
+	}
+	nextRun := time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC)
+
+	deps.repo.On("ListAssignedReviews", mock.Anything, "bob").Return([]model.DigestPullRequest{{ID: "pr-1", Name: "A", AuthorID: "alice", CreatedAt: notificationTestNow}}, nil)
+	deps.repo.On("ListAwaitingReview", mock.Anything, "bob").Return([]model.DigestPullRequest{}, nil)
+	deps.slack.On("Send", mock.Anything, mock.MatchedBy(func(m model.NotificationMessage) bool {
+		return m.UserID == "bob" && m.ContentType == "text/html; charset=UTF-8" && m.Address == "https://hooks.slack.test/bob"
+	})).Return(nil)
+	deps.repo.On("MarkSent", mock.Anything, "bob", model.DigestDaily, nextRun).Return(nil)
+
+	deps.repo.On("ListAssignedReviews", mock.Anything, "carol").Return([]model.DigestPullRequest{}, nil)
+	deps.repo.On("ListAwaitingReview", mock.Anything, "carol").Return([]model.DigestPullRequest{}, nil)
+	deps.repo.On("Retry", mock.Anything, "carol", model.DigestDaily, nextRun, "").Return(nil)
+
+	deps.repo.On("ListAssignedReviews", mock.Anything, "dave").Return([]model.DigestPullRequest{{ID: "pr-2", Name: "B", AuthorID: "alice", CreatedAt: notificationTestNow}}, nil)
+	deps.repo.On("ListAwaitingReview", mock.Anything, "dave").Return([]model.DigestPullRequest{}, nil)
+	deps.slack.On("Send", mock.Anything, mock.MatchedBy(func(m model.NotificationMessage) bool { return m.UserID == "dave" })).Return(errors.New("slack is down"))
+	deps.repo.On("Retry", mock.Anything, "dave", model.DigestDaily, notificationTestNow.Add(digestRetryDelay), "slack is down").Return(nil)
+
+	processed, err := digestService.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+type IdentityService struct {
+	identityRepo IdentityRepository
+}
+
+func NewIdentityService(identityRepo IdentityRepository) *IdentityService {
+	return &IdentityService{
+		identityRepo: identityRepo,
+	}
+}
+
+func (s *IdentityService) Link(ctx context.Context, identity model.ExternalIdentity) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if !identity.Provider.IsValid() || identity.ExternalID == "" || identity.UserID == "" {
+		return ErrInvalidIdentity
+	}
+
+	if err := s.identityRepo.Link(ctx, identity); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *IdentityService) Unlink(ctx context.Context, provider model.IdentityProvider, externalID string) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := s.identityRepo.Unlink(ctx, provider, externalID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *IdentityService) List(ctx context.Context, provider model.IdentityProvider, userID string) ([]model.ExternalIdentity, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if provider != "" && !provider.IsValid() {
+		return nil, ErrInvalidIdentity
+	}
+
+	return s.identityRepo.List(ctx, provider, userID)
+}
+
+func (s *IdentityService) Resolve(ctx context.Context, provider model.IdentityProvider, externalID string) (string, error) {
+	if externalID == "" {
+		return "", ErrNotFound
+	}
+
+	userID, err := s.identityRepo.Resolve(ctx, provider, externalID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return "", ErrNotFound
+		}
+
+		return "", err
+	}
+
+	return userID, nil
+}
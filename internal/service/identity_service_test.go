@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityService_Link_Validation(t *testing.T) {
+	mockIdentityRepo := mocks.NewIdentityRepository(t)
+	identityService := NewIdentityService(mockIdentityRepo)
+
+	identity := model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalID: "octocat", UserID: "u1"}
+
+	memberCtx := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleMember})
+	assert.Equal(t, ErrForbidden, identityService.Link(memberCtx, identity))
+
+	assert.Equal(t, ErrInvalidIdentity, identityService.Link(testAdminContext(), model.ExternalIdentity{Provider: "svn", ExternalID: "octocat", UserID: "u1"}))
+	assert.Equal(t, ErrInvalidIdentity, identityService.Link(testAdminContext(), model.ExternalIdentity{Provider: model.ProviderGitHub, UserID: "u1"}))
+
+	mockIdentityRepo.On("Link", mock.Anything, identity).Return(nil).Once()
+	require.NoError(t, identityService.Link(testAdminContext(), identity))
+
+	missing := model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalID: "ghost", UserID: "nobody"}
+	mockIdentityRepo.On("Link", mock.Anything, missing).Return(store.ErrNotFound).Once()
+	assert.Equal(t, ErrNotFound, identityService.Link(testAdminContext(), missing))
+}
+
+func TestIdentityService_Resolve(t *testing.T) {
+	mockIdentityRepo := mocks.NewIdentityRepository(t)
+	identityService := NewIdentityService(mockIdentityRepo)
+
+	mockIdentityRepo.On("Resolve", mock.Anything, model.ProviderGitHub, "octocat").Return("u1", nil)
+	mockIdentityRepo.On("Resolve", mock.Anything, model.ProviderGitHub, "ghost").Return("", store.ErrNotFound)
+
+	userID, err := identityService.Resolve(context.Background(), model.ProviderGitHub, "octocat")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", userID)
+
+	_, err = identityService.Resolve(context.Background(), model.ProviderGitHub, "ghost")
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = identityService.Resolve(context.Background(), model.ProviderGitHub, "")
+	assert.Equal(t, ErrNotFound, err)
+}
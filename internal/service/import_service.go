@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"gopkg.in/yaml.v3"
+)
+
+type ImportService struct {
+	teamRepo TeamRepository
+	userRepo UserRepository
+}
+
+func NewImportService(teamRepo TeamRepository, userRepo UserRepository) *ImportService {
+	return &ImportService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *ImportService) Import(ctx context.Context, org model.OrgImport, dryRun bool) (*model.ImportPlan, error) {
+	plan, err := s.Plan(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun || plan.IsEmpty() {
+		return plan, nil
+	}
+
+	if err := s.teamRepo.ApplyImport(ctx, *plan, actorID(ctx)); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (s *ImportService) Plan(ctx context.Context, org model.OrgImport) (*model.ImportPlan, error) {
+	if err := validateOrgImport(org); err != nil {
+		return nil, err
+	}
+
+	imported := make(map[string]struct{})
+	for _, team := range org.Teams {
+		for _, member := range team.Members {
+			imported[member.ID] = struct{}{}
+		}
+	}
+
+	plan := &model.ImportPlan{}
+	for _, importTeam := range org.Teams {
+		team, members, err := s.teamRepo.GetByName(ctx, importTeam.Name)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			plan.TeamsToCreate = append(plan.TeamsToCreate, importTeam.Name)
+		case err != nil:
+			return nil, err
+		case team.ArchivedAt != nil:
+			return nil, ErrTeamArchived
+		}
+
+		for _, member := range importTeam.Members {
+			change := model.ImportUserChange{User: member, TeamName: importTeam.Name}
+
+			existing, err := s.userRepo.GetByID(ctx, member.ID)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					plan.UsersToAdd = append(plan.UsersToAdd, change)
+					continue
+				}
+
+				return nil, err
+			}
+
+			if existing.TeamName != importTeam.Name {
+				change.FromTeamName = existing.TeamName
+				plan.UsersToMove = append(plan.UsersToMove, change)
+				continue
+			}
+
+			if existing.Username != member.Username || existing.IsActive != member.IsActive {
+				plan.UsersToUpdate = append(plan.UsersToUpdate, change)
+			}
+		}
+
+		if team == nil {
+			continue
+		}
+
+		for _, member := range members {
+			if member.TeamID != team.ID || !member.IsActive {
+				continue
+			}
+			if _, ok := imported[member.ID]; ok {
+				continue
+			}
+
+			member.IsActive = false
+			plan.UsersToDeactivate = append(plan.UsersToDeactivate, model.ImportUserChange{User: member, TeamName: team.Name})
+		}
+	}
+
+	return plan, nil
+}
+
+func validateOrgImport(org model.OrgImport) error {
+	teams := make(map[string]struct{}, len(org.Teams))
+	users := make(map[string]struct{})
+	for _, team := range org.Teams {
+		if team.Name == "" {
+			return fmt.Errorf("%w: team name is required", ErrInvalidImport)
+		}
+		if _, ok := teams[team.Name]; ok {
+			return fmt.Errorf("%w: team %s is listed more than once", ErrInvalidImport, team.Name)
+		}
+		teams[team.Name] = struct{}{}
+
+		for _, member := range team.Members {
+			if member.ID == "" || member.Username == "" {
+				return fmt.Errorf("%w: user_id and username are required in team %s", ErrInvalidImport, team.Name)
+			}
+			if _, ok := users[member.ID]; ok {
+				return fmt.Errorf("%w: user %s is listed more than once", ErrInvalidImport, member.ID)
+			}
+			users[member.ID] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+type importFile struct {
+	Teams []struct {
+		Name    string `yaml:"name"`
+		Members []struct {
+			UserID   string `yaml:"user_id"`
+			Username string `yaml:"username"`
+			IsActive *bool  `yaml:"is_active"`
+		} `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+func ParseOrgImport(r io.Reader, format model.ImportFormat) (*model.OrgImport, error) {
+	switch format {
+	case model.ImportFormatYAML:
+		return parseYAMLImport(r)
+	case model.ImportFormatCSV:
+		return parseCSVImport(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImport, format)
+	}
+}
+
+func parseYAMLImport(r io.Reader) (*model.OrgImport, error) {
+	var file importFile
+	if err := yaml.NewDecoder(r).Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	org := &model.OrgImport{}
+	for _, team := range file.Teams {
+		importTeam := model.ImportTeam{Name: team.Name}
+		for _, member := range team.Members {
+			isActive := true
+			if member.IsActive != nil {
+				isActive = *member.IsActive
+			}
+			importTeam.Members = append(importTeam.Members, model.User{
+				ID:       member.UserID,
+				Username: member.Username,
+				IsActive: isActive,
+			})
+		}
+		org.Teams = append(org.Teams, importTeam)
+	}
+
+	return org, nil
+}
+
+func parseCSVImport(r io.Reader) (*model.OrgImport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing csv header: %v", ErrInvalidImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"team_name", "user_id", "username"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing csv column %s", ErrInvalidImport, required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	org := &model.OrgImport{}
+	teamIndex := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+
+		teamName := field(record, "team_name")
+		i, ok := teamIndex[teamName]
+		if !ok {
+			i = len(org.Teams)
+			teamIndex[teamName] = i
+			org.Teams = append(org.Teams, model.ImportTeam{Name: teamName})
+		}
+
+		userID := field(record, "user_id")
+		if userID == "" {
+			continue
+		}
+
+		isActive := true
+		if raw := field(record, "is_active"); raw != "" {
+			isActive, err = strconv.ParseBool(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid is_active on line %d", ErrInvalidImport, line)
+			}
+		}
+
+		org.Teams[i].Members = append(org.Teams[i].Members, model.User{
+			ID:       userID,
+			Username: field(record, "username"),
+			IsActive: isActive,
+		})
+	}
+
+	return org, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrgImport_YAML(t *testing.T) {
+	input := `
+teams:
+  - name: backend
+    members:
+      - user_id: u1
+        username: Alice
+      - user_id: u2
+        username: Bob
+        is_active: false
+  - name: frontend
+`
+
+	org, err := ParseOrgImport(strings.NewReader(input), model.ImportFormatYAML)
+
+	require.NoError(t, err)
+	assert.Equal(t, &model.OrgImport{Teams: []model.ImportTeam{
+		{Name: "backend", Members: []model.User{
+			{ID: "u1", Username: "Alice", IsActive: true},
+			{ID: "u2", Username: "Bob", IsActive: false},
+		}},
+		{Name: "frontend"},
+	}}, org)
+}
+
+func TestParseOrgImport_CSV(t *testing.T) {
+	input := "team_name,user_id,username,is_active\n" +
+		"backend,u1,Alice,true\n" +
+		"frontend,,,\n" +
+		"backend,u2,Bob,false\n"
+
+	org, err := ParseOrgImport(strings.NewReader(input), model.ImportFormatCSV)
+
+	require.NoError(t, err)
+	assert.Equal(t, &model.OrgImport{Teams: []model.ImportTeam{
+		{Name: "backend", Members: []model.User{
+			{ID: "u1", Username: "Alice", IsActive: true},
+			{ID: "u2", Username: "Bob", IsActive: false},
+		}},
+		{Name: "frontend"},
+	}}, org)
+}
+
+func TestParseOrgImport_CSVMissingColumn(t *testing.T) {
+	_, err := ParseOrgImport(strings.NewReader("team_name,user_id\nbackend,u1\n"), model.ImportFormatCSV)
+
+	assert.ErrorIs(t, err, ErrInvalidImport)
+}
+
+func TestImportService_Plan_ComputesDiff(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	org := model.OrgImport{Teams: []model.ImportTeam{
+		{Name: "backend", Members: []model.User{
+			{ID: "u1", Username: "Alice", IsActive: true},
+			{ID: "u2", Username: "Bobby", IsActive: true},
+			{ID: "u3", Username: "Carol", IsActive: true},
+			{ID: "u4", Username: "Dave", IsActive: true},
+		}},
+		{Name: "platform"},
+	}}
+
+	backend := &model.Team{ID: 1, Name: "backend"}
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(backend, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true, TeamID: 1},
+		{ID: "u2", Username: "Bob", IsActive: true, TeamID: 1},
+		{ID: "u5", Username: "Eve", IsActive: true, TeamID: 1},
+		{ID: "u6", Username: "Frank", IsActive: true, TeamID: 9},
+	}, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "platform").Return(nil, nil, store.ErrNotFound)
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&model.FullUserInfo{User: model.User{ID: "u1", Username: "Alice", IsActive: true, TeamID: 1}, TeamName: "backend"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u2").Return(&model.FullUserInfo{User: model.User{ID: "u2", Username: "Bob", IsActive: true, TeamID: 1}, TeamName: "backend"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u3").Return(&model.FullUserInfo{User: model.User{ID: "u3", Username: "Carol", IsActive: true, TeamID: 2}, TeamName: "frontend"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u4").Return(nil, store.ErrNotFound)
+
+	importService := NewImportService(mockTeamRepo, mockUserRepo)
+
+	plan, err := importService.Import(context.Background(), org, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform"}, plan.TeamsToCreate)
+	assert.Equal(t, []model.ImportUserChange{{User: org.Teams[0].Members[3], TeamName: "backend"}}, plan.UsersToAdd)
+	assert.Equal(t, []model.ImportUserChange{{User: org.Teams[0].Members[2], TeamName: "backend", FromTeamName: "frontend"}}, plan.UsersToMove)
+	assert.Equal(t, []model.ImportUserChange{{User: org.Teams[0].Members[1], TeamName: "backend"}}, plan.UsersToUpdate)
+	require.Len(t, plan.UsersToDeactivate, 1)
+	assert.Equal(t, "u5", plan.UsersToDeactivate[0].User.ID)
+	mockTeamRepo.AssertNotCalled(t, "ApplyImport", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportService_Import_NoopWhenUpToDate(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	org := model.OrgImport{Teams: []model.ImportTeam{
+		{Name: "backend", Members: []model.User{{ID: "u1", Username: "Alice", IsActive: true}}},
+	}}
+
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&model.Team{ID: 1, Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true, TeamID: 1},
+	}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&model.FullUserInfo{User: model.User{ID: "u1", Username: "Alice", IsActive: true, TeamID: 1}, TeamName: "backend"}, nil)
+
+	importService := NewImportService(mockTeamRepo, mockUserRepo)
+
+	plan, err := importService.Import(context.Background(), org, false)
+
+	require.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+	mockTeamRepo.AssertNotCalled(t, "ApplyImport", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportService_Import_RejectsDuplicateUsers(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	org := model.OrgImport{Teams: []model.ImportTeam{
+		{Name: "backend", Members: []model.User{{ID: "u1", Username: "Alice"}}},
+		{Name: "frontend", Members: []model.User{{ID: "u1", Username: "Alice"}}},
+	}}
+
+	importService := NewImportService(mockTeamRepo, mockUserRepo)
+
+	_, err := importService.Import(context.Background(), org, false)
+
+	assert.ErrorIs(t, err, ErrInvalidImport)
+}
@@ -2,29 +2,210 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/DeadlyParkour777/pr-service/internal/model"
 )
 
 type TeamRepository interface {
-	AddTeamWithMembers(ctx context.Context, team model.Team, members []model.User) (*model.Team, error)
+	AddTeamWithMembers(ctx context.Context, team model.Team, members []model.User) (*model.TeamUpsert, error)
 	GetByName(ctx context.Context, name string) (*model.Team, []model.User, error)
+	GetByID(ctx context.Context, id int) (*model.Team, []model.User, error)
+	AddMember(ctx context.Context, teamName string, member model.User, actorID string) (*model.FullUserInfo, error)
+	SetSecondaryMember(ctx context.Context, teamName, userID string, reviewable bool, actorID string) (*model.TeamMembership, error)
+	GetAncestors(ctx context.Context, teamID int) ([]model.Team, error)
+	GetChildren(ctx context.Context, teamID int) ([]model.Team, error)
+	SetParent(ctx context.Context, teamName, parentName, actorID string) (*model.Team, error)
+	SetPolicy(ctx context.Context, teamName string, policy model.AssignmentPolicy, actorID string) (*model.Team, error)
+	Rename(ctx context.Context, teamName, newName, actorID string) (*model.Team, error)
+	SetArchived(ctx context.Context, teamName string, archived bool, actorID string) (*model.Team, error)
+	GetDeletionReport(ctx context.Context, team model.Team) (*model.TeamDeletionReport, error)
+	Delete(ctx context.Context, teamID int, actorID string) error
+	List(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, error)
+	Count(ctx context.Context, filter model.TeamFilter) (int, error)
+	ApplyImport(ctx context.Context, plan model.ImportPlan, actorID string) error
+	ApplyMembershipChange(ctx context.Context, plan model.MembershipPlan, actorID string) (*model.FullUserInfo, error)
 }
 
 type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*model.FullUserInfo, error)
-	SetIsActive(ctx context.Context, id string, isActive bool) (*model.FullUserInfo, error)
+	SetIsActive(ctx context.Context, id string, isActive bool, actorID string) (*model.FullUserInfo, error)
 	GetActiveTeamMembers(ctx context.Context, teamID int, excludeUserID string) ([]model.User, error)
+	GetMemberships(ctx context.Context, id string) ([]model.TeamMembership, error)
+	List(ctx context.Context, filter model.UserFilter) ([]model.FullUserInfo, error)
+	GetOpenReviewCount(ctx context.Context, id string) (int, error)
+	Count(ctx context.Context, filter model.UserFilter) (int, error)
+	Create(ctx context.Context, user model.User) (*model.FullUserInfo, error)
+	SetUsername(ctx context.Context, id, username string) (*model.FullUserInfo, error)
+	GetCredentials(ctx context.Context, id string) (*model.Credentials, error)
+	SetPasswordHash(ctx context.Context, id, passwordHash, actorID string) error
+	RecordLoginFailure(ctx context.Context, id string, maxAttempts int, lockout time.Duration) (*model.Credentials, error)
+	ResetLoginFailures(ctx context.Context, id string) error
+	SetRole(ctx context.Context, id string, role model.Role, actorID string) error
+	SetVacation(ctx context.Context, id string, onVacation bool) error
 }
 
 type PullRequestRepository interface {
 	Create(ctx context.Context, pr model.PullRequest) error
 	GetByID(ctx context.Context, id string) (*model.PullRequest, error)
-	Merge(ctx context.Context, id string) error
+	Merge(ctx context.Context, id, actorID string) error
 	GetByReviewerID(ctx context.Context, reviewerID string) ([]model.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, actorID string) error
+	GetByAuthorID(ctx context.Context, authorID string) ([]model.PullRequest, error)
+	Close(ctx context.Context, id, actorID string) error
+	Reopen(ctx context.Context, id, actorID string) error
+	ListOpenIDs(ctx context.Context) ([]string, error)
+	SetVerdict(ctx context.Context, prID, reviewerID string, verdict model.Verdict, actorID string) error
+	GetByIssueKey(ctx context.Context, issueKey string) ([]model.PullRequest, error)
+	ListNames(ctx context.Context) (map[string]string, error)
+	ReplaceIssueKeys(ctx context.Context, issueKeys map[string][]string) (int, error)
 }
 
 type StatsRepository interface {
 	GetReviewCountsByUser(ctx context.Context) (map[string]int, error)
+	GetTeamReviewCounts(ctx context.Context) ([]model.TeamReviewCount, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key model.APIKey, keyHash string) (*model.APIKey, error)
+	GetByID(ctx context.Context, id int) (*model.APIKey, error)
+	ListByUser(ctx context.Context, userID string) ([]model.APIKey, error)
+	Revoke(ctx context.Context, id int) (*model.APIKey, error)
+	Authenticate(ctx context.Context, keyHash string) (*model.APIKey, model.Role, error)
+}
+
+type TokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token model.RefreshToken, tokenHash string) (*model.RefreshToken, error)
+	GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id int) (bool, error)
+	RevokeRefreshFamily(ctx context.Context, familyID string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+type SigningKeyRepository interface {
+	Create(ctx context.Context, key model.SigningKey) error
+	ListUnexpired(ctx context.Context) ([]model.SigningKey, error)
+}
+
+type OIDCStateRepository interface {
+	Create(ctx context.Context, state model.OIDCState) error
+	Consume(ctx context.Context, state string) (*model.OIDCState, error)
+}
+
+type OIDCProvider interface {
+	AuthCodeURL(state, nonce, codeChallenge string) string
+	Exchange(ctx context.Context, code, codeVerifier string) (map[string]any, error)
+}
+
+type IdentityRepository interface {
+	Link(ctx context.Context, identity model.ExternalIdentity) error
+	Unlink(ctx context.Context, provider model.IdentityProvider, externalID string) error
+	Resolve(ctx context.Context, provider model.IdentityProvider, externalID string) (string, error)
+	List(ctx context.Context, provider model.IdentityProvider, userID string) ([]model.ExternalIdentity, error)
+}
+
+type WebhookDeliveryRepository interface {
+	Claim(ctx context.Context, provider model.IdentityProvider, deliveryID string, retention time.Duration) (bool, error)
+	Release(ctx context.Context, provider model.IdentityProvider, deliveryID string) error
+}
+
+type ReviewerSyncRepository interface {
+	Enqueue(ctx context.Context, prID string) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.ReviewerSyncJob, error)
+	Complete(ctx context.Context, prID string, generation int) error
+	Retry(ctx context.Context, prID string, generation int, nextAttemptAt time.Time, lastError string) error
+}
+
+type CodeHostClient interface {
+	Provider() model.IdentityProvider
+	Handles(prID string) bool
+	RequestedReviewers(ctx context.Context, prID string) ([]string, error)
+	RequestReviewers(ctx context.Context, prID string, logins []string) error
+	RemoveReviewers(ctx context.Context, prID string, logins []string) error
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, sub model.WebhookSubscription) (*model.WebhookSubscription, error)
+	List(ctx context.Context) ([]model.WebhookSubscription, error)
+	Delete(ctx context.Context, id int) error
+	Enqueue(ctx context.Context, event model.Event) (int, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.SubscriptionDelivery, error)
+	RecordAttempt(ctx context.Context, deliveryID int64, attempt model.DeliveryAttempt, status model.DeliveryStatus, nextAttemptAt time.Time) error
+	GetDelivery(ctx context.Context, id int64) (*model.SubscriptionDelivery, error)
+	ListDeliveries(ctx context.Context, filter model.DeliveryFilter) ([]model.SubscriptionDelivery, error)
+	Redeliver(ctx context.Context, id int64) error
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, delivery model.SubscriptionDelivery) (int, error)
+}
+
+type OutboxRepository interface {
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxRecord, error)
+	MarkPublished(ctx context.Context, id int64) error
+	Retry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+	MarkDead(ctx context.Context, id int64, lastError string) error
+	PurgePublished(ctx context.Context, olderThan time.Duration) (int, error)
+}
+
+type EventSink interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+type NotificationRepository interface {
+	GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error)
+	SetPreference(ctx context.Context, pref model.NotificationPreference) (*model.NotificationPreference, error)
+	ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error)
+	SetTemplate(ctx context.Context, tmpl model.NotificationTemplate) (*model.NotificationTemplate, error)
+	DeleteTemplate(ctx context.Context, kind model.NotificationKind) error
+	Enqueue(ctx context.Context, n model.Notification) (bool, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.Notification, error)
+	MarkSent(ctx context.Context, ids []int64) error
+	Retry(ctx context.Context, ids []int64, deliverAfter time.Time, lastError string) error
+	MarkFailed(ctx context.Context, ids []int64, lastError string) error
+	ListByUser(ctx context.Context, userID string, limit int) ([]model.Notification, error)
+}
+
+type Notifier interface {
+	Channel() model.NotificationChannel
+	Send(ctx context.Context, message model.NotificationMessage) error
+}
+
+type DigestRepository interface {
+	ListAssignedReviews(ctx context.Context, reviewerID string) ([]model.DigestPullRequest, error)
+	ListAwaitingReview(ctx context.Context, authorID string) ([]model.DigestPullRequest, error)
+	GetTeamDigest(ctx context.Context, teamID int, since, until time.Time) (*model.TeamDigest, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]model.DigestSubscription, error)
+	SetSubscription(ctx context.Context, sub model.DigestSubscription) (*model.DigestSubscription, error)
+	DeleteSubscription(ctx context.Context, userID string, kind model.DigestKind) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.DigestSubscription, error)
+	MarkSent(ctx context.Context, userID string, kind model.DigestKind, nextRunAt time.Time) error
+	Retry(ctx context.Context, userID string, kind model.DigestKind, nextRunAt time.Time, lastError string) error
+}
+
+type CalendarRepository interface {
+	CreateFeed(ctx context.Context, feed model.CalendarFeed) (*model.CalendarFeed, error)
+	GetFeed(ctx context.Context, id int64) (*model.CalendarFeed, error)
+	ListFeeds(ctx context.Context, filter model.CalendarFeedFilter) ([]model.CalendarFeed, error)
+	DeleteFeed(ctx context.Context, id int64) error
+	ReplaceAbsences(ctx context.Context, feedID int64, absences []model.Absence) error
+	ListAbsences(ctx context.Context, userID string, from, to time.Time) ([]model.Absence, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.CalendarFeed, error)
+	MarkSynced(ctx context.Context, feedID int64, nextSyncAt time.Time, lastError string) error
+}
+
+type CalendarFetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+type IssueTrackerRepository interface {
+	Enqueue(ctx context.Context, event model.Event) (int, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.IssueTrackerJob, error)
+	Complete(ctx context.Context, id int64) error
+	Retry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+}
+
+type IssueTracker interface {
+	PullRequestMerged(ctx context.Context, issueKey string, event model.Event) error
 }
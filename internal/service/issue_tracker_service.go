@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	issueTrackerBatch       = 20
+	issueTrackerLease       = 2 * time.Minute
+	issueTrackerPoll        = 5 * time.Second
+	issueTrackerBaseBackoff = 30 * time.Second
+	issueTrackerMaxBackoff  = time.Hour
+	issueTrackerMaxAttempts = 10
+)
+
+type IssueTrackerService struct {
+	tracker IssueTracker
+	jobRepo IssueTrackerRepository
+	wake    chan struct{}
+	now     func() time.Time
+}
+
+func NewIssueTrackerService(tracker IssueTracker, jobRepo IssueTrackerRepository) *IssueTrackerService {
+	return &IssueTrackerService{
+		tracker: tracker,
+		jobRepo: jobRepo,
+		wake:    make(chan struct{}, 1),
+		now:     time.Now,
+	}
+}
+
+func (s *IssueTrackerService) Publish(ctx context.Context, event model.Event) error {
+	if event.Type != model.EventPRMerged || len(event.PullRequest.IssueKeys) == 0 {
+		return nil
+	}
+
+	queued, err := s.jobRepo.Enqueue(ctx, event)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if queued > 0 {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (s *IssueTrackerService) ProcessDue(ctx context.Context) (int, error) {
+	jobs, err := s.jobRepo.ClaimDue(ctx, issueTrackerBatch, issueTrackerLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		pushErr := s.tracker.PullRequestMerged(ctx, job.IssueKey, job.Event)
+		if pushErr == nil || job.Attempts+1 >= issueTrackerMaxAttempts {
+			if pushErr != nil {
+				log.Printf("Giving up issue tracker update for %s (%s) after %d attempts: %v", job.IssueKey, job.Event.PullRequest.ID, job.Attempts+1, pushErr)
+			}
+			err = s.jobRepo.Complete(ctx, job.ID)
+		} else {
+			err = s.jobRepo.Retry(ctx, job.ID, s.now().Add(exponentialBackoff(job.Attempts, issueTrackerBaseBackoff, issueTrackerMaxBackoff)), pushErr.Error())
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(jobs), nil
+}
+
+func (s *IssueTrackerService) Run(ctx context.Context) {
+	poll := time.NewTicker(issueTrackerPoll)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-s.wake:
+		}
+
+		for {
+			processed, err := s.ProcessDue(ctx)
+			if err != nil {
+				log.Printf("Issue tracker sync failed: %v", err)
+			}
+			if err != nil || processed < issueTrackerBatch {
+				break
+			}
+		}
+	}
+}
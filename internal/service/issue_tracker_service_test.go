@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIssueTrackerService(t *testing.T) (*IssueTrackerService, *mocks.IssueTracker, *mocks.IssueTrackerRepository) {
+	tracker := mocks.NewIssueTracker(t)
+	jobs := mocks.NewIssueTrackerRepository(t)
+
+	trackerService := NewIssueTrackerService(tracker, jobs)
+	trackerService.now = func() time.Time { return time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC) }
+
+	return trackerService, tracker, jobs
+}
+
+func issueEvent(eventType model.EventType, issueKeys ...string) model.Event {
+	event := model.NewPullRequestEvent(eventType, "alice", model.PullRequest{ID: "pr-1", Name: "PROJ-1: fix", IssueKeys: issueKeys})
+	event.ID = 7
+	return event
+}
+
+func TestIssueTrackerService_Publish_QueuesMergedPullRequestsWithKeys(t *testing.T) {
+	trackerService, _, jobs := newTestIssueTrackerService(t)
+
+	require.NoError(t, trackerService.Publish(context.Background(), issueEvent(model.EventPRCreated, "PROJ-1")))
+	require.NoError(t, trackerService.Publish(context.Background(), issueEvent(model.EventPRMerged)))
+
+	merged := issueEvent(model.EventPRMerged, "PROJ-1", "OPS-2")
+	jobs.On("Enqueue", mock.Anything, merged).Return(2, nil).Once()
+	require.NoError(t, trackerService.Publish(context.Background(), merged))
+
+	select {
+	case <-trackerService.wake:
+	default:
+		t.Fatal("worker is not woken up")
+	}
+
+	jobs.On("Enqueue", mock.Anything, merged).Return(0, store.ErrNotFound).Once()
+	assert.NoError(t, trackerService.Publish(context.Background(), merged), "deleted pull requests are skipped")
+}
+
+func TestIssueTrackerService_ProcessDue(t *testing.T) {
+	trackerService, tracker, jobs := newTestIssueTrackerService(t)
+
+	event := issueEvent(model.EventPRMerged, "PROJ-1", "OPS-2", "OLD-3")
+	jobs.On("ClaimDue", mock.Anything, issueTrackerBatch, issueTrackerLease).Return([]model.IssueTrackerJob{
+		{ID: 1, IssueKey: "PROJ-1", Event: event},
+		{ID: 2, IssueKey: "OPS-2", Event: event, Attempts: 2},
+		{ID: 3, IssueKey: "OLD-3", Event: event, Attempts: issueTrackerMaxAttempts - 1},
+	}, nil)
+
+	unavailable := errors.New("jira api request failed: status 503")
+	tracker.On("PullRequestMerged", mock.Anything, "PROJ-1", event).Return(nil)
+	tracker.On("PullRequestMerged", mock.Anything, "OPS-2", event).Return(unavailable)
+	tracker.On("PullRequestMerged", mock.Anything, "OLD-3", event).Return(unavailable)
+
+	jobs.On("Complete", mock.Anything, int64(1)).Return(nil)
+	jobs.On("Retry", mock.Anything, int64(2), trackerService.now().Add(2*time.Minute), unavailable.Error()).Return(nil)
+	jobs.On("Complete", mock.Anything, int64(3)).Return(nil)
+
+	processed, err := trackerService.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+}
+
+func TestNewService_RegistersIssueTrackerSink(t *testing.T) {
+	s := NewService(Dependencies{
+		OutboxRepo:       mocks.NewOutboxRepository(t),
+		IssueTracker:     mocks.NewIssueTracker(t),
+		IssueTrackerRepo: mocks.NewIssueTrackerRepository(t),
+	})
+
+	require.NotNil(t, s.IssueTracker)
+	assert.Contains(t, s.Outbox.sinks, EventSink(s.IssueTracker))
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	defaultKeyRotationInterval = 30 * 24 * time.Hour
+	keyVerificationGrace       = 24 * time.Hour
+	keyRotationCheck           = time.Hour
+	keyCacheTTL                = time.Minute
+	keyReloadBackoff           = 5 * time.Second
+	rsaKeyBits                 = 2048
+	keyIDBytes                 = 12
+)
+
+type KeyService struct {
+	keyRepo   SigningKeyRepository
+	algorithm model.SigningAlgorithm
+	rotation  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	keys     []model.SigningKey
+	loadedAt time.Time
+}
+
+func NewKeyService(keyRepo SigningKeyRepository, algorithm model.SigningAlgorithm, rotation time.Duration) *KeyService {
+	if algorithm == "" {
+		algorithm = model.SigningHS256
+	}
+	if rotation <= 0 {
+		rotation = defaultKeyRotationInterval
+	}
+
+	return &KeyService{
+		keyRepo:   keyRepo,
+		algorithm: algorithm,
+		rotation:  rotation,
+		now:       time.Now,
+	}
+}
+
+func (s *KeyService) Algorithm() model.SigningAlgorithm {
+	return s.algorithm
+}
+
+func (s *KeyService) SigningKey(ctx context.Context) (*model.SigningKey, error) {
+	if !s.algorithm.IsAsymmetric() {
+		return nil, ErrNoSigningKey
+	}
+
+	keys, err := s.cachedKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if key := s.activeKey(keys); key != nil {
+		return key, nil
+	}
+
+	if err := s.Rotate(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err = s.cachedKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if key := s.activeKey(keys); key != nil {
+		return key, nil
+	}
+
+	return nil, ErrNoSigningKey
+}
+
+func (s *KeyService) VerificationKey(ctx context.Context, kid string) (*model.SigningKey, error) {
+	if !s.algorithm.IsAsymmetric() {
+		return nil, ErrNoSigningKey
+	}
+
+	keys, err := s.cachedKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if key := s.findKey(keys, kid); key != nil {
+		return key, nil
+	}
+
+	keys, err = s.cachedKeys(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if key := s.findKey(keys, kid); key != nil {
+		return key, nil
+	}
+
+	return nil, ErrNoSigningKey
+}
+
+func (s *KeyService) PublicKeys(ctx context.Context) ([]model.SigningKey, error) {
+	if !s.algorithm.IsAsymmetric() {
+		return []model.SigningKey{}, nil
+	}
+
+	keys, err := s.cachedKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	published := make([]model.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if key.ExpiresAt.After(now) {
+			published = append(published, key)
+		}
+	}
+
+	return published, nil
+}
+
+func (s *KeyService) Rotate(ctx context.Context) error {
+	if !s.algorithm.IsAsymmetric() {
+		return nil
+	}
+
+	keys, err := s.reload(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	current := s.activeKey(keys)
+	if current == nil {
+		current, err = s.createKey(ctx, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	publishAhead := s.rotation / 4
+	if !now.Before(current.RetiresAt.Add(-publishAhead)) && !s.hasSuccessor(keys, current.RetiresAt) {
+		if _, err := s.createKey(ctx, current.RetiresAt); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.reload(ctx)
+	return err
+}
+
+func (s *KeyService) RunRotation(ctx context.Context) {
+	interval := min(s.rotation/4, keyRotationCheck)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Rotate(ctx); err != nil {
+				log.Printf("Signing key rotation failed: %v", err)
+			}
+		}
+	}
+}
+
+func (s *KeyService) createKey(ctx context.Context, activatesAt time.Time) (*model.SigningKey, error) {
+	signer, err := generateSigner(s.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := randomToken(keyIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	retiresAt := activatesAt.Add(s.rotation)
+	key := model.SigningKey{
+		KID:         kid,
+		Algorithm:   s.algorithm,
+		PrivateKey:  signer,
+		ActivatesAt: activatesAt,
+		RetiresAt:   retiresAt,
+		ExpiresAt:   retiresAt.Add(keyVerificationGrace),
+	}
+
+	if err := s.keyRepo.Create(ctx, key); err != nil && !errors.Is(err, store.ErrSigningKeyExists) {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func generateSigner(algorithm model.SigningAlgorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case model.SigningRS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case model.SigningEdDSA:
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		return private, err
+	}
+
+	return nil, ErrNoSigningKey
+}
+
+func (s *KeyService) cachedKeys(ctx context.Context, force bool) ([]model.SigningKey, error) {
+	s.mu.Lock()
+	keys, loadedAt := s.keys, s.loadedAt
+	s.mu.Unlock()
+
+	age := s.now().Sub(loadedAt)
+	if loadedAt.IsZero() || age >= keyCacheTTL || (force && age >= keyReloadBackoff) {
+		return s.reload(ctx)
+	}
+
+	return keys, nil
+}
+
+func (s *KeyService) reload(ctx context.Context) ([]model.SigningKey, error) {
+	keys, err := s.keyRepo.ListUnexpired(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+
+	return keys, nil
+}
+
+func (s *KeyService) activeKey(keys []model.SigningKey) *model.SigningKey {
+	now := s.now()
+
+	var active *model.SigningKey
+	for i, key := range keys {
+		if key.Algorithm != s.algorithm || now.Before(key.ActivatesAt) || !now.Before(key.RetiresAt) {
+			continue
+		}
+		if active == nil || key.ActivatesAt.After(active.ActivatesAt) {
+			active = &keys[i]
+		}
+	}
+
+	return active
+}
+
+func (s *KeyService) hasSuccessor(keys []model.SigningKey, from time.Time) bool {
+	for _, key := range keys {
+		if key.Algorithm == s.algorithm && !key.ActivatesAt.Before(from) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *KeyService) findKey(keys []model.SigningKey, kid string) *model.SigningKey {
+	now := s.now()
+	for i, key := range keys {
+		if key.KID == kid && key.ExpiresAt.After(now) {
+			return &keys[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testSigningKey(t *testing.T, kid string, activatesAt time.Time, rotation time.Duration) model.SigningKey {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return model.SigningKey{
+		KID:         kid,
+		Algorithm:   model.SigningEdDSA,
+		PrivateKey:  private,
+		ActivatesAt: activatesAt,
+		RetiresAt:   activatesAt.Add(rotation),
+		ExpiresAt:   activatesAt.Add(rotation + keyVerificationGrace),
+	}
+}
+
+func TestKeyService_Rotate_CreatesInitialKey(t *testing.T) {
+	mockKeyRepo := mocks.NewSigningKeyRepository(t)
+
+	now := time.Now()
+	var created model.SigningKey
+	mockKeyRepo.On("ListUnexpired", mock.Anything).Return([]model.SigningKey{}, nil).Once()
+	mockKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("model.SigningKey")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(model.SigningKey) }).
+		Return(nil).Once()
+	mockKeyRepo.On("ListUnexpired", mock.Anything).Return(func(context.Context) []model.SigningKey {
+		return []model.SigningKey{created}
+	}, nil)
+
+	keyService := NewKeyService(mockKeyRepo, model.SigningEdDSA, time.Hour)
+	keyService.now = func() time.Time { return now }
+
+	require.NoError(t, keyService.Rotate(context.Background()))
+
+	assert.Equal(t, model.SigningEdDSA, created.Algorithm)
+	assert.Equal(t, now, created.ActivatesAt)
+	assert.Equal(t, now.Add(time.Hour), created.RetiresAt)
+
+	key, err := keyService.SigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, created.KID, key.KID)
+}
+
+func TestKeyService_Rotate_PublishesSuccessorAhead(t *testing.T) {
+	mockKeyRepo := mocks.NewSigningKeyRepository(t)
+
+	now := time.Now()
+	current := testSigningKey(t, "current", now.Add(-50*time.Minute), time.Hour)
+
+	var successor model.SigningKey
+	mockKeyRepo.On("ListUnexpired", mock.Anything).Return([]model.SigningKey{current}, nil)
+	mockKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("model.SigningKey")).
+		Run(func(args mock.Arguments) { successor = args.Get(1).(model.SigningKey) }).
+		Return(nil).Once()
+
+	keyService := NewKeyService(mockKeyRepo, model.SigningEdDSA, time.Hour)
+	keyService.now = func() time.Time { return now }
+
+	require.NoError(t, keyService.Rotate(context.Background()))
+
+	assert.Equal(t, current.RetiresAt, successor.ActivatesAt)
+
+	key, err := keyService.SigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "current", key.KID)
+}
+
+func TestKeyService_Rotate_NothingToDo(t *testing.T) {
+	mockKeyRepo := mocks.NewSigningKeyRepository(t)
+
+	now := time.Now()
+	current := testSigningKey(t, "current", now.Add(-10*time.Minute), time.Hour)
+	mockKeyRepo.On("ListUnexpired", mock.Anything).Return([]model.SigningKey{current}, nil)
+
+	keyService := NewKeyService(mockKeyRepo, model.SigningEdDSA, time.Hour)
+	keyService.now = func() time.Time { return now }
+
+	require.NoError(t, keyService.Rotate(context.Background()))
+	mockKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestKeyService_VerificationKey(t *testing.T) {
+	mockKeyRepo := mocks.NewSigningKeyRepository(t)
+
+	now := time.Now()
+	retired := testSigningKey(t, "retired", now.Add(-90*time.Minute), time.Hour)
+	current := testSigningKey(t, "current", now.Add(-30*time.Minute), time.Hour)
+	mockKeyRepo.On("ListUnexpired", mock.Anything).Return([]model.SigningKey{retired, current}, nil).Once()
+
+	keyService := NewKeyService(mockKeyRepo, model.SigningEdDSA, time.Hour)
+	keyService.now = func() time.Time { return now }
+
+	key, err := keyService.VerificationKey(context.Background(), "retired")
+	require.NoError(t, err)
+	assert.Equal(t, "retired", key.KID)
+
+	signing, err := keyService.SigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "current", signing.KID)
+
+	_, err = keyService.VerificationKey(context.Background(), "unknown")
+	assert.Equal(t, ErrNoSigningKey, err)
+
+	now = now.Add(2 * keyReloadBackoff)
+	mockKeyRepo.On("ListUnexpired", mock.Anything).Return([]model.SigningKey{retired, current}, nil).Once()
+
+	_, err = keyService.VerificationKey(context.Background(), "unknown")
+	assert.Equal(t, ErrNoSigningKey, err)
+}
+
+func TestKeyService_HS256HasNoKeys(t *testing.T) {
+	keyService := NewKeyService(nil, "", 0)
+
+	assert.Equal(t, model.SigningHS256, keyService.Algorithm())
+	assert.NoError(t, keyService.Rotate(context.Background()))
+
+	keys, err := keyService.PublicKeys(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = keyService.SigningKey(context.Background())
+	assert.Equal(t, ErrNoSigningKey, err)
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+type MembershipService struct {
+	teamRepo TeamRepository
+	userRepo UserRepository
+	prRepo   PullRequestRepository
+	rnd      *rand.Rand
+}
+
+func NewMembershipService(teamRepo TeamRepository, userRepo UserRepository, prRepo PullRequestRepository) *MembershipService {
+	return &MembershipService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *MembershipService) AddMember(ctx context.Context, teamName string, member model.User) (*model.FullUserInfo, error) {
+	if err := authorizeTeamChange(ctx, s.userRepo, teamName); err != nil {
+		return nil, err
+	}
+
+	user, err := s.teamRepo.AddMember(ctx, teamName, member, actorID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		if errors.Is(err, store.ErrUserInAnotherTeam) {
+			return nil, ErrUserInAnotherTeam
+		}
+		if errors.Is(err, store.ErrTeamArchived) {
+			return nil, ErrTeamArchived
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *MembershipService) RemoveMember(ctx context.Context, teamName, userID string, reviews model.ReviewPolicy) (*model.MembershipChange, error) {
+	if err := authorizeTeamChange(ctx, s.userRepo, teamName); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.userRepo.GetMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamID := 0
+	for _, m := range memberships {
+		if m.TeamName == teamName {
+			teamID = m.TeamID
+			break
+		}
+	}
+
+	if teamID == 0 {
+		return nil, ErrNotFound
+	}
+
+	plan := model.MembershipPlan{UserID: userID, LeaveTeamID: teamID}
+	if reviews == model.ReviewPolicyReassign {
+		plan.Reassignments, err = s.planReassignments(ctx, *user, teamID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.apply(ctx, plan)
+}
+
+func (s *MembershipService) MoveMember(ctx context.Context, userID, teamName string, reviews model.ReviewPolicy, authored model.AuthoredPolicy) (*model.MembershipChange, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	affectedTeams := []string{teamName}
+	if user.TeamName != "" {
+		affectedTeams = append(affectedTeams, user.TeamName)
+	}
+	if err := authorizeTeamChange(ctx, s.userRepo, affectedTeams...); err != nil {
+		return nil, err
+	}
+
+	team, _, err := s.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if team.ArchivedAt != nil {
+		return nil, ErrTeamArchived
+	}
+
+	if user.TeamID == team.ID {
+		return &model.MembershipChange{User: *user}, nil
+	}
+
+	plan := model.MembershipPlan{UserID: userID, JoinTeamID: team.ID}
+	if reviews == model.ReviewPolicyReassign && user.TeamID != 0 {
+		plan.Reassignments, err = s.planReassignments(ctx, *user, user.TeamID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if authored == model.AuthoredPolicyTransfer {
+		plan.Transfers, err = s.planTransfers(ctx, userID, user.TeamID, team.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.apply(ctx, plan)
+}
+
+func (s *MembershipService) SetSecondaryMember(ctx context.Context, teamName, userID string, reviewable bool) (*model.TeamMembership, error) {
+	if err := authorizeTeamChange(ctx, s.userRepo, teamName); err != nil {
+		return nil, err
+	}
+
+	membership, err := s.teamRepo.SetSecondaryMember(ctx, teamName, userID, reviewable, actorID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		if errors.Is(err, store.ErrPrimaryTeam) {
+			return nil, ErrPrimaryTeam
+		}
+		if errors.Is(err, store.ErrTeamArchived) {
+			return nil, ErrTeamArchived
+		}
+
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+func (s *MembershipService) GetMemberships(ctx context.Context, userID string) ([]model.TeamMembership, error) {
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.GetMemberships(ctx, userID)
+}
+
+func (s *MembershipService) Deprovision(ctx context.Context, userID string) (*model.MembershipChange, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.userRepo.GetMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := model.MembershipPlan{UserID: userID, Deactivate: true}
+	for _, m := range memberships {
+		reassignments, err := s.planReassignments(ctx, *user, m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		plan.Reassignments = append(plan.Reassignments, reassignments...)
+	}
+
+	return s.apply(ctx, plan)
+}
+
+func (s *MembershipService) apply(ctx context.Context, plan model.MembershipPlan) (*model.MembershipChange, error) {
+	user, err := s.teamRepo.ApplyMembershipChange(ctx, plan, actorID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	change := &model.MembershipChange{User: *user, Reassignments: plan.Reassignments}
+	for _, transfer := range plan.Transfers {
+		change.TransferredPRs = append(change.TransferredPRs, transfer.PullRequestID)
+	}
+
+	return change, nil
+}
+
+func (s *MembershipService) getUser(ctx context.Context, userID string) (*model.FullUserInfo, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *MembershipService) planReassignments(ctx context.Context, user model.FullUserInfo, teamID int) ([]model.ReviewReassignment, error) {
+	prs, err := s.prRepo.GetByReviewerID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMembers, err := s.userRepo.GetActiveTeamMembers(ctx, teamID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reassignments []model.ReviewReassignment
+	for _, short := range prs {
+		if short.Status != model.StatusOpen {
+			continue
+		}
+
+		pr, err := s.prRepo.GetByID(ctx, short.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		prTeamID := pr.TeamID
+		if prTeamID == 0 {
+			prTeamID = user.TeamID
+		}
+
+		if prTeamID != teamID {
+			continue
+		}
+
+		forbiddenIDs := make(map[string]struct{})
+		forbiddenIDs[pr.AuthorID] = struct{}{}
+		for _, reviewer := range pr.AssignedReviewers {
+			forbiddenIDs[reviewer] = struct{}{}
+		}
+
+		var candidates []model.User
+		for _, member := range teamMembers {
+			if _, isForbidden := forbiddenIDs[member.ID]; !isForbidden {
+				candidates = append(candidates, member)
+			}
+		}
+
+		reassignment := model.ReviewReassignment{PullRequestID: pr.ID, OldReviewerID: user.ID}
+		if picked := pickReviewers(s.rnd, candidates, 1); len(picked) > 0 {
+			reassignment.NewReviewerID = picked[0]
+		}
+
+		reassignments = append(reassignments, reassignment)
+	}
+
+	return reassignments, nil
+}
+
+func (s *MembershipService) planTransfers(ctx context.Context, authorID string, fromTeamID, toTeamID int) ([]model.PullRequestTransfer, error) {
+	prs, err := s.prRepo.GetByAuthorID(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.userRepo.GetActiveTeamMembers(ctx, toTeamID, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []model.PullRequestTransfer
+	for _, pr := range prs {
+		if pr.Status != model.StatusOpen {
+			continue
+		}
+
+		if pr.TeamID != 0 && pr.TeamID != fromTeamID {
+			continue
+		}
+
+		transfers = append(transfers, model.PullRequestTransfer{
+			PullRequestID: pr.ID,
+			ReviewerIDs:   pickReviewers(s.rnd, candidates, maxReviewers),
+		})
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipService_AddMember_FailsIfUserInAnotherTeam(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	member := model.User{ID: "u1", Username: "Alice", IsActive: true}
+	mockTeamRepo.On("AddMember", mock.Anything, "backend", member, "admin").Return(nil, store.ErrUserInAnotherTeam)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	_, err := membershipService.AddMember(testAdminContext(), "backend", member)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrUserInAnotherTeam, err)
+}
+
+func TestMembershipService_RemoveMember_FailsIfNotInTeam(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "frontend"}
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "u1").Return([]model.TeamMembership{
+		{TeamID: 1, TeamName: "frontend", UserID: "u1", IsPrimary: true, Reviewable: true},
+	}, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	_, err := membershipService.RemoveMember(testAdminContext(), "backend", "u1", model.ReviewPolicyKeep)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrNotFound, err)
+	mockTeamRepo.AssertNotCalled(t, "ApplyMembershipChange", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMembershipService_RemoveMember_ReassignsOpenReviews(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "backend"}
+	removedUser := &model.FullUserInfo{User: model.User{ID: "u1"}}
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil).Once()
+	mockUserRepo.On("GetMemberships", mock.Anything, "u1").Return([]model.TeamMembership{
+		{TeamID: 1, TeamName: "backend", UserID: "u1", IsPrimary: true, Reviewable: true},
+	}, nil)
+	mockPRRepo.On("GetByReviewerID", mock.Anything, "u1").Return([]model.PullRequest{
+		{ID: "pr-open", Status: model.StatusOpen},
+		{ID: "pr-merged", Status: model.StatusMerged},
+	}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 1, "u1").Return([]model.User{
+		{ID: "author"}, {ID: "u2"}, {ID: "u3"},
+	}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-open").Return(&model.PullRequest{
+		ID: "pr-open", AuthorID: "author", Status: model.StatusOpen, AssignedReviewers: []string{"u1", "u2"},
+	}, nil)
+	mockTeamRepo.On("ApplyMembershipChange", mock.Anything, model.MembershipPlan{
+		UserID:        "u1",
+		LeaveTeamID:   1,
+		Reassignments: []model.ReviewReassignment{{PullRequestID: "pr-open", OldReviewerID: "u1", NewReviewerID: "u3"}},
+	}, "admin").Return(removedUser, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	change, err := membershipService.RemoveMember(testAdminContext(), "backend", "u1", model.ReviewPolicyReassign)
+
+	require.NoError(t, err)
+	assert.Equal(t, *removedUser, change.User)
+	assert.Equal(t, []model.ReviewReassignment{
+		{PullRequestID: "pr-open", OldReviewerID: "u1", NewReviewerID: "u3"},
+	}, change.Reassignments)
+}
+
+func TestMembershipService_MoveMember_UnassignsIfNoCandidates(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "backend"}
+	movedUser := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 2}, TeamName: "frontend"}
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "frontend").Return(&model.Team{ID: 2, Name: "frontend"}, nil, nil)
+	mockPRRepo.On("GetByReviewerID", mock.Anything, "u1").Return([]model.PullRequest{{ID: "pr-1", Status: model.StatusOpen}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 1, "u1").Return([]model.User{}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&model.PullRequest{
+		ID: "pr-1", AuthorID: "author", Status: model.StatusOpen, AssignedReviewers: []string{"u1"},
+	}, nil)
+	mockTeamRepo.On("ApplyMembershipChange", mock.Anything, model.MembershipPlan{
+		UserID:        "u1",
+		JoinTeamID:    2,
+		Reassignments: []model.ReviewReassignment{{PullRequestID: "pr-1", OldReviewerID: "u1"}},
+	}, "admin").Return(movedUser, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	change, err := membershipService.MoveMember(testAdminContext(), "u1", "frontend", model.ReviewPolicyReassign, model.AuthoredPolicyKeep)
+
+	require.NoError(t, err)
+	assert.Equal(t, *movedUser, change.User)
+	require.Len(t, change.Reassignments, 1)
+	assert.Empty(t, change.Reassignments[0].NewReviewerID)
+}
+
+func TestMembershipService_MoveMember_TransfersAuthoredPRs(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "backend"}
+	movedUser := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 2}, TeamName: "frontend"}
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "frontend").Return(&model.Team{ID: 2, Name: "frontend"}, nil, nil)
+	mockPRRepo.On("GetByAuthorID", mock.Anything, "u1").Return([]model.PullRequest{
+		{ID: "pr-open", TeamID: 1, Status: model.StatusOpen},
+		{ID: "pr-other-team", TeamID: 3, Status: model.StatusOpen},
+		{ID: "pr-merged", TeamID: 1, Status: model.StatusMerged},
+		{ID: "pr-legacy", Status: model.StatusOpen},
+	}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 2, "u1").Return([]model.User{{ID: "f1"}}, nil).Once()
+	mockTeamRepo.On("ApplyMembershipChange", mock.Anything, model.MembershipPlan{
+		UserID:     "u1",
+		JoinTeamID: 2,
+		Transfers: []model.PullRequestTransfer{
+			{PullRequestID: "pr-open", ReviewerIDs: []string{"f1"}},
+			{PullRequestID: "pr-legacy", ReviewerIDs: []string{"f1"}},
+		},
+	}, "admin").Return(movedUser, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	change, err := membershipService.MoveMember(testAdminContext(), "u1", "frontend", model.ReviewPolicyKeep, model.AuthoredPolicyTransfer)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-open", "pr-legacy"}, change.TransferredPRs)
+	mockPRRepo.AssertNotCalled(t, "GetByReviewerID", mock.Anything, mock.Anything)
+}
+
+func TestMembershipService_MoveMember_NoopIfAlreadyInTeam(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "backend"}
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(&model.Team{ID: 1, Name: "backend"}, nil, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	change, err := membershipService.MoveMember(testAdminContext(), "u1", "backend", model.ReviewPolicyReassign, model.AuthoredPolicyTransfer)
+
+	require.NoError(t, err)
+	assert.Equal(t, *user, change.User)
+	mockTeamRepo.AssertNotCalled(t, "ApplyMembershipChange", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMembershipService_RemoveMember_SecondaryOnlyReassignsThatTeam(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "backend"}
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "u1").Return([]model.TeamMembership{
+		{TeamID: 1, TeamName: "backend", UserID: "u1", IsPrimary: true, Reviewable: true},
+		{TeamID: 2, TeamName: "platform", UserID: "u1", Reviewable: true},
+	}, nil)
+	mockPRRepo.On("GetByReviewerID", mock.Anything, "u1").Return([]model.PullRequest{
+		{ID: "pr-backend", Status: model.StatusOpen},
+		{ID: "pr-platform", Status: model.StatusOpen},
+	}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 2, "u1").Return([]model.User{{ID: "p1"}}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-backend").Return(&model.PullRequest{
+		ID: "pr-backend", AuthorID: "author", TeamID: 1, Status: model.StatusOpen, AssignedReviewers: []string{"u1"},
+	}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-platform").Return(&model.PullRequest{
+		ID: "pr-platform", AuthorID: "author", TeamID: 2, Status: model.StatusOpen, AssignedReviewers: []string{"u1"},
+	}, nil)
+	mockTeamRepo.On("ApplyMembershipChange", mock.Anything, model.MembershipPlan{
+		UserID:        "u1",
+		LeaveTeamID:   2,
+		Reassignments: []model.ReviewReassignment{{PullRequestID: "pr-platform", OldReviewerID: "u1", NewReviewerID: "p1"}},
+	}, "admin").Return(user, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	change, err := membershipService.RemoveMember(testAdminContext(), "platform", "u1", model.ReviewPolicyReassign)
+
+	require.NoError(t, err)
+	assert.Equal(t, []model.ReviewReassignment{
+		{PullRequestID: "pr-platform", OldReviewerID: "u1", NewReviewerID: "p1"},
+	}, change.Reassignments)
+}
+
+func TestMembershipService_SetSecondaryMember_FailsOnPrimaryTeam(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	mockTeamRepo.On("SetSecondaryMember", mock.Anything, "backend", "u1", false, "admin").Return(nil, store.ErrPrimaryTeam)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	_, err := membershipService.SetSecondaryMember(testAdminContext(), "backend", "u1", false)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrPrimaryTeam, err)
+}
+
+func TestMembershipService_MoveMember_FailsIfTeamArchived(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	archivedAt := time.Now()
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "frontend"}
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "legacy").Return(&model.Team{ID: 2, Name: "legacy", ArchivedAt: &archivedAt}, []model.User{}, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	_, err := membershipService.MoveMember(testAdminContext(), "u1", "legacy", model.ReviewPolicyKeep, model.AuthoredPolicyKeep)
+
+	assert.Equal(t, ErrTeamArchived, err)
+	mockTeamRepo.AssertNotCalled(t, "ApplyMembershipChange", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMembershipService_MoveMember_LeadMustLeadBothTeams(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", TeamID: 1}, TeamName: "frontend"}
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "lead").Return([]model.TeamMembership{
+		{TeamID: 2, TeamName: "backend", UserID: "lead", IsPrimary: true},
+	}, nil)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	lead := WithPrincipal(context.Background(), model.Principal{UserID: "lead", Role: model.RoleTeamLead})
+	_, err := membershipService.MoveMember(lead, "u1", "backend", model.ReviewPolicyKeep, model.AuthoredPolicyKeep)
+
+	assert.Equal(t, ErrForbidden, err)
+	mockTeamRepo.AssertNotCalled(t, "ApplyMembershipChange", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMembershipService_AddMember_ForbiddenForMembers(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	membershipService := NewMembershipService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	member := WithPrincipal(context.Background(), model.Principal{UserID: "u2", Role: model.RoleMember})
+	_, err := membershipService.AddMember(member, "backend", model.User{ID: "u1"})
+
+	assert.Equal(t, ErrForbidden, err)
+}
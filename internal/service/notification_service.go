@@ -0,0 +1,525 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/netguard"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	notificationBatch       = 50
+	notificationLease       = time.Minute
+	notificationPoll        = 10 * time.Second
+	notificationBaseBackoff = 30 * time.Second
+	notificationMaxBackoff  = time.Hour
+	notificationMaxAttempts = 6
+	defaultDigestTime       = "09:00"
+	clockLayout             = "15:04"
+)
+
+type notificationRecipient struct {
+	userID string
+	kind   model.NotificationKind
+}
+
+type NotificationService struct {
+	notificationRepo NotificationRepository
+	userRepo         UserRepository
+	notifiers        map[model.NotificationChannel]Notifier
+	wake             chan struct{}
+	now              func() time.Time
+}
+
+func NewNotificationService(notificationRepo NotificationRepository, userRepo UserRepository, notifiers ...Notifier) *NotificationService {
+	byChannel := make(map[model.NotificationChannel]Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		byChannel[notifier.Channel()] = notifier
+	}
+
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		notifiers:        byChannel,
+		wake:             make(chan struct{}, 1),
+		now:              time.Now,
+	}
+}
+
+func (s *NotificationService) GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error) {
+	userID, err := resolveSelfOrAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := s.notificationRepo.GetPreference(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+func (s *NotificationService) SetPreference(ctx context.Context, pref model.NotificationPreference) (*model.NotificationPreference, error) {
+	userID, err := resolveSelfOrAdmin(ctx, pref.UserID)
+	if err != nil {
+		return nil, err
+	}
+	pref.UserID = userID
+
+	if pref.Mode == "" {
+		pref.Mode = model.NotifyImmediately
+	}
+	if pref.Timezone == "" {
+		pref.Timezone = "UTC"
+	}
+	if pref.DigestTime == "" {
+		pref.DigestTime = defaultDigestTime
+	}
+
+	if err := s.validatePreference(pref); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.notificationRepo.SetPreference(ctx, pref)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s *NotificationService) validatePreference(pref model.NotificationPreference) error {
+	if _, ok := s.notifiers[pref.Channel]; !ok {
+		return ErrInvalidPreference
+	}
+
+	switch pref.Channel {
+	case model.ChannelEmail:
+		if _, err := mail.ParseAddress(pref.Address); err != nil {
+			return ErrInvalidPreference
+		}
+	default:
+		target, err := url.Parse(pref.Address)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" || !netguard.IsPublicHost(target.Hostname()) {
+			return ErrInvalidPreference
+		}
+	}
+
+	if !pref.Mode.IsValid() {
+		return ErrInvalidPreference
+	}
+
+	if _, err := time.LoadLocation(pref.Timezone); err != nil {
+		return ErrInvalidPreference
+	}
+
+	if _, err := time.Parse(clockLayout, pref.DigestTime); err != nil {
+		return ErrInvalidPreference
+	}
+
+	if (pref.QuietHoursStart == "") != (pref.QuietHoursEnd == "") {
+		return ErrInvalidPreference
+	}
+	if pref.QuietHoursStart != "" {
+		start, err := time.Parse(clockLayout, pref.QuietHoursStart)
+		if err != nil {
+			return ErrInvalidPreference
+		}
+		end, err := time.Parse(clockLayout, pref.QuietHoursEnd)
+		if err != nil || start.Equal(end) {
+			return ErrInvalidPreference
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, limit int) ([]model.Notification, error) {
+	userID, err := resolveSelfOrAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.notificationRepo.ListByUser(ctx, userID, pageLimit(limit))
+}
+
+func (s *NotificationService) ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.loadTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.NotificationTemplate, len(model.NotificationKinds))
+	for i, kind := range model.NotificationKinds {
+		result[i] = templates[kind]
+	}
+
+	return result, nil
+}
+
+func (s *NotificationService) SetTemplate(ctx context.Context, tmpl model.NotificationTemplate) (*model.NotificationTemplate, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if !tmpl.Kind.IsValid() {
+		return nil, ErrInvalidTemplate
+	}
+
+	if _, _, err := renderNotification(tmpl, sampleNotificationData); err != nil {
+		return nil, err
+	}
+	tmpl.UpdatedBy = actorID(ctx)
+
+	return s.notificationRepo.SetTemplate(ctx, tmpl)
+}
+
+func (s *NotificationService) ResetTemplate(ctx context.Context, kind model.NotificationKind) (*model.NotificationTemplate, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if !kind.IsValid() {
+		return nil, ErrInvalidTemplate
+	}
+
+	if err := s.notificationRepo.DeleteTemplate(ctx, kind); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	tmpl := defaultNotificationTemplates[kind]
+	return &tmpl, nil
+}
+
+func (s *NotificationService) loadTemplates(ctx context.Context) (map[model.NotificationKind]model.NotificationTemplate, error) {
+	custom, err := s.notificationRepo.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[model.NotificationKind]model.NotificationTemplate, len(defaultNotificationTemplates))
+	for kind, tmpl := range defaultNotificationTemplates {
+		templates[kind] = tmpl
+	}
+	for _, tmpl := range custom {
+		if tmpl.Kind.IsValid() {
+			templates[tmpl.Kind] = tmpl
+		}
+	}
+
+	return templates, nil
+}
+
+func (s *NotificationService) render(templates map[model.NotificationKind]model.NotificationTemplate, kind model.NotificationKind, data notificationData) (string, string) {
+	subject, body, err := renderNotification(templates[kind], data)
+	if err == nil {
+		return subject, body
+	}
+
+	log.Printf("Notification template %s failed, using the default: %v", kind, err)
+	subject, body, _ = renderNotification(defaultNotificationTemplates[kind], data)
+	return subject, body
+}
+
+func (s *NotificationService) Publish(ctx context.Context, event model.Event) error {
+	recipients := notificationRecipients(event)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	templates, err := s.loadTemplates(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	wake := false
+	for _, recipient := range recipients {
+		pref, err := s.notificationRepo.GetPreference(ctx, recipient.userID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, ok := s.notifiers[pref.Channel]; !ok || !pref.Enabled {
+			continue
+		}
+
+		user, err := s.userRepo.GetByID(ctx, recipient.userID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		data := notificationData{
+			Recipient:   user.User,
+			ActorID:     event.ActorID,
+			PullRequest: event.PullRequest,
+		}
+		if event.Reassignment != nil {
+			data.OldReviewerID = event.Reassignment.OldReviewerID
+		}
+		if event.Review != nil {
+			data.ReviewerID = event.Review.ReviewerID
+			data.Verdict = event.Review.Verdict
+		}
+
+		subject, body := s.render(templates, recipient.kind, data)
+		notification := model.Notification{
+			EventID:       event.ID,
+			UserID:        recipient.userID,
+			Kind:          recipient.kind,
+			PullRequestID: event.PullRequest.ID,
+			Channel:       pref.Channel,
+			Address:       pref.Address,
+			Digest:        pref.Mode == model.NotifyInDigest,
+			Subject:       subject,
+			Body:          body,
+			DeliverAfter:  deliverAfter(*pref, now),
+		}
+
+		queued, err := s.notificationRepo.Enqueue(ctx, notification)
+		if err != nil {
+			return err
+		}
+		if queued && !notification.DeliverAfter.After(now) {
+			wake = true
+		}
+	}
+
+	if wake {
+		s.notify()
+	}
+
+	return nil
+}
+
+func notificationRecipients(event model.Event) []notificationRecipient {
+	var recipients []notificationRecipient
+	seen := map[string]struct{}{event.ActorID: {}}
+	add := func(userID string, kind model.NotificationKind) {
+		if _, ok := seen[userID]; ok || userID == "" {
+			return
+		}
+		seen[userID] = struct{}{}
+		recipients = append(recipients, notificationRecipient{userID: userID, kind: kind})
+	}
+
+	switch event.Type {
+	case model.EventPRCreated, model.EventPRTransferred:
+		for _, reviewerID := range event.PullRequest.AssignedReviewers {
+			add(reviewerID, model.NotificationReviewRequested)
+		}
+	case model.EventReviewerReassigned:
+		if event.Reassignment != nil {
+			add(event.Reassignment.NewReviewerID, model.NotificationReviewReassigned)
+		}
+	case model.EventPRReviewed:
+		add(event.PullRequest.AuthorID, model.NotificationReviewSubmitted)
+	case model.EventPRMerged:
+		add(event.PullRequest.AuthorID, model.NotificationPRMerged)
+		for _, reviewerID := range event.PullRequest.AssignedReviewers {
+			add(reviewerID, model.NotificationPRMerged)
+		}
+	}
+
+	return recipients
+}
+
+func deliverAfter(pref model.NotificationPreference, now time.Time) time.Time {
+	location, err := time.LoadLocation(pref.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+
+	if pref.Mode == model.NotifyInDigest {
+		return nextClockTime(local, pref.DigestTime)
+	}
+
+	if pref.QuietHoursStart == "" || pref.QuietHoursEnd == "" {
+		return now
+	}
+
+	start, end, current := minuteOfDay(pref.QuietHoursStart), minuteOfDay(pref.QuietHoursEnd), local.Hour()*60+local.Minute()
+	quiet := (start < end && current >= start && current < end) || (start > end && (current >= start || current < end))
+	if !quiet {
+		return now
+	}
+
+	return nextClockTime(local, pref.QuietHoursEnd)
+}
+
+func minuteOfDay(clock string) int {
+	parsed, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return 0
+	}
+
+	return parsed.Hour()*60 + parsed.Minute()
+}
+
+func nextClockTime(local time.Time, clock string) time.Time {
+	minutes := minuteOfDay(clock)
+	next := time.Date(local.Year(), local.Month(), local.Day(), minutes/60, minutes%60, 0, 0, local.Location())
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next.UTC()
+}
+
+func (s *NotificationService) ProcessDue(ctx context.Context) (int, error) {
+	notifications, err := s.notificationRepo.ClaimDue(ctx, notificationBatch, notificationLease)
+	if err != nil {
+		return 0, err
+	}
+
+	messages, err := s.buildMessages(ctx, notifications)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		ids := make([]int64, len(message.Notifications))
+		attempts := 0
+		for i, n := range message.Notifications {
+			ids[i] = n.ID
+			attempts = max(attempts, n.Attempts)
+		}
+
+		notifier, ok := s.notifiers[message.Channel]
+		var sendErr error
+		if ok {
+			sendErr = notifier.Send(ctx, message)
+		} else {
+			sendErr = fmt.Errorf("%s notifications are not configured", message.Channel)
+		}
+
+		switch {
+		case sendErr == nil:
+			err = s.notificationRepo.MarkSent(ctx, ids)
+		case !ok || attempts+1 >= notificationMaxAttempts:
+			log.Printf("Giving up on %s notification for %s after %d attempts: %v", message.Channel, message.UserID, attempts+1, sendErr)
+			err = s.notificationRepo.MarkFailed(ctx, ids, sendErr.Error())
+		default:
+			err = s.notificationRepo.Retry(ctx, ids, s.now().Add(exponentialBackoff(attempts, notificationBaseBackoff, notificationMaxBackoff)), sendErr.Error())
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(notifications), nil
+}
+
+func (s *NotificationService) buildMessages(ctx context.Context, notifications []model.Notification) ([]model.NotificationMessage, error) {
+	var messages []model.NotificationMessage
+	digests := make(map[string]int)
+
+	for _, n := range notifications {
+		if !n.Digest {
+			messages = append(messages, model.NotificationMessage{
+				UserID:        n.UserID,
+				Channel:       n.Channel,
+				Address:       n.Address,
+				Subject:       n.Subject,
+				Body:          n.Body,
+				Notifications: []model.Notification{n},
+			})
+			continue
+		}
+
+		key := n.UserID + "\x00" + string(n.Channel) + "\x00" + n.Address
+		if i, ok := digests[key]; ok {
+			messages[i].Notifications = append(messages[i].Notifications, n)
+			continue
+		}
+
+		digests[key] = len(messages)
+		messages = append(messages, model.NotificationMessage{
+			UserID:        n.UserID,
+			Channel:       n.Channel,
+			Address:       n.Address,
+			Notifications: []model.Notification{n},
+		})
+	}
+
+	if len(digests) == 0 {
+		return messages, nil
+	}
+
+	templates, err := s.loadTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range digests {
+		recipient := model.User{ID: messages[i].UserID, Username: messages[i].UserID}
+		if user, err := s.userRepo.GetByID(ctx, messages[i].UserID); err == nil {
+			recipient = user.User
+		}
+
+		messages[i].Subject, messages[i].Body = s.render(templates, model.NotificationDigest, notificationData{
+			Recipient: recipient,
+			Items:     messages[i].Notifications,
+		})
+	}
+
+	return messages, nil
+}
+
+func (s *NotificationService) Run(ctx context.Context) {
+	poll := time.NewTicker(notificationPoll)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-s.wake:
+		}
+
+		for {
+			processed, err := s.ProcessDue(ctx)
+			if err != nil {
+				log.Printf("Notification delivery failed: %v", err)
+			}
+			if err != nil || processed < notificationBatch {
+				break
+			}
+		}
+	}
+}
+
+func (s *NotificationService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var notificationTestNow = time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC)
+
+type notificationTestDeps struct {
+	repo  *mocks.NotificationRepository
+	users *mocks.UserRepository
+	slack *mocks.Notifier
+	email *mocks.Notifier
+}
+
+func newTestNotificationService(t *testing.T) (*NotificationService, notificationTestDeps) {
+	deps := notificationTestDeps{
+		repo:  mocks.NewNotificationRepository(t),
+		users: mocks.NewUserRepository(t),
+		slack: mocks.NewNotifier(t),
+		email: mocks.NewNotifier(t),
+	}
+	deps.slack.On("Channel").Return(model.ChannelSlack)
+	deps.email.On("Channel").Return(model.ChannelEmail)
+
+	notificationService := NewNotificationService(deps.repo, deps.users, deps.slack, deps.email)
+	notificationService.now = func() time.Time { return notificationTestNow }
+
+	return notificationService, deps
+}
+
+func testUser(id, username string) *model.FullUserInfo {
+	return &model.FullUserInfo{User: model.User{ID: id, Username: username, IsActive: true}}
+}
+
+func TestNotificationService_Publish_ReviewRequested(t *testing.T) {
+	notificationService, deps := newTestNotificationService(t)
+
+	deps.repo.On("ListTemplates", mock.Anything).Return([]model.NotificationTemplate{}, nil)
+	deps.repo.On("GetPreference", mock.Anything, "bob").Return(&model.NotificationPreference{
+		UserID: "bob", Channel: model.ChannelSlack, Address: "https://hooks.slack.test/bob", Mode: model.NotifyImmediately, Enabled: true, Timezone: "UTC",
+	}, nil)
+	deps.repo.On("GetPreference", mock.Anything, "carol").Return(nil, store.ErrNotFound)
+	deps.repo.On("GetPreference", mock.Anything, "dave").Return(&model.NotificationPreference{UserID: "dave", Channel: model.ChannelSlack, Enabled: false}, nil)
+	deps.users.On("GetByID", mock.Anything, "bob").Return(testUser("bob", "Bob"), nil)
+
+	deps.repo.On("Enqueue", mock.Anything, model.Notification{
+		EventID:       42,
+		UserID:        "bob",
+		Kind:          model.NotificationReviewRequested,
+		PullRequestID: "pr-1",
+		Channel:       model.ChannelSlack,
+		Address:       "https://hooks.slack.test/bob",
+		Subject:       "Review requested: Add search",
+		Body:          `Hi Bob, you were assigned to review pr-1 "Add search" by alice.`,
+		DeliverAfter:  notificationTestNow,
+	}).Return(true, nil)
+
+	event := model.NewPullRequestEvent(model.EventPRCreated, "alice", model.PullRequest{
+		ID: "pr-1", Name: "Add search", AuthorID: "alice", AssignedReviewers: []string{"bob", "carol", "dave", "alice"},
+	})
+	event.ID = 42
+
+	require.NoError(t, notificationService.Publish(context.Background(), event))
+
+	select {
+	case <-notificationService.wake:
+	default:
+		t.Fatal("worker was not woken")
+	}
+}
+
+func TestNotificationService_Publish_CustomTemplateAndDigest(t *testing.T) {
+	notificationService, deps := newTestNotificationService(t)
+
+	deps.repo.On("ListTemplates", mock.Anything).Return([]model.NotificationTemplate{
+		{Kind: model.NotificationReviewSubmitted, Subject: "{{.Verdict}} from {{.ReviewerID}}", Body: "{{.PullRequest.ID}}", Custom: true},
+	}, nil)
+	deps.repo.On("GetPreference", mock.Anything, "alice").Return(&model.NotificationPreference{
+		UserID: "alice", Channel: model.ChannelEmail, Address: "alice@example.com", Mode: model.NotifyInDigest, Enabled: true, Timezone: "UTC", DigestTime: "09:00",
+	}, nil)
+	deps.users.On("GetByID", mock.Anything, "alice").Return(testUser("alice", "Alice"), nil)
+	deps.repo.On("Enqueue", mock.Anything, mock.MatchedBy(func(n model.Notification) bool {
+		return n.Kind == model.NotificationReviewSubmitted && n.Digest && n.Subject == "APPROVED from bob" && n.Body == "pr-1" &&
+			n.DeliverAfter.Equal(time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC))
+	})).Return(true, nil)
+
+	event := model.NewPullRequestEvent(model.EventPRReviewed, "bob", model.PullRequest{ID: "pr-1", AuthorID: "alice", AssignedReviewers: []string{"bob"}})
+	event.ID = 43
+	event.Review = &model.EventReview{ReviewerID: "bob", Verdict: model.VerdictApproved}
+
+	require.NoError(t, notificationService.Publish(context.Background(), event))
+	assert.Empty(t, notificationService.wake, "digest notifications wait for the digest time")
+}
+
+func TestNotificationRecipients(t *testing.T) {
+	pr := model.PullRequest{ID: "pr-1", AuthorID: "alice", AssignedReviewers: []string{"bob", "carol"}}
+
+	merged := notificationRecipients(model.NewPullRequestEvent(model.EventPRMerged, "bob", pr))
+	assert.Equal(t, []notificationRecipient{
+		{userID: "alice", kind: model.NotificationPRMerged},
+		{userID: "carol", kind: model.NotificationPRMerged},
+	}, merged)
+
+	reassigned := model.NewPullRequestEvent(model.EventReviewerReassigned, "alice", pr)
+	reassigned.Reassignment = &model.EventReassignment{OldReviewerID: "dave", NewReviewerID: "carol"}
+	assert.Equal(t, []notificationRecipient{{userID: "carol", kind: model.NotificationReviewReassigned}}, notificationRecipients(reassigned))
+
+	transferred := notificationRecipients(model.NewPullRequestEvent(model.EventPRTransferred, "admin", pr))
+	assert.Equal(t, []notificationRecipient{
+		{userID: "bob", kind: model.NotificationReviewRequested},
+		{userID: "carol", kind: model.NotificationReviewRequested},
+	}, transferred)
+
+	assert.Empty(t, notificationRecipients(model.NewPullRequestEvent(model.EventPRClosed, "alice", pr)))
+	assert.Empty(t, notificationRecipients(model.NewPullRequestEvent(model.EventReviewerRemoved, "admin", pr)))
+}
+
+func TestDeliverAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		pref model.NotificationPreference
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "immediate without quiet hours",
+			pref: model.NotificationPreference{Mode: model.NotifyImmediately, Timezone: "UTC"},
+			now:  notificationTestNow,
+			want: notificationTestNow,
+		},
+		{
+			name: "outside quiet hours",
+			pref: model.NotificationPreference{Mode: model.NotifyImmediately, Timezone: "UTC", QuietHoursStart: "22:00", QuietHoursEnd: "08:00"},
+			now:  notificationTestNow,
+			want: notificationTestNow,
+		},
+		{
+			name: "quiet hours across midnight in local time",
+			pref: model.NotificationPreference{Mode: model.NotifyImmediately, Timezone: "Europe/Berlin", QuietHoursStart: "22:00", QuietHoursEnd: "08:00"},
+			now:  time.Date(2025, 11, 3, 22, 30, 0, 0, time.UTC),
+			want: time.Date(2025, 11, 4, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "quiet hours within a day",
+			pref: model.NotificationPreference{Mode: model.NotifyImmediately, Timezone: "UTC", QuietHoursStart: "12:00", QuietHoursEnd: "13:30"},
+			now:  notificationTestNow,
+			want: time.Date(2025, 11, 3, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "digest later today",
+			pref: model.NotificationPreference{Mode: model.NotifyInDigest, Timezone: "UTC", DigestTime: "17:00"},
+			now:  notificationTestNow,
+			want: time.Date(2025, 11, 3, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "digest tomorrow",
+			pref: model.NotificationPreference{Mode: model.NotifyInDigest, Timezone: "UTC", DigestTime: "09:00"},
+			now:  notificationTestNow,
+			want: time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, deliverAfter(tt.pref, tt.now))
+		})
+	}
+}
+
+func TestNotificationService_ProcessDue(t *testing.T) {
+	notificationService, deps := newTestNotificationService(t)
+
+	due := []model.Notification{
+		{ID: 1, UserID: "bob", Channel: model.ChannelSlack, Address: "https://hooks.slack.test/bob", Subject: "Review requested: A", Body: "a"},
+		{ID: 2, UserID: "alice", Channel: model.ChannelEmail, Address: "alice@example.com", Digest: true, Subject: "Merged: B", Body: "b"},
+		{ID: 3, UserID: "alice", Channel: model.ChannelEmail, Address: "alice@example.com", Digest: true, Subject: "Merged: C", Body: "c", Attempts: 1},
+		{ID: 4, UserID: "carol", Channel: model.ChannelSlack, Address: "https://hooks.slack.test/carol", Subject: "Merged: D", Body: "d", Attempts: notificationMaxAttempts - 1},
+		{ID: 5, UserID: "dave", Channel: model.ChannelWebhook, Address: "https://dave.example.com", Subject: "Merged: E", Body: "e"},
+	}
+	sendErr := errors.New("notification delivery failed: status 500")
+
+	deps.repo.On("ClaimDue", mock.Anything, notificationBatch, notificationLease).Return(due, nil)
+	deps.repo.On("ListTemplates", mock.Anything).Return([]model.NotificationTemplate{}, nil)
+	deps.users.On("GetByID", mock.Anything, "alice").Return(testUser("alice", "Alice"), nil)
+
+	deps.slack.On("Send", mock.Anything, mock.MatchedBy(func(m model.NotificationMessage) bool { return m.UserID == "bob" })).Return(nil)
+	deps.slack.On("Send", mock.Anything, mock.MatchedBy(func(m model.NotificationMessage) bool { return m.UserID == "carol" })).Return(sendErr)
+	deps.email.On("Send", mock.Anything, mock.MatchedBy(func(m model.NotificationMessage) bool {
+		return m.UserID == "alice" && len(m.Notifications) == 2 && m.Subject == "2 pull request update(s)" &&
+			strings.Contains(m.Body, "Hi Alice") && strings.Contains(m.Body, "- Merged: B: b") && strings.Contains(m.Body, "- Merged: C: c")
+	})).Return(sendErr)
+
+	deps.repo.On("MarkSent", mock.Anything, []int64{1}).Return(nil)
+	deps.repo.On("Retry", mock.Anything, []int64{2, 3}, notificationTestNow.Add(time.Minute), sendErr.Error()).Return(nil)
+	deps.repo.On("MarkFailed", mock.Anything, []int64{4}, sendErr.Error()).Return(nil)
+	deps.repo.On("MarkFailed", mock.Anything, []int64{5}, "WEBHOOK notifications are not configured").Return(nil)
+
+	processed, err := notificationService.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, processed)
+}
+
+func TestNotificationService_SetPreference_Validation(t *testing.T) {
+	notificationService, _ := newTestNotificationService(t)
+	bobCtx := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		pref model.NotificationPreference
+		want error
+	}{
+		{
+			name: "other user",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{UserID: "alice", Channel: model.ChannelSlack, Address: "https://hooks.slack.test"},
+			want: ErrForbidden,
+		},
+		{
+			name: "channel not configured",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{Channel: model.ChannelWebhook, Address: "https://example.com"},
+			want: ErrInvalidPreference,
+		},
+		{
+			name: "bad email",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{Channel: model.ChannelEmail, Address: "bob"},
+			want: ErrInvalidPreference,
+		},
+		{
+			name: "bad slack url",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{Channel: model.ChannelSlack, Address: "hooks.slack.test"},
+			want: ErrInvalidPreference,
+		},
+		{
+			name: "internal webhook url",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{Channel: model.ChannelWebhook, Address: "http://169.254.169.254/latest/meta-data"},
+			want: ErrInvalidPreference,
+		},
+		{
+			name: "unknown timezone",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{Channel: model.ChannelSlack, Address: "https://hooks.slack.test", Timezone: "Mars/Olympus"},
+			want: ErrInvalidPreference,
+		},
+		{
+			name: "half-open quiet hours",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{Channel: model.ChannelSlack, Address: "https://hooks.slack.test", QuietHoursStart: "22:00"},
+			want: ErrInvalidPreference,
+		},
+		{
+			name: "malformed digest time",
+			ctx:  bobCtx,
+			pref: model.NotificationPreference{Channel: model.ChannelSlack, Address: "https://hooks.slack.test", Mode: model.NotifyInDigest, DigestTime: "9am"},
+			want: ErrInvalidPreference,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := notificationService.SetPreference(tt.ctx, tt.pref)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestNotificationService_SetPreference_Defaults(t *testing.T) {
+	notificationService, deps := newTestNotificationService(t)
+	bobCtx := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+
+	deps.repo.On("SetPreference", mock.Anything, model.NotificationPreference{
+		UserID: "bob", Channel: model.ChannelEmail, Address: "bob@example.com", Mode: model.NotifyImmediately, Enabled: true, Timezone: "UTC", DigestTime: defaultDigestTime,
+	}).Return(&model.NotificationPreference{UserID: "bob"}, nil)
+
+	pref, err := notificationService.SetPreference(bobCtx, model.NotificationPreference{Channel: model.ChannelEmail, Address: "bob@example.com", Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "bob", pref.UserID)
+}
+
+func TestNotificationService_SetTemplate(t *testing.T) {
+	notificationService, deps := newTestNotificationService(t)
+
+	_, err := notificationService.SetTemplate(testAdminContext(), model.NotificationTemplate{Kind: model.NotificationPRMerged, Subject: "{{.PullRequest.Title}}", Body: "x"})
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	_, err = notificationService.SetTemplate(testAdminContext(), model.NotificationTemplate{Kind: model.NotificationPRMerged, Subject: "{{if}}", Body: "x"})
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	_, err = notificationService.SetTemplate(testAdminContext(), model.NotificationTemplate{Kind: "pr_deleted", Subject: "x", Body: "x"})
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	memberCtx := WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember})
+	_, err = notificationService.SetTemplate(memberCtx, model.NotificationTemplate{Kind: model.NotificationPRMerged, Subject: "x", Body: "x"})
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	deps.repo.On("SetTemplate", mock.Anything, model.NotificationTemplate{
+		Kind: model.NotificationPRMerged, Subject: "Merged {{.PullRequest.ID}}", Body: "by {{.ActorID}}", UpdatedBy: "admin",
+	}).Return(&model.NotificationTemplate{Kind: model.NotificationPRMerged, Custom: true}, nil)
+
+	tmpl, err := notificationService.SetTemplate(testAdminContext(), model.NotificationTemplate{Kind: model.NotificationPRMerged, Subject: "Merged {{.PullRequest.ID}}", Body: "by {{.ActorID}}"})
+	require.NoError(t, err)
+	assert.True(t, tmpl.Custom)
+}
+
+func TestNotificationService_ListTemplates(t *testing.T) {
+	notificationService, deps := newTestNotificationService(t)
+
+	deps.repo.On("ListTemplates", mock.Anything).Return([]model.NotificationTemplate{
+		{Kind: model.NotificationDigest, Subject: "Digest", Body: "{{len .Items}}", Custom: true},
+	}, nil)
+
+	templates, err := notificationService.ListTemplates(testAdminContext())
+	require.NoError(t, err)
+	require.Len(t, templates, len(model.NotificationKinds))
+	assert.Equal(t, model.NotificationReviewRequested, templates[0].Kind)
+	assert.False(t, templates[0].Custom)
+	assert.Equal(t, "Digest", templates[len(templates)-1].Subject)
+	assert.True(t, templates[len(templates)-1].Custom)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+var defaultNotificationTemplates = map[model.NotificationKind]model.NotificationTemplate{
+	model.NotificationReviewRequested: {
+		Kind:    model.NotificationReviewRequested,
+		Subject: `Review requested: {{.PullRequest.Name}}`,
+		Body:    `Hi {{.Recipient.Username}}, you were assigned to review {{.PullRequest.ID}} "{{.PullRequest.Name}}" by {{.PullRequest.AuthorID}}.`,
+	},
+	model.NotificationReviewReassigned: {
+		Kind:    model.NotificationReviewReassigned,
+		Subject: `Review reassigned: {{.PullRequest.Name}}`,
+		Body:    `Hi {{.Recipient.Username}}, you now review {{.PullRequest.ID}} "{{.PullRequest.Name}}" instead of {{.OldReviewerID}}.`,
+	},
+	model.NotificationReviewSubmitted: {
+		Kind:    model.NotificationReviewSubmitted,
+		Subject: `{{.ReviewerID}} reviewed {{.PullRequest.Name}}`,
+		Body:    `{{.ReviewerID}} left a {{.Verdict}} verdict on {{.PullRequest.ID}} "{{.PullRequest.Name}}".`,
+	},
+	model.NotificationPRMerged: {
+		Kind:    model.NotificationPRMerged,
+		Subject: `Merged: {{.PullRequest.Name}}`,
+		Body:    `{{.PullRequest.ID}} "{{.PullRequest.Name}}" was merged{{if .ActorID}} by {{.ActorID}}{{end}}.`,
+	},
+	model.NotificationDigest: {
+		Kind:    model.NotificationDigest,
+		Subject: `{{len .Items}} pull request update(s)`,
+		Body: `Hi {{.Recipient.Username}}, here is what happened since your last digest:
+{{range .Items}}
+- {{.Subject}}: {{.Body}}{{end}}
+`,
+	},
+}
+
+type notificationData struct {
+	Recipient     model.User
+	ActorID       string
+	PullRequest   model.EventPullRequest
+	OldReviewerID string
+	ReviewerID    string
+	Verdict       model.Verdict
+	Items         []model.Notification
+}
+
+var sampleNotificationData = notificationData{
+	Recipient:     model.User{ID: "u2", Username: "Bob"},
+	ActorID:       "u1",
+	PullRequest:   model.EventPullRequest{ID: "pr-1001", Name: "Add search", AuthorID: "u1", TeamName: "backend", Status: model.StatusOpen, AssignedReviewers: []string{"u2"}},
+	OldReviewerID: "u3",
+	ReviewerID:    "u2",
+	Verdict:       model.VerdictApproved,
+	Items:         []model.Notification{{Kind: model.NotificationReviewRequested, PullRequestID: "pr-1001", Subject: "Review requested: Add search", Body: "..."}},
+}
+
+func renderNotification(tmpl model.NotificationTemplate, data notificationData) (string, string, error) {
+	subject, err := renderText(string(tmpl.Kind)+".subject", tmpl.Subject, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := renderText(string(tmpl.Kind)+".body", tmpl.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(subject), body, nil
+}
+
+func renderText(name, text string, data notificationData) (string, error) {
+	parsed, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	var out bytes.Buffer
+	if err := parsed.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return out.String(), nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	oidcStateTTL      = 10 * time.Minute
+	oidcStateBytes    = 24
+	oidcVerifierBytes = 48
+)
+
+type OIDCSettings struct {
+	UserIDClaim   string
+	UsernameClaim string
+	DefaultTeam   string
+}
+
+type OIDCService struct {
+	provider   OIDCProvider
+	stateRepo  OIDCStateRepository
+	userRepo   UserRepository
+	membership *MembershipService
+	settings   OIDCSettings
+	now        func() time.Time
+}
+
+func NewOIDCService(provider OIDCProvider, stateRepo OIDCStateRepository, userRepo UserRepository, membership *MembershipService, settings OIDCSettings) *OIDCService {
+	if settings.UserIDClaim == "" {
+		settings.UserIDClaim = "sub"
+	}
+	if settings.UsernameClaim == "" {
+		settings.UsernameClaim = "preferred_username"
+	}
+
+	return &OIDCService{
+		provider:   provider,
+		stateRepo:  stateRepo,
+		userRepo:   userRepo,
+		membership: membership,
+		settings:   settings,
+		now:        time.Now,
+	}
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (s *OIDCService) Begin(ctx context.Context) (string, string, error) {
+	state, err := randomToken(oidcStateBytes)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomToken(oidcStateBytes)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := randomToken(oidcVerifierBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.stateRepo.Create(ctx, model.OIDCState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    s.now().Add(oidcStateTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return s.provider.AuthCodeURL(state, nonce, pkceChallenge(verifier)), state, nil
+}
+
+func (s *OIDCService) Complete(ctx context.Context, state, code string) (*model.Principal, error) {
+	pending, err := s.stateRepo.Consume(ctx, state)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("%w: unknown or expired state", ErrOIDCLoginFailed)
+		}
+
+		return nil, err
+	}
+
+	claims, err := s.provider.Exchange(ctx, code, pending.CodeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginFailed, err)
+	}
+
+	if nonce, _ := claims["nonce"].(string); nonce != pending.Nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCLoginFailed)
+	}
+
+	userID, _ := claims[s.settings.UserIDClaim].(string)
+	if userID == "" {
+		return nil, fmt.Errorf("%w: claim %q is missing", ErrOIDCLoginFailed, s.settings.UserIDClaim)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		user, err = s.provision(ctx, userID, claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, ErrInvalidCredentials
+	}
+
+	creds, err := s.userRepo.GetCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	role := creds.Role
+	if role == "" {
+		role = model.RoleMember
+	}
+
+	return &model.Principal{UserID: userID, Role: role}, nil
+}
+
+func (s *OIDCService) provision(ctx context.Context, userID string, claims map[string]any) (*model.FullUserInfo, error) {
+	if s.settings.DefaultTeam == "" {
+		return nil, ErrOIDCUserNotProvisioned
+	}
+
+	username, _ := claims[s.settings.UsernameClaim].(string)
+	if username == "" {
+		username = userID
+	}
+
+	systemCtx := WithPrincipal(ctx, SystemPrincipal)
+	return s.membership.AddMember(systemCtx, s.settings.DefaultTeam, model.User{ID: userID, Username: username, IsActive: true})
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCService_Begin_UsesPKCE(t *testing.T) {
+	mockProvider := mocks.NewOIDCProvider(t)
+	mockStateRepo := mocks.NewOIDCStateRepository(t)
+
+	var saved model.OIDCState
+	mockStateRepo.On("Create", mock.Anything, mock.AnythingOfType("model.OIDCState")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(model.OIDCState) }).
+		Return(nil)
+	mockProvider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(state, nonce, challenge string) string {
+			assert.Equal(t, saved.State, state)
+			assert.Equal(t, saved.Nonce, nonce)
+			assert.Equal(t, pkceChallenge(saved.CodeVerifier), challenge)
+			return "https://idp.example/authorize"
+		})
+
+	oidcService := NewOIDCService(mockProvider, mockStateRepo, nil, nil, OIDCSettings{})
+
+	authURL, state, err := oidcService.Begin(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example/authorize", authURL)
+	assert.Equal(t, saved.State, state)
+	assert.NotEmpty(t, saved.CodeVerifier)
+	assert.WithinDuration(t, time.Now().Add(oidcStateTTL), saved.ExpiresAt, time.Minute)
+}
+
+func TestOIDCService_Complete_MapsClaimToUser(t *testing.T) {
+	mockProvider := mocks.NewOIDCProvider(t)
+	mockStateRepo := mocks.NewOIDCStateRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	mockStateRepo.On("Consume", mock.Anything, "state").Return(&model.OIDCState{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}, nil)
+	mockProvider.On("Exchange", mock.Anything, "code", "verifier").Return(map[string]any{"nonce": "nonce", "email": "u1"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&model.FullUserInfo{User: model.User{ID: "u1", IsActive: true}}, nil)
+	mockUserRepo.On("GetCredentials", mock.Anything, "u1").Return(&model.Credentials{UserID: "u1", Role: model.RoleTeamLead}, nil)
+
+	oidcService := NewOIDCService(mockProvider, mockStateRepo, mockUserRepo, nil, OIDCSettings{UserIDClaim: "email"})
+
+	principal, err := oidcService.Complete(context.Background(), "state", "code")
+
+	require.NoError(t, err)
+	assert.Equal(t, model.Principal{UserID: "u1", Role: model.RoleTeamLead}, *principal)
+}
+
+func TestOIDCService_Complete_Failures(t *testing.T) {
+	mockProvider := mocks.NewOIDCProvider(t)
+	mockStateRepo := mocks.NewOIDCStateRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	mockStateRepo.On("Consume", mock.Anything, "unknown").Return(nil, store.ErrNotFound)
+	mockStateRepo.On("Consume", mock.Anything, mock.Anything).Return(&model.OIDCState{Nonce: "nonce", CodeVerifier: "verifier"}, nil)
+	mockProvider.On("Exchange", mock.Anything, "bad-code", "verifier").Return(nil, errors.New("invalid_grant"))
+	mockProvider.On("Exchange", mock.Anything, "replayed", "verifier").Return(map[string]any{"nonce": "other", "sub": "u1"}, nil)
+	mockProvider.On("Exchange", mock.Anything, "no-claim", "verifier").Return(map[string]any{"nonce": "nonce"}, nil)
+	mockProvider.On("Exchange", mock.Anything, "stranger", "verifier").Return(map[string]any{"nonce": "nonce", "sub": "ghost"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "ghost").Return(nil, store.ErrNotFound)
+
+	oidcService := NewOIDCService(mockProvider, mockStateRepo, mockUserRepo, nil, OIDCSettings{})
+
+	_, err := oidcService.Complete(context.Background(), "unknown", "code")
+	assert.ErrorIs(t, err, ErrOIDCLoginFailed)
+
+	_, err = oidcService.Complete(context.Background(), "state", "bad-code")
+	assert.ErrorIs(t, err, ErrOIDCLoginFailed)
+
+	_, err = oidcService.Complete(context.Background(), "state", "replayed")
+	assert.ErrorIs(t, err, ErrOIDCLoginFailed)
+
+	_, err = oidcService.Complete(context.Background(), "state", "no-claim")
+	assert.ErrorIs(t, err, ErrOIDCLoginFailed)
+
+	_, err = oidcService.Complete(context.Background(), "state", "stranger")
+	assert.Equal(t, ErrOIDCUserNotProvisioned, err)
+}
+
+func TestOIDCService_Complete_ProvisionsIntoDefaultTeam(t *testing.T) {
+	mockProvider := mocks.NewOIDCProvider(t)
+	mockStateRepo := mocks.NewOIDCStateRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	mockStateRepo.On("Consume", mock.Anything, "state").Return(&model.OIDCState{Nonce: "nonce", CodeVerifier: "verifier"}, nil)
+	mockProvider.On("Exchange", mock.Anything, "code", "verifier").
+		Return(map[string]any{"nonce": "nonce", "sub": "newbie", "preferred_username": "Newbie"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "newbie").Return(nil, store.ErrNotFound)
+	mockTeamRepo.On("AddMember", mock.Anything, "everyone", model.User{ID: "newbie", Username: "Newbie", IsActive: true}, "system").
+		Return(&model.FullUserInfo{User: model.User{ID: "newbie", Username: "Newbie", IsActive: true}, TeamName: "everyone"}, nil)
+	mockUserRepo.On("GetCredentials", mock.Anything, "newbie").Return(&model.Credentials{UserID: "newbie"}, nil)
+
+	membership := NewMembershipService(mockTeamRepo, mockUserRepo, nil)
+	oidcService := NewOIDCService(mockProvider, mockStateRepo, mockUserRepo, membership, OIDCSettings{DefaultTeam: "everyone"})
+
+	principal, err := oidcService.Complete(context.Background(), "state", "code")
+
+	require.NoError(t, err)
+	assert.Equal(t, model.Principal{UserID: "newbie", Role: model.RoleMember}, *principal)
+}
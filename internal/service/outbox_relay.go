@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+const (
+	outboxBatch       = 50
+	outboxLease       = 30 * time.Second
+	outboxPoll        = time.Second
+	outboxBaseBackoff = time.Second
+	outboxMaxBackoff  = 5 * time.Minute
+	outboxMaxAttempts = 15
+	outboxRetention   = 24 * time.Hour
+	outboxPurgeEvery  = time.Hour
+)
+
+type OutboxRelay struct {
+	outboxRepo OutboxRepository
+	sinks      []EventSink
+	now        func() time.Time
+}
+
+func NewOutboxRelay(outboxRepo OutboxRepository, sinks ...EventSink) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo: outboxRepo,
+		sinks:      sinks,
+		now:        time.Now,
+	}
+}
+
+func (r *OutboxRelay) ProcessDue(ctx context.Context) (int, error) {
+	records, err := r.outboxRepo.ClaimDue(ctx, outboxBatch, outboxLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, record := range records {
+		publishErr := r.publish(ctx, record)
+		switch {
+		case publishErr == nil:
+			err = r.outboxRepo.MarkPublished(ctx, record.ID)
+		case record.Attempts+1 >= outboxMaxAttempts:
+			log.Printf("Giving up outbox event %d (%s for %s) after %d attempts: %v", record.ID, record.EventType, record.AggregateID, record.Attempts+1, publishErr)
+			err = r.outboxRepo.MarkDead(ctx, record.ID, publishErr.Error())
+		default:
+			log.Printf("Failed to publish outbox event %d (%s for %s), attempt %d: %v", record.ID, record.EventType, record.AggregateID, record.Attempts+1, publishErr)
+			err = r.outboxRepo.Retry(ctx, record.ID, r.now().Add(exponentialBackoff(record.Attempts, outboxBaseBackoff, outboxMaxBackoff)), publishErr.Error())
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(records), nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, record model.OutboxRecord) error {
+	var event model.Event
+	if err := json.Unmarshal(record.Payload, &event); err != nil {
+		log.Printf("Dropping malformed outbox event %d: %v", record.ID, err)
+		return nil
+	}
+	event.ID = record.ID
+
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *OutboxRelay) Purge(ctx context.Context) (int, error) {
+	return r.outboxRepo.PurgePublished(ctx, outboxRetention)
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	poll := time.NewTicker(outboxPoll)
+	defer poll.Stop()
+	purge := time.NewTicker(outboxPurgeEvery)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-purge.C:
+			if _, err := r.Purge(ctx); err != nil {
+				log.Printf("Outbox cleanup failed: %v", err)
+			}
+		case <-poll.C:
+			for {
+				processed, err := r.ProcessDue(ctx)
+				if err != nil {
+					log.Printf("Outbox relay failed: %v", err)
+				}
+				if err != nil || processed < outboxBatch {
+					break
+				}
+			}
+		}
+	}
+}
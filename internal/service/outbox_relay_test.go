@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var outboxTestNow = time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC)
+
+func outboxRecord(t *testing.T, id int64, attempts int, eventType model.EventType, prID string) model.OutboxRecord {
+	payload, err := json.Marshal(model.NewPullRequestEvent(eventType, "alice", model.PullRequest{ID: prID}))
+	require.NoError(t, err)
+
+	return model.OutboxRecord{ID: id, AggregateID: prID, EventType: eventType, Payload: payload, Attempts: attempts}
+}
+
+func TestOutboxRelay_ProcessDue(t *testing.T) {
+	repo := mocks.NewOutboxRepository(t)
+	logSink := mocks.NewEventSink(t)
+	webhookSink := mocks.NewEventSink(t)
+
+	relay := NewOutboxRelay(repo, logSink, webhookSink)
+	relay.now = func() time.Time { return outboxTestNow }
+
+	records := []model.OutboxRecord{
+		outboxRecord(t, 1, 0, model.EventPRCreated, "pr-1"),
+		outboxRecord(t, 2, 3, model.EventPRMerged, "pr-2"),
+	}
+	sinkErr := errors.New("sink unavailable")
+
+	repo.On("ClaimDue", mock.Anything, outboxBatch, outboxLease).Return(records, nil)
+
+	logSink.On("Publish", mock.Anything, mock.MatchedBy(func(event model.Event) bool { return event.ID == 1 })).Return(nil)
+	logSink.On("Publish", mock.Anything, mock.MatchedBy(func(event model.Event) bool { return event.ID == 2 })).Return(nil)
+	webhookSink.On("Publish", mock.Anything, mock.MatchedBy(func(event model.Event) bool {
+		return event.ID == 1 && event.Type == model.EventPRCreated && event.PullRequest.ID == "pr-1"
+	})).Return(nil)
+	webhookSink.On("Publish", mock.Anything, mock.MatchedBy(func(event model.Event) bool { return event.ID == 2 })).Return(sinkErr)
+
+	repo.On("MarkPublished", mock.Anything, int64(1)).Return(nil)
+	repo.On("Retry", mock.Anything, int64(2), outboxTestNow.Add(8*time.Second), sinkErr.Error()).Return(nil)
+
+	processed, err := relay.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+}
+
+func TestOutboxRelay_ProcessDue_DeadLettersAfterMaxAttempts(t *testing.T) {
+	repo := mocks.NewOutboxRepository(t)
+	sink := mocks.NewEventSink(t)
+
+	relay := NewOutboxRelay(repo, sink)
+
+	sinkErr := errors.New("sink unavailable")
+	repo.On("ClaimDue", mock.Anything, outboxBatch, outboxLease).Return([]model.OutboxRecord{outboxRecord(t, 3, outboxMaxAttempts-1, model.EventPRCreated, "pr-1")}, nil)
+	sink.On("Publish", mock.Anything, mock.Anything).Return(sinkErr)
+	repo.On("MarkDead", mock.Anything, int64(3), sinkErr.Error()).Return(nil)
+
+	processed, err := relay.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	repo.AssertNotCalled(t, "Retry", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOutboxRelay_ProcessDue_DropsMalformedPayload(t *testing.T) {
+	repo := mocks.NewOutboxRepository(t)
+	sink := mocks.NewEventSink(t)
+
+	relay := NewOutboxRelay(repo, sink)
+
+	repo.On("ClaimDue", mock.Anything, outboxBatch, outboxLease).Return([]model.OutboxRecord{{ID: 7, AggregateID: "pr-1", Payload: []byte("{")}}, nil)
+	repo.On("MarkPublished", mock.Anything, int64(7)).Return(nil)
+
+	processed, err := relay.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	sink.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestOutboxRelay_ProcessDue_StopsOnRepositoryError(t *testing.T) {
+	repo := mocks.NewOutboxRepository(t)
+	sink := mocks.NewEventSink(t)
+
+	relay := NewOutboxRelay(repo, sink)
+
+	repo.On("ClaimDue", mock.Anything, outboxBatch, outboxLease).Return([]model.OutboxRecord{outboxRecord(t, 1, 0, model.EventPRCreated, "pr-1")}, nil)
+	sink.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	repo.On("MarkPublished", mock.Anything, int64(1)).Return(errors.New("connection reset"))
+
+	_, err := relay.ProcessDue(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+type ProvisioningService struct {
+	teamRepo   TeamRepository
+	userRepo   UserRepository
+	membership *MembershipService
+}
+
+func NewProvisioningService(teamRepo TeamRepository, userRepo UserRepository, membership *MembershipService) *ProvisioningService {
+	return &ProvisioningService{
+		teamRepo:   teamRepo,
+		userRepo:   userRepo,
+		membership: membership,
+	}
+}
+
+func (s *ProvisioningService) ListUsers(ctx context.Context, filter model.UserFilter) ([]model.ProvisionedUser, int, error) {
+	filter.Limit = pageLimit(filter.Limit)
+
+	total, err := s.userRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	provisioned := make([]model.ProvisionedUser, 0, len(users))
+	for _, user := range users {
+		memberships, err := s.userRepo.GetMemberships(ctx, user.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		provisioned = append(provisioned, model.ProvisionedUser{User: user, Memberships: memberships})
+	}
+
+	return provisioned, total, nil
+}
+
+func (s *ProvisioningService) GetUser(ctx context.Context, userID string) (*model.ProvisionedUser, error) {
+	user, err := s.membership.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.userRepo.GetMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ProvisionedUser{User: *user, Memberships: memberships}, nil
+}
+
+func (s *ProvisioningService) CreateUser(ctx context.Context, user model.User) (*model.ProvisionedUser, error) {
+	created, err := s.userRepo.Create(ctx, user)
+	if err != nil {
+		if errors.Is(err, store.ErrUserExists) {
+			return nil, ErrUserExists
+		}
+
+		return nil, err
+	}
+
+	return &model.ProvisionedUser{User: *created, Memberships: []model.TeamMembership{}}, nil
+}
+
+func (s *ProvisioningService) UpdateUser(ctx context.Context, userID string, patch model.UserPatch) (*model.ProvisionedUser, error) {
+	user, err := s.membership.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Username != nil && *patch.Username != user.Username {
+		if _, err := s.userRepo.SetUsername(ctx, userID, *patch.Username); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrNotFound
+			}
+
+			return nil, err
+		}
+	}
+
+	if patch.IsActive != nil && *patch.IsActive != user.IsActive {
+		if *patch.IsActive {
+			_, err = s.userRepo.SetIsActive(ctx, userID, true, actorID(ctx))
+		} else {
+			_, err = s.membership.Deprovision(ctx, userID)
+		}
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrNotFound
+			}
+
+			return nil, err
+		}
+	}
+
+	return s.GetUser(ctx, userID)
+}
+
+func (s *ProvisioningService) DeprovisionUser(ctx context.Context, userID string) error {
+	isActive := false
+	_, err := s.UpdateUser(ctx, userID, model.UserPatch{IsActive: &isActive})
+	return err
+}
+
+func (s *ProvisioningService) ListGroups(ctx context.Context, filter model.TeamFilter) ([]model.ProvisionedGroup, int, error) {
+	filter.Limit = pageLimit(filter.Limit)
+	filter.IncludeArchived = false
+
+	total, err := s.teamRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	summaries, err := s.teamRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	groups := make([]model.ProvisionedGroup, 0, len(summaries))
+	for _, summary := range summaries {
+		group, err := s.GetGroup(ctx, summary.Team.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		groups = append(groups, *group)
+	}
+
+	return groups, total, nil
+}
+
+func (s *ProvisioningService) GetGroup(ctx context.Context, teamID int) (*model.ProvisionedGroup, error) {
+	team, members, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if team.ArchivedAt != nil {
+		return nil, ErrNotFound
+	}
+
+	return &model.ProvisionedGroup{Team: *team, Members: members}, nil
+}
+
+func (s *ProvisioningService) CreateGroup(ctx context.Context, name string, memberIDs []string) (*model.ProvisionedGroup, error) {
+	upsert, err := s.teamRepo.AddTeamWithMembers(ctx, model.Team{Name: name, CreatedBy: actorID(ctx)}, nil)
+	if err != nil {
+		if errors.Is(err, store.ErrTeamExists) {
+			return nil, ErrTeamExists
+		}
+
+		return nil, err
+	}
+
+	return s.UpdateGroup(ctx, upsert.Team.ID, model.GroupPatch{AddMembers: memberIDs})
+}
+
+func (s *ProvisioningService) UpdateGroup(ctx context.Context, teamID int, patch model.GroupPatch) (*model.ProvisionedGroup, error) {
+	group, err := s.GetGroup(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	team := group.Team
+
+	if patch.Name != nil && *patch.Name != team.Name {
+		renamed, err := s.teamRepo.Rename(ctx, team.Name, *patch.Name, actorID(ctx))
+		if err != nil {
+			if errors.Is(err, store.ErrTeamExists) {
+				return nil, ErrTeamExists
+			}
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrNotFound
+			}
+
+			return nil, err
+		}
+		team = *renamed
+	}
+
+	current := make(map[string]struct{}, len(group.Members))
+	for _, member := range group.Members {
+		current[member.ID] = struct{}{}
+	}
+
+	toAdd := patch.AddMembers
+	toRemove := patch.RemoveMembers
+	if patch.ReplaceMembers {
+		desired := make(map[string]struct{}, len(patch.Members))
+		toAdd = nil
+		for _, id := range patch.Members {
+			desired[id] = struct{}{}
+			toAdd = append(toAdd, id)
+		}
+
+		toRemove = nil
+		for id := range current {
+			if _, keep := desired[id]; !keep {
+				toRemove = append(toRemove, id)
+			}
+		}
+	}
+
+	for _, id := range toRemove {
+		if _, isMember := current[id]; !isMember {
+			continue
+		}
+
+		if _, err := s.membership.RemoveMember(ctx, team.Name, id, model.ReviewPolicyReassign); err != nil {
+			return nil, err
+		}
+		delete(current, id)
+	}
+
+	for _, id := range toAdd {
+		if _, isMember := current[id]; isMember {
+			continue
+		}
+
+		if err := s.addGroupMember(ctx, team, id); err != nil {
+			return nil, err
+		}
+		current[id] = struct{}{}
+	}
+
+	return s.GetGroup(ctx, teamID)
+}
+
+func (s *ProvisioningService) DeleteGroup(ctx context.Context, teamID int) error {
+	group, err := s.GetGroup(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.teamRepo.SetArchived(ctx, group.Team.Name, true, actorID(ctx)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *ProvisioningService) addGroupMember(ctx context.Context, team model.Team, userID string) error {
+	user, err := s.membership.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.TeamID == 0 {
+		_, err = s.membership.AddMember(ctx, team.Name, user.User)
+	} else {
+		_, err = s.membership.SetSecondaryMember(ctx, team.Name, userID, true)
+	}
+
+	return err
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvisioningService(teamRepo TeamRepository, userRepo UserRepository, prRepo PullRequestRepository) *ProvisioningService {
+	return NewProvisioningService(teamRepo, userRepo, NewMembershipService(teamRepo, userRepo, prRepo))
+}
+
+func TestProvisioningService_CreateUser_FailsIfExists(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := model.User{ID: "u1", Username: "alice", IsActive: true}
+	mockUserRepo.On("Create", mock.Anything, user).Return(nil, store.ErrUserExists)
+
+	provisioningService := newTestProvisioningService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	_, err := provisioningService.CreateUser(testAdminContext(), user)
+
+	assert.Equal(t, ErrUserExists, err)
+}
+
+func TestProvisioningService_UpdateUser_DeactivationReassignsOpenReviews(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", IsActive: true, TeamID: 1}, TeamName: "backend"}
+	deactivated := &model.FullUserInfo{User: model.User{ID: "u1", IsActive: false, TeamID: 1}, TeamName: "backend"}
+	memberships := []model.TeamMembership{
+		{TeamID: 1, TeamName: "backend", UserID: "u1", IsPrimary: true, Reviewable: true},
+		{TeamID: 2, TeamName: "platform", UserID: "u1", Reviewable: true},
+	}
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil).Twice()
+	mockUserRepo.On("GetMemberships", mock.Anything, "u1").Return(memberships, nil)
+	mockPRRepo.On("GetByReviewerID", mock.Anything, "u1").Return([]model.PullRequest{
+		{ID: "pr-1", Status: model.StatusOpen},
+		{ID: "pr-2", Status: model.StatusOpen},
+	}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&model.PullRequest{
+		ID: "pr-1", AuthorID: "a1", TeamID: 1, Status: model.StatusOpen, AssignedReviewers: []string{"u1"},
+	}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-2").Return(&model.PullRequest{
+		ID: "pr-2", AuthorID: "a2", TeamID: 2, Status: model.StatusOpen, AssignedReviewers: []string{"u1"},
+	}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 1, "u1").Return([]model.User{{ID: "b1"}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 2, "u1").Return([]model.User{}, nil)
+	mockTeamRepo.On("ApplyMembershipChange", mock.Anything, model.MembershipPlan{
+		UserID:     "u1",
+		Deactivate: true,
+		Reassignments: []model.ReviewReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "b1"},
+			{PullRequestID: "pr-2", OldReviewerID: "u1"},
+		},
+	}, "admin").Return(deactivated, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(deactivated, nil).Once()
+
+	provisioningService := newTestProvisioningService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	isActive := false
+	result, err := provisioningService.UpdateUser(testAdminContext(), "u1", model.UserPatch{IsActive: &isActive})
+
+	require.NoError(t, err)
+	assert.False(t, result.User.IsActive)
+	assert.Equal(t, memberships, result.Memberships)
+}
+
+func TestProvisioningService_UpdateUser_ReactivationDoesNotTouchReviews(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", Username: "alice", IsActive: false}}
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockUserRepo.On("SetUsername", mock.Anything, "u1", "alice.b").Return(user, nil)
+	mockUserRepo.On("SetIsActive", mock.Anything, "u1", true, "admin").Return(user, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "u1").Return([]model.TeamMembership{}, nil)
+
+	provisioningService := newTestProvisioningService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	isActive := true
+	username := "alice.b"
+	_, err := provisioningService.UpdateUser(testAdminContext(), "u1", model.UserPatch{Username: &username, IsActive: &isActive})
+
+	require.NoError(t, err)
+	mockPRRepo.AssertNotCalled(t, "GetByReviewerID", mock.Anything, mock.Anything)
+}
+
+func TestProvisioningService_UpdateGroup_ReplacesMembers(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	team := &model.Team{ID: 5, Name: "backend"}
+	mockTeamRepo.On("GetByID", mock.Anything, 5).Return(team, []model.User{{ID: "u1", TeamID: 5}, {ID: "u2", TeamID: 5}}, nil).Once()
+
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&model.FullUserInfo{User: model.User{ID: "u1", TeamID: 5}, TeamName: "backend"}, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "u1").Return([]model.TeamMembership{
+		{TeamID: 5, TeamName: "backend", UserID: "u1", IsPrimary: true},
+	}, nil)
+	mockPRRepo.On("GetByReviewerID", mock.Anything, "u1").Return([]model.PullRequest{}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 5, "u1").Return([]model.User{}, nil)
+	mockTeamRepo.On("ApplyMembershipChange", mock.Anything, model.MembershipPlan{UserID: "u1", LeaveTeamID: 5}, "admin").
+		Return(&model.FullUserInfo{User: model.User{ID: "u1"}}, nil)
+
+	newcomer := &model.FullUserInfo{User: model.User{ID: "u3", Username: "carol", IsActive: true}}
+	mockUserRepo.On("GetByID", mock.Anything, "u3").Return(newcomer, nil)
+	mockTeamRepo.On("AddMember", mock.Anything, "backend", newcomer.User, "admin").Return(newcomer, nil)
+
+	veteran := &model.FullUserInfo{User: model.User{ID: "u4", TeamID: 9}, TeamName: "platform"}
+	mockUserRepo.On("GetByID", mock.Anything, "u4").Return(veteran, nil)
+	mockTeamRepo.On("SetSecondaryMember", mock.Anything, "backend", "u4", true, "admin").Return(&model.TeamMembership{}, nil)
+
+	mockTeamRepo.On("GetByID", mock.Anything, 5).Return(team, []model.User{{ID: "u2"}, {ID: "u3"}, {ID: "u4"}}, nil).Once()
+
+	provisioningService := newTestProvisioningService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	group, err := provisioningService.UpdateGroup(testAdminContext(), 5, model.GroupPatch{
+		ReplaceMembers: true,
+		Members:        []string{"u2", "u3", "u4"},
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, group.Members, 3)
+	mockTeamRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, "backend", "u2")
+}
+
+func TestProvisioningService_GetGroup_HidesArchivedTeams(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	archivedAt := time.Now()
+	mockTeamRepo.On("GetByID", mock.Anything, 5).Return(&model.Team{ID: 5, Name: "old", ArchivedAt: &archivedAt}, nil, nil)
+
+	provisioningService := newTestProvisioningService(mockTeamRepo, mockUserRepo, mockPRRepo)
+
+	_, err := provisioningService.GetGroup(testAdminContext(), 5)
+
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -3,24 +3,36 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
 	"math/rand"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/DeadlyParkour777/pr-service/internal/store"
 )
 
+const maxReviewers = 2
+
+var DefaultIssueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
 type PullRequestService struct {
-	prRepo   PullRequestRepository
-	userRepo UserRepository
-	rnd      *rand.Rand
+	prRepo        PullRequestRepository
+	userRepo      UserRepository
+	teamRepo      TeamRepository
+	rnd           *rand.Rand
+	reviewerSync  *ReviewerSyncService
+	issuePatterns []*regexp.Regexp
 }
 
-func NewPullRequestService(prRepo PullRequestRepository, userRepo UserRepository) *PullRequestService {
+func NewPullRequestService(prRepo PullRequestRepository, userRepo UserRepository, teamRepo TeamRepository) *PullRequestService {
 	return &PullRequestService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		prRepo:        prRepo,
+		userRepo:      userRepo,
+		teamRepo:      teamRepo,
+		rnd:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		issuePatterns: []*regexp.Regexp{DefaultIssueKeyPattern},
 	}
 }
 
@@ -34,26 +46,25 @@ func (s *PullRequestService) Create(ctx context.Context, pr model.PullRequest) (
 		return nil, err
 	}
 
-	candidates, err := s.userRepo.GetActiveTeamMembers(ctx, author.TeamID, pr.AuthorID)
+	teamID, err := s.resolveTargetTeam(ctx, *author, pr.TeamName)
 	if err != nil {
 		return nil, err
 	}
+	pr.TeamID = teamID
 
-	s.rnd.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
-
-	var reviewers []string
-	limit := 2
-	if len(candidates) < limit {
-		limit = len(candidates)
+	chain, err := s.teamChain(ctx, teamID)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, candidate := range candidates[:limit] {
-		reviewers = append(reviewers, candidate.ID)
+	candidates, err := s.findCandidates(ctx, chain, pr.AuthorID, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	pr.AssignedReviewers = reviewers
+	pr.AssignedReviewers = pickReviewers(s.rnd, candidates, model.ResolvePolicy(chain).ReviewerCount)
+	pr.CreatedBy = actorID(ctx)
+	pr.IssueKeys = ExtractIssueKeys(pr.Name, s.issuePatterns)
 
 	if err := s.prRepo.Create(ctx, pr); err != nil {
 		if errors.Is(err, store.ErrPRExists) {
@@ -63,6 +74,8 @@ func (s *PullRequestService) Create(ctx context.Context, pr model.PullRequest) (
 		return nil, err
 	}
 
+	s.syncReviewers(ctx, pr.ID)
+
 	prs, err := s.prRepo.GetByID(ctx, pr.ID)
 	if err != nil {
 		return nil, err
@@ -81,11 +94,20 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*model.Pul
 		return nil, err
 	}
 
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || (principal.Role != model.RoleAdmin && principal.UserID != pr.AuthorID) {
+		return nil, ErrForbidden
+	}
+
 	if pr.Status == model.StatusMerged {
 		return pr, nil
 	}
 
-	err = s.prRepo.Merge(ctx, prID)
+	if pr.Status == model.StatusClosed {
+		return nil, ErrPRClosed
+	}
+
+	err = s.prRepo.Merge(ctx, prID, principal.UserID)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			return nil, ErrNotFound
@@ -116,6 +138,10 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 		return nil, "", ErrPRMerged
 	}
 
+	if pr.Status == model.StatusClosed {
+		return nil, "", ErrPRClosed
+	}
+
 	isAssigned := false
 	for _, reviewer := range pr.AssignedReviewers {
 		if reviewer == oldReviewerID {
@@ -137,7 +163,16 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 		return nil, "", err
 	}
 
-	allActiveMembers, err := s.userRepo.GetActiveTeamMembers(ctx, oldReviewer.TeamID, "")
+	teamID := pr.TeamID
+	if teamID == 0 {
+		teamID = oldReviewer.TeamID
+	}
+
+	if err := s.authorizeReassign(ctx, teamID, oldReviewerID); err != nil {
+		return nil, "", err
+	}
+
+	chain, err := s.teamChain(ctx, teamID)
 	if err != nil {
 		return nil, "", err
 	}
@@ -148,11 +183,9 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 		forbiddenIDs[reviewer] = struct{}{}
 	}
 
-	var candidates []model.User
-	for _, member := range allActiveMembers {
-		if _, isForbidden := forbiddenIDs[member.ID]; !isForbidden {
-			candidates = append(candidates, member)
-		}
+	candidates, err := s.findCandidates(ctx, chain, "", forbiddenIDs)
+	if err != nil {
+		return nil, "", err
 	}
 
 	if len(candidates) == 0 {
@@ -165,11 +198,13 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 
 	newReviewer := candidates[0]
 
-	err = s.prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewer.ID)
+	err = s.prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewer.ID, actorID(ctx))
 	if err != nil {
 		return nil, "", err
 	}
 
+	s.syncReviewers(ctx, prID)
+
 	updatedPR, err := s.prRepo.GetByID(ctx, prID)
 	if err != nil {
 		return nil, "", err
@@ -178,6 +213,112 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 	return updatedPR, newReviewer.ID, nil
 }
 
+func (s *PullRequestService) Close(ctx context.Context, prID string) (*model.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || (principal.Role != model.RoleAdmin && principal.UserID != pr.AuthorID) {
+		return nil, ErrForbidden
+	}
+
+	if pr.Status == model.StatusClosed {
+		return pr, nil
+	}
+
+	if pr.Status == model.StatusMerged {
+		return nil, ErrPRMerged
+	}
+
+	if err := s.prRepo.Close(ctx, prID, principal.UserID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return s.prRepo.GetByID(ctx, prID)
+}
+
+func (s *PullRequestService) Reopen(ctx context.Context, prID string) (*model.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || (principal.Role != model.RoleAdmin && principal.UserID != pr.AuthorID) {
+		return nil, ErrForbidden
+	}
+
+	if pr.Status == model.StatusOpen {
+		return pr, nil
+	}
+
+	if pr.Status == model.StatusMerged {
+		return nil, ErrPRMerged
+	}
+
+	if err := s.prRepo.Reopen(ctx, prID, principal.UserID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return s.prRepo.GetByID(ctx, prID)
+}
+
+func (s *PullRequestService) SubmitVerdict(ctx context.Context, prID, reviewerID string, verdict model.Verdict) (*model.PullRequest, error) {
+	if !verdict.IsValid() {
+		return nil, ErrInvalidVerdict
+	}
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || (principal.Role != model.RoleAdmin && principal.UserID != reviewerID) {
+		return nil, ErrForbidden
+	}
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if pr.Status == model.StatusMerged {
+		return nil, ErrPRMerged
+	}
+
+	if pr.Status == model.StatusClosed {
+		return nil, ErrPRClosed
+	}
+
+	if err := s.prRepo.SetVerdict(ctx, prID, reviewerID, verdict, principal.UserID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotAssigned
+		}
+
+		return nil, err
+	}
+
+	return s.prRepo.GetByID(ctx, prID)
+}
+
 func (s *PullRequestService) GetByID(ctx context.Context, prID string) (*model.PullRequest, error) {
 	pr, err := s.prRepo.GetByID(ctx, prID)
 	if err != nil {
@@ -190,3 +331,176 @@ func (s *PullRequestService) GetByID(ctx context.Context, prID string) (*model.P
 
 	return pr, nil
 }
+
+func (s *PullRequestService) ListByIssueKey(ctx context.Context, issueKey string) ([]model.PullRequest, error) {
+	issueKey = strings.ToUpper(strings.TrimSpace(issueKey))
+	if issueKey == "" {
+		return nil, ErrInvalidIssueKey
+	}
+
+	return s.prRepo.GetByIssueKey(ctx, issueKey)
+}
+
+func (s *PullRequestService) BackfillIssueKeys(ctx context.Context) (int, error) {
+	names, err := s.prRepo.ListNames(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	issueKeys := make(map[string][]string, len(names))
+	for prID, name := range names {
+		issueKeys[prID] = ExtractIssueKeys(name, s.issuePatterns)
+	}
+
+	return s.prRepo.ReplaceIssueKeys(ctx, issueKeys)
+}
+
+func ExtractIssueKeys(text string, patterns []*regexp.Regexp) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+			key := match[0]
+			if len(match) > 1 {
+				key = match[1]
+			}
+
+			key = strings.ToUpper(strings.TrimSpace(key))
+			if key != "" && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+func (s *PullRequestService) syncReviewers(ctx context.Context, prID string) {
+	if s.reviewerSync == nil {
+		return
+	}
+
+	if err := s.reviewerSync.Enqueue(ctx, prID); err != nil {
+		log.Printf("Failed to queue reviewer sync for %s: %v", prID, err)
+	}
+}
+
+func (s *PullRequestService) authorizeReassign(ctx context.Context, prTeamID int, oldReviewerID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+
+	if principal.Role == model.RoleAdmin || principal.UserID == oldReviewerID {
+		return nil
+	}
+
+	actorMemberships, err := s.userRepo.GetMemberships(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	if principal.Role == model.RoleTeamLead {
+		for _, actor := range actorMemberships {
+			if actor.TeamID == prTeamID {
+				return nil
+			}
+		}
+	}
+
+	reviewerMemberships, err := s.userRepo.GetMemberships(ctx, oldReviewerID)
+	if err != nil {
+		return err
+	}
+
+	for _, actor := range actorMemberships {
+		for _, reviewer := range reviewerMemberships {
+			if actor.TeamID == reviewer.TeamID {
+				return nil
+			}
+		}
+	}
+
+	return ErrForbidden
+}
+
+func (s *PullRequestService) resolveTargetTeam(ctx context.Context, author model.FullUserInfo, teamName string) (int, error) {
+	if teamName == "" || teamName == author.TeamName {
+		return author.TeamID, nil
+	}
+
+	memberships, err := s.userRepo.GetMemberships(ctx, author.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range memberships {
+		if m.TeamName == teamName {
+			return m.TeamID, nil
+		}
+	}
+
+	return 0, ErrNotTeamMember
+}
+
+func (s *PullRequestService) teamChain(ctx context.Context, teamID int) ([]model.Team, error) {
+	if teamID == 0 {
+		return []model.Team{{}}, nil
+	}
+
+	chain, err := s.teamRepo.GetAncestors(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return chain, nil
+}
+
+func (s *PullRequestService) findCandidates(ctx context.Context, chain []model.Team, excludeUserID string, forbiddenIDs map[string]struct{}) ([]model.User, error) {
+	searchChain := chain[:1]
+	if model.ResolvePolicy(chain).FallbackToParent {
+		searchChain = chain
+	}
+
+	for _, team := range searchChain {
+		members, err := s.userRepo.GetActiveTeamMembers(ctx, team.ID, excludeUserID)
+		if err != nil {
+			return nil, err
+		}
+
+		var candidates []model.User
+		for _, member := range members {
+			if _, isForbidden := forbiddenIDs[member.ID]; !isForbidden {
+				candidates = append(candidates, member)
+			}
+		}
+
+		if len(candidates) > 0 {
+			return candidates, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func pickReviewers(rnd *rand.Rand, candidates []model.User, limit int) []string {
+	rnd.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	if len(candidates) < limit {
+		limit = len(candidates)
+	}
+
+	var reviewers []string
+	for _, candidate := range candidates[:limit] {
+		reviewers = append(reviewers, candidate.ID)
+	}
+
+	return reviewers
+}
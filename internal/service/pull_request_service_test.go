@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/DeadlyParkour777/pr-service/internal/model"
@@ -10,11 +11,13 @@ import (
 	"github.com/DeadlyParkour777/pr-service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPullRequestService_Reassign_FailsIfPRIsMerged(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	mergedPR := &model.PullRequest{
 		ID:     "pr-1",
@@ -22,7 +25,7 @@ func TestPullRequestService_Reassign_FailsIfPRIsMerged(t *testing.T) {
 	}
 	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(mergedPR, nil)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	_, _, err := prService.Reassign(context.Background(), "pr-1", "old-reviewer-id")
 
@@ -33,6 +36,7 @@ func TestPullRequestService_Reassign_FailsIfPRIsMerged(t *testing.T) {
 func TestPullRequestService_Reassign_FailsIfReviewerNotAssigned(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	openPR := &model.PullRequest{
 		ID:                "pr-1",
@@ -42,7 +46,7 @@ func TestPullRequestService_Reassign_FailsIfReviewerNotAssigned(t *testing.T) {
 
 	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(openPR, nil)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	_, _, err := prService.Reassign(context.Background(), "pr-1", "user-A")
 
@@ -53,6 +57,7 @@ func TestPullRequestService_Reassign_FailsIfReviewerNotAssigned(t *testing.T) {
 func TestPullRequestService_Create_Success(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
 	prToCreate := model.PullRequest{ID: "pr-1", AuthorID: "author-1"}
@@ -64,6 +69,7 @@ func TestPullRequestService_Create_Success(t *testing.T) {
 	}
 
 	mockUserRepo.On("GetByID", context.Background(), "author-1").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, author.TeamID).Return([]model.Team{{ID: author.TeamID}}, nil)
 	mockUserRepo.On("GetActiveTeamMembers", context.Background(), author.TeamID, author.ID).Return(candidates, nil)
 
 	mockPRRepo.On("Create", context.Background(), mock.AnythingOfType("model.PullRequest")).Return(nil)
@@ -75,7 +81,7 @@ func TestPullRequestService_Create_Success(t *testing.T) {
 	}
 	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(finalPR, nil)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	createdPR, err := prService.Create(context.Background(), prToCreate)
 
@@ -89,6 +95,7 @@ func TestPullRequestService_Create_Success(t *testing.T) {
 func TestPullRequestService_Create_AssignsOneReviewerIfOnlyOneCandidate(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
 	prToCreate := model.PullRequest{ID: "pr-1", AuthorID: "author-1"}
@@ -98,6 +105,7 @@ func TestPullRequestService_Create_AssignsOneReviewerIfOnlyOneCandidate(t *testi
 	}
 
 	mockUserRepo.On("GetByID", context.Background(), "author-1").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, author.TeamID).Return([]model.Team{{ID: author.TeamID}}, nil)
 	mockUserRepo.On("GetActiveTeamMembers", context.Background(), author.TeamID, author.ID).Return(candidates, nil)
 
 	mockPRRepo.On("Create", context.Background(), mock.MatchedBy(func(pr model.PullRequest) bool {
@@ -107,7 +115,7 @@ func TestPullRequestService_Create_AssignsOneReviewerIfOnlyOneCandidate(t *testi
 	finalPR := &model.PullRequest{ID: "pr-1", AuthorID: "author-1", AssignedReviewers: []string{"user-A"}}
 	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(finalPR, nil)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	createdPR, err := prService.Create(context.Background(), prToCreate)
 
@@ -120,6 +128,7 @@ func TestPullRequestService_Create_AssignsOneReviewerIfOnlyOneCandidate(t *testi
 func TestPullRequestService_Create_AssignsZeroReviewersIfNoCandidates(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
 	prToCreate := model.PullRequest{ID: "pr-1", AuthorID: "author-1"}
@@ -127,6 +136,7 @@ func TestPullRequestService_Create_AssignsZeroReviewersIfNoCandidates(t *testing
 	candidates := []model.User{}
 
 	mockUserRepo.On("GetByID", context.Background(), "author-1").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, author.TeamID).Return([]model.Team{{ID: author.TeamID}}, nil)
 	mockUserRepo.On("GetActiveTeamMembers", context.Background(), author.TeamID, author.ID).Return(candidates, nil)
 
 	mockPRRepo.On("Create", context.Background(), mock.MatchedBy(func(pr model.PullRequest) bool {
@@ -136,7 +146,7 @@ func TestPullRequestService_Create_AssignsZeroReviewersIfNoCandidates(t *testing
 	finalPR := &model.PullRequest{ID: "pr-1", AuthorID: "author-1", AssignedReviewers: []string{}}
 	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(finalPR, nil)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	createdPR, err := prService.Create(context.Background(), prToCreate)
 
@@ -148,6 +158,7 @@ func TestPullRequestService_Create_AssignsZeroReviewersIfNoCandidates(t *testing
 func TestPullRequestService_Reassign_FailsIfNoCandidatesAvailable(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	openPR := &model.PullRequest{
 		ID:                "pr-1",
@@ -157,35 +168,38 @@ func TestPullRequestService_Reassign_FailsIfNoCandidatesAvailable(t *testing.T)
 	}
 	oldReviewer := &model.FullUserInfo{User: model.User{ID: "old-reviewer", TeamID: 123}}
 
-	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(openPR, nil)
-	mockUserRepo.On("GetByID", context.Background(), "old-reviewer").Return(oldReviewer, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "old-reviewer").Return(oldReviewer, nil)
 
-	mockUserRepo.On("GetActiveTeamMembers", context.Background(), oldReviewer.TeamID, "").Return([]model.User{}, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, oldReviewer.TeamID).Return([]model.Team{{ID: oldReviewer.TeamID}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, oldReviewer.TeamID, "").Return([]model.User{}, nil)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
-	_, _, err := prService.Reassign(context.Background(), "pr-1", "old-reviewer")
+	_, _, err := prService.Reassign(testAdminContext(), "pr-1", "old-reviewer")
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrNoCandidates, err)
 }
 
 func TestPullRequestService_Merge_Success(t *testing.T) {
+	ctx := testAdminContext()
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	prID := "pr-1"
 
 	openPR := &model.PullRequest{ID: prID, Status: model.StatusOpen}
 	mergedPR := &model.PullRequest{ID: prID, Status: model.StatusMerged}
 
-	mockPRRepo.On("GetByID", context.Background(), prID).Return(openPR, nil).Once()
-	mockPRRepo.On("Merge", context.Background(), prID).Return(nil)
-	mockPRRepo.On("GetByID", context.Background(), prID).Return(mergedPR, nil).Once()
+	mockPRRepo.On("GetByID", ctx, prID).Return(openPR, nil).Once()
+	mockPRRepo.On("Merge", ctx, prID, "admin").Return(nil)
+	mockPRRepo.On("GetByID", ctx, prID).Return(mergedPR, nil).Once()
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
-	resultPR, err := prService.Merge(context.Background(), prID)
+	resultPR, err := prService.Merge(ctx, prID)
 	assert.NoError(t, err)
 	assert.NotNil(t, resultPR)
 	assert.Equal(t, model.StatusMerged, resultPR.Status)
@@ -194,37 +208,40 @@ func TestPullRequestService_Merge_Success(t *testing.T) {
 }
 
 func TestPullRequestService_Merge_IsIdempotent(t *testing.T) {
+	ctx := testAdminContext()
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	prID := "pr-1"
 
 	mergedPR := &model.PullRequest{ID: prID, Status: model.StatusMerged}
 
-	mockPRRepo.On("GetByID", context.Background(), prID).Return(mergedPR, nil)
+	mockPRRepo.On("GetByID", ctx, prID).Return(mergedPR, nil)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
-	resultPR, err := prService.Merge(context.Background(), prID)
+	resultPR, err := prService.Merge(ctx, prID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resultPR)
 	assert.Equal(t, model.StatusMerged, resultPR.Status)
 
-	mockPRRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
 
 	mockPRRepo.AssertExpectations(t)
 }
 
 func TestPullRequestService_Create_FailsIfAuthorNotFound(t *testing.T) {
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 
 	prToCreate := model.PullRequest{AuthorID: "non-existent-author"}
 
 	mockUserRepo.On("GetByID", mock.Anything, prToCreate.AuthorID).Return(nil, store.ErrNotFound)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	_, err := prService.Create(context.Background(), prToCreate)
 
@@ -238,6 +255,7 @@ func TestPullRequestService_Create_FailsIfAuthorNotFound(t *testing.T) {
 func TestPullRequestService_Reassign_FailsIfOldReviewerNotFound(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	openPR := &model.PullRequest{
 		ID: "pr-1", Status: model.StatusOpen, AssignedReviewers: []string{"old-reviewer"},
@@ -247,7 +265,7 @@ func TestPullRequestService_Reassign_FailsIfOldReviewerNotFound(t *testing.T) {
 	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(openPR, nil)
 	mockUserRepo.On("GetByID", context.Background(), oldReviewerID).Return(nil, store.ErrNotFound)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 	_, _, err := prService.Reassign(context.Background(), "pr-1", oldReviewerID)
 
 	assert.Error(t, err)
@@ -259,6 +277,7 @@ func TestPullRequestService_Reassign_FailsIfOldReviewerNotFound(t *testing.T) {
 
 func TestPullRequestService_Create_HandlesErrorFromGetActiveMembers(t *testing.T) {
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 
 	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
@@ -266,9 +285,10 @@ func TestPullRequestService_Create_HandlesErrorFromGetActiveMembers(t *testing.T
 
 	mockUserRepo.On("GetByID", mock.Anything, prToCreate.AuthorID).Return(author, nil)
 	expectedErr := errors.New("database error")
+	mockTeamRepo.On("GetAncestors", mock.Anything, author.TeamID).Return([]model.Team{{ID: author.TeamID}}, nil)
 	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, author.TeamID, author.ID).Return(nil, expectedErr)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	_, err := prService.Create(context.Background(), prToCreate)
 	assert.Error(t, err)
@@ -277,19 +297,21 @@ func TestPullRequestService_Create_HandlesErrorFromGetActiveMembers(t *testing.T
 }
 
 func TestPullRequestService_Merge_HandlesErrorFromRepo(t *testing.T) {
+	ctx := testAdminContext()
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	prID := "pr-1"
 	openPR := &model.PullRequest{ID: prID, Status: model.StatusOpen}
 
-	mockPRRepo.On("GetByID", context.Background(), prID).Return(openPR, nil)
+	mockPRRepo.On("GetByID", ctx, prID).Return(openPR, nil)
 	expectedErr := errors.New("concurrent update error")
-	mockPRRepo.On("Merge", context.Background(), prID).Return(expectedErr)
+	mockPRRepo.On("Merge", ctx, prID, "admin").Return(expectedErr)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
-	_, err := prService.Merge(context.Background(), prID)
+	_, err := prService.Merge(ctx, prID)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
@@ -299,6 +321,7 @@ func TestPullRequestService_Merge_HandlesErrorFromRepo(t *testing.T) {
 func TestPullRequestService_Reassign_HandlesErrorFromRepo(t *testing.T) {
 	mockPRRepo := mocks.NewPullRequestRepository(t)
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
 
 	openPR := &model.PullRequest{
 		ID: "pr-1", Status: model.StatusOpen, AssignedReviewers: []string{"old-reviewer"},
@@ -308,17 +331,437 @@ func TestPullRequestService_Reassign_HandlesErrorFromRepo(t *testing.T) {
 
 	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil)
 	mockUserRepo.On("GetByID", mock.Anything, "old-reviewer").Return(oldReviewer, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, oldReviewer.TeamID).Return([]model.Team{{ID: oldReviewer.TeamID}}, nil)
 	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, oldReviewer.TeamID, "").Return(candidates, nil)
 
 	expectedErr := errors.New("db transaction failed")
-	mockPRRepo.On("ReassignReviewer", mock.Anything, "pr-1", "old-reviewer", "new-reviewer").Return(expectedErr)
+	mockPRRepo.On("ReassignReviewer", mock.Anything, "pr-1", "old-reviewer", "new-reviewer", "admin").Return(expectedErr)
 
-	prService := NewPullRequestService(mockPRRepo, mockUserRepo)
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
 
-	_, _, err := prService.Reassign(context.Background(), "pr-1", "old-reviewer")
+	_, _, err := prService.Reassign(testAdminContext(), "pr-1", "old-reviewer")
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
 	mockPRRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
 }
+
+func TestPullRequestService_Create_UsesTargetTeam(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 1}, TeamName: "feature"}
+	prToCreate := model.PullRequest{ID: "pr-1", AuthorID: "author-1", TeamName: "platform"}
+
+	mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(author, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "author-1").Return([]model.TeamMembership{
+		{TeamID: 1, TeamName: "feature", UserID: "author-1", IsPrimary: true, Reviewable: true},
+		{TeamID: 7, TeamName: "platform", UserID: "author-1", Reviewable: false},
+	}, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 7).Return([]model.Team{{ID: 7}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 7, "author-1").Return([]model.User{{ID: "guild-1", TeamID: 3}}, nil)
+	mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr model.PullRequest) bool {
+		return pr.TeamID == 7 && len(pr.AssignedReviewers) == 1 && pr.AssignedReviewers[0] == "guild-1"
+	})).Return(nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&model.PullRequest{ID: "pr-1", TeamID: 7}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	createdPR, err := prService.Create(context.Background(), prToCreate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, createdPR.TeamID)
+}
+
+func TestPullRequestService_Create_FailsIfAuthorNotInTargetTeam(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 1}, TeamName: "feature"}
+	prToCreate := model.PullRequest{ID: "pr-1", AuthorID: "author-1", TeamName: "platform"}
+
+	mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(author, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "author-1").Return([]model.TeamMembership{
+		{TeamID: 1, TeamName: "feature", UserID: "author-1", IsPrimary: true, Reviewable: true},
+	}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, err := prService.Create(context.Background(), prToCreate)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrNotTeamMember, err)
+	mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_Reassign_UsesPRTeam(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	openPR := &model.PullRequest{
+		ID: "pr-1", AuthorID: "author-1", TeamID: 7, Status: model.StatusOpen, AssignedReviewers: []string{"old-reviewer"},
+	}
+	oldReviewer := &model.FullUserInfo{User: model.User{ID: "old-reviewer", TeamID: 123}}
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil).Once()
+	mockUserRepo.On("GetByID", mock.Anything, "old-reviewer").Return(oldReviewer, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 7).Return([]model.Team{{ID: 7}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 7, "").Return([]model.User{{ID: "guild-1"}}, nil)
+	mockPRRepo.On("ReassignReviewer", mock.Anything, "pr-1", "old-reviewer", "guild-1", "admin").Return(nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil).Once()
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, newReviewerID, err := prService.Reassign(testAdminContext(), "pr-1", "old-reviewer")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "guild-1", newReviewerID)
+}
+
+func TestPullRequestService_Create_UsesInheritedReviewerCount(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	reviewerCount := 3
+	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
+	chain := []model.Team{
+		{ID: 123, ParentID: 10},
+		{ID: 10, Policy: model.AssignmentPolicy{ReviewerCount: &reviewerCount}},
+	}
+	candidates := []model.User{{ID: "user-A"}, {ID: "user-B"}, {ID: "user-C"}, {ID: "user-D"}}
+
+	mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 123).Return(chain, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 123, "author-1").Return(candidates, nil)
+	mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr model.PullRequest) bool {
+		return len(pr.AssignedReviewers) == 3
+	})).Return(nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&model.PullRequest{ID: "pr-1"}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, err := prService.Create(context.Background(), model.PullRequest{ID: "pr-1", AuthorID: "author-1"})
+
+	assert.NoError(t, err)
+}
+
+func TestPullRequestService_Create_FallsBackToParentTeam(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	fallback := true
+	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
+	chain := []model.Team{
+		{ID: 123, ParentID: 10},
+		{ID: 10, Policy: model.AssignmentPolicy{FallbackToParent: &fallback}},
+	}
+
+	mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 123).Return(chain, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 123, "author-1").Return([]model.User{}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 10, "author-1").Return([]model.User{{ID: "lead-1", TeamID: 10}}, nil)
+	mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr model.PullRequest) bool {
+		return len(pr.AssignedReviewers) == 1 && pr.AssignedReviewers[0] == "lead-1"
+	})).Return(nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&model.PullRequest{ID: "pr-1"}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, err := prService.Create(context.Background(), model.PullRequest{ID: "pr-1", AuthorID: "author-1"})
+
+	assert.NoError(t, err)
+}
+
+func TestPullRequestService_Reassign_DoesNotFallBackWhenDisabled(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	fallback := true
+	disabled := false
+	openPR := &model.PullRequest{
+		ID: "pr-1", AuthorID: "author-1", TeamID: 123, Status: model.StatusOpen, AssignedReviewers: []string{"old-reviewer"},
+	}
+	chain := []model.Team{
+		{ID: 123, ParentID: 10, Policy: model.AssignmentPolicy{FallbackToParent: &disabled}},
+		{ID: 10, Policy: model.AssignmentPolicy{FallbackToParent: &fallback}},
+	}
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "old-reviewer").Return(&model.FullUserInfo{User: model.User{ID: "old-reviewer", TeamID: 123}}, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 123).Return(chain, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 123, "").Return([]model.User{{ID: "old-reviewer"}}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, _, err := prService.Reassign(testAdminContext(), "pr-1", "old-reviewer")
+
+	assert.Equal(t, ErrNoCandidates, err)
+	mockUserRepo.AssertNotCalled(t, "GetActiveTeamMembers", mock.Anything, 10, "")
+}
+
+func TestPullRequestService_Merge_OnlyAuthorOrAdmin(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	mergedPR := &model.PullRequest{ID: "pr-1", AuthorID: "author", Status: model.StatusMerged}
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(mergedPR, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	reviewer := WithPrincipal(context.Background(), model.Principal{UserID: "reviewer", Role: model.RoleTeamLead})
+	_, err := prService.Merge(reviewer, "pr-1")
+	assert.Equal(t, ErrForbidden, err)
+
+	author := WithPrincipal(context.Background(), model.Principal{UserID: "author", Role: model.RoleMember})
+	resultPR, err := prService.Merge(author, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusMerged, resultPR.Status)
+}
+
+func TestPullRequestService_Reassign_RequiresSharedTeamOrLead(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	openPR := &model.PullRequest{
+		ID: "pr-1", AuthorID: "author-1", TeamID: 7, Status: model.StatusOpen, AssignedReviewers: []string{"old-reviewer"},
+	}
+	oldReviewer := &model.FullUserInfo{User: model.User{ID: "old-reviewer", TeamID: 7}}
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "old-reviewer").Return(oldReviewer, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "old-reviewer").Return([]model.TeamMembership{{TeamID: 7}}, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "outsider").Return([]model.TeamMembership{{TeamID: 9}}, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "teammate").Return([]model.TeamMembership{{TeamID: 9}, {TeamID: 7}}, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "other-lead").Return([]model.TeamMembership{{TeamID: 7, IsPrimary: true}}, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "foreign-lead").Return([]model.TeamMembership{{TeamID: 9, IsPrimary: true}}, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 7).Return([]model.Team{{ID: 7}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 7, "").Return([]model.User{{ID: "new-reviewer"}}, nil)
+	mockPRRepo.On("ReassignReviewer", mock.Anything, "pr-1", "old-reviewer", "new-reviewer", "teammate").Return(nil).Once()
+	mockPRRepo.On("ReassignReviewer", mock.Anything, "pr-1", "old-reviewer", "new-reviewer", "other-lead").Return(nil).Once()
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	outsider := WithPrincipal(context.Background(), model.Principal{UserID: "outsider", Role: model.RoleMember})
+	_, _, err := prService.Reassign(outsider, "pr-1", "old-reviewer")
+	assert.Equal(t, ErrForbidden, err)
+
+	teammate := WithPrincipal(context.Background(), model.Principal{UserID: "teammate", Role: model.RoleMember})
+	_, _, err = prService.Reassign(teammate, "pr-1", "old-reviewer")
+	assert.NoError(t, err)
+
+	lead := WithPrincipal(context.Background(), model.Principal{UserID: "other-lead", Role: model.RoleTeamLead})
+	_, _, err = prService.Reassign(lead, "pr-1", "old-reviewer")
+	assert.NoError(t, err)
+
+	foreignLead := WithPrincipal(context.Background(), model.Principal{UserID: "foreign-lead", Role: model.RoleTeamLead})
+	_, _, err = prService.Reassign(foreignLead, "pr-1", "old-reviewer")
+	assert.Equal(t, ErrForbidden, err)
+}
+
+func TestPullRequestService_Create_RecordsActor(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "ci-bot", Role: model.RoleBot})
+
+	mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 123).Return([]model.Team{{ID: 123}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 123, "author-1").Return([]model.User{}, nil)
+	mockPRRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr model.PullRequest) bool {
+		return pr.CreatedBy == "ci-bot"
+	})).Return(nil)
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&model.PullRequest{ID: "pr-1", CreatedBy: "ci-bot"}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	createdPR, err := prService.Create(ctx, model.PullRequest{ID: "pr-1", AuthorID: "author-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-bot", createdPR.CreatedBy)
+}
+
+func TestPullRequestService_Close_AuthorOrAdmin(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	openPR := &model.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: model.StatusOpen}
+	closedPR := &model.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: model.StatusClosed, ClosedBy: "author-1"}
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil).Twice()
+	mockPRRepo.On("Close", mock.Anything, "pr-1", "author-1").Return(nil).Once()
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(closedPR, nil).Once()
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	memberCtx := WithPrincipal(context.Background(), model.Principal{UserID: "someone-else", Role: model.RoleMember})
+	_, err := prService.Close(memberCtx, "pr-1")
+	assert.Equal(t, ErrForbidden, err)
+
+	authorCtx := WithPrincipal(context.Background(), model.Principal{UserID: "author-1", Role: model.RoleMember})
+	result, err := prService.Close(authorCtx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusClosed, result.Status)
+	assert.Equal(t, "author-1", result.ClosedBy)
+}
+
+func TestPullRequestService_Close_MergedAndClosedStates(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	mockPRRepo.On("GetByID", mock.Anything, "merged").Return(&model.PullRequest{ID: "merged", Status: model.StatusMerged}, nil)
+	mockPRRepo.On("GetByID", mock.Anything, "closed").Return(&model.PullRequest{ID: "closed", Status: model.StatusClosed}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, err := prService.Close(testAdminContext(), "merged")
+	assert.Equal(t, ErrPRMerged, err)
+
+	result, err := prService.Close(testAdminContext(), "closed")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusClosed, result.Status)
+
+	_, err = prService.Merge(testAdminContext(), "closed")
+	assert.Equal(t, ErrPRClosed, err)
+
+	_, _, err = prService.Reassign(testAdminContext(), "closed", "user-A")
+	assert.Equal(t, ErrPRClosed, err)
+}
+
+func TestPullRequestService_SubmitVerdict(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	openPR := &model.PullRequest{ID: "pr-1", Status: model.StatusOpen, AssignedReviewers: []string{"user-A"}}
+	reviewedPR := &model.PullRequest{
+		ID:                "pr-1",
+		Status:            model.StatusOpen,
+		AssignedReviewers: []string{"user-A"},
+		Verdicts:          map[string]model.Verdict{"user-A": model.VerdictApproved},
+	}
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil).Once()
+	mockPRRepo.On("SetVerdict", mock.Anything, "pr-1", "user-A", model.VerdictApproved, "user-A").Return(nil).Once()
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(reviewedPR, nil).Once()
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(openPR, nil).Once()
+	mockPRRepo.On("SetVerdict", mock.Anything, "pr-1", "user-B", model.VerdictCommented, "user-B").Return(store.ErrNotFound).Once()
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, err := prService.SubmitVerdict(testAdminContext(), "pr-1", "user-A", "LGTM")
+	assert.Equal(t, ErrInvalidVerdict, err)
+
+	otherCtx := WithPrincipal(context.Background(), model.Principal{UserID: "user-B", Role: model.RoleMember})
+	_, err = prService.SubmitVerdict(otherCtx, "pr-1", "user-A", model.VerdictApproved)
+	assert.Equal(t, ErrForbidden, err)
+
+	reviewerCtx := WithPrincipal(context.Background(), model.Principal{UserID: "user-A", Role: model.RoleMember})
+	result, err := prService.SubmitVerdict(reviewerCtx, "pr-1", "user-A", model.VerdictApproved)
+	require.NoError(t, err)
+	assert.Equal(t, model.VerdictApproved, result.Verdicts["user-A"])
+
+	_, err = prService.SubmitVerdict(otherCtx, "pr-1", "user-B", model.VerdictCommented)
+	assert.Equal(t, ErrNotAssigned, err)
+}
+
+func TestPullRequestService_Reopen(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	closedPR := &model.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: model.StatusClosed}
+	reopenedPR := &model.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: model.StatusOpen}
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(closedPR, nil).Twice()
+	mockPRRepo.On("Reopen", mock.Anything, "pr-1", "author-1").Return(nil).Once()
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(reopenedPR, nil).Once()
+	mockPRRepo.On("GetByID", mock.Anything, "merged").Return(&model.PullRequest{ID: "merged", Status: model.StatusMerged}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	memberCtx := WithPrincipal(context.Background(), model.Principal{UserID: "someone-else", Role: model.RoleMember})
+	_, err := prService.Reopen(memberCtx, "pr-1")
+	assert.Equal(t, ErrForbidden, err)
+
+	authorCtx := WithPrincipal(context.Background(), model.Principal{UserID: "author-1", Role: model.RoleMember})
+	result, err := prService.Reopen(authorCtx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusOpen, result.Status)
+
+	_, err = prService.Reopen(testAdminContext(), "merged")
+	assert.Equal(t, ErrPRMerged, err)
+}
+
+func TestPullRequestService_Create_LinksIssueKeys(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	author := &model.FullUserInfo{User: model.User{ID: "author-1", TeamID: 123}}
+	mockUserRepo.On("GetByID", context.Background(), "author-1").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, author.TeamID).Return([]model.Team{{ID: author.TeamID}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", context.Background(), author.TeamID, author.ID).Return([]model.User{}, nil)
+
+	var created model.PullRequest
+	mockPRRepo.On("Create", context.Background(), mock.AnythingOfType("model.PullRequest")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(model.PullRequest) }).
+		Return(nil)
+	mockPRRepo.On("GetByID", context.Background(), "pr-1").Return(&model.PullRequest{ID: "pr-1"}, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, err := prService.Create(context.Background(), model.PullRequest{ID: "pr-1", Name: "PROJ-123: fix login (see OPS-7, PROJ-123)", AuthorID: "author-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROJ-123", "OPS-7"}, created.IssueKeys)
+}
+
+func TestPullRequestService_BackfillIssueKeys(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	mockPRRepo.On("ListNames", context.Background()).Return(map[string]string{"pr-1": "Fixes #7", "pr-2": "PROJ-1: login"}, nil)
+	mockPRRepo.On("ReplaceIssueKeys", context.Background(), map[string][]string{"pr-1": {"7"}, "pr-2": nil}).Return(1, nil)
+
+	prService := NewPullRequestService(mockPRRepo, mocks.NewUserRepository(t), mocks.NewTeamRepository(t))
+	prService.issuePatterns = []*regexp.Regexp{regexp.MustCompile(`(?i)fixes\s+#(\d+)`)}
+
+	linked, err := prService.BackfillIssueKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, linked)
+}
+
+func TestExtractIssueKeys(t *testing.T) {
+	hashes := regexp.MustCompile(`(?i)\bgh-(\d+)\b`)
+	prefixed := regexp.MustCompile(`(?i)\b(?:fixes|refs)\s+(core-\d+)`)
+
+	assert.Equal(t, []string{"PROJ-1", "AB_2-10"}, ExtractIssueKeys("PROJ-1 and AB_2-10, not proj-2, X-1 or PROJ-0", []*regexp.Regexp{DefaultIssueKeyPattern}))
+	assert.Equal(t, []string{"CORE-42", "17"}, ExtractIssueKeys("Refs core-42, closes gh-17", []*regexp.Regexp{prefixed, hashes}), "the first capture group is the key")
+	assert.Empty(t, ExtractIssueKeys("fix login", []*regexp.Regexp{DefaultIssueKeyPattern}))
+}
+
+func TestPullRequestService_ListByIssueKey(t *testing.T) {
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+	prService := NewPullRequestService(mockPRRepo, mocks.NewUserRepository(t), mocks.NewTeamRepository(t))
+
+	prs := []model.PullRequest{{ID: "pr-1", IssueKeys: []string{"PROJ-123"}}}
+	mockPRRepo.On("GetByIssueKey", mock.Anything, "PROJ-123").Return(prs, nil)
+
+	got, err := prService.ListByIssueKey(context.Background(), " proj-123 ")
+	require.NoError(t, err)
+	assert.Equal(t, prs, got)
+
+	_, err = prService.ListByIssueKey(context.Background(), "  ")
+	assert.ErrorIs(t, err, ErrInvalidIssueKey)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	reviewerSyncBatch        = 20
+	reviewerSyncLease        = 2 * time.Minute
+	reviewerSyncPoll         = 5 * time.Second
+	reviewerSyncBaseBackoff  = 30 * time.Second
+	reviewerSyncMaxBackoff   = time.Hour
+	reviewerSyncMaxAttempts  = 10
+	defaultReconcileInterval = time.Hour
+)
+
+type ReviewerSyncService struct {
+	client       CodeHostClient
+	jobRepo      ReviewerSyncRepository
+	prRepo       PullRequestRepository
+	identityRepo IdentityRepository
+	reconcile    time.Duration
+	wake         chan struct{}
+	now          func() time.Time
+}
+
+func NewReviewerSyncService(client CodeHostClient, jobRepo ReviewerSyncRepository, prRepo PullRequestRepository, identityRepo IdentityRepository, reconcileInterval time.Duration) *ReviewerSyncService {
+	if reconcileInterval <= 0 {
+		reconcileInterval = defaultReconcileInterval
+	}
+
+	return &ReviewerSyncService{
+		client:       client,
+		jobRepo:      jobRepo,
+		prRepo:       prRepo,
+		identityRepo: identityRepo,
+		reconcile:    reconcileInterval,
+		wake:         make(chan struct{}, 1),
+		now:          time.Now,
+	}
+}
+
+func (s *ReviewerSyncService) Enqueue(ctx context.Context, prID string) error {
+	if !s.client.Handles(prID) {
+		return nil
+	}
+
+	if err := s.jobRepo.Enqueue(ctx, prID); err != nil {
+		return err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (s *ReviewerSyncService) Sync(ctx context.Context, prID string) error {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if pr.Status != model.StatusOpen {
+		return nil
+	}
+
+	provider := s.client.Provider()
+	assigned := make(map[string]struct{}, len(pr.AssignedReviewers))
+	desired := make(map[string]struct{}, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		assigned[reviewerID] = struct{}{}
+		if _, reviewed := pr.Verdicts[reviewerID]; reviewed {
+			continue
+		}
+
+		identities, err := s.identityRepo.List(ctx, provider, reviewerID)
+		if err != nil {
+			return err
+		}
+		if len(identities) > 0 {
+			desired[identities[0].ExternalID] = struct{}{}
+		}
+	}
+
+	requested, err := s.client.RequestedReviewers(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]struct{}, len(requested))
+	var toRemove []string
+	for _, login := range requested {
+		current[login] = struct{}{}
+		if _, keep := desired[login]; keep {
+			continue
+		}
+
+		userID, err := s.identityRepo.Resolve(ctx, provider, login)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if _, isReviewer := assigned[userID]; !isReviewer {
+			toRemove = append(toRemove, login)
+		}
+	}
+
+	var toAdd []string
+	for login := range desired {
+		if _, ok := current[login]; !ok {
+			toAdd = append(toAdd, login)
+		}
+	}
+
+	if len(toRemove) > 0 {
+		if err := s.client.RemoveReviewers(ctx, prID, toRemove); err != nil {
+			return err
+		}
+	}
+
+	if len(toAdd) > 0 {
+		if err := s.client.RequestReviewers(ctx, prID, toAdd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ReviewerSyncService) ProcessDue(ctx context.Context) (int, error) {
+	jobs, err := s.jobRepo.ClaimDue(ctx, reviewerSyncBatch, reviewerSyncLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		syncErr := s.Sync(ctx, job.PullRequestID)
+		if syncErr == nil || job.Attempts+1 >= reviewerSyncMaxAttempts {
+			if syncErr != nil {
+				log.Printf("Giving up reviewer sync for %s after %d attempts: %v", job.PullRequestID, job.Attempts+1, syncErr)
+			}
+			err = s.jobRepo.Complete(ctx, job.PullRequestID, job.Generation)
+		} else {
+			err = s.jobRepo.Retry(ctx, job.PullRequestID, job.Generation, s.now().Add(exponentialBackoff(job.Attempts, reviewerSyncBaseBackoff, reviewerSyncMaxBackoff)), syncErr.Error())
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(jobs), nil
+}
+
+func (s *ReviewerSyncService) Reconcile(ctx context.Context) error {
+	ids, err := s.prRepo.ListOpenIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.Enqueue(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ReviewerSyncService) Run(ctx context.Context) {
+	poll := time.NewTicker(reviewerSyncPoll)
+	defer poll.Stop()
+	reconcile := time.NewTicker(s.reconcile)
+	defer reconcile.Stop()
+
+	if err := s.Reconcile(ctx); err != nil {
+		log.Printf("Reviewer reconciliation failed: %v", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reconcile.C:
+			if err := s.Reconcile(ctx); err != nil {
+				log.Printf("Reviewer reconciliation failed: %v", err)
+			}
+		case <-poll.C:
+		case <-s.wake:
+		}
+
+		for {
+			processed, err := s.ProcessDue(ctx)
+			if err != nil {
+				log.Printf("Reviewer sync failed: %v", err)
+			}
+			if err != nil || processed < reviewerSyncBatch {
+				break
+			}
+		}
+	}
+}
+
+func exponentialBackoff(attempts int, base, limit time.Duration) time.Duration {
+	backoff := base
+	for i := 0; i < attempts && backoff < limit; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, limit)
+}
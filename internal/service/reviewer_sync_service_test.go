@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type reviewerSyncTestRepos struct {
+	client     *mocks.CodeHostClient
+	jobs       *mocks.ReviewerSyncRepository
+	prs        *mocks.PullRequestRepository
+	identities *mocks.IdentityRepository
+}
+
+func newTestReviewerSyncService(t *testing.T) (*ReviewerSyncService, reviewerSyncTestRepos) {
+	repos := reviewerSyncTestRepos{
+		client:     mocks.NewCodeHostClient(t),
+		jobs:       mocks.NewReviewerSyncRepository(t),
+		prs:        mocks.NewPullRequestRepository(t),
+		identities: mocks.NewIdentityRepository(t),
+	}
+	repos.client.On("Provider").Return(model.ProviderGitHub).Maybe()
+
+	syncService := NewReviewerSyncService(repos.client, repos.jobs, repos.prs, repos.identities, 0)
+	syncService.now = func() time.Time { return time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC) }
+
+	return syncService, repos
+}
+
+func linkedIdentity(userID, login string) []model.ExternalIdentity {
+	return []model.ExternalIdentity{{Provider: model.ProviderGitHub, ExternalID: login, UserID: userID}}
+}
+
+func TestReviewerSyncService_Sync_RepairsDrift(t *testing.T) {
+	syncService, repos := newTestReviewerSyncService(t)
+
+	pr := &model.PullRequest{
+		ID:                "acme/api#42",
+		Status:            model.StatusOpen,
+		AssignedReviewers: []string{"bob", "carol", "dave", "erin"},
+		Verdicts:          map[string]model.Verdict{"dave": model.VerdictApproved},
+	}
+
+	repos.prs.On("GetByID", mock.Anything, "acme/api#42").Return(pr, nil)
+	repos.identities.On("List", mock.Anything, model.ProviderGitHub, "bob").Return(linkedIdentity("bob", "bob-gh"), nil)
+	repos.identities.On("List", mock.Anything, model.ProviderGitHub, "carol").Return(linkedIdentity("carol", "carol-gh"), nil)
+	repos.identities.On("List", mock.Anything, model.ProviderGitHub, "erin").Return([]model.ExternalIdentity{}, nil)
+	repos.client.On("RequestedReviewers", mock.Anything, "acme/api#42").Return([]string{"carol-gh", "alice-gh", "outsider"}, nil)
+	repos.identities.On("Resolve", mock.Anything, model.ProviderGitHub, "alice-gh").Return("alice", nil)
+	repos.identities.On("Resolve", mock.Anything, model.ProviderGitHub, "outsider").Return("", store.ErrNotFound)
+	repos.client.On("RemoveReviewers", mock.Anything, "acme/api#42", []string{"alice-gh"}).Return(nil)
+	repos.client.On("RequestReviewers", mock.Anything, "acme/api#42", []string{"bob-gh"}).Return(nil)
+
+	require.NoError(t, syncService.Sync(context.Background(), "acme/api#42"))
+}
+
+func TestReviewerSyncService_Sync_SkipsClosedAndMissingPRs(t *testing.T) {
+	syncService, repos := newTestReviewerSyncService(t)
+
+	repos.prs.On("GetByID", mock.Anything, "acme/api#1").Return(&model.PullRequest{ID: "acme/api#1", Status: model.StatusMerged}, nil)
+	repos.prs.On("GetByID", mock.Anything, "acme/api#2").Return(nil, store.ErrNotFound)
+
+	require.NoError(t, syncService.Sync(context.Background(), "acme/api#1"))
+	require.NoError(t, syncService.Sync(context.Background(), "acme/api#2"))
+}
+
+func TestReviewerSyncService_ProcessDue_RetriesWithBackoff(t *testing.T) {
+	syncService, repos := newTestReviewerSyncService(t)
+
+	hostErr := errors.New("github api request failed: status 502")
+	jobs := []model.ReviewerSyncJob{
+		{PullRequestID: "acme/api#1", Generation: 1, Attempts: 2},
+		{PullRequestID: "acme/api#2", Generation: 3, Attempts: reviewerSyncMaxAttempts - 1},
+		{PullRequestID: "acme/api#3", Generation: 1},
+	}
+
+	repos.jobs.On("ClaimDue", mock.Anything, reviewerSyncBatch, reviewerSyncLease).Return(jobs, nil)
+	for _, id := range []string{"acme/api#1", "acme/api#2"} {
+		repos.prs.On("GetByID", mock.Anything, id).Return(&model.PullRequest{ID: id, Status: model.StatusOpen}, nil)
+		repos.client.On("RequestedReviewers", mock.Anything, id).Return(nil, hostErr)
+	}
+	repos.prs.On("GetByID", mock.Anything, "acme/api#3").Return(&model.PullRequest{ID: "acme/api#3", Status: model.StatusOpen}, nil)
+	repos.client.On("RequestedReviewers", mock.Anything, "acme/api#3").Return([]string{}, nil)
+
+	expectedRetry := syncService.now().Add(2 * time.Minute)
+	repos.jobs.On("Retry", mock.Anything, "acme/api#1", 1, expectedRetry, hostErr.Error()).Return(nil)
+	repos.jobs.On("Complete", mock.Anything, "acme/api#2", 3).Return(nil)
+	repos.jobs.On("Complete", mock.Anything, "acme/api#3", 1).Return(nil)
+
+	processed, err := syncService.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+}
+
+func TestReviewerSyncService_Backoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, exponentialBackoff(0, reviewerSyncBaseBackoff, reviewerSyncMaxBackoff))
+	assert.Equal(t, time.Minute, exponentialBackoff(1, reviewerSyncBaseBackoff, reviewerSyncMaxBackoff))
+	assert.Equal(t, 8*time.Minute, exponentialBackoff(4, reviewerSyncBaseBackoff, reviewerSyncMaxBackoff))
+	assert.Equal(t, time.Hour, exponentialBackoff(9, reviewerSyncBaseBackoff, reviewerSyncMaxBackoff))
+}
+
+func TestReviewerSyncService_Reconcile_EnqueuesHostedOpenPRs(t *testing.T) {
+	syncService, repos := newTestReviewerSyncService(t)
+
+	repos.prs.On("ListOpenIDs", mock.Anything).Return([]string{"acme/api#1", "pr-1001"}, nil)
+	repos.client.On("Handles", "acme/api#1").Return(true)
+	repos.client.On("Handles", "pr-1001").Return(false)
+	repos.jobs.On("Enqueue", mock.Anything, "acme/api#1").Return(nil).Once()
+
+	require.NoError(t, syncService.Reconcile(context.Background()))
+}
+
+func TestPullRequestService_Create_QueuesReviewerSync(t *testing.T) {
+	syncService, repos := newTestReviewerSyncService(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	prService := NewPullRequestService(repos.prs, mockUserRepo, mockTeamRepo)
+	prService.reviewerSync = syncService
+
+	author := &model.FullUserInfo{User: model.User{ID: "alice", TeamID: 1}}
+	mockUserRepo.On("GetByID", mock.Anything, "alice").Return(author, nil)
+	mockTeamRepo.On("GetAncestors", mock.Anything, 1).Return([]model.Team{{ID: 1}}, nil)
+	mockUserRepo.On("GetActiveTeamMembers", mock.Anything, 1, "alice").Return([]model.User{{ID: "bob"}}, nil)
+	repos.prs.On("Create", mock.Anything, mock.AnythingOfType("model.PullRequest")).Return(nil)
+	repos.prs.On("GetByID", mock.Anything, "acme/api#42").Return(&model.PullRequest{ID: "acme/api#42"}, nil)
+	repos.client.On("Handles", "acme/api#42").Return(true)
+	repos.jobs.On("Enqueue", mock.Anything, "acme/api#42").Return(errors.New("db is down"))
+
+	_, err := prService.Create(testAdminContext(), model.PullRequest{ID: "acme/api#42", Name: "Sync", AuthorID: "alice"})
+	require.NoError(t, err)
+}
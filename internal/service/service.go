@@ -1,41 +1,213 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
 
 var (
-	ErrTeamExists   = errors.New("team already exists")
-	ErrPRExists     = errors.New("pr already exists")
-	ErrPRMerged     = errors.New("cannot change merged pr")
-	ErrNotAssigned  = errors.New("user is not assigned to this pr")
-	ErrNoCandidates = errors.New("no active replacement candidate in team")
-	ErrNotFound     = errors.New("resource not found")
+	ErrTeamExists             = errors.New("team already exists")
+	ErrPRExists               = errors.New("pr already exists")
+	ErrPRMerged               = errors.New("cannot change merged pr")
+	ErrNotAssigned            = errors.New("user is not assigned to this pr")
+	ErrNoCandidates           = errors.New("no active replacement candidate in team")
+	ErrNotFound               = errors.New("resource not found")
+	ErrUserInAnotherTeam      = errors.New("user is a member of another team")
+	ErrDuplicateMember        = errors.New("user is listed more than once")
+	ErrPrimaryTeam            = errors.New("team is the user's primary team")
+	ErrNotTeamMember          = errors.New("user is not a member of the team")
+	ErrHierarchyCycle         = errors.New("team cannot be nested under itself or its descendant")
+	ErrTeamArchived           = errors.New("team is archived")
+	ErrTeamNotArchived        = errors.New("team must be archived before deletion")
+	ErrTeamNotEmpty           = errors.New("team still has members, open pull requests or child teams")
+	ErrInvalidImport          = errors.New("invalid org import")
+	ErrUserExists             = errors.New("user already exists")
+	ErrInvalidCredentials     = errors.New("invalid user id or password")
+	ErrAccountLocked          = errors.New("account is temporarily locked")
+	ErrWeakPassword           = errors.New("password is too short or too long")
+	ErrForbidden              = errors.New("operation is not permitted")
+	ErrInvalidRole            = errors.New("unknown role")
+	ErrInvalidScope           = errors.New("unknown or missing api key scope")
+	ErrInvalidExpiry          = errors.New("api key expiry is out of range")
+	ErrInsufficientScope      = errors.New("api key lacks the required scope")
+	ErrInvalidRefreshToken    = errors.New("refresh token is invalid, expired or revoked")
+	ErrNoSigningKey           = errors.New("no usable signing key")
+	ErrOIDCLoginFailed        = errors.New("oidc login failed")
+	ErrOIDCUserNotProvisioned = errors.New("oidc user is not provisioned")
+	ErrPRClosed               = errors.New("cannot change closed pr")
+	ErrInvalidVerdict         = errors.New("unknown review verdict")
+	ErrInvalidIdentity        = errors.New("invalid external identity")
+	ErrInvalidSubscription    = errors.New("invalid webhook subscription")
+	ErrInvalidPreference      = errors.New("invalid notification preference")
+	ErrInvalidTemplate        = errors.New("invalid notification template")
+	ErrInvalidDigest          = errors.New("invalid digest subscription")
+	ErrInvalidCalendar        = errors.New("invalid calendar feed")
+	ErrInvalidIssueKey        = errors.New("invalid issue key")
 )
 
 type Service struct {
-	Team  *TeamService
-	User  *UserService
-	PR    *PullRequestService
-	Stats *StatsService
+	Team          *TeamService
+	User          *UserService
+	PR            *PullRequestService
+	Stats         *StatsService
+	Membership    *MembershipService
+	Import        *ImportService
+	Provisioning  *ProvisioningService
+	Auth          *AuthService
+	APIKey        *APIKeyService
+	Token         *TokenService
+	Keys          *KeyService
+	OIDC          *OIDCService
+	Identity      *IdentityService
+	CodeHost      *CodeHostService
+	ChatOps       *ChatOpsService
+	ReviewerSync  *ReviewerSyncService
+	Subscriptions *SubscriptionService
+	Outbox        *OutboxRelay
+	Notifications *NotificationService
+	Digests       *DigestService
+	Calendars     *CalendarService
+	IssueTracker  *IssueTrackerService
 }
 
 type Dependencies struct {
-	TeamRepo  TeamRepository
-	UserRepo  UserRepository
-	PRRepo    PullRequestRepository
-	StatsRepo StatsRepository
+	TeamRepo   TeamRepository
+	UserRepo   UserRepository
+	PRRepo     PullRequestRepository
+	StatsRepo  StatsRepository
+	APIKeyRepo APIKeyRepository
+	TokenRepo  TokenRepository
+
+	SigningKeyRepo      SigningKeyRepository
+	JWTAlgorithm        model.SigningAlgorithm
+	KeyRotationInterval time.Duration
+
+	OIDCProvider  OIDCProvider
+	OIDCStateRepo OIDCStateRepository
+	OIDC          OIDCSettings
+
+	IdentityRepo        IdentityRepository
+	WebhookDeliveryRepo WebhookDeliveryRepository
+
+	CodeHostClient    CodeHostClient
+	ReviewerSyncRepo  ReviewerSyncRepository
+	ReconcileInterval time.Duration
+
+	SubscriptionRepo SubscriptionRepository
+	WebhookSender    WebhookSender
+
+	OutboxRepo OutboxRepository
+	EventSinks []EventSink
+
+	NotificationRepo NotificationRepository
+	Notifiers        []Notifier
+
+	DigestRepo DigestRepository
+
+	CalendarRepo         CalendarRepository
+	CalendarFetcher      CalendarFetcher
+	CalendarSyncInterval time.Duration
+
+	IssueKeyPatterns []*regexp.Regexp
+	IssueTracker     IssueTracker
+	IssueTrackerRepo IssueTrackerRepository
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+
+	return limit
 }
 
 func NewService(d Dependencies) *Service {
 	teamService := NewTeamService(d.TeamRepo)
 	userService := NewUserService(d.UserRepo, d.PRRepo)
-	prService := NewPullRequestService(d.PRRepo, d.UserRepo)
+	prService := NewPullRequestService(d.PRRepo, d.UserRepo, d.TeamRepo)
+	if len(d.IssueKeyPatterns) > 0 {
+		prService.issuePatterns = d.IssueKeyPatterns
+	}
 	statsService := NewStatsService(d.StatsRepo)
+	membershipService := NewMembershipService(d.TeamRepo, d.UserRepo, d.PRRepo)
+	importService := NewImportService(d.TeamRepo, d.UserRepo)
+	provisioningService := NewProvisioningService(d.TeamRepo, d.UserRepo, membershipService)
+	authService := NewAuthService(d.UserRepo)
+	apiKeyService := NewAPIKeyService(d.APIKeyRepo)
+	tokenService := NewTokenService(d.TokenRepo, d.UserRepo)
+	keyService := NewKeyService(d.SigningKeyRepo, d.JWTAlgorithm, d.KeyRotationInterval)
+	identityService := NewIdentityService(d.IdentityRepo)
+	codeHostService := NewCodeHostService(d.WebhookDeliveryRepo, identityService, prService)
 
 	service := &Service{
-		Team:  teamService,
-		User:  userService,
-		PR:    prService,
-		Stats: statsService,
+		Team:         teamService,
+		User:         userService,
+		PR:           prService,
+		Stats:        statsService,
+		Membership:   membershipService,
+		Import:       importService,
+		Provisioning: provisioningService,
+		Auth:         authService,
+		APIKey:       apiKeyService,
+		Token:        tokenService,
+		Keys:         keyService,
+		Identity:     identityService,
+		CodeHost:     codeHostService,
+		ChatOps:      NewChatOpsService(identityService, d.UserRepo, userService, prService, statsService),
+	}
+
+	if d.CodeHostClient != nil {
+		service.ReviewerSync = NewReviewerSyncService(d.CodeHostClient, d.ReviewerSyncRepo, d.PRRepo, d.IdentityRepo, d.ReconcileInterval)
+		prService.reviewerSync = service.ReviewerSync
+	}
+
+	if d.SubscriptionRepo != nil {
+		service.Subscriptions = NewSubscriptionService(d.SubscriptionRepo, d.WebhookSender)
+	}
+
+	if d.NotificationRepo != nil {
+		service.Notifications = NewNotificationService(d.NotificationRepo, d.UserRepo, d.Notifiers...)
+
+		if d.DigestRepo != nil {
+			service.Digests = NewDigestService(d.DigestRepo, d.UserRepo, d.NotificationRepo, d.Notifiers...)
+		}
+	}
+
+	if d.CalendarRepo != nil {
+		service.Calendars = NewCalendarService(d.CalendarRepo, d.UserRepo, d.TeamRepo, d.CalendarFetcher, d.CalendarSyncInterval)
+	}
+
+	if d.IssueTracker != nil {
+		service.IssueTracker = NewIssueTrackerService(d.IssueTracker, d.IssueTrackerRepo)
+	}
+
+	if d.OutboxRepo != nil {
+		sinks := append([]EventSink{}, d.EventSinks...)
+		if service.Subscriptions != nil {
+			sinks = append(sinks, service.Subscriptions)
+		}
+		if service.Notifications != nil {
+			sinks = append(sinks, service.Notifications)
+		}
+		if service.IssueTracker != nil {
+			sinks = append(sinks, service.IssueTracker)
+		}
+		service.Outbox = NewOutboxRelay(d.OutboxRepo, sinks...)
+	}
+
+	if d.OIDCProvider != nil {
+		service.OIDC = NewOIDCService(d.OIDCProvider, d.OIDCStateRepo, d.UserRepo, membershipService, d.OIDC)
 	}
 
 	return service
@@ -32,3 +32,52 @@ func (s *StatsService) GetUserStats(ctx context.Context) ([]model.UserStats, err
 
 	return stats, nil
 }
+
+func (s *StatsService) GetTeamStats(ctx context.Context, rootName string) ([]model.TeamStats, error) {
+	counts, err := s.repo.GetTeamReviewCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[int][]model.TeamReviewCount)
+	var roots []model.TeamReviewCount
+	for _, c := range counts {
+		switch {
+		case rootName != "" && c.TeamName == rootName:
+			roots = append(roots, c)
+		case rootName == "" && c.ParentID == 0:
+			roots = append(roots, c)
+		}
+		if c.ParentID != 0 {
+			children[c.ParentID] = append(children[c.ParentID], c)
+		}
+	}
+
+	if rootName != "" && len(roots) == 0 {
+		return nil, ErrNotFound
+	}
+
+	var build func(c model.TeamReviewCount) model.TeamStats
+	build = func(c model.TeamReviewCount) model.TeamStats {
+		stats := model.TeamStats{
+			TeamName:         c.TeamName,
+			ReviewCount:      c.ReviewCount,
+			TotalReviewCount: c.ReviewCount,
+			Children:         []model.TeamStats{},
+		}
+		for _, child := range children[c.TeamID] {
+			childStats := build(child)
+			stats.TotalReviewCount += childStats.TotalReviewCount
+			stats.Children = append(stats.Children, childStats)
+		}
+
+		return stats
+	}
+
+	stats := make([]model.TeamStats, 0, len(roots))
+	for _, root := range roots {
+		stats = append(stats, build(root))
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStatsService_GetTeamStats_RollsUpChildren(t *testing.T) {
+	mockStatsRepo := mocks.NewStatsRepository(t)
+
+	counts := []model.TeamReviewCount{
+		{TeamID: 2, ParentID: 1, TeamName: "backend", ReviewCount: 3},
+		{TeamID: 1, TeamName: "engineering", ReviewCount: 1},
+		{TeamID: 3, ParentID: 2, TeamName: "payments", ReviewCount: 4},
+		{TeamID: 4, TeamName: "sales", ReviewCount: 2},
+	}
+	mockStatsRepo.On("GetTeamReviewCounts", mock.Anything).Return(counts, nil)
+
+	statsService := NewStatsService(mockStatsRepo)
+
+	stats, err := statsService.GetTeamStats(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "engineering", stats[0].TeamName)
+	assert.Equal(t, 8, stats[0].TotalReviewCount)
+	assert.Equal(t, 7, stats[0].Children[0].TotalReviewCount)
+	assert.Equal(t, "payments", stats[0].Children[0].Children[0].TeamName)
+	assert.Equal(t, 2, stats[1].TotalReviewCount)
+}
+
+func TestStatsService_GetTeamStats_FailsIfRootNotFound(t *testing.T) {
+	mockStatsRepo := mocks.NewStatsRepository(t)
+	mockStatsRepo.On("GetTeamReviewCounts", mock.Anything).Return([]model.TeamReviewCount{}, nil)
+
+	statsService := NewStatsService(mockStatsRepo)
+
+	_, err := statsService.GetTeamStats(context.Background(), "ghost")
+
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/netguard"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	subscriptionSecretBytes   = 32
+	subscriptionDeliveryBatch = 20
+	subscriptionDeliveryLease = time.Minute
+	subscriptionDeliveryPoll  = 5 * time.Second
+	subscriptionBaseBackoff   = 10 * time.Second
+	subscriptionMaxBackoff    = 6 * time.Hour
+	subscriptionMaxAttempts   = 8
+)
+
+type SubscriptionService struct {
+	subscriptionRepo SubscriptionRepository
+	sender           WebhookSender
+	wake             chan struct{}
+	now              func() time.Time
+}
+
+func NewSubscriptionService(subscriptionRepo SubscriptionRepository, sender WebhookSender) *SubscriptionService {
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		sender:           sender,
+		wake:             make(chan struct{}, 1),
+		now:              time.Now,
+	}
+}
+
+func (s *SubscriptionService) Create(ctx context.Context, sub model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	target, err := url.Parse(sub.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" || !netguard.IsPublicHost(target.Hostname()) {
+		return nil, ErrInvalidSubscription
+	}
+
+	if len(sub.EventTypes) == 0 {
+		return nil, ErrInvalidSubscription
+	}
+	for _, eventType := range sub.EventTypes {
+		if !eventType.IsValid() {
+			return nil, ErrInvalidSubscription
+		}
+	}
+
+	if sub.Secret == "" {
+		sub.Secret, err = randomToken(subscriptionSecretBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sub.CreatedBy = actorID(ctx)
+
+	return s.subscriptionRepo.Create(ctx, sub)
+}
+
+func (s *SubscriptionService) List(ctx context.Context) ([]model.WebhookSubscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.subscriptionRepo.List(ctx)
+}
+
+func (s *SubscriptionService) Delete(ctx context.Context, id int) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := s.subscriptionRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *SubscriptionService) ListDeliveries(ctx context.Context, filter model.DeliveryFilter) ([]model.SubscriptionDelivery, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, ErrInvalidSubscription
+	}
+	filter.Limit = pageLimit(filter.Limit)
+
+	return s.subscriptionRepo.ListDeliveries(ctx, filter)
+}
+
+func (s *SubscriptionService) Redeliver(ctx context.Context, deliveryID int64) (*model.SubscriptionDelivery, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Redeliver(ctx, deliveryID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	s.notify()
+
+	return s.subscriptionRepo.GetDelivery(ctx, deliveryID)
+}
+
+func (s *SubscriptionService) Publish(ctx context.Context, event model.Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.now().UTC()
+	}
+
+	queued, err := s.subscriptionRepo.Enqueue(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	if queued > 0 {
+		s.notify()
+	}
+
+	return nil
+}
+
+func (s *SubscriptionService) ProcessDue(ctx context.Context) (int, error) {
+	deliveries, err := s.subscriptionRepo.ClaimDue(ctx, subscriptionDeliveryBatch, subscriptionDeliveryLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		started := s.now()
+		statusCode, sendErr := s.sender.Send(ctx, delivery)
+		attempt := model.DeliveryAttempt{
+			StatusCode:  statusCode,
+			Duration:    s.now().Sub(started),
+			AttemptedAt: started,
+		}
+
+		status := model.DeliveryDelivered
+		nextAttemptAt := started
+		if sendErr != nil {
+			attempt.Error = sendErr.Error()
+			status = model.DeliveryPending
+			nextAttemptAt = started.Add(exponentialBackoff(delivery.Attempts, subscriptionBaseBackoff, subscriptionMaxBackoff))
+			if delivery.Attempts+1 >= subscriptionMaxAttempts {
+				log.Printf("Webhook delivery %d to %s is dead after %d attempts: %v", delivery.ID, delivery.URL, delivery.Attempts+1, sendErr)
+				status = model.DeliveryDead
+			}
+		}
+
+		if err := s.subscriptionRepo.RecordAttempt(ctx, delivery.ID, attempt, status, nextAttemptAt); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (s *SubscriptionService) Run(ctx context.Context) {
+	poll := time.NewTicker(subscriptionDeliveryPoll)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-s.wake:
+		}
+
+		for {
+			processed, err := s.ProcessDue(ctx)
+			if err != nil {
+				log.Printf("Webhook delivery failed: %v", err)
+			}
+			if err != nil || processed < subscriptionDeliveryBatch {
+				break
+			}
+		}
+	}
+}
+
+func (s *SubscriptionService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
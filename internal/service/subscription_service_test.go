@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var subscriptionTestNow = time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC)
+
+func newTestSubscriptionService(t *testing.T) (*SubscriptionService, *mocks.SubscriptionRepository, *mocks.WebhookSender) {
+	repo := mocks.NewSubscriptionRepository(t)
+	sender := mocks.NewWebhookSender(t)
+
+	subscriptionService := NewSubscriptionService(repo, sender)
+	subscriptionService.now = func() time.Time { return subscriptionTestNow }
+
+	return subscriptionService, repo, sender
+}
+
+func TestSubscriptionService_Create(t *testing.T) {
+	subscriptionService, repo, _ := newTestSubscriptionService(t)
+
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(sub model.WebhookSubscription) bool {
+		return sub.URL == "https://ci.example.com/hooks" && len(sub.Secret) > 20 && sub.CreatedBy == "admin"
+	})).Return(&model.WebhookSubscription{ID: 1, URL: "https://ci.example.com/hooks"}, nil)
+
+	sub, err := subscriptionService.Create(testAdminContext(), model.WebhookSubscription{
+		URL:        "https://ci.example.com/hooks",
+		EventTypes: []model.EventType{model.EventPRMerged},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, sub.ID)
+}
+
+func TestSubscriptionService_Create_Validation(t *testing.T) {
+	subscriptionService, _, _ := newTestSubscriptionService(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		sub  model.WebhookSubscription
+		want error
+	}{
+		{
+			name: "not admin",
+			ctx:  WithPrincipal(context.Background(), model.Principal{UserID: "bob", Role: model.RoleMember}),
+			sub:  model.WebhookSubscription{URL: "https://ci.example.com", EventTypes: []model.EventType{model.EventPRMerged}},
+			want: ErrForbidden,
+		},
+		{
+			name: "relative url",
+			ctx:  testAdminContext(),
+			sub:  model.WebhookSubscription{URL: "/hooks", EventTypes: []model.EventType{model.EventPRMerged}},
+			want: ErrInvalidSubscription,
+		},
+		{
+			name: "unsupported scheme",
+			ctx:  testAdminContext(),
+			sub:  model.WebhookSubscription{URL: "ftp://ci.example.com", EventTypes: []model.EventType{model.EventPRMerged}},
+			want: ErrInvalidSubscription,
+		},
+		{
+			name: "internal host",
+			ctx:  testAdminContext(),
+			sub:  model.WebhookSubscription{URL: "http://169.254.169.254/latest/meta-data", EventTypes: []model.EventType{model.EventPRMerged}},
+			want: ErrInvalidSubscription,
+		},
+		{
+			name: "no events",
+			ctx:  testAdminContext(),
+			sub:  model.WebhookSubscription{URL: "https://ci.example.com"},
+			want: ErrInvalidSubscription,
+		},
+		{
+			name: "unknown event",
+			ctx:  testAdminContext(),
+			sub:  model.WebhookSubscription{URL: "https://ci.example.com", EventTypes: []model.EventType{"pull_request.deleted"}},
+			want: ErrInvalidSubscription,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := subscriptionService.Create(tt.ctx, tt.sub)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestSubscriptionService_Publish(t *testing.T) {
+	subscriptionService, repo, _ := newTestSubscriptionService(t)
+
+	repo.On("Enqueue", mock.Anything, mock.MatchedBy(func(event model.Event) bool {
+		return event.Type == model.EventPRMerged && event.OccurredAt.Equal(subscriptionTestNow) && event.PullRequest.ID == "pr-1"
+	})).Return(1, nil)
+
+	event := model.NewPullRequestEvent(model.EventPRMerged, "alice", model.PullRequest{ID: "pr-1", Status: model.StatusMerged})
+	require.NoError(t, subscriptionService.Publish(context.Background(), event))
+
+	select {
+	case <-subscriptionService.wake:
+	default:
+		t.Fatal("worker was not woken")
+	}
+}
+
+func TestSubscriptionService_ProcessDue(t *testing.T) {
+	subscriptionService, repo, sender := newTestSubscriptionService(t)
+
+	deliveries := []model.SubscriptionDelivery{
+		{ID: 1, URL: "https://a.example.com"},
+		{ID: 2, URL: "https://b.example.com", Attempts: 2},
+		{ID: 3, URL: "https://c.example.com", Attempts: subscriptionMaxAttempts - 1},
+	}
+	sendErr := errors.New("webhook delivery failed: status 503")
+
+	repo.On("ClaimDue", mock.Anything, subscriptionDeliveryBatch, subscriptionDeliveryLease).Return(deliveries, nil)
+	sender.On("Send", mock.Anything, deliveries[0]).Return(200, nil)
+	sender.On("Send", mock.Anything, deliveries[1]).Return(503, sendErr)
+	sender.On("Send", mock.Anything, deliveries[2]).Return(0, sendErr)
+
+	repo.On("RecordAttempt", mock.Anything, int64(1), model.DeliveryAttempt{StatusCode: 200, AttemptedAt: subscriptionTestNow}, model.DeliveryDelivered, subscriptionTestNow).Return(nil)
+	repo.On("RecordAttempt", mock.Anything, int64(2), model.DeliveryAttempt{StatusCode: 503, Error: sendErr.Error(), AttemptedAt: subscriptionTestNow}, model.DeliveryPending, subscriptionTestNow.Add(40*time.Second)).Return(nil)
+	repo.On("RecordAttempt", mock.Anything, int64(3), model.DeliveryAttempt{Error: sendErr.Error(), AttemptedAt: subscriptionTestNow}, model.DeliveryDead, mock.Anything).Return(nil)
+
+	processed, err := subscriptionService.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+}
+
+func TestSubscriptionService_Redeliver(t *testing.T) {
+	subscriptionService, repo, _ := newTestSubscriptionService(t)
+
+	repo.On("Redeliver", mock.Anything, int64(5)).Return(nil)
+	repo.On("GetDelivery", mock.Anything, int64(5)).Return(&model.SubscriptionDelivery{ID: 5, Status: model.DeliveryPending}, nil)
+	repo.On("Redeliver", mock.Anything, int64(6)).Return(store.ErrNotFound)
+
+	delivery, err := subscriptionService.Redeliver(testAdminContext(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, model.DeliveryPending, delivery.Status)
+
+	_, err = subscriptionService.Redeliver(testAdminContext(), 6)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSubscriptionService_ListDeliveries_RejectsUnknownStatus(t *testing.T) {
+	subscriptionService, _, _ := newTestSubscriptionService(t)
+
+	_, err := subscriptionService.ListDeliveries(testAdminContext(), model.DeliveryFilter{Status: "LOST"})
+	assert.ErrorIs(t, err, ErrInvalidSubscription)
+}
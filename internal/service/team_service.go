@@ -16,17 +16,26 @@ func NewTeamService(repo TeamRepository) *TeamService {
 	return &TeamService{repo: repo}
 }
 
-func (s *TeamService) Create(ctx context.Context, team model.Team, members []model.User) (*model.Team, []model.User, error) {
-	createdTeam, err := s.repo.AddTeamWithMembers(ctx, team, members)
+func (s *TeamService) Create(ctx context.Context, team model.Team, members []model.User) (*model.TeamUpsert, error) {
+	seen := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		if _, ok := seen[member.ID]; ok {
+			return nil, ErrDuplicateMember
+		}
+		seen[member.ID] = struct{}{}
+	}
+
+	team.CreatedBy = actorID(ctx)
+	result, err := s.repo.AddTeamWithMembers(ctx, team, members)
 	if err != nil {
 		if errors.Is(err, store.ErrTeamExists) {
-			return nil, nil, ErrTeamExists
+			return nil, ErrTeamExists
 		}
 
-		return nil, nil, err
+		return nil, err
 	}
 
-	return createdTeam, members, nil
+	return result, nil
 }
 
 func (s *TeamService) Get(ctx context.Context, name string) (*model.Team, []model.User, error) {
@@ -42,4 +51,133 @@ func (s *TeamService) Get(ctx context.Context, name string) (*model.Team, []mode
 	return team, members, nil
 }
 
+func (s *TeamService) GetHierarchy(ctx context.Context, team model.Team) (*model.TeamHierarchy, error) {
+	chain, err := s.repo.GetAncestors(ctx, team.ID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	children, err := s.repo.GetChildren(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TeamHierarchy{
+		Ancestors: chain[1:],
+		Children:  children,
+		Policy:    model.ResolvePolicy(chain),
+	}, nil
+}
+
+func (s *TeamService) SetParent(ctx context.Context, teamName, parentName string) (*model.Team, error) {
+	team, err := s.repo.SetParent(ctx, teamName, parentName, actorID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		if errors.Is(err, store.ErrHierarchyCycle) {
+			return nil, ErrHierarchyCycle
+		}
+
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (s *TeamService) SetPolicy(ctx context.Context, teamName string, policy model.AssignmentPolicy) (*model.Team, error) {
+	team, err := s.repo.SetPolicy(ctx, teamName, policy, actorID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
 
+	return team, nil
+}
+
+func (s *TeamService) Rename(ctx context.Context, teamName, newName string) (*model.Team, error) {
+	team, err := s.repo.Rename(ctx, teamName, newName, actorID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		if errors.Is(err, store.ErrTeamExists) {
+			return nil, ErrTeamExists
+		}
+
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (s *TeamService) SetArchived(ctx context.Context, teamName string, archived bool) (*model.Team, error) {
+	team, err := s.repo.SetArchived(ctx, teamName, archived, actorID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (s *TeamService) GetDeletionReport(ctx context.Context, teamName string) (*model.TeamDeletionReport, error) {
+	team, _, err := s.Get(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDeletionReport(ctx, *team)
+}
+
+func (s *TeamService) Delete(ctx context.Context, teamName string, force bool) (*model.TeamDeletionReport, error) {
+	report, err := s.GetDeletionReport(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Team.ArchivedAt == nil {
+		return nil, ErrTeamNotArchived
+	}
+
+	if !force && !report.IsEmpty() {
+		return nil, ErrTeamNotEmpty
+	}
+
+	if err := s.repo.Delete(ctx, report.Team.ID, actorID(ctx)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *TeamService) List(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, string, error) {
+	limit := pageLimit(filter.Limit)
+	filter.Limit = limit + 1
+
+	teams, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(teams) <= limit {
+		return teams, "", nil
+	}
+
+	teams = teams[:limit]
+	return teams, teams[limit-1].Team.Name, nil
+}
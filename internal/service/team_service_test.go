@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/DeadlyParkour777/pr-service/internal/store"
@@ -18,18 +19,33 @@ func TestTeamService_Create_Success(t *testing.T) {
 	teamToCreate := model.Team{Name: "backend"}
 	membersToCreate := []model.User{{ID: "u1"}}
 
-	createdTeamWithID := model.Team{ID: 1, Name: "backend"}
-	mockTeamRepo.On("AddTeamWithMembers", mock.Anything, teamToCreate, membersToCreate).Return(&createdTeamWithID, nil)
+	upsertResult := model.TeamUpsert{
+		Team:    model.Team{ID: 1, Name: "backend"},
+		Created: []model.User{{ID: "u1", TeamID: 1}},
+	}
+	mockTeamRepo.On("AddTeamWithMembers", mock.Anything, teamToCreate, membersToCreate).Return(&upsertResult, nil)
 	teamService := NewTeamService(mockTeamRepo)
 
-	resultTeam, resultMembers, err := teamService.Create(context.Background(), teamToCreate, membersToCreate)
+	result, err := teamService.Create(context.Background(), teamToCreate, membersToCreate)
 
 	assert.NoError(t, err)
-	assert.Equal(t, &createdTeamWithID, resultTeam)
-	assert.Equal(t, membersToCreate, resultMembers)
+	assert.Equal(t, &upsertResult, result)
 	mockTeamRepo.AssertExpectations(t)
 }
 
+func TestTeamService_Create_RecordsCreator(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	mockTeamRepo.On("AddTeamWithMembers", mock.Anything, model.Team{Name: "backend", CreatedBy: "admin"}, []model.User(nil)).
+		Return(&model.TeamUpsert{Team: model.Team{ID: 1, Name: "backend", CreatedBy: "admin"}}, nil)
+	teamService := NewTeamService(mockTeamRepo)
+
+	result, err := teamService.Create(testAdminContext(), model.Team{Name: "backend"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", result.Team.CreatedBy)
+}
+
 func TestTeamService_Create_FailsIfTeamExists(t *testing.T) {
 	mockTeamRepo := mocks.NewTeamRepository(t)
 	teamToCreate := model.Team{Name: "backend"}
@@ -37,7 +53,7 @@ func TestTeamService_Create_FailsIfTeamExists(t *testing.T) {
 
 	teamService := NewTeamService(mockTeamRepo)
 
-	_, _, err := teamService.Create(context.Background(), teamToCreate, nil)
+	_, err := teamService.Create(context.Background(), teamToCreate, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrTeamExists, err)
@@ -108,9 +124,159 @@ func TestTeamService_Create_FailsOnGenericRepoError(t *testing.T) {
 
 	teamService := NewTeamService(mockTeamRepo)
 
-	_, _, err := teamService.Create(context.Background(), teamToCreate, nil)
+	_, err := teamService.Create(context.Background(), teamToCreate, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedErr, err)
 	mockTeamRepo.AssertExpectations(t)
 }
+
+func TestTeamService_Create_FailsOnDuplicateMembers(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	teamToCreate := model.Team{Name: "backend"}
+	membersToCreate := []model.User{{ID: "u1"}, {ID: "u2"}, {ID: "u1"}}
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	_, err := teamService.Create(context.Background(), teamToCreate, membersToCreate)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrDuplicateMember, err)
+	mockTeamRepo.AssertNotCalled(t, "AddTeamWithMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamService_GetHierarchy_ResolvesInheritedPolicy(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	reviewerCount := 1
+	overridden := 3
+	fallback := true
+	team := model.Team{ID: 5, Name: "payments", ParentID: 2, Policy: model.AssignmentPolicy{ReviewerCount: &overridden}}
+	chain := []model.Team{
+		team,
+		{ID: 2, Name: "backend", ParentID: 1, Policy: model.AssignmentPolicy{ReviewerCount: &reviewerCount}},
+		{ID: 1, Name: "engineering", Policy: model.AssignmentPolicy{FallbackToParent: &fallback}},
+	}
+	children := []model.Team{{ID: 8, Name: "payments-api", ParentID: 5}}
+
+	mockTeamRepo.On("GetAncestors", mock.Anything, 5).Return(chain, nil)
+	mockTeamRepo.On("GetChildren", mock.Anything, 5).Return(children, nil)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	hierarchy, err := teamService.GetHierarchy(context.Background(), team)
+
+	assert.NoError(t, err)
+	assert.Equal(t, chain[1:], hierarchy.Ancestors)
+	assert.Equal(t, children, hierarchy.Children)
+	assert.Equal(t, model.ResolvedPolicy{ReviewerCount: 3, FallbackToParent: true}, hierarchy.Policy)
+}
+
+func TestTeamService_SetParent_FailsOnCycle(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockTeamRepo.On("SetParent", mock.Anything, "engineering", "payments", "").Return(nil, store.ErrHierarchyCycle)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	_, err := teamService.SetParent(context.Background(), "engineering", "payments")
+
+	assert.Equal(t, ErrHierarchyCycle, err)
+}
+
+func TestTeamService_SetPolicy_FailsIfTeamNotFound(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockTeamRepo.On("SetPolicy", mock.Anything, "ghost", mock.Anything, "").Return(nil, store.ErrNotFound)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	_, err := teamService.SetPolicy(context.Background(), "ghost", model.AssignmentPolicy{})
+
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestTeamService_Rename_FailsIfNameTaken(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+	mockTeamRepo.On("Rename", mock.Anything, "backend", "frontend", "").Return(nil, store.ErrTeamExists)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	_, err := teamService.Rename(context.Background(), "backend", "frontend")
+
+	assert.Equal(t, ErrTeamExists, err)
+}
+
+func TestTeamService_Delete_FailsIfNotArchived(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	team := &model.Team{ID: 1, Name: "backend"}
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, []model.User{}, nil)
+	mockTeamRepo.On("GetDeletionReport", mock.Anything, *team).Return(&model.TeamDeletionReport{Team: *team}, nil)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	_, err := teamService.Delete(context.Background(), "backend", true)
+
+	assert.Equal(t, ErrTeamNotArchived, err)
+	mockTeamRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamService_Delete_RequiresForceIfNotEmpty(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	archivedAt := time.Now()
+	team := &model.Team{ID: 1, Name: "backend", ArchivedAt: &archivedAt}
+	report := &model.TeamDeletionReport{
+		Team:             *team,
+		MemberIDs:        []string{"u1"},
+		PrimaryMemberIDs: []string{"u1"},
+	}
+	mockTeamRepo.On("GetByName", mock.Anything, "backend").Return(team, []model.User{{ID: "u1"}}, nil)
+	mockTeamRepo.On("GetDeletionReport", mock.Anything, *team).Return(report, nil)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	_, err := teamService.Delete(context.Background(), "backend", false)
+	assert.Equal(t, ErrTeamNotEmpty, err)
+	mockTeamRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+
+	mockTeamRepo.On("Delete", mock.Anything, 1, "").Return(nil)
+
+	result, err := teamService.Delete(context.Background(), "backend", true)
+	assert.NoError(t, err)
+	assert.Equal(t, report, result)
+}
+
+func TestTeamService_List_ReturnsNextCursor(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	filter := model.TeamFilter{NamePrefix: "b", Limit: 2}
+	mockTeamRepo.On("List", mock.Anything, model.TeamFilter{NamePrefix: "b", Limit: 3}).Return([]model.TeamSummary{
+		{Team: model.Team{Name: "backend"}},
+		{Team: model.Team{Name: "billing"}},
+		{Team: model.Team{Name: "builds"}},
+	}, nil)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	teams, next, err := teamService.List(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, teams, 2)
+	assert.Equal(t, "billing", next)
+}
+
+func TestTeamService_List_LastPageHasNoCursor(t *testing.T) {
+	mockTeamRepo := mocks.NewTeamRepository(t)
+
+	mockTeamRepo.On("List", mock.Anything, model.TeamFilter{Limit: defaultPageSize + 1}).Return([]model.TeamSummary{
+		{Team: model.Team{Name: "backend"}},
+	}, nil)
+
+	teamService := NewTeamService(mockTeamRepo)
+
+	teams, next, err := teamService.List(context.Background(), model.TeamFilter{})
+
+	assert.NoError(t, err)
+	assert.Len(t, teams, 1)
+	assert.Empty(t, next)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+)
+
+const (
+	refreshTokenTTL       = 30 * 24 * time.Hour
+	refreshTokenBytes     = 32
+	familyIDBytes         = 16
+	denylistRecheck       = 5 * time.Second
+	denylistPruneInterval = time.Minute
+)
+
+type denylistEntry struct {
+	revoked bool
+	until   time.Time
+}
+
+type TokenService struct {
+	tokenRepo TokenRepository
+	userRepo  UserRepository
+	now       func() time.Time
+
+	mu        sync.Mutex
+	denylist  map[string]denylistEntry
+	nextPrune time.Time
+}
+
+func NewTokenService(tokenRepo TokenRepository, userRepo UserRepository) *TokenService {
+	return &TokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		now:       time.Now,
+		denylist:  make(map[string]denylistEntry),
+	}
+}
+
+func randomToken(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func (s *TokenService) IssueRefreshToken(ctx context.Context, userID string) (string, error) {
+	familyID, err := randomToken(familyIDBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return s.issueRefreshToken(ctx, userID, familyID)
+}
+
+func (s *TokenService) issueRefreshToken(ctx context.Context, userID, familyID string) (string, error) {
+	secret, err := randomToken(refreshTokenBytes)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.tokenRepo.CreateRefreshToken(ctx, model.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		ExpiresAt: s.now().Add(refreshTokenTTL),
+	}, hashSecret(secret))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return "", ErrNotFound
+		}
+
+		return "", err
+	}
+
+	return secret, nil
+}
+
+func (s *TokenService) getRefreshToken(ctx context.Context, secret string) (*model.RefreshToken, error) {
+	token, err := s.tokenRepo.GetRefreshToken(ctx, hashSecret(secret))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *TokenService) Refresh(ctx context.Context, secret string) (*model.TokenPair, error) {
+	token, err := s.getRefreshToken(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RevokedAt != nil || !token.ExpiresAt.After(s.now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if token.UsedAt != nil {
+		return nil, s.revokeFamily(ctx, token.FamilyID)
+	}
+
+	marked, err := s.tokenRepo.MarkRefreshTokenUsed(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, s.revokeFamily(ctx, token.FamilyID)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		return nil, s.revokeFamily(ctx, token.FamilyID)
+	}
+
+	creds, err := s.userRepo.GetCredentials(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	role := creds.Role
+	if role == "" {
+		role = model.RoleMember
+	}
+
+	next, err := s.issueRefreshToken(ctx, token.UserID, token.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TokenPair{
+		Principal:    model.Principal{UserID: token.UserID, Role: role},
+		RefreshToken: next,
+	}, nil
+}
+
+func (s *TokenService) revokeFamily(ctx context.Context, familyID string) error {
+	if err := s.tokenRepo.RevokeRefreshFamily(ctx, familyID); err != nil {
+		return err
+	}
+
+	return ErrInvalidRefreshToken
+}
+
+func (s *TokenService) Logout(ctx context.Context, refreshSecret string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+
+	var refresh *model.RefreshToken
+	if refreshSecret != "" {
+		token, err := s.getRefreshToken(ctx, refreshSecret)
+		if err != nil {
+			return err
+		}
+		if token.UserID != principal.UserID {
+			return ErrForbidden
+		}
+		refresh = token
+	}
+
+	if principal.TokenID != "" {
+		if err := s.tokenRepo.RevokeAccessToken(ctx, principal.TokenID, principal.TokenExpiresAt); err != nil {
+			return err
+		}
+		s.cacheDenylist(principal.TokenID, true, principal.TokenExpiresAt)
+	}
+
+	if refresh != nil {
+		if err := s.tokenRepo.RevokeRefreshFamily(ctx, refresh.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TokenService) IsRevoked(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	entry, ok := s.denylist[jti]
+	s.mu.Unlock()
+
+	if ok && now.Before(entry.until) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := s.tokenRepo.IsAccessTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+
+	until := now.Add(denylistRecheck)
+	if revoked {
+		until = expiresAt
+	}
+	s.cacheDenylist(jti, revoked, until)
+
+	return revoked, nil
+}
+
+func (s *TokenService) cacheDenylist(jti string, revoked bool, until time.Time) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.denylist[jti] = denylistEntry{revoked: revoked, until: until}
+
+	if now.After(s.nextPrune) {
+		for id, entry := range s.denylist {
+			if !now.Before(entry.until) {
+				delete(s.denylist, id)
+			}
+		}
+		s.nextPrune = now.Add(denylistPruneInterval)
+	}
+}
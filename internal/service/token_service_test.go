@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/DeadlyParkour777/pr-service/internal/store"
+	"github.com/DeadlyParkour777/pr-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenService_Refresh_Rotates(t *testing.T) {
+	mockTokenRepo := mocks.NewTokenRepository(t)
+	mockUserRepo := mocks.NewUserRepository(t)
+
+	current := &model.RefreshToken{ID: 1, UserID: "u1", FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokenRepo.On("GetRefreshToken", mock.Anything, hashSecret("old")).Return(current, nil)
+	mockTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, 1).Return(true, nil)
+	mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token model.RefreshToken) bool {
+		return token.UserID == "u1" && token.FamilyID == "fam"
+	}), mock.AnythingOfType("string")).Return(&model.RefreshToken{ID: 2}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(&model.FullUserInfo{User: model.User{ID: "u1", IsActive: true}}, nil)
+	mockUserRepo.On("GetCredentials", mock.Anything, "u1").Return(&model.Credentials{UserID: "u1", Role: model.RoleTeamLead}, nil)
+
+	tokenService := NewTokenService(mockTokenRepo, mockUserRepo)
+
+	pair, err := tokenService.Refresh(context.Background(), "old")
+
+	require.NoError(t, err)
+	assert.Equal(t, model.Principal{UserID: "u1", Role: model.RoleTeamLead}, pair.Principal)
+	assert.NotEmpty(t, pair.RefreshToken)
+	assert.NotEqual(t, "old", pair.RefreshToken)
+}
+
+func TestTokenService_Refresh_ReplayRevokesFamily(t *testing.T) {
+	mockTokenRepo := mocks.NewTokenRepository(t)
+
+	usedAt := time.Now().Add(-time.Minute)
+	used := &model.RefreshToken{ID: 1, UserID: "u1", FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+	mockTokenRepo.On("GetRefreshToken", mock.Anything, hashSecret("old")).Return(used, nil)
+	mockTokenRepo.On("RevokeRefreshFamily", mock.Anything, "fam").Return(nil).Once()
+
+	tokenService := NewTokenService(mockTokenRepo, mocks.NewUserRepository(t))
+
+	_, err := tokenService.Refresh(context.Background(), "old")
+
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestTokenService_Refresh_LostRaceRevokesFamily(t *testing.T) {
+	mockTokenRepo := mocks.NewTokenRepository(t)
+
+	current := &model.RefreshToken{ID: 1, UserID: "u1", FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokenRepo.On("GetRefreshToken", mock.Anything, hashSecret("old")).Return(current, nil)
+	mockTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, 1).Return(false, nil)
+	mockTokenRepo.On("RevokeRefreshFamily", mock.Anything, "fam").Return(nil).Once()
+
+	tokenService := NewTokenService(mockTokenRepo, mocks.NewUserRepository(t))
+
+	_, err := tokenService.Refresh(context.Background(), "old")
+
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestTokenService_Refresh_Unknown(t *testing.T) {
+	mockTokenRepo := mocks.NewTokenRepository(t)
+	mockTokenRepo.On("GetRefreshToken", mock.Anything, mock.Anything).Return(nil, store.ErrNotFound)
+
+	tokenService := NewTokenService(mockTokenRepo, mocks.NewUserRepository(t))
+
+	_, err := tokenService.Refresh(context.Background(), "nope")
+
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestTokenService_Logout(t *testing.T) {
+	mockTokenRepo := mocks.NewTokenRepository(t)
+
+	expiresAt := time.Now().Add(10 * time.Minute)
+	mockTokenRepo.On("GetRefreshToken", mock.Anything, hashSecret("refresh")).
+		Return(&model.RefreshToken{ID: 1, UserID: "u1", FamilyID: "fam"}, nil)
+	mockTokenRepo.On("RevokeAccessToken", mock.Anything, "jti-1", expiresAt).Return(nil).Once()
+	mockTokenRepo.On("RevokeRefreshFamily", mock.Anything, "fam").Return(nil).Once()
+
+	tokenService := NewTokenService(mockTokenRepo, mocks.NewUserRepository(t))
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleMember, TokenID: "jti-1", TokenExpiresAt: expiresAt})
+
+	require.NoError(t, tokenService.Logout(ctx, "refresh"))
+
+	revoked, err := tokenService.IsRevoked(ctx, "jti-1", expiresAt)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestTokenService_Logout_ForeignRefreshToken(t *testing.T) {
+	mockTokenRepo := mocks.NewTokenRepository(t)
+	mockTokenRepo.On("GetRefreshToken", mock.Anything, hashSecret("refresh")).
+		Return(&model.RefreshToken{ID: 1, UserID: "u2", FamilyID: "fam"}, nil)
+
+	tokenService := NewTokenService(mockTokenRepo, mocks.NewUserRepository(t))
+	ctx := WithPrincipal(context.Background(), model.Principal{UserID: "u1", Role: model.RoleMember, TokenID: "jti-1"})
+
+	assert.Equal(t, ErrForbidden, tokenService.Logout(ctx, "refresh"))
+}
+
+func TestTokenService_IsRevoked_CachesLookups(t *testing.T) {
+	mockTokenRepo := mocks.NewTokenRepository(t)
+	mockTokenRepo.On("IsAccessTokenRevoked", mock.Anything, "jti-1").Return(false, nil).Once()
+	mockTokenRepo.On("IsAccessTokenRevoked", mock.Anything, "jti-2").Return(true, nil).Once()
+
+	now := time.Now()
+	tokenService := NewTokenService(mockTokenRepo, mocks.NewUserRepository(t))
+	tokenService.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		revoked, err := tokenService.IsRevoked(context.Background(), "jti-1", now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		revoked, err = tokenService.IsRevoked(context.Background(), "jti-2", now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	}
+
+	now = now.Add(2 * denylistRecheck)
+	mockTokenRepo.On("IsAccessTokenRevoked", mock.Anything, "jti-1").Return(true, nil).Once()
+
+	revoked, err := tokenService.IsRevoked(context.Background(), "jti-1", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
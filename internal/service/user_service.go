@@ -21,7 +21,11 @@ func NewUserService(userRepo UserRepository, prRepo PullRequestRepository) *User
 }
 
 func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*model.FullUserInfo, error) {
-	user, err := s.userRepo.SetIsActive(ctx, userID, isActive)
+	if err := s.authorizeUserChange(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.SetIsActive(ctx, userID, isActive, actorID(ctx))
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			return nil, ErrNotFound
@@ -49,3 +53,57 @@ func (s *UserService) GetReviewsForUser(ctx context.Context, userID string) ([]m
 
 	return prs, nil
 }
+
+func (s *UserService) Get(ctx context.Context, userID string) (*model.UserDetails, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	openReviews, err := s.userRepo.GetOpenReviewCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserDetails{FullUserInfo: *user, OpenReviewCount: openReviews}, nil
+}
+
+func (s *UserService) List(ctx context.Context, filter model.UserFilter) ([]model.FullUserInfo, string, error) {
+	limit := pageLimit(filter.Limit)
+	filter.Limit = limit + 1
+
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(users) <= limit {
+		return users, "", nil
+	}
+
+	users = users[:limit]
+	return users, users[limit-1].ID, nil
+}
+
+func (s *UserService) authorizeUserChange(ctx context.Context, userID string) error {
+	if requireAdmin(ctx) == nil {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	if user.TeamName == "" {
+		return ErrForbidden
+	}
+
+	return authorizeTeamChange(ctx, s.userRepo, user.TeamName)
+}
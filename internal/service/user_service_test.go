@@ -10,6 +10,7 @@ import (
 	"github.com/DeadlyParkour777/pr-service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUserService_GetReviewsForUser_Success(t *testing.T) {
@@ -64,11 +65,11 @@ func TestUserService_SetIsActive_Success(t *testing.T) {
 		TeamName: "backend",
 	}
 
-	mockUserRepo.On("SetIsActive", mock.Anything, userID, statusToSet).Return(updatedUser, nil)
+	mockUserRepo.On("SetIsActive", mock.Anything, userID, statusToSet, "admin").Return(updatedUser, nil)
 
 	userService := NewUserService(mockUserRepo, mockPRRepo)
 
-	resultUser, err := userService.SetIsActive(context.Background(), userID, statusToSet)
+	resultUser, err := userService.SetIsActive(testAdminContext(), userID, statusToSet)
 
 	assert.NoError(t, err)
 	assert.Equal(t, updatedUser, resultUser)
@@ -81,10 +82,10 @@ func TestUserService_SetIsActive_FailsIfNotFound(t *testing.T) {
 
 	userID := "non-existent-user"
 
-	mockUserRepo.On("SetIsActive", mock.Anything, userID, true).Return(nil, store.ErrNotFound)
+	mockUserRepo.On("SetIsActive", mock.Anything, userID, true, "admin").Return(nil, store.ErrNotFound)
 
 	userService := NewUserService(mockUserRepo, mockPRRepo)
-	_, err := userService.SetIsActive(context.Background(), userID, true)
+	_, err := userService.SetIsActive(testAdminContext(), userID, true)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrNotFound, err)
@@ -132,3 +133,61 @@ func TestUserService_GetReviewsForUser_FailsOnPRError(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 	mockPRRepo.AssertExpectations(t)
 }
+
+func TestUserService_Get_IncludesOpenReviewLoad(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", Username: "Alice"}, TeamName: "backend"}
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockUserRepo.On("GetOpenReviewCount", mock.Anything, "u1").Return(3, nil)
+
+	userService := NewUserService(mockUserRepo, mockPRRepo)
+
+	details, err := userService.Get(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, *user, details.FullUserInfo)
+	assert.Equal(t, 3, details.OpenReviewCount)
+}
+
+func TestUserService_List_CapsPageSize(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	isActive := true
+	mockUserRepo.On("List", mock.Anything, model.UserFilter{IsActive: &isActive, Limit: maxPageSize + 1}).Return([]model.FullUserInfo{}, nil)
+
+	userService := NewUserService(mockUserRepo, mockPRRepo)
+
+	users, next, err := userService.List(context.Background(), model.UserFilter{IsActive: &isActive, Limit: 1000})
+
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Empty(t, next)
+}
+
+func TestUserService_SetIsActive_LeadOfUsersTeam(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockPRRepo := mocks.NewPullRequestRepository(t)
+
+	user := &model.FullUserInfo{User: model.User{ID: "u1", IsActive: true, TeamID: 1}, TeamName: "backend"}
+	mockUserRepo.On("GetByID", mock.Anything, "u1").Return(user, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "lead").Return([]model.TeamMembership{
+		{TeamID: 1, TeamName: "backend", UserID: "lead", IsPrimary: true},
+	}, nil)
+	mockUserRepo.On("GetMemberships", mock.Anything, "other-lead").Return([]model.TeamMembership{
+		{TeamID: 2, TeamName: "frontend", UserID: "other-lead", IsPrimary: true},
+	}, nil)
+	mockUserRepo.On("SetIsActive", mock.Anything, "u1", false, "lead").Return(user, nil)
+
+	userService := NewUserService(mockUserRepo, mockPRRepo)
+
+	otherLead := WithPrincipal(context.Background(), model.Principal{UserID: "other-lead", Role: model.RoleTeamLead})
+	_, err := userService.SetIsActive(otherLead, "u1", false)
+	assert.Equal(t, ErrForbidden, err)
+
+	lead := WithPrincipal(context.Background(), model.Principal{UserID: "lead", Role: model.RoleTeamLead})
+	_, err = userService.SetIsActive(lead, "u1", false)
+	require.NoError(t, err)
+}
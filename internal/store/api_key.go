@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const apiKeyColumns = `id, user_id, name, prefix, scopes, expires_at, last_used_at, revoked_at, created_at`
+
+type APIKeyStore struct {
+	conn *pgxpool.Pool
+}
+
+func scanAPIKey(row pgx.Row, extra ...any) (*model.APIKey, error) {
+	var key model.APIKey
+	var scopes []string
+
+	dest := append([]any{
+		&key.ID, &key.UserID, &key.Name, &key.Prefix, &scopes,
+		&key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = make([]model.Scope, len(scopes))
+	for i, scope := range scopes {
+		key.Scopes[i] = model.Scope(scope)
+	}
+
+	return &key, nil
+}
+
+func (s *APIKeyStore) Create(ctx context.Context, key model.APIKey, keyHash string) (*model.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + apiKeyColumns + `;
+	`
+
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	created, err := scanAPIKey(s.conn.QueryRow(ctx, query, key.UserID, key.Name, key.Prefix, keyHash, scopes, key.ExpiresAt))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *APIKeyStore) GetByID(ctx context.Context, id int) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1;`
+
+	key, err := scanAPIKey(s.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (s *APIKeyStore) ListByUser(ctx context.Context, userID string) ([]model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE user_id = $1 ORDER BY id;`
+
+	rows, err := s.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]model.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *APIKeyStore) Revoke(ctx context.Context, id int) (*model.APIKey, error) {
+	query := `
+		UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING ` + apiKeyColumns + `;
+	`
+
+	key, err := scanAPIKey(s.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (s *APIKeyStore) Authenticate(ctx context.Context, keyHash string) (*model.APIKey, model.Role, error) {
+	query := `
+		UPDATE api_keys AS k SET last_used_at = NOW()
+		FROM users AS u
+		WHERE k.key_hash = $1 AND u.id = k.user_id AND u.is_active
+			AND k.revoked_at IS NULL AND k.expires_at > NOW()
+		RETURNING k.id, k.user_id, k.name, k.prefix, k.scopes, k.expires_at, k.last_used_at, k.revoked_at, k.created_at, u.role;
+	`
+
+	var role string
+	key, err := scanAPIKey(s.conn.QueryRow(ctx, query, keyHash), &role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrNotFound
+		}
+		return nil, "", fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+
+	return key, model.Role(role), nil
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyStore_Integration_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.APIKey()
+
+	key := model.APIKey{
+		UserID:    "active-user-1",
+		Name:      "ci",
+		Prefix:    "prs_abcdefgh",
+		Scopes:    []model.Scope{model.ScopePRWrite, model.ScopeStatsRead},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	created, err := s.Create(ctx, key, "hash-1")
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, key.Scopes, created.Scopes)
+	assert.Nil(t, created.LastUsedAt)
+
+	_, err = s.Create(ctx, model.APIKey{UserID: "ghost", Name: "ci", Scopes: key.Scopes, ExpiresAt: key.ExpiresAt}, "hash-2")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	authenticated, role, err := s.Authenticate(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, authenticated.ID)
+	assert.Equal(t, model.RoleMember, role)
+	assert.NotNil(t, authenticated.LastUsedAt)
+
+	_, _, err = s.Authenticate(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	keys, err := s.ListByUser(ctx, "active-user-1")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+
+	revoked, err := s.Revoke(ctx, created.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, revoked.RevokedAt)
+
+	_, _, err = s.Authenticate(ctx, "hash-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.Revoke(ctx, 9999)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAPIKeyStore_Integration_AuthenticateRejectsExpiredAndInactive(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.APIKey()
+	scopes := []model.Scope{model.ScopePRRead}
+
+	_, err := s.Create(ctx, model.APIKey{UserID: "active-user-1", Name: "old", Prefix: "prs_old", Scopes: scopes, ExpiresAt: time.Now().Add(-time.Minute)}, "expired")
+	require.NoError(t, err)
+	_, err = s.Create(ctx, model.APIKey{UserID: "inactive-user", Name: "bot", Prefix: "prs_bot", Scopes: scopes, ExpiresAt: time.Now().Add(time.Hour)}, "inactive")
+	require.NoError(t, err)
+
+	_, _, err = s.Authenticate(ctx, "expired")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, _, err = s.Authenticate(ctx, "inactive")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
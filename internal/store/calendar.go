@@ -0,0 +1,236 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const calendarFeedQuery = `
+	SELECT f.id, COALESCE(f.user_id, ''), COALESCE(f.team_id, 0), COALESCE(t.name, ''), COALESCE(f.url, ''),
+		f.categories, COALESCE(f.created_by, ''), f.created_at, f.next_sync_at, f.last_synced_at,
+		COALESCE(f.last_error, ''), (SELECT COUNT(*) FROM absences AS a WHERE a.feed_id = f.id)
+	FROM calendar_feeds AS f
+	LEFT JOIN teams AS t ON t.id = f.team_id
+`
+
+type CalendarStore struct {
+	conn *pgxpool.Pool
+}
+
+func scanCalendarFeed(row pgx.Row) (*model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+
+	err := row.Scan(&feed.ID, &feed.UserID, &feed.TeamID, &feed.TeamName, &feed.URL, &feed.Categories, &feed.CreatedBy,
+		&feed.CreatedAt, &feed.NextSyncAt, &feed.LastSyncedAt, &feed.LastError, &feed.AbsenceCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+func (s *CalendarStore) listFeeds(ctx context.Context, query string, args ...any) ([]model.CalendarFeed, error) {
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query calendar feeds: %w", err)
+	}
+	defer rows.Close()
+
+	feeds := []model.CalendarFeed{}
+	for rows.Next() {
+		feed, err := scanCalendarFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan calendar feed: %w", err)
+		}
+		feeds = append(feeds, *feed)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error calendar feed rows: %w", err)
+	}
+
+	return feeds, nil
+}
+
+func (s *CalendarStore) CreateFeed(ctx context.Context, feed model.CalendarFeed) (*model.CalendarFeed, error) {
+	query := `
+		INSERT INTO calendar_feeds (user_id, team_id, url, categories, created_by)
+		VALUES (NULLIF($1, ''), NULLIF($2, 0), NULLIF($3, ''), $4, NULLIF($5, ''))
+		RETURNING id;
+	`
+
+	categories := feed.Categories
+	if categories == nil {
+		categories = []string{}
+	}
+
+	var id int64
+	err := s.conn.QueryRow(ctx, query, feed.UserID, feed.TeamID, feed.URL, categories, feed.CreatedBy).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to create calendar feed: %w", err)
+	}
+
+	return s.GetFeed(ctx, id)
+}
+
+func (s *CalendarStore) GetFeed(ctx context.Context, id int64) (*model.CalendarFeed, error) {
+	feed, err := scanCalendarFeed(s.conn.QueryRow(ctx, calendarFeedQuery+` WHERE f.id = $1;`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+func (s *CalendarStore) ListFeeds(ctx context.Context, filter model.CalendarFeedFilter) ([]model.CalendarFeed, error) {
+	return s.listFeeds(ctx, calendarFeedQuery+`
+		WHERE ($1 = '' OR f.user_id = $1) AND ($2 = '' OR t.name = $2)
+		ORDER BY f.id;
+	`, filter.UserID, filter.TeamName)
+}
+
+func (s *CalendarStore) DeleteFeed(ctx context.Context, id int64) error {
+	commandTag, err := s.conn.Exec(ctx, `DELETE FROM calendar_feeds WHERE id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *CalendarStore) ReplaceAbsences(ctx context.Context, feedID int64, absences []model.Absence) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM calendar_feeds WHERE id = $1 FOR UPDATE;`, feedID); err != nil {
+		return fmt.Errorf("failed to lock calendar feed: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM absences WHERE feed_id = $1;`, feedID); err != nil {
+		return fmt.Errorf("failed to delete absences: %w", err)
+	}
+
+	type absenceKey struct {
+		userID, uid string
+		startsAt    int64
+	}
+	seen := make(map[absenceKey]struct{}, len(absences))
+
+	rows := make([][]any, 0, len(absences))
+	for _, absence := range absences {
+		key := absenceKey{absence.UserID, absence.UID, absence.StartsAt.UnixMicro()}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		rows = append(rows, []any{feedID, absence.UserID, absence.UID, absence.Summary, absence.StartsAt, absence.EndsAt})
+	}
+
+	if len(rows) > 0 {
+		_, err := tx.CopyFrom(
+			ctx,
+			pgx.Identifier{"absences"},
+			[]string{"feed_id", "user_id", "uid", "summary", "starts_at", "ends_at"},
+			pgx.CopyFromRows(rows),
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to insert absences: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *CalendarStore) ListAbsences(ctx context.Context, userID string, from, to time.Time) ([]model.Absence, error) {
+	query := `
+		SELECT feed_id, user_id, uid, summary, starts_at, ends_at
+		FROM absences
+		WHERE user_id = $1 AND ends_at > $2 AND starts_at < $3
+		ORDER BY starts_at, feed_id, uid;
+	`
+
+	rows, err := s.conn.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query absences: %w", err)
+	}
+	defer rows.Close()
+
+	absences := []model.Absence{}
+	for rows.Next() {
+		var absence model.Absence
+		if err := rows.Scan(&absence.FeedID, &absence.UserID, &absence.UID, &absence.Summary, &absence.StartsAt, &absence.EndsAt); err != nil {
+			return nil, fmt.Errorf("failed to scan absence: %w", err)
+		}
+		absences = append(absences, absence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error absence rows: %w", err)
+	}
+
+	return absences, nil
+}
+
+func (s *CalendarStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.CalendarFeed, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE calendar_feeds
+			SET next_sync_at = NOW() + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT id
+				FROM calendar_feeds
+				WHERE url IS NOT NULL AND next_sync_at <= NOW()
+				ORDER BY next_sync_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id
+		)
+	` + calendarFeedQuery + `
+		WHERE f.id IN (SELECT id FROM claimed)
+		ORDER BY f.id;
+	`
+
+	return s.listFeeds(ctx, query, limit, lease.Seconds())
+}
+
+func (s *CalendarStore) MarkSynced(ctx context.Context, feedID int64, nextSyncAt time.Time, lastError string) error {
+	query := `
+		UPDATE calendar_feeds
+		SET next_sync_at = $2,
+			last_error = NULLIF($3, ''),
+			last_synced_at = CASE WHEN $3 = '' THEN NOW() ELSE last_synced_at END
+		WHERE id = $1;
+	`
+
+	if _, err := s.conn.Exec(ctx, query, feedID, nextSyncAt, lastError); err != nil {
+		return fmt.Errorf("failed to mark calendar feed synced: %w", err)
+	}
+
+	return nil
+}
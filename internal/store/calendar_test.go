@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarStore_Integration_FeedsAndAbsences(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	team, _, err := testStore.Team().GetByName(ctx, "test-team")
+	require.NoError(t, err)
+
+	s := testStore.Calendar()
+
+	upload, err := s.CreateFeed(ctx, model.CalendarFeed{TeamID: team.ID, Categories: []string{"VACATION"}, CreatedBy: "author-1"})
+	require.NoError(t, err)
+	assert.True(t, upload.IsUpload())
+	assert.Equal(t, "test-team", upload.TeamName)
+	assert.Equal(t, []string{"VACATION"}, upload.Categories)
+
+	subscribed, err := s.CreateFeed(ctx, model.CalendarFeed{UserID: "reviewer-2", URL: "https://calendar.example.com/r2.ics"})
+	require.NoError(t, err)
+	assert.Empty(t, subscribed.Categories)
+
+	_, err = s.CreateFeed(ctx, model.CalendarFeed{UserID: "ghost"})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	current := model.Absence{FeedID: upload.ID, UserID: "reviewer-1", UID: "vacation", Summary: "Reviewer 1: vacation", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(48 * time.Hour)}
+	future := model.Absence{FeedID: upload.ID, UserID: "reviewer-1", UID: "trip", Summary: "Trip", StartsAt: now.Add(72 * time.Hour), EndsAt: now.Add(96 * time.Hour)}
+	require.NoError(t, s.ReplaceAbsences(ctx, upload.ID, []model.Absence{current, future, current}))
+
+	absences, err := s.ListAbsences(ctx, "reviewer-1", now, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, absences, 1)
+	assert.Equal(t, "vacation", absences[0].UID)
+	assert.True(t, current.EndsAt.Equal(absences[0].EndsAt))
+
+	members, err := testStore.User().GetActiveTeamMembers(ctx, team.ID, "author-1")
+	require.NoError(t, err)
+	var ids []string
+	for _, member := range members {
+		ids = append(ids, member.ID)
+	}
+	assert.ElementsMatch(t, []string{"reviewer-2", "new-reviewer"}, ids, "absent users are not eligible reviewers")
+
+	feeds, err := s.ListFeeds(ctx, model.CalendarFeedFilter{TeamName: "test-team"})
+	require.NoError(t, err)
+	require.Len(t, feeds, 1)
+	assert.Equal(t, 2, feeds[0].AbsenceCount)
+
+	require.NoError(t, s.ReplaceAbsences(ctx, upload.ID, []model.Absence{future}))
+	absences, err = s.ListAbsences(ctx, "reviewer-1", now.Add(-24*time.Hour), now.Add(7*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, absences, 1, "replacing drops cancelled events")
+	assert.Equal(t, "trip", absences[0].UID)
+
+	require.NoError(t, s.DeleteFeed(ctx, upload.ID))
+	assert.ErrorIs(t, s.DeleteFeed(ctx, upload.ID), ErrNotFound)
+	absences, err = s.ListAbsences(ctx, "reviewer-1", now.Add(-24*time.Hour), now.Add(7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, absences)
+}
+
+func TestCalendarStore_Integration_ClaimDueAndMarkSynced(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.Calendar()
+
+	_, err := s.CreateFeed(ctx, model.CalendarFeed{UserID: "reviewer-1"})
+	require.NoError(t, err)
+	subscribed, err := s.CreateFeed(ctx, model.CalendarFeed{UserID: "reviewer-2", URL: "https://calendar.example.com/r2.ics"})
+	require.NoError(t, err)
+
+	claimed, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1, "uploaded feeds are never synced")
+	assert.Equal(t, subscribed.ID, claimed[0].ID)
+
+	claimed, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "claimed feeds are leased")
+
+	require.NoError(t, s.MarkSynced(ctx, subscribed.ID, time.Now().Add(-time.Second), "calendar fetch failed: status 500"))
+	feed, err := s.GetFeed(ctx, subscribed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "calendar fetch failed: status 500", feed.LastError)
+	assert.Nil(t, feed.LastSyncedAt)
+
+	require.NoError(t, s.MarkSynced(ctx, subscribed.ID, time.Now().Add(time.Hour), ""))
+	feed, err = s.GetFeed(ctx, subscribed.ID)
+	require.NoError(t, err)
+	assert.Empty(t, feed.LastError)
+	assert.NotNil(t, feed.LastSyncedAt)
+
+	claimed, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+}
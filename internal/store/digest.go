@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	digestSubscriptionColumns = `user_id, kind, format, next_run_at, last_sent_at, COALESCE(last_error, ''), created_at`
+	digestPullRequestQuery    = `
+		SELECT p.id, p.name, p.author_id, COALESCE(t.name, ''), p.created_at,
+			COALESCE(MAX(prr.verdict) FILTER (WHERE prr.reviewer_id = $1), ''),
+			COALESCE(ARRAY_AGG(prr.reviewer_id ORDER BY prr.reviewer_id)
+				FILTER (WHERE prr.reviewer_id IS NOT NULL AND prr.verdict IS DISTINCT FROM 'APPROVED'), '{}')
+		FROM pull_requests AS p
+		LEFT JOIN teams AS t ON t.id = p.team_id
+		LEFT JOIN pull_request_reviewers AS prr ON prr.pull_request_id = p.id
+		WHERE p.status = 'OPEN' AND %s
+		GROUP BY p.id, t.name
+		ORDER BY p.created_at, p.id;
+	`
+)
+
+type DigestStore struct {
+	conn *pgxpool.Pool
+}
+
+func scanDigestSubscription(row pgx.Row) (*model.DigestSubscription, error) {
+	var sub model.DigestSubscription
+
+	err := row.Scan(&sub.UserID, &sub.Kind, &sub.Format, &sub.NextRunAt, &sub.LastSentAt, &sub.LastError, &sub.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (s *DigestStore) listPullRequests(ctx context.Context, condition string, args ...any) ([]model.DigestPullRequest, error) {
+	rows, err := s.conn.Query(ctx, fmt.Sprintf(digestPullRequestQuery, condition), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query digest pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []model.DigestPullRequest{}
+	for rows.Next() {
+		var pr model.DigestPullRequest
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.TeamName, &pr.CreatedAt, &pr.Verdict, &pr.PendingReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan digest pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error digest pull request rows: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (s *DigestStore) ListAssignedReviews(ctx context.Context, reviewerID string) ([]model.DigestPullRequest, error) {
+	return s.listPullRequests(ctx, `EXISTS (
+		SELECT 1
+		FROM pull_request_reviewers AS mine
+		WHERE mine.pull_request_id = p.id AND mine.reviewer_id = $1 AND mine.verdict IS DISTINCT FROM 'APPROVED'
+	)`, reviewerID)
+}
+
+func (s *DigestStore) ListAwaitingReview(ctx context.Context, authorID string) ([]model.DigestPullRequest, error) {
+	return s.listPullRequests(ctx, `p.author_id = $1`, authorID)
+}
+
+func (s *DigestStore) GetTeamDigest(ctx context.Context, teamID int, since, until time.Time) (*model.TeamDigest, error) {
+	activityQuery := `
+		SELECT
+			COUNT(*) FILTER (WHERE created_at >= $2 AND created_at < $3),
+			COUNT(*) FILTER (WHERE merged_at >= $2 AND merged_at < $3),
+			COUNT(*) FILTER (WHERE closed_at >= $2 AND closed_at < $3),
+			COALESCE(EXTRACT(EPOCH FROM AVG(merged_at - created_at) FILTER (WHERE merged_at >= $2 AND merged_at < $3)), 0)::FLOAT8
+		FROM pull_requests
+		WHERE team_id = $1;
+	`
+
+	var team model.TeamDigest
+	var avgSeconds float64
+	err := s.conn.QueryRow(ctx, activityQuery, teamID, since, until).Scan(&team.Opened, &team.Merged, &team.Closed, &avgSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team activity: %w", err)
+	}
+	team.AverageTimeToMerge = time.Duration(avgSeconds * float64(time.Second)).Round(time.Minute)
+
+	team.OpenPullRequests, err = s.listPullRequests(ctx, `p.team_id = $2`, "", teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	loadQuery := `
+		SELECT u.id, u.username, COUNT(p.id)
+		FROM team_members AS tm
+		JOIN users AS u ON u.id = tm.user_id
+		LEFT JOIN pull_request_reviewers AS prr ON prr.reviewer_id = u.id
+		LEFT JOIN pull_requests AS p ON p.id = prr.pull_request_id AND p.status = 'OPEN'
+		WHERE tm.team_id = $1 AND u.is_active
+		GROUP BY u.id, u.username
+		ORDER BY COUNT(p.id) DESC, u.id;
+	`
+
+	rows, err := s.conn.Query(ctx, loadQuery, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviewer load: %w", err)
+	}
+	defer rows.Close()
+
+	team.ReviewerLoad = []model.ReviewerLoad{}
+	for rows.Next() {
+		var load model.ReviewerLoad
+		if err := rows.Scan(&load.UserID, &load.Username, &load.OpenReviews); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer load: %w", err)
+		}
+		team.ReviewerLoad = append(team.ReviewerLoad, load)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reviewer load rows: %w", err)
+	}
+
+	return &team, nil
+}
+
+func (s *DigestStore) ListSubscriptions(ctx context.Context, userID string) ([]model.DigestSubscription, error) {
+	query := `SELECT ` + digestSubscriptionColumns + ` FROM digest_subscriptions WHERE user_id = $1 ORDER BY kind;`
+
+	rows, err := s.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := []model.DigestSubscription{}
+	for rows.Next() {
+		sub, err := scanDigestSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan digest subscription: %w", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error digest subscription rows: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (s *DigestStore) SetSubscription(ctx context.Context, sub model.DigestSubscription) (*model.DigestSubscription, error) {
+	query := `
+		INSERT INTO digest_subscriptions (user_id, kind, format, next_run_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, kind) DO UPDATE
+		SET format = EXCLUDED.format, next_run_at = EXCLUDED.next_run_at
+		RETURNING ` + digestSubscriptionColumns + `;
+	`
+
+	saved, err := scanDigestSubscription(s.conn.QueryRow(ctx, query, sub.UserID, string(sub.Kind), string(sub.Format), sub.NextRunAt))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to save digest subscription: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *DigestStore) DeleteSubscription(ctx context.Context, userID string, kind model.DigestKind) error {
+	commandTag, err := s.conn.Exec(ctx, `DELETE FROM digest_subscriptions WHERE user_id = $1 AND kind = $2;`, userID, string(kind))
+	if err != nil {
+		return fmt.Errorf("failed to delete digest subscription: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *DigestStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.DigestSubscription, error) {
+	query := `
+		UPDATE digest_subscriptions
+		SET next_run_at = NOW() + make_interval(secs => $2)
+		WHERE (user_id, kind) IN (
+			SELECT user_id, kind
+			FROM digest_subscriptions
+			WHERE next_run_at <= NOW()
+			ORDER BY next_run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + digestSubscriptionColumns + `;
+	`
+
+	rows, err := s.conn.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim digest subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []model.DigestSubscription
+	for rows.Next() {
+		sub, err := scanDigestSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan digest subscription: %w", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error digest subscription rows: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (s *DigestStore) MarkSent(ctx context.Context, userID string, kind model.DigestKind, nextRunAt time.Time) error {
+	query := `
+		UPDATE digest_subscriptions
+		SET next_run_at = $3, last_sent_at = NOW(), last_error = NULL
+		WHERE user_id = $1 AND kind = $2;
+	`
+
+	if _, err := s.conn.Exec(ctx, query, userID, string(kind), nextRunAt); err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+
+	return nil
+}
+
+func (s *DigestStore) Retry(ctx context.Context, userID string, kind model.DigestKind, nextRunAt time.Time, lastError string) error {
+	query := `
+		UPDATE digest_subscriptions
+		SET next_run_at = $3, last_error = NULLIF($4, '')
+		WHERE user_id = $1 AND kind = $2;
+	`
+
+	if _, err := s.conn.Exec(ctx, query, userID, string(kind), nextRunAt, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule digest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestStore_Integration_PullRequests(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	team, _, err := testStore.Team().GetByName(ctx, "test-team")
+	require.NoError(t, err)
+
+	prs := testStore.PR()
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-1", Name: "First", AuthorID: "author-1", TeamID: team.ID, AssignedReviewers: []string{"reviewer-1", "reviewer-2"}}))
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-2", Name: "Second", AuthorID: "author-1", TeamID: team.ID, AssignedReviewers: []string{"reviewer-1"}}))
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-3", Name: "Merged", AuthorID: "author-1", TeamID: team.ID, AssignedReviewers: []string{"reviewer-1"}}))
+	require.NoError(t, prs.SetVerdict(ctx, "pr-2", "reviewer-1", model.VerdictApproved, "reviewer-1"))
+	require.NoError(t, prs.SetVerdict(ctx, "pr-1", "reviewer-2", model.VerdictChangesRequested, "reviewer-2"))
+	require.NoError(t, prs.Merge(ctx, "pr-3", "author-1"))
+
+	s := testStore.Digest()
+
+	assigned, err := s.ListAssignedReviews(ctx, "reviewer-1")
+	require.NoError(t, err)
+	require.Len(t, assigned, 1, "approved and merged pull requests are not waiting for the reviewer")
+	assert.Equal(t, "pr-1", assigned[0].ID)
+	assert.Equal(t, "test-team", assigned[0].TeamName)
+
+	assigned, err = s.ListAssignedReviews(ctx, "reviewer-2")
+	require.NoError(t, err)
+	require.Len(t, assigned, 1)
+	assert.Equal(t, model.VerdictChangesRequested, assigned[0].Verdict)
+
+	awaiting, err := s.ListAwaitingReview(ctx, "author-1")
+	require.NoError(t, err)
+	require.Len(t, awaiting, 2)
+	assert.Equal(t, "pr-1", awaiting[0].ID, "oldest first")
+	assert.Equal(t, []string{"reviewer-1", "reviewer-2"}, awaiting[0].PendingReviewers)
+	assert.Empty(t, awaiting[1].PendingReviewers)
+
+	digest, err := s.GetTeamDigest(ctx, team.ID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 3, digest.Opened)
+	assert.Equal(t, 1, digest.Merged)
+	assert.Equal(t, 0, digest.Closed)
+	assert.Len(t, digest.OpenPullRequests, 2)
+	require.NotEmpty(t, digest.ReviewerLoad)
+	assert.Equal(t, model.ReviewerLoad{UserID: "reviewer-1", Username: "Reviewer 1", OpenReviews: 2}, digest.ReviewerLoad[0])
+
+	digest, err = s.GetTeamDigest(ctx, team.ID, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, digest.Opened)
+	assert.Zero(t, digest.AverageTimeToMerge)
+}
+
+func TestDigestStore_Integration_Subscriptions(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.Digest()
+	past := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+
+	sub, err := s.SetSubscription(ctx, model.DigestSubscription{UserID: "reviewer-1", Kind: model.DigestDaily, Format: model.DigestHTML, NextRunAt: past})
+	require.NoError(t, err)
+	assert.Equal(t, model.DigestHTML, sub.Format)
+
+	_, err = s.SetSubscription(ctx, model.DigestSubscription{UserID: "reviewer-1", Kind: model.DigestWeekly, Format: model.DigestText, NextRunAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, err = s.SetSubscription(ctx, model.DigestSubscription{UserID: "ghost", Kind: model.DigestDaily, Format: model.DigestText, NextRunAt: past})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	claimed, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, model.DigestDaily, claimed[0].Kind)
+
+	claimed, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "claimed subscriptions are leased")
+
+	next := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, s.Retry(ctx, "reviewer-1", model.DigestDaily, next, "slack is down"))
+
+	subs, err := s.ListSubscriptions(ctx, "reviewer-1")
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	assert.Equal(t, "slack is down", subs[0].LastError)
+	assert.Nil(t, subs[0].LastSentAt)
+
+	require.NoError(t, s.MarkSent(ctx, "reviewer-1", model.DigestDaily, next))
+	subs, err = s.ListSubscriptions(ctx, "reviewer-1")
+	require.NoError(t, err)
+	assert.Empty(t, subs[0].LastError)
+	assert.NotNil(t, subs[0].LastSentAt)
+	assert.True(t, subs[0].NextRunAt.Equal(next))
+
+	require.NoError(t, s.DeleteSubscription(ctx, "reviewer-1", model.DigestDaily))
+	assert.ErrorIs(t, s.DeleteSubscription(ctx, "reviewer-1", model.DigestDaily), ErrNotFound)
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdentityStore struct {
+	conn *pgxpool.Pool
+}
+
+func (s *IdentityStore) Link(ctx context.Context, identity model.ExternalIdentity) error {
+	query := `
+		INSERT INTO external_identities (provider, external_id, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, external_id) DO UPDATE
+		SET user_id = EXCLUDED.user_id, created_at = NOW();
+	`
+
+	if _, err := s.conn.Exec(ctx, query, identity.Provider, identity.ExternalID, identity.UserID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return nil
+}
+
+func (s *IdentityStore) Unlink(ctx context.Context, provider model.IdentityProvider, externalID string) error {
+	query := `DELETE FROM external_identities WHERE provider = $1 AND external_id = $2;`
+
+	commandTag, err := s.conn.Exec(ctx, query, provider, externalID)
+	if err != nil {
+		return fmt.Errorf("failed to unlink identity: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *IdentityStore) Resolve(ctx context.Context, provider model.IdentityProvider, externalID string) (string, error) {
+	query := `SELECT user_id FROM external_identities WHERE provider = $1 AND external_id = $2;`
+
+	var userID string
+	if err := s.conn.QueryRow(ctx, query, provider, externalID).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to resolve identity: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (s *IdentityStore) List(ctx context.Context, provider model.IdentityProvider, userID string) ([]model.ExternalIdentity, error) {
+	query := `
+		SELECT provider, external_id, user_id
+		FROM external_identities
+		WHERE ($1 = '' OR provider = $1) AND ($2 = '' OR user_id = $2)
+		ORDER BY provider, external_id;
+	`
+
+	rows, err := s.conn.Query(ctx, query, provider, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []model.ExternalIdentity{}
+	for rows.Next() {
+		var identity model.ExternalIdentity
+		if err := rows.Scan(&identity.Provider, &identity.ExternalID, &identity.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error identity rows: %w", err)
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityStore_Integration_LinkResolveUnlink(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.Identity()
+
+	require.NoError(t, s.Link(ctx, model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalID: "octocat", UserID: "author-1"}))
+	require.NoError(t, s.Link(ctx, model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalID: "octocat", UserID: "reviewer-1"}))
+	assert.ErrorIs(t, s.Link(ctx, model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalID: "ghost", UserID: "nobody"}), ErrNotFound)
+
+	userID, err := s.Resolve(ctx, model.ProviderGitHub, "octocat")
+	require.NoError(t, err)
+	assert.Equal(t, "reviewer-1", userID)
+
+	identities, err := s.List(ctx, "", "reviewer-1")
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "octocat", identities[0].ExternalID)
+
+	require.NoError(t, s.Unlink(ctx, model.ProviderGitHub, "octocat"))
+	assert.ErrorIs(t, s.Unlink(ctx, model.ProviderGitHub, "octocat"), ErrNotFound)
+
+	_, err = s.Resolve(ctx, model.ProviderGitHub, "octocat")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IssueTrackerStore struct {
+	conn *pgxpool.Pool
+}
+
+func (s *IssueTrackerStore) Enqueue(ctx context.Context, event model.Event) (int, error) {
+	if len(event.PullRequest.IssueKeys) == 0 {
+		return 0, nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	query := `
+		INSERT INTO issue_tracker_jobs (event_id, issue_key, pull_request_id, payload)
+		SELECT $1, issue_key, $3, $4
+		FROM unnest($2::text[]) AS issue_key
+		ON CONFLICT (event_id, issue_key) DO NOTHING;
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query, event.ID, event.PullRequest.IssueKeys, event.PullRequest.ID, payload)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to enqueue issue tracker jobs: %w", err)
+	}
+
+	return int(commandTag.RowsAffected()), nil
+}
+
+func (s *IssueTrackerStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.IssueTrackerJob, error) {
+	query := `
+		UPDATE issue_tracker_jobs
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM issue_tracker_jobs
+			WHERE next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, issue_key, payload, attempts, COALESCE(last_error, ''), next_attempt_at;
+	`
+
+	rows, err := s.conn.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim issue tracker jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []model.IssueTrackerJob
+	for rows.Next() {
+		var job model.IssueTrackerJob
+		var payload []byte
+		if err := rows.Scan(&job.ID, &job.IssueKey, &payload, &job.Attempts, &job.LastError, &job.NextAttemptAt); err != nil {
+			return nil, fmt.Errorf("failed to scan issue tracker job: %w", err)
+		}
+		if err := json.Unmarshal(payload, &job.Event); err != nil {
+			return nil, fmt.Errorf("failed to decode issue tracker job %d: %w", job.ID, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error issue tracker job rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (s *IssueTrackerStore) Complete(ctx context.Context, id int64) error {
+	if _, err := s.conn.Exec(ctx, `DELETE FROM issue_tracker_jobs WHERE id = $1;`, id); err != nil {
+		return fmt.Errorf("failed to complete issue tracker job: %w", err)
+	}
+
+	return nil
+}
+
+func (s *IssueTrackerStore) Retry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	query := `
+		UPDATE issue_tracker_jobs
+		SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+		WHERE id = $1;
+	`
+
+	if _, err := s.conn.Exec(ctx, query, id, nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule issue tracker job: %w", err)
+	}
+
+	return nil
+}
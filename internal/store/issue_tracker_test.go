@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestStore_Integration_IssueLinks(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.PR()
+	require.NoError(t, s.Create(ctx, model.PullRequest{ID: "pr-1", Name: "PROJ-2: second", AuthorID: "author-1", IssueKeys: []string{"PROJ-2", "OPS-1"}}))
+	require.NoError(t, s.Create(ctx, model.PullRequest{ID: "pr-2", Name: "PROJ-2 follow-up", AuthorID: "author-1", IssueKeys: []string{"PROJ-2"}}))
+	require.NoError(t, s.Create(ctx, model.PullRequest{ID: "pr-3", Name: "Unrelated", AuthorID: "author-1"}))
+
+	pr, err := s.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OPS-1", "PROJ-2"}, pr.IssueKeys)
+
+	pr, err = s.GetByID(ctx, "pr-3")
+	require.NoError(t, err)
+	assert.Empty(t, pr.IssueKeys)
+
+	prs, err := s.GetByIssueKey(ctx, "PROJ-2")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, "pr-1", prs[0].ID)
+	assert.Equal(t, "test-team", prs[0].TeamName)
+	assert.Equal(t, model.StatusOpen, prs[1].Status)
+
+	prs, err = s.GetByIssueKey(ctx, "NONE-1")
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+
+	records, err := testStore.Outbox().ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "pr-1", records[0].AggregateID)
+	assert.Contains(t, string(records[0].Payload), `"issue_keys":["OPS-1","PROJ-2"]`, "events carry the linked keys")
+	assert.NotContains(t, string(records[2].Payload), `"issue_keys"`)
+}
+
+func TestIssueTrackerStore_Integration_Jobs(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{ID: "pr-1", Name: "PROJ-1", AuthorID: "author-1", IssueKeys: []string{"PROJ-1", "OPS-2"}}))
+
+	s := testStore.IssueTracker()
+
+	event := model.NewPullRequestEvent(model.EventPRMerged, "author-1", model.PullRequest{ID: "pr-1", Name: "PROJ-1", IssueKeys: []string{"PROJ-1", "OPS-2"}})
+	event.ID = 5
+
+	queued, err := s.Enqueue(ctx, event)
+	require.NoError(t, err)
+	assert.Equal(t, 2, queued)
+
+	queued, err = s.Enqueue(ctx, event)
+	require.NoError(t, err)
+	assert.Zero(t, queued, "a redelivered event is queued once")
+
+	missing := model.NewPullRequestEvent(model.EventPRMerged, "author-1", model.PullRequest{ID: "ghost", IssueKeys: []string{"PROJ-1"}})
+	_, err = s.Enqueue(ctx, missing)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	jobs, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "PROJ-1", jobs[0].IssueKey)
+	assert.Equal(t, event.PullRequest.IssueKeys, jobs[0].Event.PullRequest.IssueKeys)
+	assert.Equal(t, int64(5), jobs[1].Event.ID)
+
+	jobs, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, jobs, "claimed jobs are leased")
+
+	require.NoError(t, s.Retry(ctx, 1, time.Now().Add(-time.Second), "status 503"))
+	require.NoError(t, s.Complete(ctx, 2))
+
+	jobs, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 1, jobs[0].Attempts)
+	assert.Equal(t, "status 503", jobs[0].LastError)
+}
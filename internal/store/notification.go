@@ -0,0 +1,276 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	notificationPreferenceColumns = `user_id, channel, address, mode, enabled, timezone,
+	COALESCE(quiet_hours_start, ''), COALESCE(quiet_hours_end, ''), digest_time, updated_at`
+	notificationColumns = `id, event_id, user_id, kind, pull_request_id, channel, address, digest, subject, body,
+	status, attempts, COALESCE(last_error, ''), deliver_after, sent_at, created_at`
+)
+
+type NotificationStore struct {
+	conn *pgxpool.Pool
+}
+
+func scanNotificationPreference(row pgx.Row) (*model.NotificationPreference, error) {
+	var pref model.NotificationPreference
+
+	err := row.Scan(
+		&pref.UserID, &pref.Channel, &pref.Address, &pref.Mode, &pref.Enabled, &pref.Timezone,
+		&pref.QuietHoursStart, &pref.QuietHoursEnd, &pref.DigestTime, &pref.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pref, nil
+}
+
+func scanNotification(row pgx.Row) (*model.Notification, error) {
+	var n model.Notification
+
+	err := row.Scan(
+		&n.ID, &n.EventID, &n.UserID, &n.Kind, &n.PullRequestID, &n.Channel, &n.Address, &n.Digest, &n.Subject, &n.Body,
+		&n.Status, &n.Attempts, &n.LastError, &n.DeliverAfter, &n.SentAt, &n.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+func (s *NotificationStore) GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error) {
+	query := `SELECT ` + notificationPreferenceColumns + ` FROM notification_preferences WHERE user_id = $1;`
+
+	pref, err := scanNotificationPreference(s.conn.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+
+	return pref, nil
+}
+
+func (s *NotificationStore) SetPreference(ctx context.Context, pref model.NotificationPreference) (*model.NotificationPreference, error) {
+	query := `
+		INSERT INTO notification_preferences (user_id, channel, address, mode, enabled, timezone, quiet_hours_start, quiet_hours_end, digest_time)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9)
+		ON CONFLICT (user_id) DO UPDATE
+		SET channel = EXCLUDED.channel,
+			address = EXCLUDED.address,
+			mode = EXCLUDED.mode,
+			enabled = EXCLUDED.enabled,
+			timezone = EXCLUDED.timezone,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			digest_time = EXCLUDED.digest_time,
+			updated_at = NOW()
+		RETURNING ` + notificationPreferenceColumns + `;
+	`
+
+	saved, err := scanNotificationPreference(s.conn.QueryRow(ctx, query,
+		pref.UserID, string(pref.Channel), pref.Address, string(pref.Mode), pref.Enabled, pref.Timezone,
+		pref.QuietHoursStart, pref.QuietHoursEnd, pref.DigestTime,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to save notification preference: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *NotificationStore) ListTemplates(ctx context.Context) ([]model.NotificationTemplate, error) {
+	query := `SELECT kind, subject, body, COALESCE(updated_by, ''), updated_at FROM notification_templates ORDER BY kind;`
+
+	rows, err := s.conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []model.NotificationTemplate{}
+	for rows.Next() {
+		tmpl := model.NotificationTemplate{Custom: true}
+		if err := rows.Scan(&tmpl.Kind, &tmpl.Subject, &tmpl.Body, &tmpl.UpdatedBy, &tmpl.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification template: %w", err)
+		}
+		templates = append(templates, tmpl)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error notification template rows: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (s *NotificationStore) SetTemplate(ctx context.Context, tmpl model.NotificationTemplate) (*model.NotificationTemplate, error) {
+	query := `
+		INSERT INTO notification_templates (kind, subject, body, updated_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (kind) DO UPDATE
+		SET subject = EXCLUDED.subject, body = EXCLUDED.body, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		RETURNING kind, subject, body, COALESCE(updated_by, ''), updated_at;
+	`
+
+	saved := model.NotificationTemplate{Custom: true}
+	err := s.conn.QueryRow(ctx, query, string(tmpl.Kind), tmpl.Subject, tmpl.Body, tmpl.UpdatedBy).
+		Scan(&saved.Kind, &saved.Subject, &saved.Body, &saved.UpdatedBy, &saved.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save notification template: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func (s *NotificationStore) DeleteTemplate(ctx context.Context, kind model.NotificationKind) error {
+	commandTag, err := s.conn.Exec(ctx, `DELETE FROM notification_templates WHERE kind = $1;`, string(kind))
+	if err != nil {
+		return fmt.Errorf("failed to delete notification template: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *NotificationStore) Enqueue(ctx context.Context, n model.Notification) (bool, error) {
+	query := `
+		INSERT INTO notifications (event_id, user_id, kind, pull_request_id, channel, address, digest, subject, body, deliver_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (event_id, user_id) DO NOTHING;
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query,
+		n.EventID, n.UserID, string(n.Kind), n.PullRequestID, string(n.Channel), n.Address, n.Digest, n.Subject, n.Body, n.DeliverAfter,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+
+	return commandTag.RowsAffected() > 0, nil
+}
+
+func (s *NotificationStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.Notification, error) {
+	query := `
+		UPDATE notifications
+		SET deliver_after = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM notifications
+			WHERE status = 'PENDING' AND deliver_after <= NOW()
+			ORDER BY deliver_after, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + notificationColumns + `;
+	`
+
+	rows, err := s.conn.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []model.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, *n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error notification rows: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (s *NotificationStore) MarkSent(ctx context.Context, ids []int64) error {
+	query := `
+		UPDATE notifications
+		SET status = 'SENT', attempts = attempts + 1, sent_at = NOW(), last_error = NULL
+		WHERE id = ANY($1);
+	`
+
+	if _, err := s.conn.Exec(ctx, query, ids); err != nil {
+		return fmt.Errorf("failed to mark notifications sent: %w", err)
+	}
+
+	return nil
+}
+
+func (s *NotificationStore) Retry(ctx context.Context, ids []int64, deliverAfter time.Time, lastError string) error {
+	query := `
+		UPDATE notifications
+		SET attempts = attempts + 1, deliver_after = $2, last_error = $3
+		WHERE id = ANY($1);
+	`
+
+	if _, err := s.conn.Exec(ctx, query, ids, deliverAfter, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule notifications: %w", err)
+	}
+
+	return nil
+}
+
+func (s *NotificationStore) MarkFailed(ctx context.Context, ids []int64, lastError string) error {
+	query := `
+		UPDATE notifications
+		SET status = 'FAILED', attempts = attempts + 1, last_error = $2
+		WHERE id = ANY($1);
+	`
+
+	if _, err := s.conn.Exec(ctx, query, ids, lastError); err != nil {
+		return fmt.Errorf("failed to mark notifications failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *NotificationStore) ListByUser(ctx context.Context, userID string, limit int) ([]model.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = $1 ORDER BY id DESC LIMIT $2;`
+
+	rows, err := s.conn.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []model.Notification{}
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, *n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error notification rows: %w", err)
+	}
+
+	return notifications, nil
+}
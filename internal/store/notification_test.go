@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationStore_Integration_Preferences(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.Notification()
+
+	_, err := s.GetPreference(ctx, "reviewer-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	pref, err := s.SetPreference(ctx, model.NotificationPreference{
+		UserID: "reviewer-1", Channel: model.ChannelEmail, Address: "r1@example.com",
+		Mode: model.NotifyImmediately, Enabled: true, Timezone: "UTC", DigestTime: "09:00",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, pref.QuietHoursStart)
+
+	pref, err = s.SetPreference(ctx, model.NotificationPreference{
+		UserID: "reviewer-1", Channel: model.ChannelSlack, Address: "https://hooks.example.com/r1",
+		Mode: model.NotifyInDigest, Enabled: true, Timezone: "Europe/Berlin",
+		QuietHoursStart: "22:00", QuietHoursEnd: "07:00", DigestTime: "08:30",
+	})
+	require.NoError(t, err)
+
+	got, err := s.GetPreference(ctx, "reviewer-1")
+	require.NoError(t, err)
+	assert.Equal(t, pref, got)
+	assert.Equal(t, model.ChannelSlack, got.Channel)
+	assert.Equal(t, "22:00", got.QuietHoursStart)
+
+	_, err = s.SetPreference(ctx, model.NotificationPreference{
+		UserID: "ghost", Channel: model.ChannelEmail, Address: "g@example.com",
+		Mode: model.NotifyImmediately, Enabled: true, Timezone: "UTC", DigestTime: "09:00",
+	})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNotificationStore_Integration_Templates(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Notification()
+
+	_, err := s.SetTemplate(ctx, model.NotificationTemplate{Kind: model.NotificationPRMerged, Subject: "v1", Body: "b1", UpdatedBy: "admin"})
+	require.NoError(t, err)
+	tmpl, err := s.SetTemplate(ctx, model.NotificationTemplate{Kind: model.NotificationPRMerged, Subject: "v2", Body: "b2", UpdatedBy: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, "v2", tmpl.Subject)
+
+	templates, err := s.ListTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.True(t, templates[0].Custom)
+	assert.Equal(t, "b2", templates[0].Body)
+
+	require.NoError(t, s.DeleteTemplate(ctx, model.NotificationPRMerged))
+	assert.ErrorIs(t, s.DeleteTemplate(ctx, model.NotificationPRMerged), ErrNotFound)
+}
+
+func TestNotificationStore_Integration_Queue(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.Notification()
+	now := time.Now()
+
+	due := model.Notification{
+		EventID: 1, UserID: "reviewer-1", Kind: model.NotificationReviewRequested, PullRequestID: "pr-1",
+		Channel: model.ChannelEmail, Address: "r1@example.com", Subject: "s", Body: "b", DeliverAfter: now.Add(-time.Second),
+	}
+	inserted, err := s.Enqueue(ctx, due)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	inserted, err = s.Enqueue(ctx, due)
+	require.NoError(t, err)
+	assert.False(t, inserted, "the same event is delivered to a user only once")
+
+	later := due
+	later.EventID = 2
+	later.Digest = true
+	later.DeliverAfter = now.Add(time.Hour)
+	_, err = s.Enqueue(ctx, later)
+	require.NoError(t, err)
+
+	claimed, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, int64(1), claimed[0].EventID)
+
+	claimed, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "claimed notifications are leased")
+
+	listed, err := s.ListByUser(ctx, "reviewer-1", 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	first, second := listed[1], listed[0]
+
+	require.NoError(t, s.Retry(ctx, []int64{first.ID}, now.Add(-time.Second), "smtp unavailable"))
+	claimed, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, 1, claimed[0].Attempts)
+	assert.Equal(t, "smtp unavailable", claimed[0].LastError)
+
+	require.NoError(t, s.MarkSent(ctx, []int64{first.ID}))
+	require.NoError(t, s.MarkFailed(ctx, []int64{second.ID}, "no notifier"))
+
+	listed, err = s.ListByUser(ctx, "reviewer-1", 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, model.NotificationFailed, listed[0].Status)
+	assert.Equal(t, model.NotificationSent, listed[1].Status)
+	assert.Empty(t, listed[1].LastError)
+	require.NotNil(t, listed[1].SentAt)
+	assert.Equal(t, 2, listed[1].Attempts)
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OIDCStateStore struct {
+	conn *pgxpool.Pool
+}
+
+func (s *OIDCStateStore) Create(ctx context.Context, state model.OIDCState) error {
+	query := `
+		WITH purged AS (
+			DELETE FROM oidc_states WHERE expires_at < NOW()
+		)
+		INSERT INTO oidc_states (state, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4);
+	`
+
+	if _, err := s.conn.Exec(ctx, query, state.State, state.Nonce, state.CodeVerifier, state.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to create oidc state: %w", err)
+	}
+
+	return nil
+}
+
+func (s *OIDCStateStore) Consume(ctx context.Context, state string) (*model.OIDCState, error) {
+	query := `
+		DELETE FROM oidc_states
+		WHERE state = $1 AND expires_at > NOW()
+		RETURNING state, nonce, code_verifier, expires_at;
+	`
+
+	var consumed model.OIDCState
+	err := s.conn.QueryRow(ctx, query, state).Scan(
+		&consumed.State, &consumed.Nonce, &consumed.CodeVerifier, &consumed.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to consume oidc state: %w", err)
+	}
+
+	return &consumed, nil
+}
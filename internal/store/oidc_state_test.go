@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCStateStore_Integration_ConsumeOnce(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.OIDCState()
+
+	state := model.OIDCState{State: "state-1", Nonce: "nonce-1", CodeVerifier: "verifier-1", ExpiresAt: time.Now().Add(time.Minute)}
+	require.NoError(t, s.Create(ctx, state))
+	require.NoError(t, s.Create(ctx, model.OIDCState{State: "stale", Nonce: "n", CodeVerifier: "v", ExpiresAt: time.Now().Add(-time.Minute)}))
+
+	consumed, err := s.Consume(ctx, "state-1")
+	require.NoError(t, err)
+	assert.Equal(t, "nonce-1", consumed.Nonce)
+	assert.Equal(t, "verifier-1", consumed.CodeVerifier)
+
+	_, err = s.Consume(ctx, "state-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.Consume(ctx, "stale")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
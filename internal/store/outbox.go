@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxStore struct {
+	conn *pgxpool.Pool
+}
+
+func appendPullRequestEvent(ctx context.Context, tx pgx.Tx, prID string, detail model.Event) error {
+	pr, err := loadPullRequest(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+
+	event := model.NewPullRequestEvent(detail.Type, detail.ActorID, *pr)
+	event.OccurredAt = time.Now().UTC()
+	event.Reassignment = detail.Reassignment
+	event.Review = detail.Review
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	query := `INSERT INTO outbox (aggregate_id, event_type, payload) VALUES ($1, $2, $3);`
+	if _, err := tx.Exec(ctx, query, prID, string(event.Type), payload); err != nil {
+		return fmt.Errorf("failed to write %s event to outbox: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+func (s *OutboxStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxRecord, error) {
+	query := `
+		UPDATE outbox
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT o.id
+			FROM outbox o
+			WHERE o.published_at IS NULL
+				AND o.dead_at IS NULL
+				AND o.next_attempt_at <= NOW()
+				AND NOT EXISTS (
+					SELECT 1 FROM outbox earlier
+					WHERE earlier.aggregate_id = o.aggregate_id
+						AND earlier.published_at IS NULL
+						AND earlier.dead_at IS NULL
+						AND earlier.id < o.id
+				)
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, aggregate_id, event_type, payload, attempts, created_at;
+	`
+
+	rows, err := s.conn.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var records []model.OutboxRecord
+	for rows.Next() {
+		var record model.OutboxRecord
+		if err := rows.Scan(&record.ID, &record.AggregateID, &record.EventType, &record.Payload, &record.Attempts, &record.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error outbox rows: %w", err)
+	}
+
+	return records, nil
+}
+
+func (s *OutboxStore) MarkPublished(ctx context.Context, id int64) error {
+	query := `UPDATE outbox SET published_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1;`
+
+	if _, err := s.conn.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+
+	return nil
+}
+
+func (s *OutboxStore) Retry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE id = $1;`
+
+	if _, err := s.conn.Exec(ctx, query, id, nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule outbox event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *OutboxStore) MarkDead(ctx context.Context, id int64, lastError string) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, dead_at = NOW(), last_error = $2 WHERE id = $1;`
+
+	if _, err := s.conn.Exec(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("failed to mark outbox event dead: %w", err)
+	}
+
+	return nil
+}
+
+func (s *OutboxStore) PurgePublished(ctx context.Context, olderThan time.Duration) (int, error) {
+	query := `DELETE FROM outbox WHERE published_at < NOW() - make_interval(secs => $1);`
+
+	commandTag, err := s.conn.Exec(ctx, query, olderThan.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox: %w", err)
+	}
+
+	return int(commandTag.RowsAffected()), nil
+}
@@ -0,0 +1,179 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxStore_Integration_WrittenWithStateChanges(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	prs := testStore.PR()
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-1", Name: "Outbox", AuthorID: "author-1", AssignedReviewers: []string{"reviewer-1"}}))
+	require.NoError(t, prs.ReassignReviewer(ctx, "pr-1", "reviewer-1", "new-reviewer", "lead-1"))
+	require.NoError(t, prs.Merge(ctx, "pr-1", "author-1"))
+	require.NoError(t, prs.Merge(ctx, "pr-1", "author-1"))
+
+	records, err := testStore.Outbox().ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1, "later events of the same pull request wait for the first one")
+	assert.Equal(t, "pr-1", records[0].AggregateID)
+	assert.Equal(t, model.EventPRCreated, records[0].EventType)
+
+	var created model.Event
+	require.NoError(t, json.Unmarshal(records[0].Payload, &created))
+	assert.Equal(t, []string{"reviewer-1"}, created.PullRequest.AssignedReviewers)
+
+	require.NoError(t, testStore.Outbox().MarkPublished(ctx, records[0].ID))
+
+	records, err = testStore.Outbox().ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, model.EventReviewerReassigned, records[0].EventType)
+
+	var reassigned model.Event
+	require.NoError(t, json.Unmarshal(records[0].Payload, &reassigned))
+	assert.Equal(t, "lead-1", reassigned.ActorID)
+	assert.Equal(t, &model.EventReassignment{OldReviewerID: "reviewer-1", NewReviewerID: "new-reviewer"}, reassigned.Reassignment)
+	assert.Equal(t, []string{"new-reviewer"}, reassigned.PullRequest.AssignedReviewers)
+
+	require.NoError(t, testStore.Outbox().MarkPublished(ctx, records[0].ID))
+
+	records, err = testStore.Outbox().ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1, "repeated merge does not write a second event")
+	assert.Equal(t, model.EventPRMerged, records[0].EventType)
+}
+
+func TestOutboxStore_Integration_WrittenWithMembershipChanges(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	other, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "other-team"}, []model.User{{ID: "other-reviewer", Username: "Other", IsActive: true}})
+	require.NoError(t, err)
+
+	prs := testStore.PR()
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-review", Name: "Review", AuthorID: "author-1", AssignedReviewers: []string{"reviewer-1"}}))
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-authored", Name: "Authored", AuthorID: "reviewer-1", AssignedReviewers: []string{"reviewer-2"}}))
+
+	plan := model.MembershipPlan{
+		UserID:        "reviewer-1",
+		JoinTeamID:    other.Team.ID,
+		Reassignments: []model.ReviewReassignment{{PullRequestID: "pr-review", OldReviewerID: "reviewer-1"}},
+		Transfers:     []model.PullRequestTransfer{{PullRequestID: "pr-authored", ReviewerIDs: []string{"other-reviewer"}}},
+	}
+	_, err = testStore.Team().ApplyMembershipChange(ctx, plan, "admin")
+	require.NoError(t, err)
+
+	rows, err := testStore.conn.Query(ctx, `SELECT payload FROM outbox WHERE event_type <> $1 ORDER BY id`, string(model.EventPRCreated))
+	require.NoError(t, err)
+	var events []model.Event
+	for rows.Next() {
+		var payload []byte
+		require.NoError(t, rows.Scan(&payload))
+		var event model.Event
+		require.NoError(t, json.Unmarshal(payload, &event))
+		events = append(events, event)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, events, 2)
+
+	assert.Equal(t, model.EventReviewerRemoved, events[0].Type)
+	assert.Equal(t, "admin", events[0].ActorID)
+	assert.Equal(t, &model.EventReassignment{OldReviewerID: "reviewer-1"}, events[0].Reassignment)
+	assert.Empty(t, events[0].PullRequest.AssignedReviewers)
+
+	assert.Equal(t, model.EventPRTransferred, events[1].Type)
+	assert.Equal(t, "admin", events[1].ActorID)
+	assert.Equal(t, "other-team", events[1].PullRequest.TeamName)
+	assert.Equal(t, []string{"other-reviewer"}, events[1].PullRequest.AssignedReviewers)
+}
+
+func TestOutboxStore_Integration_NotWrittenOnRollback(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	prs := testStore.PR()
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-1", Name: "Outbox", AuthorID: "author-1", AssignedReviewers: []string{"reviewer-1"}}))
+	require.Error(t, prs.ReassignReviewer(ctx, "pr-1", "reviewer-2", "new-reviewer", "lead-1"))
+	require.ErrorIs(t, prs.Merge(ctx, "missing", "author-1"), ErrNotFound)
+
+	records, err := testStore.Outbox().ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.NoError(t, testStore.Outbox().MarkPublished(ctx, records[0].ID))
+
+	records, err = testStore.Outbox().ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestOutboxStore_Integration_RetryAndPurge(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	prs := testStore.PR()
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-1", Name: "First", AuthorID: "author-1"}))
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-2", Name: "Second", AuthorID: "author-1"}))
+
+	o := testStore.Outbox()
+
+	records, err := o.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "different pull requests are relayed independently")
+
+	again, err := o.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again, "claimed events are leased")
+
+	require.NoError(t, o.Retry(ctx, records[0].ID, time.Now().Add(-time.Second), "sink unavailable"))
+	require.NoError(t, o.MarkPublished(ctx, records[1].ID))
+
+	retried, err := o.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, records[0].ID, retried[0].ID)
+	assert.Equal(t, 1, retried[0].Attempts)
+
+	purged, err := o.PurgePublished(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, purged, "recently published events are retained")
+
+	purged, err = o.PurgePublished(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestOutboxStore_Integration_DeadEventDoesNotBlockAggregate(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	prs := testStore.PR()
+	require.NoError(t, prs.Create(ctx, model.PullRequest{ID: "pr-1", Name: "Outbox", AuthorID: "author-1", AssignedReviewers: []string{"reviewer-1"}}))
+	require.NoError(t, prs.ReassignReviewer(ctx, "pr-1", "reviewer-1", "new-reviewer", "lead-1"))
+
+	o := testStore.Outbox()
+
+	records, err := o.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, model.EventPRCreated, records[0].EventType)
+
+	require.NoError(t, o.MarkDead(ctx, records[0].ID, "sink unavailable"))
+
+	records, err = o.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1, "a dead event no longer holds back the pull request")
+	assert.Equal(t, model.EventReviewerReassigned, records[0].EventType)
+
+	purged, err := o.PurgePublished(ctx, 0)
+	require.NoError(t, err)
+	assert.Zero(t, purged, "dead events are kept for inspection")
+}
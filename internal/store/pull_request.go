@@ -13,6 +13,14 @@ import (
 
 var ErrPRExists = errors.New("PR with this id already exists")
 
+func nullableActor(actorID string) *string {
+	if actorID == "" {
+		return nil
+	}
+
+	return &actorID
+}
+
 type PullRequestStore struct {
 	conn *pgxpool.Pool
 }
@@ -24,8 +32,11 @@ func (s *PullRequestStore) Create(ctx context.Context, pr model.PullRequest) err
 	}
 	defer tx.Rollback(ctx)
 
-	prQuery := `INSERT INTO pull_requests (id, name, author_id) VALUES ($1, $2, $3);`
-	if _, err := tx.Exec(ctx, prQuery, pr.ID, pr.Name, pr.AuthorID); err != nil {
+	prQuery := `
+		INSERT INTO pull_requests (id, name, author_id, team_id, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, ''));
+	`
+	if _, err := tx.Exec(ctx, prQuery, pr.ID, pr.Name, pr.AuthorID, pr.TeamID, pr.CreatedBy); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolationCode {
 			return ErrPRExists
@@ -36,13 +47,13 @@ func (s *PullRequestStore) Create(ctx context.Context, pr model.PullRequest) err
 	if len(pr.AssignedReviewers) > 0 {
 		rows := make([][]any, len(pr.AssignedReviewers))
 		for i, reviewerID := range pr.AssignedReviewers {
-			rows[i] = []any{pr.ID, reviewerID}
+			rows[i] = []any{pr.ID, reviewerID, nullableActor(pr.CreatedBy)}
 		}
 
 		_, err := tx.CopyFrom(
 			ctx,
 			pgx.Identifier{"pull_request_reviewers"},
-			[]string{"pull_request_id", "reviewer_id"},
+			[]string{"pull_request_id", "reviewer_id", "assigned_by"},
 			pgx.CopyFromRows(rows),
 		)
 
@@ -51,6 +62,28 @@ func (s *PullRequestStore) Create(ctx context.Context, pr model.PullRequest) err
 		}
 	}
 
+	if len(pr.IssueKeys) > 0 {
+		rows := make([][]any, len(pr.IssueKeys))
+		for i, issueKey := range pr.IssueKeys {
+			rows[i] = []any{pr.ID, issueKey}
+		}
+
+		_, err := tx.CopyFrom(
+			ctx,
+			pgx.Identifier{"pull_request_issues"},
+			[]string{"pull_request_id", "issue_key"},
+			pgx.CopyFromRows(rows),
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to insert issue links: %w", err)
+		}
+	}
+
+	if err := appendPullRequestEvent(ctx, tx, pr.ID, model.Event{Type: model.EventPRCreated, ActorID: pr.CreatedBy}); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
@@ -61,15 +94,31 @@ func (s *PullRequestStore) GetByID(ctx context.Context, id string) (*model.PullR
 	}
 	defer tx.Rollback(ctx)
 
+	pr, err := loadPullRequest(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return pr, nil
+}
+
+func loadPullRequest(ctx context.Context, tx pgx.Tx, id string) (*model.PullRequest, error) {
 	prQuery := `
-		SELECT id, name, author_id, status, created_at, merged_at
-		FROM pull_requests
-		WHERE id = $1
+		SELECT p.id, p.name, p.author_id, COALESCE(p.team_id, 0), COALESCE(t.name, ''), p.status, p.created_at, p.merged_at,
+			COALESCE(p.created_by, ''), COALESCE(p.merged_by, ''), p.closed_at, COALESCE(p.closed_by, '')
+		FROM pull_requests AS p
+		LEFT JOIN teams AS t ON t.id = p.team_id
+		WHERE p.id = $1
 	`
 
 	var pr model.PullRequest
-	err = tx.QueryRow(ctx, prQuery, id).Scan(
-		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+	err := tx.QueryRow(ctx, prQuery, id).Scan(
+		&pr.ID, &pr.Name, &pr.AuthorID, &pr.TeamID, &pr.TeamName, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+		&pr.CreatedBy, &pr.MergedBy, &pr.ClosedAt, &pr.ClosedBy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -80,9 +129,9 @@ func (s *PullRequestStore) GetByID(ctx context.Context, id string) (*model.PullR
 	}
 
 	reviewerQuery := `
-		SELECT reviewer_id
+		SELECT reviewer_id, COALESCE(assigned_by, ''), COALESCE(verdict, '')
 		FROM pull_request_reviewers
-		WHERE pull_request_id = $1	
+		WHERE pull_request_id = $1
 	`
 	rows, err := tx.Query(ctx, reviewerQuery, id)
 	if err != nil {
@@ -91,12 +140,21 @@ func (s *PullRequestStore) GetByID(ctx context.Context, id string) (*model.PullR
 	defer rows.Close()
 
 	var reviewers []string
+	assignedBy := make(map[string]string)
+	verdicts := make(map[string]model.Verdict)
 	for rows.Next() {
-		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
+		var reviewerID, actorID string
+		var verdict model.Verdict
+		if err := rows.Scan(&reviewerID, &actorID, &verdict); err != nil {
 			return nil, fmt.Errorf("failed to scan reviewer id: %w", err)
 		}
 		reviewers = append(reviewers, reviewerID)
+		if actorID != "" {
+			assignedBy[reviewerID] = actorID
+		}
+		if verdict != "" {
+			verdicts[reviewerID] = verdict
+		}
 	}
 
 	if err := rows.Err(); err != nil {
@@ -104,42 +162,155 @@ func (s *PullRequestStore) GetByID(ctx context.Context, id string) (*model.PullR
 	}
 
 	pr.AssignedReviewers = reviewers
+	pr.AssignedBy = assignedBy
+	pr.Verdicts = verdicts
+
+	issueQuery := `
+		SELECT issue_key
+		FROM pull_request_issues
+		WHERE pull_request_id = $1
+		ORDER BY issue_key
+	`
+	issueRows, err := tx.Query(ctx, issueQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issue links: %w", err)
+	}
+	defer issueRows.Close()
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	for issueRows.Next() {
+		var issueKey string
+		if err := issueRows.Scan(&issueKey); err != nil {
+			return nil, fmt.Errorf("failed to scan issue key: %w", err)
+		}
+		pr.IssueKeys = append(pr.IssueKeys, issueKey)
+	}
+
+	if err := issueRows.Err(); err != nil {
+		return nil, fmt.Errorf("error issue link rows: %w", err)
 	}
 
 	return &pr, nil
 }
 
-func (s *PullRequestStore) Merge(ctx context.Context, id string) error {
+func (s *PullRequestStore) Merge(ctx context.Context, id, actorID string) error {
+	query := `
+		UPDATE pull_requests
+		SET status = 'MERGED', merged_at = NOW(), merged_by = NULLIF($2, '')
+		WHERE id = $1 AND status = 'OPEN'
+	`
+
+	return s.changeStatus(ctx, id, model.EventPRMerged, actorID, query, id, actorID)
+}
+
+func (s *PullRequestStore) Close(ctx context.Context, id, actorID string) error {
 	query := `
 		UPDATE pull_requests
-		SET status = 'MERGED', merged_at = NOW()
-		WHERE id = $1 AND status = 'OPEN'	
+		SET status = 'CLOSED', closed_at = NOW(), closed_by = NULLIF($2, '')
+		WHERE id = $1 AND status = 'OPEN'
 	`
 
-	commandTag, err := s.conn.Exec(ctx, query, id)
+	return s.changeStatus(ctx, id, model.EventPRClosed, actorID, query, id, actorID)
+}
+
+func (s *PullRequestStore) Reopen(ctx context.Context, id, actorID string) error {
+	query := `
+		UPDATE pull_requests
+		SET status = 'OPEN', closed_at = NULL, closed_by = NULL
+		WHERE id = $1 AND status = 'CLOSED'
+	`
+
+	return s.changeStatus(ctx, id, model.EventPRReopened, actorID, query, id)
+}
+
+func (s *PullRequestStore) changeStatus(ctx context.Context, id string, eventType model.EventType, actorID, query string, args ...any) error {
+	tx, err := s.conn.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to merge PR: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	commandTag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to change PR status: %w", err)
 	}
 
 	if commandTag.RowsAffected() == 0 {
 		checkQuery := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE id = $1)`
 		var exists bool
-		if err := s.conn.QueryRow(ctx, checkQuery, id).Scan(&exists); err != nil || !exists {
+		if err := tx.QueryRow(ctx, checkQuery, id).Scan(&exists); err != nil || !exists {
 			return ErrNotFound
 		}
+
+		return nil
+	}
+
+	if err := appendPullRequestEvent(ctx, tx, id, model.Event{Type: eventType, ActorID: actorID}); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *PullRequestStore) SetVerdict(ctx context.Context, prID, reviewerID string, verdict model.Verdict, actorID string) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE pull_request_reviewers
+		SET verdict = $3, verdict_at = NOW()
+		WHERE pull_request_id = $1 AND reviewer_id = $2
+	`
+
+	commandTag, err := tx.Exec(ctx, query, prID, reviewerID, verdict)
+	if err != nil {
+		return fmt.Errorf("failed to set verdict: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	review := &model.EventReview{ReviewerID: reviewerID, Verdict: verdict}
+	if err := appendPullRequestEvent(ctx, tx, prID, model.Event{Type: model.EventPRReviewed, ActorID: actorID, Review: review}); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *PullRequestStore) ListOpenIDs(ctx context.Context) ([]string, error) {
+	query := `SELECT id FROM pull_requests WHERE status = 'OPEN' ORDER BY created_at, id;`
+
+	rows, err := s.conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open PRs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan PR id: %w", err)
+		}
+		ids = append(ids, id)
 	}
 
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error open PR rows: %w", err)
+	}
+
+	return ids, nil
 }
 
 func (s *PullRequestStore) GetByReviewerID(ctx context.Context, reviewerID string) ([]model.PullRequest, error) {
 	query := `
 		SELECT p.id, p.name, p.author_id, p.status
 		FROM pull_requests AS p
-		JOIN pull_requests_reviewers AS prr ON p.id = prr.pull_request_id
+		JOIN pull_request_reviewers AS prr ON p.id = prr.pull_request_id
 		WHERE prr.reviewer_id = $1
 	`
 
@@ -165,13 +336,26 @@ func (s *PullRequestStore) GetByReviewerID(ctx context.Context, reviewerID strin
 	return prs, nil
 }
 
-func (s *PullRequestStore) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+func (s *PullRequestStore) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, actorID string) error {
 	tx, err := s.conn.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
+	if err := reassignReviewer(ctx, tx, prID, oldReviewerID, newReviewerID, actorID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to commit reassign transaction: %w", err)
+	}
+
+	return nil
+}
+
+func reassignReviewer(ctx context.Context, tx pgx.Tx, prID, oldReviewerID, newReviewerID, actorID string) error {
 	deleteQuery := `
 		DELETE FROM pull_request_reviewers
 		WHERE pull_request_id = $1 AND reviewer_id = $2
@@ -187,11 +371,11 @@ func (s *PullRequestStore) ReassignReviewer(ctx context.Context, prID, oldReview
 	}
 
 	insertQuery := `
-		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
-		VALUES ($1, $2)
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, assigned_by)
+		VALUES ($1, $2, NULLIF($3, ''))
 	`
 
-	_, err = tx.Exec(ctx, insertQuery, prID, newReviewerID)
+	_, err = tx.Exec(ctx, insertQuery, prID, newReviewerID, actorID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolationCode {
@@ -201,12 +385,8 @@ func (s *PullRequestStore) ReassignReviewer(ctx context.Context, prID, oldReview
 		return fmt.Errorf("failed to insert new reviewer: %w", err)
 	}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to commit reassign transaction: %w", err)
-	}
-
-	return nil
+	reassignment := &model.EventReassignment{OldReviewerID: oldReviewerID, NewReviewerID: newReviewerID}
+	return appendPullRequestEvent(ctx, tx, prID, model.Event{Type: model.EventReviewerReassigned, ActorID: actorID, Reassignment: reassignment})
 }
 
 func (s *PullRequestStore) GetReviewCountsByUser(ctx context.Context) (map[string]int, error) {
@@ -237,3 +417,216 @@ func (s *PullRequestStore) GetReviewCountsByUser(ctx context.Context) (map[strin
 
 	return stats, nil
 }
+
+func (s *PullRequestStore) GetByAuthorID(ctx context.Context, authorID string) ([]model.PullRequest, error) {
+	query := `
+		SELECT id, name, author_id, COALESCE(team_id, 0), status
+		FROM pull_requests
+		WHERE author_id = $1
+	`
+
+	rows, err := s.conn.Query(ctx, query, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PR by author: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []model.PullRequest
+	for rows.Next() {
+		var pr model.PullRequest
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.TeamID, &pr.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan pr for author: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error prs for author: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (s *PullRequestStore) GetByIssueKey(ctx context.Context, issueKey string) ([]model.PullRequest, error) {
+	query := `
+		SELECT p.id, p.name, p.author_id, COALESCE(p.team_id, 0), COALESCE(t.name, ''), p.status, p.created_at, p.merged_at
+		FROM pull_requests AS p
+		JOIN pull_request_issues AS pi ON pi.pull_request_id = p.id
+		LEFT JOIN teams AS t ON t.id = p.team_id
+		WHERE pi.issue_key = $1
+		ORDER BY p.created_at, p.id
+	`
+
+	rows, err := s.conn.Query(ctx, query, issueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PR by issue key: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []model.PullRequest{}
+	for rows.Next() {
+		var pr model.PullRequest
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.TeamID, &pr.TeamName, &pr.Status, &pr.CreatedAt, &pr.MergedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pr for issue key: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error prs for issue key: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (s *PullRequestStore) ListNames(ctx context.Context) (map[string]string, error) {
+	query := `SELECT id, name FROM pull_requests;`
+
+	rows, err := s.conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PR names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan PR name: %w", err)
+		}
+		names[id] = name
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error PR name rows: %w", err)
+	}
+
+	return names, nil
+}
+
+func (s *PullRequestStore) ReplaceIssueKeys(ctx context.Context, issueKeys map[string][]string) (int, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	prIDs := make([]string, 0, len(issueKeys))
+	var linkedPRs, linkedKeys []string
+	for prID, keys := range issueKeys {
+		prIDs = append(prIDs, prID)
+		for _, key := range keys {
+			linkedPRs = append(linkedPRs, prID)
+			linkedKeys = append(linkedKeys, key)
+		}
+	}
+
+	deleteQuery := `DELETE FROM pull_request_issues WHERE pull_request_id = ANY($1);`
+	if _, err := tx.Exec(ctx, deleteQuery, prIDs); err != nil {
+		return 0, fmt.Errorf("failed to delete issue links: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO pull_request_issues (pull_request_id, issue_key)
+		SELECT l.pull_request_id, l.issue_key
+		FROM unnest($1::text[], $2::text[]) AS l(pull_request_id, issue_key)
+		JOIN pull_requests AS p ON p.id = l.pull_request_id
+		ON CONFLICT DO NOTHING;
+	`
+	commandTag, err := tx.Exec(ctx, insertQuery, linkedPRs, linkedKeys)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert issue links: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int(commandTag.RowsAffected()), nil
+}
+
+func removeReviewer(ctx context.Context, tx pgx.Tx, prID, reviewerID, actorID string) error {
+	query := `
+		DELETE FROM pull_request_reviewers
+		WHERE pull_request_id = $1 AND reviewer_id = $2
+	`
+
+	commandTag, err := tx.Exec(ctx, query, prID, reviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete reviewer: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	removal := &model.EventReassignment{OldReviewerID: reviewerID}
+	return appendPullRequestEvent(ctx, tx, prID, model.Event{Type: model.EventReviewerRemoved, ActorID: actorID, Reassignment: removal})
+}
+
+func transferToTeam(ctx context.Context, tx pgx.Tx, prID string, teamID int, reviewerIDs []string, actorID string) error {
+	updateQuery := `UPDATE pull_requests SET team_id = $2 WHERE id = $1`
+	commandTag, err := tx.Exec(ctx, updateQuery, prID, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to update PR team: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	deleteQuery := `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1`
+	if _, err := tx.Exec(ctx, deleteQuery, prID); err != nil {
+		return fmt.Errorf("failed to delete reviewers: %w", err)
+	}
+
+	if len(reviewerIDs) > 0 {
+		rows := make([][]any, len(reviewerIDs))
+		for i, reviewerID := range reviewerIDs {
+			rows[i] = []any{prID, reviewerID, nullableActor(actorID)}
+		}
+
+		_, err = tx.CopyFrom(
+			ctx,
+			pgx.Identifier{"pull_request_reviewers"},
+			[]string{"pull_request_id", "reviewer_id", "assigned_by"},
+			pgx.CopyFromRows(rows),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert reviewers: %w", err)
+		}
+	}
+
+	return appendPullRequestEvent(ctx, tx, prID, model.Event{Type: model.EventPRTransferred, ActorID: actorID})
+}
+
+func (s *PullRequestStore) GetTeamReviewCounts(ctx context.Context) ([]model.TeamReviewCount, error) {
+	query := `
+		SELECT t.id, COALESCE(t.parent_id, 0), t.name, COUNT(prr.reviewer_id)
+		FROM teams AS t
+		LEFT JOIN pull_requests AS p ON p.team_id = t.id
+		LEFT JOIN pull_request_reviewers AS prr ON prr.pull_request_id = p.id
+		GROUP BY t.id
+		ORDER BY t.name
+	`
+	rows, err := s.conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team review counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []model.TeamReviewCount
+	for rows.Next() {
+		var c model.TeamReviewCount
+		if err := rows.Scan(&c.TeamID, &c.ParentID, &c.TeamName, &c.ReviewCount); err != nil {
+			return nil, fmt.Errorf("failed to scan team review count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading team review count rows: %w", err)
+	}
+
+	return counts, nil
+}
@@ -59,11 +59,11 @@ func TestPullRequestStore_Integration_Merge(t *testing.T) {
 
 	s := testStore.PR()
 
-	prToCreate := model.PullRequest{ID: "pr-to-merge", Name: "Merge Test", AuthorID: "author-1"}
+	prToCreate := model.PullRequest{ID: "pr-to-merge", Name: "Merge Test", AuthorID: "author-1", CreatedBy: "author-1"}
 	err := s.Create(ctx, prToCreate)
 	require.NoError(t, err)
 
-	err = s.Merge(ctx, "pr-to-merge")
+	err = s.Merge(ctx, "pr-to-merge", "admin")
 	require.NoError(t, err)
 
 	mergedPR, err := s.GetByID(ctx, "pr-to-merge")
@@ -72,6 +72,56 @@ func TestPullRequestStore_Integration_Merge(t *testing.T) {
 	assert.Equal(t, model.StatusMerged, mergedPR.Status)
 	require.NotNil(t, mergedPR.MergedAt)
 	assert.WithinDuration(t, time.Now(), *mergedPR.MergedAt, 5*time.Second)
+	assert.Equal(t, "author-1", mergedPR.CreatedBy)
+	assert.Equal(t, "admin", mergedPR.MergedBy)
+}
+
+func TestPullRequestStore_Integration_CloseAndVerdict(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.PR()
+
+	prToCreate := model.PullRequest{ID: "pr-1", Name: "Close Test", AuthorID: "author-1", AssignedReviewers: []string{"reviewer-1"}}
+	require.NoError(t, s.Create(ctx, prToCreate))
+
+	require.NoError(t, s.SetVerdict(ctx, "pr-1", "reviewer-1", model.VerdictChangesRequested, "reviewer-1"))
+	assert.ErrorIs(t, s.SetVerdict(ctx, "pr-1", "reviewer-2", model.VerdictApproved, "reviewer-2"), ErrNotFound)
+
+	require.NoError(t, s.Close(ctx, "pr-1", "author-1"))
+	assert.ErrorIs(t, s.Close(ctx, "missing", "author-1"), ErrNotFound)
+
+	closedPR, err := s.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.StatusClosed, closedPR.Status)
+	assert.Equal(t, "author-1", closedPR.ClosedBy)
+	require.NotNil(t, closedPR.ClosedAt)
+	assert.Nil(t, closedPR.MergedAt)
+	assert.Equal(t, map[string]model.Verdict{"reviewer-1": model.VerdictChangesRequested}, closedPR.Verdicts)
+
+	require.NoError(t, s.Merge(ctx, "pr-1", "admin"))
+	closedPR, err = s.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusClosed, closedPR.Status)
+}
+
+func TestPullRequestStore_Integration_Reopen(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.PR()
+
+	require.NoError(t, s.Create(ctx, model.PullRequest{ID: "pr-1", Name: "Reopen Test", AuthorID: "author-1"}))
+	require.NoError(t, s.Close(ctx, "pr-1", "author-1"))
+	require.NoError(t, s.Reopen(ctx, "pr-1", "author-1"))
+	assert.ErrorIs(t, s.Reopen(ctx, "missing", ""), ErrNotFound)
+
+	reopenedPR, err := s.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusOpen, reopenedPR.Status)
+	assert.Nil(t, reopenedPR.ClosedAt)
+	assert.Empty(t, reopenedPR.ClosedBy)
 }
 
 func TestPullRequestStore_Integration_Reassign(t *testing.T) {
@@ -89,7 +139,7 @@ func TestPullRequestStore_Integration_Reassign(t *testing.T) {
 	err := s.Create(ctx, prToCreate)
 	require.NoError(t, err)
 
-	err = s.ReassignReviewer(ctx, "pr-to-reassign", "reviewer-1", "new-reviewer")
+	err = s.ReassignReviewer(ctx, "pr-to-reassign", "reviewer-1", "new-reviewer", "lead-1")
 	require.NoError(t, err)
 
 	reassignedPR, err := s.GetByID(ctx, "pr-to-reassign")
@@ -97,6 +147,7 @@ func TestPullRequestStore_Integration_Reassign(t *testing.T) {
 
 	expectedReviewers := []string{"new-reviewer"}
 	assert.Equal(t, expectedReviewers, reassignedPR.AssignedReviewers)
+	assert.Equal(t, map[string]string{"new-reviewer": "lead-1"}, reassignedPR.AssignedBy)
 }
 
 func TestPullRequestStore_Integration_Merge_NotFound(t *testing.T) {
@@ -105,7 +156,7 @@ func TestPullRequestStore_Integration_Merge_NotFound(t *testing.T) {
 
 	s := testStore.PR()
 
-	err := s.Merge(ctx, "non-existent-pr")
+	err := s.Merge(ctx, "non-existent-pr", "")
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrNotFound, err)
@@ -125,7 +176,7 @@ func TestPullRequestStore_Integration_Reassign_FailsIfOldReviewerNotAssigned(t *
 	err := s.Create(ctx, prToCreate)
 	require.NoError(t, err)
 
-	err = s.ReassignReviewer(ctx, "pr-reassign-fail", "reviewer-2", "new-reviewer")
+	err = s.ReassignReviewer(ctx, "pr-reassign-fail", "reviewer-2", "new-reviewer", "")
 
 	assert.Error(t, err)
 }
@@ -157,3 +208,59 @@ func TestPullRequestStore_Integration_Create_FailsOnDuplicate(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, ErrPRExists, err)
 }
+
+func TestPullRequestStore_Integration_GetTeamReviewCounts(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	team, _, err := testStore.Team().GetByName(ctx, "test-team")
+	require.NoError(t, err)
+
+	_, err = testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "engineering"}, nil)
+	require.NoError(t, err)
+	_, err = testStore.Team().SetParent(ctx, "test-team", "engineering", "")
+	require.NoError(t, err)
+
+	s := testStore.PR()
+	err = s.Create(ctx, model.PullRequest{
+		ID: "pr-1", AuthorID: "author-1", TeamID: team.ID, AssignedReviewers: []string{"reviewer-1", "reviewer-2"},
+	})
+	require.NoError(t, err)
+
+	counts, err := s.GetTeamReviewCounts(ctx)
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+
+	assert.Equal(t, "engineering", counts[0].TeamName)
+	assert.Equal(t, 0, counts[0].ReviewCount)
+	assert.Equal(t, "test-team", counts[1].TeamName)
+	assert.Equal(t, counts[0].TeamID, counts[1].ParentID)
+	assert.Equal(t, 2, counts[1].ReviewCount)
+}
+
+func TestPullRequestStore_Integration_ReplaceIssueKeys(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	s := testStore.PR()
+
+	require.NoError(t, s.Create(ctx, model.PullRequest{ID: "pr-1", Name: "PROJ-1: fix #7", AuthorID: "author-1", IssueKeys: []string{"PROJ-1"}}))
+	require.NoError(t, s.Create(ctx, model.PullRequest{ID: "pr-2", Name: "Unrelated", AuthorID: "author-1"}))
+
+	names, err := s.ListNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pr-1": "PROJ-1: fix #7", "pr-2": "Unrelated"}, names)
+
+	linked, err := s.ReplaceIssueKeys(ctx, map[string][]string{"pr-1": {"7"}, "pr-2": nil, "gone": {"PROJ-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, linked, "links of missing PRs are skipped")
+
+	prs, err := s.GetByIssueKey(ctx, "PROJ-1")
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+
+	prs, err = s.GetByIssueKey(ctx, "7")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, "pr-1", prs[0].ID)
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewerSyncStore struct {
+	conn *pgxpool.Pool
+}
+
+func (s *ReviewerSyncStore) Enqueue(ctx context.Context, prID string) error {
+	query := `
+		INSERT INTO reviewer_sync_jobs (pull_request_id)
+		VALUES ($1)
+		ON CONFLICT (pull_request_id) DO UPDATE
+		SET generation = reviewer_sync_jobs.generation + 1,
+			attempts = 0,
+			last_error = NULL,
+			next_attempt_at = NOW();
+	`
+
+	if _, err := s.conn.Exec(ctx, query, prID); err != nil {
+		return fmt.Errorf("failed to enqueue reviewer sync: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ReviewerSyncStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.ReviewerSyncJob, error) {
+	query := `
+		UPDATE reviewer_sync_jobs
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE pull_request_id IN (
+			SELECT pull_request_id
+			FROM reviewer_sync_jobs
+			WHERE next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING pull_request_id, generation, attempts, COALESCE(last_error, ''), next_attempt_at;
+	`
+
+	rows, err := s.conn.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim reviewer sync jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []model.ReviewerSyncJob
+	for rows.Next() {
+		var job model.ReviewerSyncJob
+		if err := rows.Scan(&job.PullRequestID, &job.Generation, &job.Attempts, &job.LastError, &job.NextAttemptAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer sync job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reviewer sync job rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (s *ReviewerSyncStore) Complete(ctx context.Context, prID string, generation int) error {
+	query := `DELETE FROM reviewer_sync_jobs WHERE pull_request_id = $1 AND generation = $2;`
+
+	if _, err := s.conn.Exec(ctx, query, prID, generation); err != nil {
+		return fmt.Errorf("failed to complete reviewer sync: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ReviewerSyncStore) Retry(ctx context.Context, prID string, generation int, nextAttemptAt time.Time, lastError string) error {
+	query := `
+		UPDATE reviewer_sync_jobs
+		SET attempts = attempts + 1, next_attempt_at = $3, last_error = $4
+		WHERE pull_request_id = $1 AND generation = $2;
+	`
+
+	if _, err := s.conn.Exec(ctx, query, prID, generation, nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule reviewer sync: %w", err)
+	}
+
+	return nil
+}
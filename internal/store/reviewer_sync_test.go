@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewerSyncStore_Integration_Queue(t *testing.T) {
+	ctx := context.Background()
+	setupPRTestData(ctx, t)
+
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{ID: "acme/api#1", Name: "Sync", AuthorID: "author-1"}))
+
+	s := testStore.ReviewerSync()
+
+	require.NoError(t, s.Enqueue(ctx, "acme/api#1"))
+
+	jobs, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "acme/api#1", jobs[0].PullRequestID)
+	assert.Equal(t, 1, jobs[0].Generation)
+
+	jobs, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, jobs, "claimed job is leased")
+
+	require.NoError(t, s.Retry(ctx, "acme/api#1", 1, time.Now().Add(-time.Second), "status 502"))
+
+	jobs, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 1, jobs[0].Attempts)
+	assert.Equal(t, "status 502", jobs[0].LastError)
+
+	require.NoError(t, s.Enqueue(ctx, "acme/api#1"))
+	require.NoError(t, s.Complete(ctx, "acme/api#1", 1))
+
+	jobs, err = s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1, "re-enqueued job survives completion of the previous generation")
+	assert.Equal(t, 2, jobs[0].Generation)
+	assert.Zero(t, jobs[0].Attempts)
+
+	require.NoError(t, s.Complete(ctx, "acme/api#1", 2))
+
+	ids, err := testStore.PR().ListOpenIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/api#1"}, ids)
+
+	require.NoError(t, testStore.PR().Merge(ctx, "acme/api#1", ""))
+
+	ids, err = testStore.PR().ListOpenIDs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
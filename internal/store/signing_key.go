@@ -0,0 +1,212 @@
+package store
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const dataKeyBytes = 32
+
+var (
+	ErrSigningKeyExists        = errors.New("signing key for this period already exists")
+	ErrNoKeyEncryptionKey      = errors.New("signing key encryption key is not configured")
+	ErrSigningKeyUndecryptable = errors.New("signing key cannot be decrypted with the configured encryption key")
+)
+
+type SigningKeyStore struct {
+	conn             *pgxpool.Pool
+	keyEncryptionKey []byte
+}
+
+func (s *SigningKeyStore) Create(ctx context.Context, key model.SigningKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to encode signing key: %w", err)
+	}
+
+	sealed, wrappedKey, err := s.seal(key.KID, der)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		WITH purged AS (
+			DELETE FROM signing_keys WHERE expires_at < NOW()
+		)
+		INSERT INTO signing_keys (kid, algorithm, private_key, wrapped_key, activates_at, retires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	_, err = s.conn.Exec(ctx, query, key.KID, string(key.Algorithm), sealed, wrappedKey, key.ActivatesAt, key.RetiresAt, key.ExpiresAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolationCode {
+			return ErrSigningKeyExists
+		}
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SigningKeyStore) ListUnexpired(ctx context.Context) ([]model.SigningKey, error) {
+	query := `
+		SELECT kid, algorithm, private_key, wrapped_key, activates_at, retires_at, expires_at
+		FROM signing_keys
+		WHERE expires_at > NOW()
+		ORDER BY activates_at;
+	`
+
+	rows, err := s.conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]model.SigningKey, 0)
+	plaintext := make(map[string][]byte)
+	for rows.Next() {
+		var key model.SigningKey
+		var algorithm string
+		var stored, wrappedKey []byte
+		if err := rows.Scan(&key.KID, &algorithm, &stored, &wrappedKey, &key.ActivatesAt, &key.RetiresAt, &key.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+
+		der := stored
+		if wrappedKey == nil {
+			plaintext[key.KID] = der
+		} else if der, err = s.open(key.KID, stored, wrappedKey); err != nil {
+			return nil, err
+		}
+
+		parsed, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signing key %s: %w", key.KID, err)
+		}
+
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("signing key %s is not a signer", key.KID)
+		}
+
+		key.Algorithm = model.SigningAlgorithm(algorithm)
+		key.PrivateKey = signer
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate signing keys: %w", err)
+	}
+
+	if err := s.encryptPlaintext(ctx, plaintext); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *SigningKeyStore) encryptPlaintext(ctx context.Context, plaintext map[string][]byte) error {
+	query := `UPDATE signing_keys SET private_key = $2, wrapped_key = $3 WHERE kid = $1 AND wrapped_key IS NULL;`
+
+	for kid, der := range plaintext {
+		sealed, wrappedKey, err := s.seal(kid, der)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.conn.Exec(ctx, query, kid, sealed, wrappedKey); err != nil {
+			return fmt.Errorf("failed to encrypt signing key %s: %w", kid, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SigningKeyStore) seal(kid string, der []byte) ([]byte, []byte, error) {
+	if len(s.keyEncryptionKey) == 0 {
+		return nil, nil, ErrNoKeyEncryptionKey
+	}
+
+	dataKey := make([]byte, dataKeyBytes)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+
+	sealed, err := sealWith(dataKey, kid, der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrappedKey, err := sealWith(s.keyEncryptionKey, kid, dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sealed, wrappedKey, nil
+}
+
+func (s *SigningKeyStore) open(kid string, sealed, wrappedKey []byte) ([]byte, error) {
+	if len(s.keyEncryptionKey) == 0 {
+		return nil, ErrNoKeyEncryptionKey
+	}
+
+	dataKey, err := openWith(s.keyEncryptionKey, kid, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSigningKeyUndecryptable, kid)
+	}
+
+	der, err := openWith(dataKey, kid, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSigningKeyUndecryptable, kid)
+	}
+
+	return der, nil
+}
+
+func sealWith(key []byte, kid string, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, []byte(kid)), nil
+}
+
+func openWith(key []byte, kid string, sealed []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, []byte(kid))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key encryption key: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKeyEncryptionKey = []byte("store-test-key-encryption-key-32")
+
+func TestSigningKeyStore_Integration_CreateAndList(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.SigningKey(testKeyEncryptionKey)
+	now := time.Now().Truncate(time.Microsecond)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Create(ctx, model.SigningKey{
+		KID: "rsa-1", Algorithm: model.SigningRS256, PrivateKey: rsaKey,
+		ActivatesAt: now, RetiresAt: now.Add(time.Hour), ExpiresAt: now.Add(2 * time.Hour),
+	}))
+	require.NoError(t, s.Create(ctx, model.SigningKey{
+		KID: "ed-1", Algorithm: model.SigningEdDSA, PrivateKey: edKey,
+		ActivatesAt: now.Add(time.Hour), RetiresAt: now.Add(2 * time.Hour), ExpiresAt: now.Add(3 * time.Hour),
+	}))
+	require.NoError(t, s.Create(ctx, model.SigningKey{
+		KID: "expired", Algorithm: model.SigningEdDSA, PrivateKey: edKey,
+		ActivatesAt: now.Add(-3 * time.Hour), RetiresAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour),
+	}))
+
+	err = s.Create(ctx, model.SigningKey{
+		KID: "rsa-dup", Algorithm: model.SigningRS256, PrivateKey: rsaKey,
+		ActivatesAt: now, RetiresAt: now.Add(time.Hour), ExpiresAt: now.Add(2 * time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrSigningKeyExists)
+
+	keys, err := s.ListUnexpired(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	assert.Equal(t, "rsa-1", keys[0].KID)
+	assert.Equal(t, model.SigningRS256, keys[0].Algorithm)
+	assert.True(t, rsaKey.PublicKey.Equal(keys[0].PublicKey()))
+
+	assert.Equal(t, "ed-1", keys[1].KID)
+	assert.True(t, edKey.Public().(ed25519.PublicKey).Equal(keys[1].PublicKey()))
+}
+
+func TestSigningKeyStore_Integration_PrivateKeysAreEncrypted(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.SigningKey(testKeyEncryptionKey)
+	now := time.Now().Truncate(time.Microsecond)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Create(ctx, model.SigningKey{
+		KID: "ed-1", Algorithm: model.SigningEdDSA, PrivateKey: edKey,
+		ActivatesAt: now, RetiresAt: now.Add(time.Hour), ExpiresAt: now.Add(2 * time.Hour),
+	}))
+
+	var stored []byte
+	require.NoError(t, testStore.conn.QueryRow(ctx, `SELECT private_key FROM signing_keys WHERE kid = 'ed-1'`).Scan(&stored))
+	assert.NotContains(t, string(stored), string(der))
+
+	_, err = testStore.SigningKey([]byte("another-key-encryption-key-0032b")).ListUnexpired(ctx)
+	assert.ErrorIs(t, err, ErrSigningKeyUndecryptable)
+
+	_, err = testStore.conn.Exec(ctx, `
+		INSERT INTO signing_keys (kid, algorithm, private_key, activates_at, retires_at, expires_at)
+		VALUES ('legacy', 'EdDSA', $1, $2, $3, $4)
+	`, der, now.Add(time.Hour), now.Add(2*time.Hour), now.Add(3*time.Hour))
+	require.NoError(t, err)
+
+	keys, err := s.ListUnexpired(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "legacy", keys[1].KID)
+	assert.True(t, edKey.Public().(ed25519.PublicKey).Equal(keys[1].PublicKey()))
+
+	var wrapped []byte
+	require.NoError(t, testStore.conn.QueryRow(ctx, `SELECT private_key, wrapped_key FROM signing_keys WHERE kid = 'legacy'`).Scan(&stored, &wrapped))
+	assert.NotNil(t, wrapped, "plaintext keys are encrypted when loaded")
+	assert.NotEqual(t, der, stored)
+
+	keys, err = s.ListUnexpired(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+}
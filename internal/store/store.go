@@ -13,10 +13,22 @@ var (
 )
 
 type Store struct {
-	conn *pgxpool.Pool
-	team *TeamStore
-	user *UserStore
-	pr   *PullRequestStore
+	conn     *pgxpool.Pool
+	team     *TeamStore
+	user     *UserStore
+	pr       *PullRequestStore
+	apiKey   *APIKeyStore
+	token    *TokenStore
+	oidc     *OIDCStateStore
+	ident    *IdentityStore
+	hooks    *WebhookDeliveryStore
+	sync     *ReviewerSyncStore
+	subs     *SubscriptionStore
+	outbox   *OutboxStore
+	notify   *NotificationStore
+	digest   *DigestStore
+	calendar *CalendarStore
+	issues   *IssueTrackerStore
 }
 
 func NewStore(databaseURL string) (*Store, error) {
@@ -61,8 +73,108 @@ func (s *Store) PR() *PullRequestStore {
 	return s.pr
 }
 
+func (s *Store) APIKey() *APIKeyStore {
+	if s.apiKey == nil {
+		s.apiKey = &APIKeyStore{conn: s.conn}
+	}
+
+	return s.apiKey
+}
+
+func (s *Store) Token() *TokenStore {
+	if s.token == nil {
+		s.token = &TokenStore{conn: s.conn}
+	}
+
+	return s.token
+}
+
+func (s *Store) SigningKey(keyEncryptionKey []byte) *SigningKeyStore {
+	return &SigningKeyStore{conn: s.conn, keyEncryptionKey: keyEncryptionKey}
+}
+
+func (s *Store) OIDCState() *OIDCStateStore {
+	if s.oidc == nil {
+		s.oidc = &OIDCStateStore{conn: s.conn}
+	}
+
+	return s.oidc
+}
+
+func (s *Store) Identity() *IdentityStore {
+	if s.ident == nil {
+		s.ident = &IdentityStore{conn: s.conn}
+	}
+
+	return s.ident
+}
+
+func (s *Store) WebhookDelivery() *WebhookDeliveryStore {
+	if s.hooks == nil {
+		s.hooks = &WebhookDeliveryStore{conn: s.conn}
+	}
+
+	return s.hooks
+}
+
+func (s *Store) ReviewerSync() *ReviewerSyncStore {
+	if s.sync == nil {
+		s.sync = &ReviewerSyncStore{conn: s.conn}
+	}
+
+	return s.sync
+}
+
+func (s *Store) Subscription() *SubscriptionStore {
+	if s.subs == nil {
+		s.subs = &SubscriptionStore{conn: s.conn}
+	}
+
+	return s.subs
+}
+
+func (s *Store) Outbox() *OutboxStore {
+	if s.outbox == nil {
+		s.outbox = &OutboxStore{conn: s.conn}
+	}
+
+	return s.outbox
+}
+
+func (s *Store) Notification() *NotificationStore {
+	if s.notify == nil {
+		s.notify = &NotificationStore{conn: s.conn}
+	}
+
+	return s.notify
+}
+
+func (s *Store) Digest() *DigestStore {
+	if s.digest == nil {
+		s.digest = &DigestStore{conn: s.conn}
+	}
+
+	return s.digest
+}
+
+func (s *Store) Calendar() *CalendarStore {
+	if s.calendar == nil {
+		s.calendar = &CalendarStore{conn: s.conn}
+	}
+
+	return s.calendar
+}
+
+func (s *Store) IssueTracker() *IssueTrackerStore {
+	if s.issues == nil {
+		s.issues = &IssueTrackerStore{conn: s.conn}
+	}
+
+	return s.issues
+}
+
 func (s *Store) TruncateAllTables(ctx context.Context) error {
-	_, err := s.conn.Exec(ctx, `TRUNCATE teams, users, pull_requests, pull_request_reviewers RESTART IDENTITY CASCADE;`)
+	_, err := s.conn.Exec(ctx, `TRUNCATE teams, users, team_members, pull_requests, pull_request_reviewers, api_keys, refresh_tokens, revoked_tokens, signing_keys, oidc_states, external_identities, webhook_deliveries, reviewer_sync_jobs, webhook_subscriptions, subscription_deliveries, subscription_delivery_attempts, outbox, notification_preferences, notification_templates, notifications, digest_subscriptions, calendar_feeds, absences, pull_request_issues, issue_tracker_jobs RESTART IDENTITY CASCADE;`)
 	return err
 }
 
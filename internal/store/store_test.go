@@ -69,7 +69,7 @@ func TestMain(m *testing.M) {
 }
 
 func truncateTables(ctx context.Context) {
-	_, err := testStore.conn.Exec(ctx, `TRUNCATE teams, users, pull_requests, pull_request_reviewers RESTART IDENTITY CASCADE;`)
+	_, err := testStore.conn.Exec(ctx, `TRUNCATE teams, users, team_members, pull_requests, pull_request_reviewers RESTART IDENTITY CASCADE;`)
 	if err != nil {
 		log.Fatalf("failed to truncate tables: %v", err)
 	}
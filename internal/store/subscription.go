@@ -0,0 +1,338 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const subscriptionDeliveryColumns = `d.id, d.subscription_id, s.url, s.secret, d.event_type, d.payload, d.status, d.attempts,
+	COALESCE(d.last_error, ''), d.next_attempt_at, d.delivered_at, d.created_at`
+
+type SubscriptionStore struct {
+	conn *pgxpool.Pool
+}
+
+func scanSubscription(row pgx.Row) (*model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	var eventTypes []string
+
+	if err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, &eventTypes, &sub.CreatedBy, &sub.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	sub.EventTypes = make([]model.EventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		sub.EventTypes[i] = model.EventType(eventType)
+	}
+
+	return &sub, nil
+}
+
+func scanSubscriptionDelivery(row pgx.Row) (*model.SubscriptionDelivery, error) {
+	var delivery model.SubscriptionDelivery
+
+	err := row.Scan(
+		&delivery.ID, &delivery.SubscriptionID, &delivery.URL, &delivery.Secret, &delivery.EventType, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.LastError, &delivery.NextAttemptAt, &delivery.DeliveredAt, &delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (s *SubscriptionStore) Create(ctx context.Context, sub model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING id, url, secret, event_types, COALESCE(created_by, ''), created_at;
+	`
+
+	eventTypes := make([]string, len(sub.EventTypes))
+	for i, eventType := range sub.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	created, err := scanSubscription(s.conn.QueryRow(ctx, query, sub.URL, sub.Secret, eventTypes, sub.CreatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *SubscriptionStore) List(ctx context.Context) ([]model.WebhookSubscription, error) {
+	query := `
+		SELECT id, url, secret, event_types, COALESCE(created_by, ''), created_at
+		FROM webhook_subscriptions
+		ORDER BY id;
+	`
+
+	rows, err := s.conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := []model.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error webhook subscription rows: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (s *SubscriptionStore) Delete(ctx context.Context, id int) error {
+	commandTag, err := s.conn.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *SubscriptionStore) Enqueue(ctx context.Context, event model.Event) (int, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	query := `
+		INSERT INTO subscription_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT id, NULLIF($1, 0), $2, $3
+		FROM webhook_subscriptions
+		WHERE $2 = ANY(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING;
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query, event.ID, string(event.Type), payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return int(commandTag.RowsAffected()), nil
+}
+
+func (s *SubscriptionStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.SubscriptionDelivery, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE subscription_deliveries
+			SET next_attempt_at = NOW() + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT id
+				FROM subscription_deliveries
+				WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+				ORDER BY next_attempt_at, id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + subscriptionDeliveryColumns + `
+		FROM claimed d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		ORDER BY d.id;
+	`
+
+	rows, err := s.conn.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.SubscriptionDelivery
+	for rows.Next() {
+		delivery, err := scanSubscriptionDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error webhook delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (s *SubscriptionStore) RecordAttempt(ctx context.Context, deliveryID int64, attempt model.DeliveryAttempt, status model.DeliveryStatus, nextAttemptAt time.Time) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertQuery := `
+		INSERT INTO subscription_delivery_attempts (delivery_id, status_code, error, duration_ms, attempted_at)
+		VALUES ($1, NULLIF($2, 0), NULLIF($3, ''), $4, $5);
+	`
+	if _, err := tx.Exec(ctx, insertQuery, deliveryID, attempt.StatusCode, attempt.Error, attempt.Duration.Milliseconds(), attempt.AttemptedAt); err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	updateQuery := `
+		UPDATE subscription_deliveries
+		SET attempts = attempts + 1,
+			status = $2,
+			next_attempt_at = $3,
+			last_error = NULLIF($4, ''),
+			delivered_at = CASE WHEN $2 = 'DELIVERED' THEN $5 ELSE delivered_at END
+		WHERE id = $1;
+	`
+	commandTag, err := tx.Exec(ctx, updateQuery, deliveryID, string(status), nextAttemptAt, attempt.Error, attempt.AttemptedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *SubscriptionStore) GetDelivery(ctx context.Context, id int64) (*model.SubscriptionDelivery, error) {
+	query := `
+		SELECT ` + subscriptionDeliveryColumns + `
+		FROM subscription_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.id = $1;
+	`
+
+	delivery, err := scanSubscriptionDelivery(s.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	deliveries := []model.SubscriptionDelivery{*delivery}
+	if err := s.loadAttempts(ctx, deliveries); err != nil {
+		return nil, err
+	}
+
+	return &deliveries[0], nil
+}
+
+func (s *SubscriptionStore) ListDeliveries(ctx context.Context, filter model.DeliveryFilter) ([]model.SubscriptionDelivery, error) {
+	query := `
+		SELECT ` + subscriptionDeliveryColumns + `
+		FROM subscription_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE ($1 = 0 OR d.subscription_id = $1) AND ($2 = '' OR d.status::text = $2)
+		ORDER BY d.id DESC
+		LIMIT $3;
+	`
+
+	rows, err := s.conn.Query(ctx, query, filter.SubscriptionID, string(filter.Status), filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []model.SubscriptionDelivery{}
+	for rows.Next() {
+		delivery, err := scanSubscriptionDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error webhook delivery rows: %w", err)
+	}
+
+	if err := s.loadAttempts(ctx, deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (s *SubscriptionStore) Redeliver(ctx context.Context, id int64) error {
+	query := `
+		UPDATE subscription_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+		WHERE id = $1;
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to schedule webhook redelivery: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *SubscriptionStore) loadAttempts(ctx context.Context, deliveries []model.SubscriptionDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(deliveries))
+	index := make(map[int64]int, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i] = delivery.ID
+		index[delivery.ID] = i
+		deliveries[i].AttemptLog = []model.DeliveryAttempt{}
+	}
+
+	query := `
+		SELECT delivery_id, COALESCE(status_code, 0), COALESCE(error, ''), duration_ms, attempted_at
+		FROM subscription_delivery_attempts
+		WHERE delivery_id = ANY($1)
+		ORDER BY id;
+	`
+
+	rows, err := s.conn.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to query webhook attempts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var deliveryID int64
+		var durationMs int64
+		var attempt model.DeliveryAttempt
+		if err := rows.Scan(&deliveryID, &attempt.StatusCode, &attempt.Error, &durationMs, &attempt.AttemptedAt); err != nil {
+			return fmt.Errorf("failed to scan webhook attempt: %w", err)
+		}
+		attempt.Duration = time.Duration(durationMs) * time.Millisecond
+
+		i := index[deliveryID]
+		deliveries[i].AttemptLog = append(deliveries[i].AttemptLog, attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error webhook attempt rows: %w", err)
+	}
+
+	return nil
+}
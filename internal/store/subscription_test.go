@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionStore_Integration_DeliveryLifecycle(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Subscription()
+
+	merged, err := s.Create(ctx, model.WebhookSubscription{URL: "https://a.example.com", Secret: "secret-a", EventTypes: []model.EventType{model.EventPRMerged}})
+	require.NoError(t, err)
+	assert.Equal(t, []model.EventType{model.EventPRMerged}, merged.EventTypes)
+	assert.Empty(t, merged.CreatedBy)
+
+	all, err := s.Create(ctx, model.WebhookSubscription{URL: "https://b.example.com", Secret: "secret-b", EventTypes: []model.EventType{model.EventPRCreated, model.EventPRMerged}, CreatedBy: "admin"})
+	require.NoError(t, err)
+
+	subs, err := s.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	assert.Equal(t, "admin", subs[1].CreatedBy)
+
+	created := model.Event{ID: 1, Type: model.EventPRCreated, PullRequest: model.EventPullRequest{ID: "pr-1"}}
+	queued, err := s.Enqueue(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	queued, err = s.Enqueue(ctx, model.Event{ID: 2, Type: model.EventPRMerged})
+	require.NoError(t, err)
+	assert.Equal(t, 2, queued)
+
+	queued, err = s.Enqueue(ctx, model.Event{ID: 2, Type: model.EventPRMerged})
+	require.NoError(t, err)
+	assert.Zero(t, queued, "a relayed event is queued once per subscription")
+
+	due, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, due, 3)
+	assert.Equal(t, all.ID, due[0].SubscriptionID)
+	assert.Equal(t, "https://b.example.com", due[0].URL)
+	assert.Equal(t, "secret-b", due[0].Secret)
+	var payload model.Event
+	require.NoError(t, json.Unmarshal(due[0].Payload, &payload))
+	assert.Equal(t, created.ID, payload.ID)
+	assert.Equal(t, "pr-1", payload.PullRequest.ID)
+
+	again, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again, "claimed deliveries are leased")
+
+	now := time.Now()
+	require.NoError(t, s.RecordAttempt(ctx, due[0].ID, model.DeliveryAttempt{StatusCode: 200, Duration: 15 * time.Millisecond, AttemptedAt: now}, model.DeliveryDelivered, now))
+	require.NoError(t, s.RecordAttempt(ctx, due[1].ID, model.DeliveryAttempt{StatusCode: 503, Error: "status 503", AttemptedAt: now}, model.DeliveryPending, now.Add(-time.Second)))
+	require.NoError(t, s.RecordAttempt(ctx, due[2].ID, model.DeliveryAttempt{Error: "connection refused", AttemptedAt: now}, model.DeliveryDead, now))
+
+	retried, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, due[1].ID, retried[0].ID)
+	assert.Equal(t, 1, retried[0].Attempts)
+	assert.Equal(t, "status 503", retried[0].LastError)
+
+	delivered, err := s.GetDelivery(ctx, due[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DeliveryDelivered, delivered.Status)
+	assert.NotNil(t, delivered.DeliveredAt)
+	require.Len(t, delivered.AttemptLog, 1)
+	assert.Equal(t, 200, delivered.AttemptLog[0].StatusCode)
+	assert.Equal(t, 15*time.Millisecond, delivered.AttemptLog[0].Duration)
+
+	dead, err := s.ListDeliveries(ctx, model.DeliveryFilter{Status: model.DeliveryDead, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "connection refused", dead[0].AttemptLog[0].Error)
+	assert.Zero(t, dead[0].AttemptLog[0].StatusCode)
+
+	forSub, err := s.ListDeliveries(ctx, model.DeliveryFilter{SubscriptionID: merged.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, forSub, 1)
+
+	require.NoError(t, s.Redeliver(ctx, dead[0].ID))
+	redelivered, err := s.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, redelivered, 1)
+	assert.Equal(t, dead[0].ID, redelivered[0].ID)
+	assert.Zero(t, redelivered[0].Attempts)
+
+	assert.ErrorIs(t, s.Redeliver(ctx, 999), ErrNotFound)
+	_, err = s.GetDelivery(ctx, 999)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, s.Delete(ctx, all.ID))
+	assert.ErrorIs(t, s.Delete(ctx, all.ID), ErrNotFound)
+
+	remaining, err := s.ListDeliveries(ctx, model.DeliveryFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, remaining, 1, "deliveries are removed with their subscription")
+}
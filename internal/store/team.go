@@ -11,24 +11,49 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-const postgresUniqueViolationCode = "23505"
+const (
+	postgresUniqueViolationCode     = "23505"
+	postgresForeignKeyViolationCode = "23503"
 
-var ErrTeamExists = errors.New("team with this name already exists")
+	teamHierarchyLock int64 = 0x7465616d73
+)
+
+var (
+	ErrTeamExists        = errors.New("team with this name already exists")
+	ErrUserInAnotherTeam = errors.New("user is a member of another team")
+	ErrPrimaryTeam       = errors.New("team is the user's primary team")
+	ErrHierarchyCycle    = errors.New("team cannot be nested under itself or its descendant")
+	ErrTeamArchived      = errors.New("team is archived")
+)
+
+const teamColumns = `t.id, t.name, COALESCE(t.parent_id, 0), t.reviewer_count, t.fallback_to_parent, t.archived_at, COALESCE(t.created_by, '')`
+
+const deactivatedByOnUpsert = `CASE
+	WHEN EXCLUDED.is_active THEN NULL
+	WHEN users.is_active THEN EXCLUDED.deactivated_by
+	ELSE users.deactivated_by
+END`
+
+func teamFields(team *model.Team) []any {
+	return []any{
+		&team.ID, &team.Name, &team.ParentID, &team.Policy.ReviewerCount, &team.Policy.FallbackToParent, &team.ArchivedAt, &team.CreatedBy,
+	}
+}
 
 type TeamStore struct {
 	conn *pgxpool.Pool
 }
 
-func (s *TeamStore) AddTeamWithMembers(ctx context.Context, team model.Team, members []model.User) (*model.Team, error) {
+func (s *TeamStore) AddTeamWithMembers(ctx context.Context, team model.Team, members []model.User) (*model.TeamUpsert, error) {
 	tx, err := s.conn.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	createTeamQuery := `INSERT INTO teams (name) VALUES ($1) RETURNING id;`
+	createTeamQuery := `INSERT INTO teams (name, created_by) VALUES ($1, NULLIF($2, '')) RETURNING id;`
 	var teamID int
-	err = tx.QueryRow(ctx, createTeamQuery, team.Name).Scan(&teamID)
+	err = tx.QueryRow(ctx, createTeamQuery, team.Name, team.CreatedBy).Scan(&teamID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolationCode {
@@ -37,20 +62,45 @@ func (s *TeamStore) AddTeamWithMembers(ctx context.Context, team model.Team, mem
 		return nil, fmt.Errorf("failed to insert team: %w", err)
 	}
 
-	if len(members) > 0 {
-		rows := make([][]any, len(members))
-		for i, member := range members {
-			rows[i] = []any{member.ID, member.Username, member.IsActive, teamID}
-		}
+	result := &model.TeamUpsert{Team: team}
+	result.Team.ID = teamID
 
-		_, err := tx.CopyFrom(
-			ctx,
-			pgx.Identifier{"users"},
-			[]string{"id", "username", "is_active", "team_id"},
-			pgx.CopyFromRows(rows),
-		)
+	upsertUserQuery := `
+		INSERT INTO users (id, username, is_active, deactivated_by)
+		VALUES ($1, $2, $3, CASE WHEN $3 THEN NULL ELSE NULLIF($4, '') END)
+		ON CONFLICT (id) DO UPDATE
+		SET username = EXCLUDED.username, is_active = EXCLUDED.is_active,
+			deactivated_by = ` + deactivatedByOnUpsert + `
+		RETURNING (xmax = 0) AS inserted;
+	`
+	dropPrimaryQuery := `DELETE FROM team_members WHERE user_id = $1 AND is_primary;`
+	addPrimaryQuery := `
+		INSERT INTO team_members (team_id, user_id, is_primary, reviewable)
+		VALUES ($1, $2, TRUE, TRUE);
+	`
+
+	for _, member := range members {
+		var inserted bool
+		err := tx.QueryRow(ctx, upsertUserQuery, member.ID, member.Username, member.IsActive, team.CreatedBy).Scan(&inserted)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert users: %w", err)
+			return nil, fmt.Errorf("failed to upsert user %s: %w", member.ID, err)
+		}
+
+		if !inserted {
+			if _, err := tx.Exec(ctx, dropPrimaryQuery, member.ID); err != nil {
+				return nil, fmt.Errorf("failed to drop primary team of user %s: %w", member.ID, err)
+			}
+		}
+
+		if _, err := tx.Exec(ctx, addPrimaryQuery, teamID, member.ID); err != nil {
+			return nil, fmt.Errorf("failed to add user %s to team: %w", member.ID, err)
+		}
+
+		member.TeamID = teamID
+		if inserted {
+			result.Created = append(result.Created, member)
+		} else {
+			result.Updated = append(result.Updated, member)
 		}
 	}
 
@@ -58,21 +108,29 @@ func (s *TeamStore) AddTeamWithMembers(ctx context.Context, team model.Team, mem
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	createdTeam := team
-	createdTeam.ID = teamID
-	return &createdTeam, nil
+	return result, nil
 }
 
 func (s *TeamStore) GetByName(ctx context.Context, name string) (*model.Team, []model.User, error) {
+	return s.getWithMembers(ctx, `t.name = $1`, name)
+}
+
+func (s *TeamStore) GetByID(ctx context.Context, id int) (*model.Team, []model.User, error) {
+	return s.getWithMembers(ctx, `t.id = $1`, id)
+}
+
+func (s *TeamStore) getWithMembers(ctx context.Context, condition string, arg any) (*model.Team, []model.User, error) {
 	query := `
-		SELECT t.id, t.name, u.id, u.username, u.is_active, u.team_id
+		SELECT ` + teamColumns + `, u.id, u.username, u.is_active, p.team_id
 		FROM teams AS t
-		LEFT JOIN users AS u ON t.id = u.team_id
-		WHERE t.name = $1;
+		LEFT JOIN team_members AS tm ON tm.team_id = t.id
+		LEFT JOIN users AS u ON u.id = tm.user_id
+		LEFT JOIN team_members AS p ON p.user_id = u.id AND p.is_primary
+		WHERE ` + condition + `;
 	`
-	rows, err := s.conn.Query(ctx, query, name)
+	rows, err := s.conn.Query(ctx, query, arg)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to query team by name: %w", err)
+		return nil, nil, fmt.Errorf("failed to query team: %w", err)
 	}
 	defer rows.Close()
 
@@ -86,7 +144,7 @@ func (s *TeamStore) GetByName(ctx context.Context, name string) (*model.Team, []
 		var isActive *bool
 		var teamID *int
 
-		if err := rows.Scan(&team.ID, &team.Name, &UserID, &username, &isActive, &teamID); err != nil {
+		if err := rows.Scan(append(teamFields(&team), &UserID, &username, &isActive, &teamID)...); err != nil {
 			return nil, nil, fmt.Errorf("failed to scan team row: %w", err)
 		}
 		teamFound = true
@@ -95,7 +153,9 @@ func (s *TeamStore) GetByName(ctx context.Context, name string) (*model.Team, []
 			member.ID = *UserID
 			member.Username = *username
 			member.IsActive = *isActive
-			member.TeamID = *teamID
+			if teamID != nil {
+				member.TeamID = *teamID
+			}
 			members = append(members, member)
 		}
 	}
@@ -110,3 +170,631 @@ func (s *TeamStore) GetByName(ctx context.Context, name string) (*model.Team, []
 
 	return &team, members, nil
 }
+
+func (s *TeamStore) AddMember(ctx context.Context, teamName string, member model.User, actorID string) (*model.FullUserInfo, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var teamID int
+	var archived bool
+	teamQuery := `SELECT id, archived_at IS NOT NULL FROM teams WHERE name = $1;`
+	err = tx.QueryRow(ctx, teamQuery, teamName).Scan(&teamID, &archived)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get team by name: %w", err)
+	}
+	if archived {
+		return nil, ErrTeamArchived
+	}
+
+	var primaryTeamID int
+	primaryQuery := `SELECT team_id FROM team_members WHERE user_id = $1 AND is_primary;`
+	err = tx.QueryRow(ctx, primaryQuery, member.ID).Scan(&primaryTeamID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get primary team: %w", err)
+	}
+	if err == nil && primaryTeamID != teamID {
+		return nil, ErrUserInAnotherTeam
+	}
+
+	upsertQuery := `
+		INSERT INTO users (id, username, is_active)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE
+		SET username = EXCLUDED.username, is_active = EXCLUDED.is_active
+		RETURNING id, username, is_active;
+	`
+
+	user := model.FullUserInfo{TeamName: teamName}
+	user.TeamID = teamID
+	err = tx.QueryRow(ctx, upsertQuery, member.ID, member.Username, member.IsActive).Scan(
+		&user.ID, &user.Username, &user.IsActive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add team member: %w", err)
+	}
+
+	membershipQuery := `
+		INSERT INTO team_members (team_id, user_id, is_primary, reviewable, added_by)
+		VALUES ($1, $2, TRUE, TRUE, NULLIF($3, ''))
+		ON CONFLICT (team_id, user_id) DO UPDATE
+		SET is_primary = TRUE, reviewable = TRUE, added_by = EXCLUDED.added_by;
+	`
+	if _, err := tx.Exec(ctx, membershipQuery, teamID, member.ID, actorID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolationCode {
+			return nil, ErrUserInAnotherTeam
+		}
+		return nil, fmt.Errorf("failed to add team membership: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (s *TeamStore) SetSecondaryMember(ctx context.Context, teamName, userID string, reviewable bool, actorID string) (*model.TeamMembership, error) {
+	query := `
+		WITH team AS (
+			SELECT id, name FROM teams WHERE name = $1 AND archived_at IS NULL
+		), membership AS (
+			INSERT INTO team_members (team_id, user_id, is_primary, reviewable, added_by)
+			SELECT team.id, $2, FALSE, $3, NULLIF($4, '') FROM team
+			ON CONFLICT (team_id, user_id) DO UPDATE
+			SET reviewable = EXCLUDED.reviewable, added_by = EXCLUDED.added_by
+			WHERE NOT team_members.is_primary
+			RETURNING team_id, user_id, is_primary, reviewable
+		)
+		SELECT m.team_id, team.name, m.user_id, m.is_primary, m.reviewable
+		FROM membership AS m
+		JOIN team ON team.id = m.team_id;
+	`
+
+	var membership model.TeamMembership
+	err := s.conn.QueryRow(ctx, query, teamName, userID, reviewable, actorID).Scan(
+		&membership.TeamID, &membership.TeamName, &membership.UserID, &membership.IsPrimary, &membership.Reviewable,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return nil, ErrNotFound
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.secondaryMemberConflict(ctx, teamName)
+		}
+		return nil, fmt.Errorf("failed to set secondary member: %w", err)
+	}
+
+	return &membership, nil
+}
+
+func (s *TeamStore) secondaryMemberConflict(ctx context.Context, teamName string) error {
+	var archived bool
+	checkQuery := `SELECT archived_at IS NOT NULL FROM teams WHERE name = $1`
+	if err := s.conn.QueryRow(ctx, checkQuery, teamName).Scan(&archived); err != nil {
+		return ErrNotFound
+	}
+
+	if archived {
+		return ErrTeamArchived
+	}
+
+	return ErrPrimaryTeam
+}
+
+func (s *TeamStore) RemoveMember(ctx context.Context, teamName, userID string) error {
+	query := `
+		DELETE FROM team_members
+		WHERE user_id = $1 AND team_id = (SELECT id FROM teams WHERE name = $2);
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query, userID, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func scanTeams(rows pgx.Rows) ([]model.Team, error) {
+	var teams []model.Team
+	for rows.Next() {
+		var team model.Team
+		if err := rows.Scan(teamFields(&team)...); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error team rows: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (s *TeamStore) GetAncestors(ctx context.Context, teamID int) ([]model.Team, error) {
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT id, parent_id, 0 AS depth FROM teams WHERE id = $1
+			UNION ALL
+			SELECT t.id, t.parent_id, c.depth + 1
+			FROM teams AS t
+			JOIN chain AS c ON t.id = c.parent_id
+		) CYCLE id SET is_cycle USING path
+		SELECT ` + teamColumns + `
+		FROM chain AS c
+		JOIN teams AS t ON t.id = c.id
+		WHERE NOT c.is_cycle
+		ORDER BY c.depth;
+	`
+
+	rows, err := s.conn.Query(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team ancestors: %w", err)
+	}
+	defer rows.Close()
+
+	teams, err := scanTeams(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(teams) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return teams, nil
+}
+
+func (s *TeamStore) GetChildren(ctx context.Context, teamID int) ([]model.Team, error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams AS t
+		WHERE t.parent_id = $1
+		ORDER BY t.name;
+	`
+
+	rows, err := s.conn.Query(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team children: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeams(rows)
+}
+
+func (s *TeamStore) SetParent(ctx context.Context, teamName, parentName, actorID string) (*model.Team, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1);`, teamHierarchyLock); err != nil {
+		return nil, fmt.Errorf("failed to lock team hierarchy: %w", err)
+	}
+
+	var teamID int
+	err = tx.QueryRow(ctx, `SELECT id FROM teams WHERE name = $1 FOR UPDATE;`, teamName).Scan(&teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get team by name: %w", err)
+	}
+
+	var parentID *int
+	if parentName != "" {
+		cycleQuery := `
+			WITH RECURSIVE chain AS (
+				SELECT id, parent_id FROM teams WHERE name = $1
+				UNION ALL
+				SELECT t.id, t.parent_id
+				FROM teams AS t
+				JOIN chain AS c ON t.id = c.parent_id
+			) CYCLE id SET is_cycle USING path
+			SELECT (SELECT id FROM teams WHERE name = $1), EXISTS(SELECT 1 FROM chain WHERE id = $2 OR is_cycle);
+		`
+
+		var id *int
+		var isCycle bool
+		if err := tx.QueryRow(ctx, cycleQuery, parentName, teamID).Scan(&id, &isCycle); err != nil {
+			return nil, fmt.Errorf("failed to check team hierarchy: %w", err)
+		}
+		if id == nil {
+			return nil, ErrNotFound
+		}
+		if isCycle {
+			return nil, ErrHierarchyCycle
+		}
+		parentID = id
+	}
+
+	query := `
+		UPDATE teams AS t SET parent_id = $2, updated_by = NULLIF($3, '')
+		WHERE t.id = $1
+		RETURNING ` + teamColumns + `;
+	`
+
+	var team model.Team
+	err = tx.QueryRow(ctx, query, teamID, parentID, actorID).Scan(teamFields(&team)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set team parent: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &team, nil
+}
+
+func (s *TeamStore) SetPolicy(ctx context.Context, teamName string, policy model.AssignmentPolicy, actorID string) (*model.Team, error) {
+	query := `
+		UPDATE teams AS t
+		SET reviewer_count = $2, fallback_to_parent = $3, updated_by = NULLIF($4, '')
+		WHERE t.name = $1
+		RETURNING ` + teamColumns + `;
+	`
+
+	var team model.Team
+	err := s.conn.QueryRow(ctx, query, teamName, policy.ReviewerCount, policy.FallbackToParent, actorID).Scan(teamFields(&team)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set team policy: %w", err)
+	}
+
+	return &team, nil
+}
+
+func (s *TeamStore) Rename(ctx context.Context, teamName, newName, actorID string) (*model.Team, error) {
+	query := `
+		UPDATE teams AS t SET name = $2, updated_by = NULLIF($3, '')
+		WHERE t.name = $1
+		RETURNING ` + teamColumns + `;
+	`
+
+	var team model.Team
+	err := s.conn.QueryRow(ctx, query, teamName, newName, actorID).Scan(teamFields(&team)...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolationCode {
+			return nil, ErrTeamExists
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to rename team: %w", err)
+	}
+
+	return &team, nil
+}
+
+func (s *TeamStore) SetArchived(ctx context.Context, teamName string, archived bool, actorID string) (*model.Team, error) {
+	query := `
+		UPDATE teams AS t
+		SET archived_at = CASE WHEN $2 THEN COALESCE(t.archived_at, NOW()) ELSE NULL END,
+			archived_by = CASE
+				WHEN NOT $2 THEN NULL
+				WHEN t.archived_at IS NULL THEN NULLIF($3, '')
+				ELSE t.archived_by
+			END
+		WHERE t.name = $1
+		RETURNING ` + teamColumns + `;
+	`
+
+	var team model.Team
+	err := s.conn.QueryRow(ctx, query, teamName, archived, actorID).Scan(teamFields(&team)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set team archived: %w", err)
+	}
+
+	return &team, nil
+}
+
+func (s *TeamStore) GetDeletionReport(ctx context.Context, team model.Team) (*model.TeamDeletionReport, error) {
+	report := &model.TeamDeletionReport{
+		Team:               team,
+		MemberIDs:          []string{},
+		PrimaryMemberIDs:   []string{},
+		OpenPullRequestIDs: []string{},
+		ChildTeams:         []string{},
+	}
+
+	membersQuery := `SELECT user_id, is_primary FROM team_members WHERE team_id = $1 ORDER BY user_id;`
+	rows, err := s.conn.Query(ctx, membersQuery, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team members: %w", err)
+	}
+	for rows.Next() {
+		var userID string
+		var isPrimary bool
+		if err := rows.Scan(&userID, &isPrimary); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		report.MemberIDs = append(report.MemberIDs, userID)
+		if isPrimary {
+			report.PrimaryMemberIDs = append(report.PrimaryMemberIDs, userID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error team member rows: %w", err)
+	}
+
+	prQuery := `SELECT id, status FROM pull_requests WHERE team_id = $1 ORDER BY id;`
+	rows, err = s.conn.Query(ctx, prQuery, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team pull requests: %w", err)
+	}
+	for rows.Next() {
+		var prID string
+		var status model.PRStatus
+		if err := rows.Scan(&prID, &status); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan team pull request: %w", err)
+		}
+		report.PullRequestCount++
+		if status == model.StatusOpen {
+			report.OpenPullRequestIDs = append(report.OpenPullRequestIDs, prID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error team pull request rows: %w", err)
+	}
+
+	children, err := s.GetChildren(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		report.ChildTeams = append(report.ChildTeams, child.Name)
+	}
+
+	return report, nil
+}
+
+func (s *TeamStore) Delete(ctx context.Context, teamID int, actorID string) error {
+	query := `
+		WITH deleted AS (
+			DELETE FROM teams WHERE id = $1 AND archived_at IS NOT NULL
+			RETURNING id, name
+		)
+		INSERT INTO team_deletions (team_id, team_name, deleted_by)
+		SELECT id, name, NULLIF($2, '') FROM deleted;
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query, teamID, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+const teamFilterClause = `
+	WHERE ($1 OR t.archived_at IS NULL)
+		AND starts_with(t.name, $2)
+		AND ($3 = '' OR parent.name = $3)
+		AND ($4 = '' OR t.name > $4)
+		AND ($5 = '' OR t.name = $5)
+`
+
+func (s *TeamStore) List(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, error) {
+	query := `
+		SELECT ` + teamColumns + `, COALESCE(parent.name, ''),
+			COUNT(tm.user_id), COUNT(tm.user_id) FILTER (WHERE u.is_active)
+		FROM teams AS t
+		LEFT JOIN teams AS parent ON parent.id = t.parent_id
+		LEFT JOIN team_members AS tm ON tm.team_id = t.id
+		LEFT JOIN users AS u ON u.id = tm.user_id
+		` + teamFilterClause + `
+		GROUP BY t.id, parent.name
+		ORDER BY t.name
+		LIMIT $6 OFFSET $7;
+	`
+
+	rows, err := s.conn.Query(ctx, query,
+		filter.IncludeArchived, filter.NamePrefix, filter.ParentName, filter.After, filter.Name,
+		filter.Limit, filter.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []model.TeamSummary{}
+	for rows.Next() {
+		var summary model.TeamSummary
+		fields := append(teamFields(&summary.Team), &summary.ParentName, &summary.MemberCount, &summary.ActiveMemberCount)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("failed to scan team summary: %w", err)
+		}
+		teams = append(teams, summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error team rows: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (s *TeamStore) Count(ctx context.Context, filter model.TeamFilter) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM teams AS t
+		LEFT JOIN teams AS parent ON parent.id = t.parent_id
+		` + teamFilterClause + `;
+	`
+
+	var count int
+	err := s.conn.QueryRow(ctx, query,
+		filter.IncludeArchived, filter.NamePrefix, filter.ParentName, filter.After, filter.Name,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *TeamStore) ApplyImport(ctx context.Context, plan model.ImportPlan, actorID string) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	createTeamQuery := `INSERT INTO teams (name, created_by) VALUES ($1, NULLIF($2, '')) ON CONFLICT (name) DO NOTHING;`
+	for _, name := range plan.TeamsToCreate {
+		if _, err := tx.Exec(ctx, createTeamQuery, name, actorID); err != nil {
+			return fmt.Errorf("failed to create team %s: %w", name, err)
+		}
+	}
+
+	upsertUserQuery := `
+		INSERT INTO users (id, username, is_active, deactivated_by)
+		VALUES ($1, $2, $3, CASE WHEN $3 THEN NULL ELSE NULLIF($4, '') END)
+		ON CONFLICT (id) DO UPDATE
+		SET username = EXCLUDED.username, is_active = EXCLUDED.is_active,
+			deactivated_by = ` + deactivatedByOnUpsert + `;
+	`
+	dropPrimaryQuery := `DELETE FROM team_members WHERE user_id = $1 AND is_primary;`
+	setPrimaryQuery := `
+		INSERT INTO team_members (team_id, user_id, is_primary, reviewable)
+		SELECT id, $2, TRUE, TRUE FROM teams WHERE name = $1
+		ON CONFLICT (team_id, user_id) DO UPDATE
+		SET is_primary = TRUE, reviewable = TRUE;
+	`
+
+	placements := append(append([]model.ImportUserChange{}, plan.UsersToAdd...), plan.UsersToMove...)
+	for _, change := range placements {
+		user := change.User
+		if _, err := tx.Exec(ctx, upsertUserQuery, user.ID, user.Username, user.IsActive, actorID); err != nil {
+			return fmt.Errorf("failed to upsert user %s: %w", user.ID, err)
+		}
+		if _, err := tx.Exec(ctx, dropPrimaryQuery, user.ID); err != nil {
+			return fmt.Errorf("failed to drop primary team of user %s: %w", user.ID, err)
+		}
+		if _, err := tx.Exec(ctx, setPrimaryQuery, change.TeamName, user.ID); err != nil {
+			return fmt.Errorf("failed to add user %s to team %s: %w", user.ID, change.TeamName, err)
+		}
+	}
+
+	for _, change := range plan.UsersToUpdate {
+		user := change.User
+		if _, err := tx.Exec(ctx, upsertUserQuery, user.ID, user.Username, user.IsActive, actorID); err != nil {
+			return fmt.Errorf("failed to update user %s: %w", user.ID, err)
+		}
+	}
+
+	deactivateQuery := `
+		UPDATE users
+		SET is_active = FALSE, deactivated_by = CASE WHEN is_active THEN NULLIF($2, '') ELSE deactivated_by END
+		WHERE id = $1;
+	`
+	for _, change := range plan.UsersToDeactivate {
+		if _, err := tx.Exec(ctx, deactivateQuery, change.User.ID, actorID); err != nil {
+			return fmt.Errorf("failed to deactivate user %s: %w", change.User.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TeamStore) ApplyMembershipChange(ctx context.Context, plan model.MembershipPlan, actorID string) (*model.FullUserInfo, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if plan.Deactivate {
+		deactivateQuery := `
+			UPDATE users
+			SET is_active = FALSE,
+				deactivated_by = CASE WHEN is_active THEN NULLIF($2, '') ELSE deactivated_by END
+			WHERE id = $1;
+		`
+		commandTag, err := tx.Exec(ctx, deactivateQuery, plan.UserID, actorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deactivate user: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return nil, ErrNotFound
+		}
+	}
+
+	for _, r := range plan.Reassignments {
+		if r.NewReviewerID == "" {
+			err = removeReviewer(ctx, tx, r.PullRequestID, r.OldReviewerID, actorID)
+		} else {
+			err = reassignReviewer(ctx, tx, r.PullRequestID, r.OldReviewerID, r.NewReviewerID, actorID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if plan.LeaveTeamID != 0 {
+		leaveQuery := `DELETE FROM team_members WHERE user_id = $1 AND team_id = $2;`
+		commandTag, err := tx.Exec(ctx, leaveQuery, plan.UserID, plan.LeaveTeamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove team member: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return nil, ErrNotFound
+		}
+	}
+
+	if plan.JoinTeamID != 0 {
+		if err := setPrimaryTeam(ctx, tx, plan.UserID, plan.JoinTeamID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, transfer := range plan.Transfers {
+		if err := transferToTeam(ctx, tx, transfer.PullRequestID, plan.JoinTeamID, transfer.ReviewerIDs, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := scanUser(tx.QueryRow(ctx, userByIDQuery, plan.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return user, nil
+}
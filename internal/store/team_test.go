@@ -15,14 +15,15 @@ func TestTeamStore_Integration_AddTeamWithMembers_And_GetByName(t *testing.T) {
 
 	s := testStore.Team()
 
-	teamToCreate := model.Team{Name: "backend-team"}
+	teamToCreate := model.Team{Name: "backend-team", CreatedBy: "admin"}
 	membersToCreate := []model.User{
 		{ID: "u1", Username: "Alice", IsActive: true},
 		{ID: "u2", Username: "Bob", IsActive: false},
 	}
 
-	createdTeam, err := s.AddTeamWithMembers(ctx, teamToCreate, membersToCreate)
+	result, err := s.AddTeamWithMembers(ctx, teamToCreate, membersToCreate)
 	require.NoError(t, err, "AddTeamWithMembers should not return an error")
+	createdTeam := result.Team
 
 	require.NotZero(t, createdTeam.ID, "Created team should have a non-zero ID")
 
@@ -32,6 +33,7 @@ func TestTeamStore_Integration_AddTeamWithMembers_And_GetByName(t *testing.T) {
 	require.NotNil(t, fetchedTeam)
 	assert.Equal(t, createdTeam.ID, fetchedTeam.ID, "Team ID should match")
 	assert.Equal(t, "backend-team", fetchedTeam.Name, "Team name should match")
+	assert.Equal(t, "admin", fetchedTeam.CreatedBy)
 
 	require.Len(t, fetchedMembers, 2, "Should fetch 2 members")
 
@@ -88,3 +90,461 @@ func TestTeamStore_Integration_AddTeamWithMembers_NoMembers(t *testing.T) {
 	assert.Equal(t, "empty-team", fetchedTeam.Name)
 	assert.Empty(t, fetchedMembers, "Should have no members")
 }
+
+func TestTeamStore_Integration_AddMember(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	backend, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{{ID: "u1", Username: "Alice", IsActive: true}})
+	require.NoError(t, err)
+	_, err = s.AddTeamWithMembers(ctx, model.Team{Name: "frontend"}, nil)
+	require.NoError(t, err)
+
+	added, err := s.AddMember(ctx, "backend", model.User{ID: "u2", Username: "Bob", IsActive: true}, "")
+	require.NoError(t, err)
+	assert.Equal(t, backend.Team.ID, added.TeamID)
+	assert.Equal(t, "backend", added.TeamName)
+
+	updated, err := s.AddMember(ctx, "backend", model.User{ID: "u2", Username: "Robert", IsActive: false}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Robert", updated.Username)
+	assert.False(t, updated.IsActive)
+
+	_, err = s.AddMember(ctx, "frontend", model.User{ID: "u1", Username: "Alice", IsActive: true}, "")
+	assert.Equal(t, ErrUserInAnotherTeam, err)
+
+	_, err = s.AddMember(ctx, "non-existent-team", model.User{ID: "u3", Username: "Carol"}, "")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestTeamStore_Integration_RemoveMember(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	_, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{{ID: "u1", Username: "Alice", IsActive: true}})
+	require.NoError(t, err)
+
+	err = s.RemoveMember(ctx, "backend", "u1")
+	require.NoError(t, err)
+
+	_, members, err := s.GetByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+
+	user, err := testStore.User().GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Zero(t, user.TeamID)
+	assert.Empty(t, user.TeamName)
+
+	err = s.RemoveMember(ctx, "backend", "u1")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestTeamStore_Integration_ApplyMembershipChange(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	backend, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+		{ID: "u2", Username: "Bob", IsActive: true},
+		{ID: "u3", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+	frontend, err := s.AddTeamWithMembers(ctx, model.Team{Name: "frontend"}, []model.User{{ID: "f1", Username: "Fiona", IsActive: true}})
+	require.NoError(t, err)
+
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{ID: "pr-review", AuthorID: "u2", AssignedReviewers: []string{"u1"}}))
+	require.NoError(t, testStore.PR().Create(ctx, model.PullRequest{ID: "pr-authored", AuthorID: "u1", AssignedReviewers: []string{"u2"}}))
+
+	plan := model.MembershipPlan{
+		UserID:        "u1",
+		JoinTeamID:    frontend.Team.ID,
+		Reassignments: []model.ReviewReassignment{{PullRequestID: "pr-review", OldReviewerID: "u1", NewReviewerID: "u3"}},
+		Transfers:     []model.PullRequestTransfer{{PullRequestID: "pr-authored", ReviewerIDs: []string{"f1"}}, {PullRequestID: "pr-missing"}},
+	}
+	_, err = s.ApplyMembershipChange(ctx, plan, "admin")
+	assert.Equal(t, ErrNotFound, err)
+
+	user, err := testStore.User().GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, backend.Team.ID, user.TeamID, "a failed change is rolled back as a whole")
+	reviewed, err := testStore.PR().GetByID(ctx, "pr-review")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, reviewed.AssignedReviewers)
+
+	plan.Transfers = plan.Transfers[:1]
+	user, err = s.ApplyMembershipChange(ctx, plan, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, frontend.Team.ID, user.TeamID)
+	assert.Equal(t, "frontend", user.TeamName)
+
+	reviewed, err = testStore.PR().GetByID(ctx, "pr-review")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, reviewed.AssignedReviewers)
+	authored, err := testStore.PR().GetByID(ctx, "pr-authored")
+	require.NoError(t, err)
+	assert.Equal(t, frontend.Team.ID, authored.TeamID)
+	assert.Equal(t, []string{"f1"}, authored.AssignedReviewers)
+
+	byAuthor, err := testStore.PR().GetByAuthorID(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, byAuthor, 1)
+	assert.Equal(t, "pr-authored", byAuthor[0].ID)
+
+	user, err = s.ApplyMembershipChange(ctx, model.MembershipPlan{UserID: "u1", LeaveTeamID: frontend.Team.ID}, "admin")
+	require.NoError(t, err)
+	assert.Zero(t, user.TeamID)
+
+	_, err = s.ApplyMembershipChange(ctx, model.MembershipPlan{UserID: "u1", LeaveTeamID: frontend.Team.ID}, "admin")
+	assert.Equal(t, ErrNotFound, err)
+
+	deprovision := model.MembershipPlan{
+		UserID:        "u3",
+		Deactivate:    true,
+		Reassignments: []model.ReviewReassignment{{PullRequestID: "pr-review", OldReviewerID: "u3"}, {PullRequestID: "pr-missing", OldReviewerID: "u3"}},
+	}
+	_, err = s.ApplyMembershipChange(ctx, deprovision, "admin")
+	assert.Equal(t, ErrNotFound, err)
+
+	user, err = testStore.User().GetByID(ctx, "u3")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive, "deactivation is rolled back together with the reassignments")
+
+	deprovision.Reassignments = deprovision.Reassignments[:1]
+	user, err = s.ApplyMembershipChange(ctx, deprovision, "admin")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+	assert.Equal(t, "admin", user.DeactivatedBy)
+
+	reviewed, err = testStore.PR().GetByID(ctx, "pr-review")
+	require.NoError(t, err)
+	assert.Empty(t, reviewed.AssignedReviewers)
+}
+
+func TestTeamStore_Integration_AddTeamWithMembers_MovesExistingUsers(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	_, err := s.AddTeamWithMembers(ctx, model.Team{Name: "old-team"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: false},
+		{ID: "u2", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	result, err := s.AddTeamWithMembers(ctx, model.Team{Name: "new-team"}, []model.User{
+		{ID: "u1", Username: "Alice Smith", IsActive: true},
+		{ID: "u3", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Created, 1)
+	assert.Equal(t, "u3", result.Created[0].ID)
+	require.Len(t, result.Updated, 1)
+	assert.Equal(t, "u1", result.Updated[0].ID)
+
+	user, err := testStore.User().GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "new-team", user.TeamName)
+	assert.Equal(t, "Alice Smith", user.Username)
+	assert.True(t, user.IsActive)
+
+	_, oldMembers, err := s.GetByName(ctx, "old-team")
+	require.NoError(t, err)
+	require.Len(t, oldMembers, 1)
+	assert.Equal(t, "u2", oldMembers[0].ID)
+}
+
+func TestTeamStore_Integration_Hierarchy(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	for _, name := range []string{"engineering", "backend", "payments"} {
+		_, err := s.AddTeamWithMembers(ctx, model.Team{Name: name}, nil)
+		require.NoError(t, err)
+	}
+
+	backend, err := s.SetParent(ctx, "backend", "engineering", "")
+	require.NoError(t, err)
+	payments, err := s.SetParent(ctx, "payments", "backend", "")
+	require.NoError(t, err)
+	assert.Equal(t, backend.ID, payments.ParentID)
+
+	chain, err := s.GetAncestors(ctx, payments.ID)
+	require.NoError(t, err)
+	require.Len(t, chain, 3)
+	assert.Equal(t, []string{"payments", "backend", "engineering"}, []string{chain[0].Name, chain[1].Name, chain[2].Name})
+
+	children, err := s.GetChildren(ctx, backend.ID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Equal(t, "payments", children[0].Name)
+
+	_, err = s.SetParent(ctx, "engineering", "payments", "")
+	assert.Equal(t, ErrHierarchyCycle, err)
+
+	_, err = s.SetParent(ctx, "backend", "backend", "")
+	assert.Equal(t, ErrHierarchyCycle, err)
+
+	_, err = s.SetParent(ctx, "backend", "ghost", "")
+	assert.Equal(t, ErrNotFound, err)
+
+	detached, err := s.SetParent(ctx, "backend", "", "")
+	require.NoError(t, err)
+	assert.Zero(t, detached.ParentID)
+}
+
+func TestTeamStore_Integration_HierarchyConcurrentReparent(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	for _, name := range []string{"backend", "frontend"} {
+		_, err := s.AddTeamWithMembers(ctx, model.Team{Name: name}, nil)
+		require.NoError(t, err)
+	}
+
+	errs := make(chan error, 2)
+	for _, pair := range [][2]string{{"backend", "frontend"}, {"frontend", "backend"}} {
+		go func(team, parent string) {
+			_, err := s.SetParent(ctx, team, parent, "")
+			errs <- err
+		}(pair[0], pair[1])
+	}
+
+	var failures []error
+	for range 2 {
+		if err := <-errs; err != nil {
+			failures = append(failures, err)
+		}
+	}
+	require.Len(t, failures, 1, "only one of two opposite reparents commits")
+	assert.Equal(t, ErrHierarchyCycle, failures[0])
+}
+
+func TestTeamStore_Integration_GetAncestorsStopsOnCycle(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	backend, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, nil)
+	require.NoError(t, err)
+	frontend, err := s.AddTeamWithMembers(ctx, model.Team{Name: "frontend"}, nil)
+	require.NoError(t, err)
+
+	_, err = testStore.conn.Exec(ctx, `UPDATE teams SET parent_id = CASE id WHEN $1 THEN $2 ELSE $1 END WHERE id IN ($1, $2);`, backend.Team.ID, frontend.Team.ID)
+	require.NoError(t, err)
+
+	chain, err := s.GetAncestors(ctx, backend.Team.ID)
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	assert.Equal(t, []string{"backend", "frontend"}, []string{chain[0].Name, chain[1].Name})
+}
+
+func TestTeamStore_Integration_SetPolicy(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	_, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, nil)
+	require.NoError(t, err)
+
+	reviewerCount := 3
+	team, err := s.SetPolicy(ctx, "backend", model.AssignmentPolicy{ReviewerCount: &reviewerCount}, "")
+	require.NoError(t, err)
+	require.NotNil(t, team.Policy.ReviewerCount)
+	assert.Equal(t, 3, *team.Policy.ReviewerCount)
+	assert.Nil(t, team.Policy.FallbackToParent)
+
+	fetched, _, err := s.GetByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, team.Policy, fetched.Policy)
+
+	_, err = s.SetPolicy(ctx, "ghost", model.AssignmentPolicy{}, "")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestTeamStore_Integration_RenameArchiveDelete(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	_, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+		{ID: "u2", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+	_, err = s.AddTeamWithMembers(ctx, model.Team{Name: "frontend"}, nil)
+	require.NoError(t, err)
+
+	_, err = s.Rename(ctx, "backend", "frontend", "")
+	assert.Equal(t, ErrTeamExists, err)
+
+	renamed, err := s.Rename(ctx, "backend", "core", "")
+	require.NoError(t, err)
+	assert.Equal(t, "core", renamed.Name)
+
+	archived, err := s.SetArchived(ctx, "core", true, "admin")
+	require.NoError(t, err)
+	require.NotNil(t, archived.ArchivedAt)
+
+	var archivedBy string
+	require.NoError(t, testStore.conn.QueryRow(ctx, `SELECT archived_by FROM teams WHERE id = $1`, archived.ID).Scan(&archivedBy))
+	assert.Equal(t, "admin", archivedBy)
+
+	candidates, err := testStore.User().GetActiveTeamMembers(ctx, archived.ID, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	_, err = s.AddMember(ctx, "core", model.User{ID: "u3", Username: "Carol", IsActive: true}, "")
+	assert.Equal(t, ErrTeamArchived, err)
+
+	err = testStore.PR().Create(ctx, model.PullRequest{ID: "pr-1", AuthorID: "u1", TeamID: archived.ID, AssignedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+
+	report, err := s.GetDeletionReport(ctx, *archived)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, report.MemberIDs)
+	assert.Equal(t, []string{"u1", "u2"}, report.PrimaryMemberIDs)
+	assert.Equal(t, []string{"pr-1"}, report.OpenPullRequestIDs)
+	assert.Equal(t, 1, report.PullRequestCount)
+
+	err = s.Delete(ctx, archived.ID, "admin")
+	require.NoError(t, err)
+
+	var deletedBy string
+	require.NoError(t, testStore.conn.QueryRow(ctx, `SELECT deleted_by FROM team_deletions WHERE team_id = $1`, archived.ID).Scan(&deletedBy))
+	assert.Equal(t, "admin", deletedBy)
+
+	_, _, err = s.GetByName(ctx, "core")
+	assert.Equal(t, ErrNotFound, err)
+
+	user, err := testStore.User().GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Zero(t, user.TeamID)
+
+	pr, err := testStore.PR().GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Zero(t, pr.TeamID)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestTeamStore_Integration_List(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	_, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+		{ID: "u2", Username: "Bob", IsActive: false},
+	})
+	require.NoError(t, err)
+	for _, name := range []string{"billing", "engineering", "legacy"} {
+		_, err := s.AddTeamWithMembers(ctx, model.Team{Name: name}, nil)
+		require.NoError(t, err)
+	}
+	_, err = s.SetParent(ctx, "billing", "engineering", "")
+	require.NoError(t, err)
+	_, err = s.SetArchived(ctx, "legacy", true, "")
+	require.NoError(t, err)
+
+	teams, err := s.List(ctx, model.TeamFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, teams, 3)
+	assert.Equal(t, "backend", teams[0].Team.Name)
+	assert.Equal(t, 2, teams[0].MemberCount)
+	assert.Equal(t, 1, teams[0].ActiveMemberCount)
+
+	teams, err = s.List(ctx, model.TeamFilter{IncludeArchived: true, After: "engineering", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "legacy", teams[0].Team.Name)
+
+	teams, err = s.List(ctx, model.TeamFilter{NamePrefix: "b", ParentName: "engineering", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "billing", teams[0].Team.Name)
+	assert.Equal(t, "engineering", teams[0].ParentName)
+}
+
+func TestTeamStore_Integration_GetByIDAndCount(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	upsert, err := s.AddTeamWithMembers(ctx, model.Team{Name: "backend"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+	})
+	require.NoError(t, err)
+	_, err = s.AddTeamWithMembers(ctx, model.Team{Name: "billing"}, nil)
+	require.NoError(t, err)
+
+	team, members, err := s.GetByID(ctx, upsert.Team.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.Name)
+	require.Len(t, members, 1)
+	assert.Equal(t, "u1", members[0].ID)
+
+	_, _, err = s.GetByID(ctx, upsert.Team.ID+100)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	count, err := s.Count(ctx, model.TeamFilter{NamePrefix: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	teams, err := s.List(ctx, model.TeamFilter{Name: "billing", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "billing", teams[0].Team.Name)
+
+	teams, err = s.List(ctx, model.TeamFilter{Offset: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "billing", teams[0].Team.Name)
+}
+
+func TestTeamStore_Integration_ApplyImport(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Team()
+
+	_, err := s.AddTeamWithMembers(ctx, model.Team{Name: "frontend"}, []model.User{
+		{ID: "u2", Username: "Bob", IsActive: true},
+		{ID: "u3", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	plan := model.ImportPlan{
+		TeamsToCreate:     []string{"backend"},
+		UsersToAdd:        []model.ImportUserChange{{User: model.User{ID: "u1", Username: "Alice", IsActive: true}, TeamName: "backend"}},
+		UsersToMove:       []model.ImportUserChange{{User: model.User{ID: "u2", Username: "Bob", IsActive: true}, TeamName: "backend", FromTeamName: "frontend"}},
+		UsersToDeactivate: []model.ImportUserChange{{User: model.User{ID: "u3", Username: "Carol"}, TeamName: "frontend"}},
+	}
+
+	require.NoError(t, s.ApplyImport(ctx, plan, ""))
+
+	_, members, err := s.GetByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u1", "u2"}, []string{members[0].ID, members[1].ID})
+
+	carol, err := testStore.User().GetByID(ctx, "u3")
+	require.NoError(t, err)
+	assert.False(t, carol.IsActive)
+	assert.Equal(t, "frontend", carol.TeamName)
+}
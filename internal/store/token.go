@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TokenStore struct {
+	conn *pgxpool.Pool
+}
+
+func (s *TokenStore) CreateRefreshToken(ctx context.Context, token model.RefreshToken, tokenHash string) (*model.RefreshToken, error) {
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, family_id, expires_at, used_at, revoked_at, created_at;
+	`
+
+	var created model.RefreshToken
+	err := s.conn.QueryRow(ctx, query, token.UserID, token.FamilyID, tokenHash, token.ExpiresAt).Scan(
+		&created.ID, &created.UserID, &created.FamilyID, &created.ExpiresAt,
+		&created.UsedAt, &created.RevokedAt, &created.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (s *TokenStore) GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, family_id, expires_at, used_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1;
+	`
+
+	var token model.RefreshToken
+	err := s.conn.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID, &token.UserID, &token.FamilyID, &token.ExpiresAt,
+		&token.UsedAt, &token.RevokedAt, &token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (s *TokenStore) MarkRefreshTokenUsed(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL;`
+
+	tag, err := s.conn.Exec(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark refresh token used: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *TokenStore) RevokeRefreshFamily(ctx context.Context, familyID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL;`
+
+	if _, err := s.conn.Exec(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TokenStore) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	query := `
+		WITH purged AS (
+			DELETE FROM revoked_tokens WHERE expires_at < NOW()
+		)
+		INSERT INTO revoked_tokens (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING;
+	`
+
+	if _, err := s.conn.Exec(ctx, query, jti, expiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TokenStore) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1);`
+
+	var revoked bool
+	if err := s.conn.QueryRow(ctx, query, jti).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+
+	return revoked, nil
+}
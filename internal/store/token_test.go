@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenStore_Integration_RefreshTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.Token()
+
+	created, err := s.CreateRefreshToken(ctx, model.RefreshToken{UserID: "active-user-1", FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour)}, "hash-1")
+	require.NoError(t, err)
+	_, err = s.CreateRefreshToken(ctx, model.RefreshToken{UserID: "active-user-1", FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour)}, "hash-2")
+	require.NoError(t, err)
+
+	_, err = s.CreateRefreshToken(ctx, model.RefreshToken{UserID: "ghost", FamilyID: "x", ExpiresAt: time.Now().Add(time.Hour)}, "hash-3")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	token, err := s.GetRefreshToken(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, token.ID)
+	assert.Nil(t, token.UsedAt)
+
+	marked, err := s.MarkRefreshTokenUsed(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, marked)
+
+	marked, err = s.MarkRefreshTokenUsed(ctx, created.ID)
+	require.NoError(t, err)
+	assert.False(t, marked)
+
+	require.NoError(t, s.RevokeRefreshFamily(ctx, "fam"))
+
+	token, err = s.GetRefreshToken(ctx, "hash-2")
+	require.NoError(t, err)
+	assert.NotNil(t, token.RevokedAt)
+
+	_, err = s.GetRefreshToken(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTokenStore_Integration_PasswordChangeRevokesRefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.Token()
+
+	_, err := s.CreateRefreshToken(ctx, model.RefreshToken{UserID: "active-user-1", FamilyID: "fam-1", ExpiresAt: time.Now().Add(time.Hour)}, "hash-1")
+	require.NoError(t, err)
+	_, err = s.CreateRefreshToken(ctx, model.RefreshToken{UserID: "active-user-2", FamilyID: "fam-2", ExpiresAt: time.Now().Add(time.Hour)}, "hash-2")
+	require.NoError(t, err)
+
+	require.NoError(t, testStore.User().SetPasswordHash(ctx, "active-user-1", "new-hash", ""))
+
+	token, err := s.GetRefreshToken(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.NotNil(t, token.RevokedAt)
+
+	token, err = s.GetRefreshToken(ctx, "hash-2")
+	require.NoError(t, err)
+	assert.Nil(t, token.RevokedAt)
+}
+
+func TestTokenStore_Integration_AccessTokenDenylist(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.Token()
+
+	revoked, err := s.IsAccessTokenRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, s.RevokeAccessToken(ctx, "jti-1", time.Now().Add(time.Hour)))
+	require.NoError(t, s.RevokeAccessToken(ctx, "jti-1", time.Now().Add(time.Hour)))
+
+	revoked, err = s.IsAccessTokenRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
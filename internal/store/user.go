@@ -4,27 +4,37 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var ErrUserExists = errors.New("user with this id already exists")
+
 type UserStore struct {
 	conn *pgxpool.Pool
 }
 
 func (s *UserStore) GetByID(ctx context.Context, id string) (*model.FullUserInfo, error) {
-	query := `
-		SELECT u.id, u.username, u.is_active, u.team_id, t.name AS team_name
-		FROM users AS u
-		JOIN teams AS t ON u.team_id = t.id
-		WHERE u.id = $1;
-	`
+	return scanUser(s.conn.QueryRow(ctx, userByIDQuery, id))
+}
 
+const userByIDQuery = `
+	SELECT u.id, u.username, u.is_active, COALESCE(tm.team_id, 0), COALESCE(t.name, '') AS team_name,
+		COALESCE(u.deactivated_by, '')
+	FROM users AS u
+	LEFT JOIN team_members AS tm ON tm.user_id = u.id AND tm.is_primary
+	LEFT JOIN teams AS t ON tm.team_id = t.id
+	WHERE u.id = $1;
+`
+
+func scanUser(row pgx.Row) (*model.FullUserInfo, error) {
 	var user model.FullUserInfo
-	err := s.conn.QueryRow(ctx, query, id).Scan(
-		&user.ID, &user.Username, &user.IsActive, &user.TeamID, &user.TeamName,
+	err := row.Scan(
+		&user.ID, &user.Username, &user.IsActive, &user.TeamID, &user.TeamName, &user.DeactivatedBy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -36,20 +46,29 @@ func (s *UserStore) GetByID(ctx context.Context, id string) (*model.FullUserInfo
 	return &user, nil
 }
 
-func (s *UserStore) SetIsActive(ctx context.Context, id string, isActive bool) (*model.FullUserInfo, error) {
+func (s *UserStore) SetIsActive(ctx context.Context, id string, isActive bool, actorID string) (*model.FullUserInfo, error) {
 	query := `
 		WITH updated_user AS (
-			UPDATE users SET is_active = $2 WHERE id = $1
-			RETURNING id, username, is_active, team_id
+			UPDATE users
+			SET is_active = $2,
+				deactivated_by = CASE
+					WHEN $2 THEN NULL
+					WHEN is_active THEN NULLIF($3, '')
+					ELSE deactivated_by
+				END
+			WHERE id = $1
+			RETURNING id, username, is_active, deactivated_by
 		)
-		SELECT u.id, u.username, u.is_active, u.team_id, t.name as team_name
+		SELECT u.id, u.username, u.is_active, COALESCE(tm.team_id, 0), COALESCE(t.name, '') as team_name,
+			COALESCE(u.deactivated_by, '')
 		FROM updated_user AS u
-		JOIN teams AS t ON u.team_id = t.id;
+		LEFT JOIN team_members AS tm ON tm.user_id = u.id AND tm.is_primary
+		LEFT JOIN teams AS t ON tm.team_id = t.id;
 	`
 
 	var user model.FullUserInfo
-	err := s.conn.QueryRow(ctx, query, id, isActive).Scan(
-		&user.ID, &user.Username, &user.IsActive, &user.TeamID, &user.TeamName,
+	err := s.conn.QueryRow(ctx, query, id, isActive, actorID).Scan(
+		&user.ID, &user.Username, &user.IsActive, &user.TeamID, &user.TeamName, &user.DeactivatedBy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -63,9 +82,16 @@ func (s *UserStore) SetIsActive(ctx context.Context, id string, isActive bool) (
 
 func (s *UserStore) GetActiveTeamMembers(ctx context.Context, teamID int, excludeUserId string) ([]model.User, error) {
 	query := `
-		SELECT id, username, is_active, team_id
-		FROM users
-		WHERE team_id = $1 AND is_active = true AND id != $2;	
+		SELECT u.id, u.username, u.is_active, COALESCE(p.team_id, 0)
+		FROM team_members AS tm
+		JOIN teams AS t ON t.id = tm.team_id AND t.archived_at IS NULL
+		JOIN users AS u ON u.id = tm.user_id
+		LEFT JOIN team_members AS p ON p.user_id = u.id AND p.is_primary
+		WHERE tm.team_id = $1 AND tm.reviewable AND u.is_active = true AND NOT u.on_vacation AND u.id != $2
+			AND NOT EXISTS (
+				SELECT 1 FROM absences AS a
+				WHERE a.user_id = u.id AND a.starts_at <= NOW() AND a.ends_at > NOW()
+			);
 	`
 
 	rows, err := s.conn.Query(ctx, query, teamID, excludeUserId)
@@ -89,3 +115,298 @@ func (s *UserStore) GetActiveTeamMembers(ctx context.Context, teamID int, exclud
 
 	return members, nil
 }
+
+func setPrimaryTeam(ctx context.Context, tx pgx.Tx, userID string, teamID int) error {
+	dropPrimaryQuery := `DELETE FROM team_members WHERE user_id = $1 AND is_primary;`
+	if _, err := tx.Exec(ctx, dropPrimaryQuery, userID); err != nil {
+		return fmt.Errorf("failed to drop primary team: %w", err)
+	}
+
+	setPrimaryQuery := `
+		INSERT INTO team_members (team_id, user_id, is_primary, reviewable)
+		VALUES ($2, $1, TRUE, TRUE)
+		ON CONFLICT (team_id, user_id) DO UPDATE
+		SET is_primary = TRUE, reviewable = TRUE;
+	`
+	if _, err := tx.Exec(ctx, setPrimaryQuery, userID, teamID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresForeignKeyViolationCode {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to set primary team: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UserStore) GetMemberships(ctx context.Context, id string) ([]model.TeamMembership, error) {
+	query := `
+		SELECT tm.team_id, t.name, tm.user_id, tm.is_primary, tm.reviewable
+		FROM team_members AS tm
+		JOIN teams AS t ON t.id = tm.team_id
+		WHERE tm.user_id = $1
+		ORDER BY tm.is_primary DESC, t.name;
+	`
+
+	rows, err := s.conn.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user memberships: %w", err)
+	}
+	defer rows.Close()
+
+	memberships := []model.TeamMembership{}
+	for rows.Next() {
+		var m model.TeamMembership
+		if err := rows.Scan(&m.TeamID, &m.TeamName, &m.UserID, &m.IsPrimary, &m.Reviewable); err != nil {
+			return nil, fmt.Errorf("failed to scan membership: %w", err)
+		}
+		memberships = append(memberships, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error membership rows: %w", err)
+	}
+
+	return memberships, nil
+}
+
+const userFilterClause = `
+	WHERE ($1::BOOLEAN IS NULL OR u.is_active = $1)
+		AND starts_with(u.username, $2)
+		AND ($3 = '' OR EXISTS (
+			SELECT 1
+			FROM team_members AS tm
+			JOIN teams AS ft ON ft.id = tm.team_id
+			WHERE tm.user_id = u.id AND ft.name = $3
+		))
+		AND ($4 = '' OR u.id > $4)
+		AND ($5 = '' OR u.username = $5)
+`
+
+func (s *UserStore) List(ctx context.Context, filter model.UserFilter) ([]model.FullUserInfo, error) {
+	query := `
+		SELECT u.id, u.username, u.is_active, COALESCE(p.team_id, 0), COALESCE(t.name, ''),
+			COALESCE(u.deactivated_by, '')
+		FROM users AS u
+		LEFT JOIN team_members AS p ON p.user_id = u.id AND p.is_primary
+		LEFT JOIN teams AS t ON t.id = p.team_id
+		` + userFilterClause + `
+		ORDER BY u.id
+		LIMIT $6 OFFSET $7;
+	`
+
+	rows, err := s.conn.Query(ctx, query,
+		filter.IsActive, filter.NamePrefix, filter.TeamName, filter.After, filter.Username,
+		filter.Limit, filter.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []model.FullUserInfo{}
+	for rows.Next() {
+		var user model.FullUserInfo
+		if err := rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamID, &user.TeamName, &user.DeactivatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error user rows: %w", err)
+	}
+
+	return users, nil
+}
+
+func (s *UserStore) GetOpenReviewCount(ctx context.Context, id string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM pull_request_reviewers AS prr
+		JOIN pull_requests AS p ON p.id = prr.pull_request_id
+		WHERE prr.reviewer_id = $1 AND p.status = 'OPEN';
+	`
+
+	var count int
+	if err := s.conn.QueryRow(ctx, query, id).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *UserStore) Count(ctx context.Context, filter model.UserFilter) (int, error) {
+	query := `SELECT COUNT(*) FROM users AS u ` + userFilterClause + `;`
+
+	var count int
+	err := s.conn.QueryRow(ctx, query,
+		filter.IsActive, filter.NamePrefix, filter.TeamName, filter.After, filter.Username,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *UserStore) Create(ctx context.Context, user model.User) (*model.FullUserInfo, error) {
+	query := `
+		INSERT INTO users (id, username, is_active)
+		VALUES ($1, $2, $3)
+		RETURNING id, username, is_active;
+	`
+
+	var created model.FullUserInfo
+	err := s.conn.QueryRow(ctx, query, user.ID, user.Username, user.IsActive).Scan(
+		&created.ID, &created.Username, &created.IsActive,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolationCode {
+			return nil, ErrUserExists
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (s *UserStore) SetUsername(ctx context.Context, id, username string) (*model.FullUserInfo, error) {
+	query := `
+		WITH updated_user AS (
+			UPDATE users SET username = $2 WHERE id = $1
+			RETURNING id, username, is_active, deactivated_by
+		)
+		SELECT u.id, u.username, u.is_active, COALESCE(tm.team_id, 0), COALESCE(t.name, ''),
+			COALESCE(u.deactivated_by, '')
+		FROM updated_user AS u
+		LEFT JOIN team_members AS tm ON tm.user_id = u.id AND tm.is_primary
+		LEFT JOIN teams AS t ON tm.team_id = t.id;
+	`
+
+	var user model.FullUserInfo
+	err := s.conn.QueryRow(ctx, query, id, username).Scan(
+		&user.ID, &user.Username, &user.IsActive, &user.TeamID, &user.TeamName, &user.DeactivatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set username: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (s *UserStore) GetCredentials(ctx context.Context, id string) (*model.Credentials, error) {
+	query := `
+		SELECT id, role, COALESCE(password_hash, ''), failed_login_attempts, locked_until
+		FROM users
+		WHERE id = $1;
+	`
+
+	var creds model.Credentials
+	err := s.conn.QueryRow(ctx, query, id).Scan(
+		&creds.UserID, &creds.Role, &creds.PasswordHash, &creds.FailedAttempts, &creds.LockedUntil,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user credentials: %w", err)
+	}
+
+	return &creds, nil
+}
+
+func (s *UserStore) SetPasswordHash(ctx context.Context, id, passwordHash, actorID string) error {
+	query := `
+		WITH revoked AS (
+			UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL
+		)
+		UPDATE users
+		SET password_hash = $2, password_changed_by = NULLIF($3, ''), failed_login_attempts = 0, locked_until = NULL
+		WHERE id = $1;
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query, id, passwordHash, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to set password hash: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *UserStore) RecordLoginFailure(ctx context.Context, id string, maxAttempts int, lockout time.Duration) (*model.Credentials, error) {
+	query := `
+		UPDATE users
+		SET failed_login_attempts = CASE
+				WHEN failed_login_attempts + 1 >= $2 THEN 0
+				ELSE failed_login_attempts + 1
+			END,
+			locked_until = CASE
+				WHEN failed_login_attempts + 1 >= $2 THEN NOW() + $3::DOUBLE PRECISION * INTERVAL '1 second'
+				ELSE locked_until
+			END
+		WHERE id = $1
+		RETURNING id, role, COALESCE(password_hash, ''), failed_login_attempts, locked_until;
+	`
+
+	var creds model.Credentials
+	err := s.conn.QueryRow(ctx, query, id, maxAttempts, lockout.Seconds()).Scan(
+		&creds.UserID, &creds.Role, &creds.PasswordHash, &creds.FailedAttempts, &creds.LockedUntil,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return &creds, nil
+}
+
+func (s *UserStore) ResetLoginFailures(ctx context.Context, id string) error {
+	query := `UPDATE users SET failed_login_attempts = 0, locked_until = NULL WHERE id = $1;`
+
+	if _, err := s.conn.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UserStore) SetVacation(ctx context.Context, id string, onVacation bool) error {
+	query := `UPDATE users SET on_vacation = $2 WHERE id = $1;`
+
+	commandTag, err := s.conn.Exec(ctx, query, id, onVacation)
+	if err != nil {
+		return fmt.Errorf("failed to set user vacation: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *UserStore) SetRole(ctx context.Context, id string, role model.Role, actorID string) error {
+	query := `UPDATE users SET role = $2, role_changed_by = NULLIF($3, '') WHERE id = $1;`
+
+	commandTag, err := s.conn.Exec(ctx, query, id, role, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to set user role: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
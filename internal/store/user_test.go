@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/DeadlyParkour777/pr-service/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -49,15 +50,25 @@ func TestUserStore_Integration_SetIsActive(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, initialUser.IsActive)
 
-	updatedUser, err := s.SetIsActive(ctx, "active-user-1", false)
+	updatedUser, err := s.SetIsActive(ctx, "active-user-1", false, "admin")
 	require.NoError(t, err)
 
 	require.NotNil(t, updatedUser)
 	assert.False(t, updatedUser.IsActive)
+	assert.Equal(t, "admin", updatedUser.DeactivatedBy)
+
+	updatedUser, err = s.SetIsActive(ctx, "active-user-1", false, "someone-else")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", updatedUser.DeactivatedBy)
 
 	finalUser, err := s.GetByID(ctx, "active-user-1")
 	require.NoError(t, err)
 	assert.False(t, finalUser.IsActive)
+	assert.Equal(t, "admin", finalUser.DeactivatedBy)
+
+	reactivated, err := s.SetIsActive(ctx, "active-user-1", true, "admin")
+	require.NoError(t, err)
+	assert.Empty(t, reactivated.DeactivatedBy)
 }
 
 func TestUserStore_Integration_GetActiveTeamMembers(t *testing.T) {
@@ -91,7 +102,7 @@ func TestUserStore_Integration_GetActiveTeamMembers_AllActive(t *testing.T) {
 	createdTeam, err := testStore.Team().AddTeamWithMembers(ctx, team, users)
 	require.NoError(t, err)
 
-	members, err := s.GetActiveTeamMembers(ctx, createdTeam.ID, "u1")
+	members, err := s.GetActiveTeamMembers(ctx, createdTeam.Team.ID, "u1")
 	require.NoError(t, err)
 
 	require.Len(t, members, 2)
@@ -110,3 +121,190 @@ func TestUserStore_Integration_GetByID_NotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, ErrNotFound, err)
 }
+
+func TestUserStore_Integration_SecondaryMemberships(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	feature, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "feature"}, []model.User{
+		{ID: "u1", Username: "Alice", IsActive: true},
+		{ID: "u2", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+	guild, err := testStore.Team().AddTeamWithMembers(ctx, model.Team{Name: "platform-guild"}, []model.User{
+		{ID: "g1", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.Team().SetSecondaryMember(ctx, "platform-guild", "u1", true, "")
+	require.NoError(t, err)
+	_, err = testStore.Team().SetSecondaryMember(ctx, "platform-guild", "u2", false, "")
+	require.NoError(t, err)
+
+	_, err = testStore.Team().SetSecondaryMember(ctx, "feature", "u1", true, "")
+	assert.Equal(t, ErrPrimaryTeam, err)
+	_, err = testStore.Team().SetSecondaryMember(ctx, "platform-guild", "missing-user", true, "")
+	assert.Equal(t, ErrNotFound, err)
+
+	s := testStore.User()
+
+	members, err := s.GetActiveTeamMembers(ctx, guild.Team.ID, "g1")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, "u1", members[0].ID)
+	assert.Equal(t, feature.Team.ID, members[0].TeamID)
+
+	memberships, err := s.GetMemberships(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, memberships, 2)
+	assert.Equal(t, "feature", memberships[0].TeamName)
+	assert.True(t, memberships[0].IsPrimary)
+	assert.Equal(t, "platform-guild", memberships[1].TeamName)
+	assert.False(t, memberships[1].IsPrimary)
+
+	user, err := s.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "feature", user.TeamName)
+}
+
+func TestUserStore_Integration_List(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.User()
+
+	isActive := true
+	users, err := s.List(ctx, model.UserFilter{IsActive: &isActive, TeamName: "user-test-team", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "active-user-1", users[0].ID)
+	assert.Equal(t, "user-test-team", users[0].TeamName)
+
+	users, err = s.List(ctx, model.UserFilter{NamePrefix: "Ch", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "active-user-2", users[0].ID)
+
+	users, err = s.List(ctx, model.UserFilter{After: "active-user-2", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "inactive-user", users[0].ID)
+
+	users, err = s.List(ctx, model.UserFilter{TeamName: "ghost", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestUserStore_Integration_GetOpenReviewCount(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	prs := []model.PullRequest{
+		{ID: "pr-1", AuthorID: "active-user-1", AssignedReviewers: []string{"active-user-2"}},
+		{ID: "pr-2", AuthorID: "active-user-1", AssignedReviewers: []string{"active-user-2"}},
+	}
+	for _, pr := range prs {
+		require.NoError(t, testStore.PR().Create(ctx, pr))
+	}
+	require.NoError(t, testStore.PR().Merge(ctx, "pr-2", ""))
+
+	count, err := testStore.User().GetOpenReviewCount(ctx, "active-user-2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestUserStore_Integration_CountAndOffset(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.User()
+
+	count, err := s.Count(ctx, model.UserFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	users, err := s.List(ctx, model.UserFilter{Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "active-user-2", users[0].ID)
+
+	count, err = s.Count(ctx, model.UserFilter{Username: "Bob"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestUserStore_Integration_CreateAndSetUsername(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.User()
+
+	created, err := s.Create(ctx, model.User{ID: "scim-user", Username: "Dave", IsActive: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Dave", created.Username)
+	assert.Empty(t, created.TeamName)
+
+	_, err = s.Create(ctx, model.User{ID: "active-user-1", Username: "Alice"})
+	assert.ErrorIs(t, err, ErrUserExists)
+
+	renamed, err := s.SetUsername(ctx, "active-user-1", "Alicia")
+	require.NoError(t, err)
+	assert.Equal(t, "Alicia", renamed.Username)
+	assert.Equal(t, "user-test-team", renamed.TeamName)
+
+	_, err = s.SetUsername(ctx, "ghost", "Nobody")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestUserStore_Integration_LoginFailuresAndLockout(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.User()
+
+	creds, err := s.GetCredentials(ctx, "active-user-1")
+	require.NoError(t, err)
+	assert.Empty(t, creds.PasswordHash)
+
+	require.NoError(t, s.SetPasswordHash(ctx, "active-user-1", "hash", ""))
+	assert.ErrorIs(t, s.SetPasswordHash(ctx, "ghost", "hash", ""), ErrNotFound)
+
+	creds, err = s.RecordLoginFailure(ctx, "active-user-1", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, creds.FailedAttempts)
+	assert.Nil(t, creds.LockedUntil)
+
+	creds, err = s.RecordLoginFailure(ctx, "active-user-1", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 0, creds.FailedAttempts)
+	require.NotNil(t, creds.LockedUntil)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), *creds.LockedUntil, 10*time.Second)
+
+	require.NoError(t, s.ResetLoginFailures(ctx, "active-user-1"))
+	creds, err = s.GetCredentials(ctx, "active-user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "hash", creds.PasswordHash)
+	assert.Nil(t, creds.LockedUntil)
+}
+
+func TestUserStore_Integration_SetRole(t *testing.T) {
+	ctx := context.Background()
+	setupUserTestData(ctx, t)
+
+	s := testStore.User()
+
+	creds, err := s.GetCredentials(ctx, "active-user-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleMember, creds.Role)
+
+	require.NoError(t, s.SetRole(ctx, "active-user-1", model.RoleTeamLead, "admin"))
+	creds, err = s.GetCredentials(ctx, "active-user-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleTeamLead, creds.Role)
+
+	var changedBy string
+	require.NoError(t, testStore.conn.QueryRow(ctx, `SELECT role_changed_by FROM users WHERE id = 'active-user-1'`).Scan(&changedBy))
+	assert.Equal(t, "admin", changedBy)
+
+	assert.ErrorIs(t, s.SetRole(ctx, "ghost", model.RoleAdmin, ""), ErrNotFound)
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookDeliveryStore struct {
+	conn *pgxpool.Pool
+}
+
+func (s *WebhookDeliveryStore) Claim(ctx context.Context, provider model.IdentityProvider, deliveryID string, retention time.Duration) (bool, error) {
+	query := `
+		WITH purged AS (
+			DELETE FROM webhook_deliveries WHERE received_at < NOW() - make_interval(secs => $3)
+		)
+		INSERT INTO webhook_deliveries (provider, delivery_id)
+		VALUES ($1, $2)
+		ON CONFLICT (provider, delivery_id) DO NOTHING;
+	`
+
+	commandTag, err := s.conn.Exec(ctx, query, provider, deliveryID, retention.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+
+	return commandTag.RowsAffected() == 1, nil
+}
+
+func (s *WebhookDeliveryStore) Release(ctx context.Context, provider model.IdentityProvider, deliveryID string) error {
+	query := `DELETE FROM webhook_deliveries WHERE provider = $1 AND delivery_id = $2;`
+
+	if _, err := s.conn.Exec(ctx, query, provider, deliveryID); err != nil {
+		return fmt.Errorf("failed to release webhook delivery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryStore_Integration_ClaimOnce(t *testing.T) {
+	ctx := context.Background()
+	truncateTables(ctx)
+
+	s := testStore.WebhookDelivery()
+
+	claimed, err := s.Claim(ctx, model.ProviderGitHub, "delivery-1", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = s.Claim(ctx, model.ProviderGitHub, "delivery-1", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	require.NoError(t, s.Release(ctx, model.ProviderGitHub, "delivery-1"))
+
+	claimed, err = s.Claim(ctx, model.ProviderGitHub, "delivery-1", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DeadlyParkour777/pr-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send_SignsPayload(t *testing.T) {
+	var gotHeaders http.Header
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	payload := []byte(`{"event":"pull_request.merged"}`)
+	status, err := NewSender(server.Client()).Send(context.Background(), model.SubscriptionDelivery{
+		ID:        7,
+		URL:       server.URL,
+		Secret:    "s3cret",
+		EventType: model.EventPRMerged,
+		Payload:   payload,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, payload, gotBody)
+	assert.Equal(t, "application/json", gotHeaders.Get("Content-Type"))
+	assert.Equal(t, "pull_request.merged", gotHeaders.Get(EventHeader))
+	assert.Equal(t, "7", gotHeaders.Get(DeliveryHeader))
+	assert.Equal(t, Sign("s3cret", payload), gotHeaders.Get(SignatureHeader))
+}
+
+func TestSign_KnownVector(t *testing.T) {
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestSender_Send_NonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	status, err := NewSender(server.Client()).Send(context.Background(), model.SubscriptionDelivery{URL: server.URL, Payload: []byte(`{}`)})
+
+	assert.ErrorIs(t, err, ErrDeliveryFailed)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestSender_Send_DefaultClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal address must not be reached")
+	}))
+	defer server.Close()
+
+	status, err := NewSender(nil).Send(context.Background(), model.SubscriptionDelivery{URL: server.URL, Payload: []byte(`{}`)})
+
+	assert.ErrorIs(t, err, ErrDeliveryFailed)
+	assert.ErrorContains(t, err, "not allowed")
+	assert.Equal(t, 0, status)
+}
+
+func TestSender_Send_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	status, err := NewSender(nil).Send(context.Background(), model.SubscriptionDelivery{URL: url, Payload: []byte(`{}`)})
+
+	assert.ErrorIs(t, err, ErrDeliveryFailed)
+	assert.Equal(t, 0, status)
+}
@@ -0,0 +1,13 @@
+DO $$
+DECLARE
+    teamless BIGINT;
+BEGIN
+    SELECT COUNT(*) INTO teamless FROM users WHERE team_id IS NULL;
+    IF teamless > 0 THEN
+        RAISE EXCEPTION 'cannot restore NOT NULL on users.team_id: % users have no team', teamless
+            USING HINT = 'Assign these users a team_id or delete them, then run the down migration again.';
+    END IF;
+END
+$$;
+
+ALTER TABLE users ALTER COLUMN team_id SET NOT NULL;
@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN team_id BIGINT;
+ALTER TABLE users
+    ADD CONSTRAINT fk_team
+    FOREIGN KEY(team_id)
+    REFERENCES teams(id)
+    ON DELETE CASCADE;
+CREATE INDEX idx_users_team_id ON users(team_id);
+
+UPDATE users AS u
+SET team_id = tm.team_id
+FROM team_members AS tm
+WHERE tm.user_id = u.id AND tm.is_primary;
+
+UPDATE users AS u
+SET team_id = (SELECT MIN(tm.team_id) FROM team_members AS tm WHERE tm.user_id = u.id)
+WHERE u.team_id IS NULL;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS team_id;
+
+DROP TABLE IF EXISTS team_members;
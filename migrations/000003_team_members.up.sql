@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id BIGINT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    reviewable BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (team_id, user_id),
+    CONSTRAINT fk_member_team
+        FOREIGN KEY(team_id)
+        REFERENCES teams(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_member_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_team_members_user_id ON team_members(user_id);
+CREATE UNIQUE INDEX idx_team_members_primary ON team_members(user_id) WHERE is_primary;
+
+INSERT INTO team_members (team_id, user_id, is_primary, reviewable)
+SELECT team_id, id, TRUE, TRUE
+FROM users
+WHERE team_id IS NOT NULL;
+
+ALTER TABLE pull_requests ADD COLUMN team_id BIGINT;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT fk_pr_team
+    FOREIGN KEY(team_id)
+    REFERENCES teams(id)
+    ON DELETE SET NULL;
+
+UPDATE pull_requests AS p
+SET team_id = u.team_id
+FROM users AS u
+WHERE u.id = p.author_id;
+
+ALTER TABLE users DROP COLUMN team_id;
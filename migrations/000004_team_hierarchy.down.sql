@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS fallback_to_parent;
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewer_count;
+ALTER TABLE teams DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,10 @@
+ALTER TABLE teams ADD COLUMN parent_id BIGINT;
+ALTER TABLE teams
+    ADD CONSTRAINT fk_team_parent
+    FOREIGN KEY(parent_id)
+    REFERENCES teams(id)
+    ON DELETE SET NULL;
+CREATE INDEX idx_teams_parent_id ON teams(parent_id);
+
+ALTER TABLE teams ADD COLUMN reviewer_count INT CHECK (reviewer_count >= 0);
+ALTER TABLE teams ADD COLUMN fallback_to_parent BOOLEAN;
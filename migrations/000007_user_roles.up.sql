@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'member'
+    CHECK (role IN ('admin', 'team_lead', 'member', 'bot'));
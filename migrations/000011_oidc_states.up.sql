@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS oidc_states (
+    state VARCHAR(128) PRIMARY KEY,
+    nonce VARCHAR(128) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
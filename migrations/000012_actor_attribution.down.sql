@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS team_deletions;
+
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS assigned_by;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS merged_by;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS created_by;
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_by;
+ALTER TABLE users DROP COLUMN IF EXISTS role_changed_by;
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_by;
+ALTER TABLE team_members DROP COLUMN IF EXISTS added_by;
+ALTER TABLE teams DROP COLUMN IF EXISTS archived_by;
+ALTER TABLE teams DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE teams DROP COLUMN IF EXISTS created_by;
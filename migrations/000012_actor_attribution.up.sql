@@ -0,0 +1,18 @@
+ALTER TABLE teams ADD COLUMN created_by VARCHAR(255);
+ALTER TABLE teams ADD COLUMN updated_by VARCHAR(255);
+ALTER TABLE teams ADD COLUMN archived_by VARCHAR(255);
+ALTER TABLE team_members ADD COLUMN added_by VARCHAR(255);
+ALTER TABLE users ADD COLUMN deactivated_by VARCHAR(255);
+ALTER TABLE users ADD COLUMN role_changed_by VARCHAR(255);
+ALTER TABLE users ADD COLUMN password_changed_by VARCHAR(255);
+ALTER TABLE pull_requests ADD COLUMN created_by VARCHAR(255);
+ALTER TABLE pull_requests ADD COLUMN merged_by VARCHAR(255);
+ALTER TABLE pull_request_reviewers ADD COLUMN assigned_by VARCHAR(255);
+
+CREATE TABLE IF NOT EXISTS team_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    deleted_by VARCHAR(255),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
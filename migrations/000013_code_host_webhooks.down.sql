@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS external_identities;
+
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS verdict_at;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS verdict;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_by;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
+
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+ALTER TYPE pr_status RENAME TO pr_status_old;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
+ALTER TABLE pull_requests ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN status TYPE pr_status USING status::text::pr_status;
+ALTER TABLE pull_requests ALTER COLUMN status SET DEFAULT 'OPEN';
+DROP TYPE pr_status_old;
@@ -0,0 +1,28 @@
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'CLOSED';
+
+ALTER TABLE pull_requests ADD COLUMN closed_at TIMESTAMPTZ;
+ALTER TABLE pull_requests ADD COLUMN closed_by VARCHAR(255);
+
+ALTER TABLE pull_request_reviewers ADD COLUMN verdict VARCHAR(32)
+    CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED'));
+ALTER TABLE pull_request_reviewers ADD COLUMN verdict_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS external_identities (
+    provider VARCHAR(32) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, external_id),
+    CONSTRAINT fk_external_identity_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_external_identities_user_id ON external_identities(user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    provider VARCHAR(32) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, delivery_id)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS reviewer_sync_jobs (
+    pull_request_id VARCHAR(255) PRIMARY KEY,
+    generation INTEGER NOT NULL DEFAULT 1,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_reviewer_sync_pull_request
+        FOREIGN KEY(pull_request_id)
+        REFERENCES pull_requests(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_reviewer_sync_jobs_next_attempt_at ON reviewer_sync_jobs(next_attempt_at);
@@ -0,0 +1,43 @@
+CREATE TYPE subscription_delivery_status AS ENUM ('PENDING', 'DELIVERED', 'DEAD');
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS subscription_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id INTEGER NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status subscription_delivery_status NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_subscription_delivery_subscription
+        FOREIGN KEY(subscription_id)
+        REFERENCES webhook_subscriptions(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_subscription_deliveries_subscription_id ON subscription_deliveries(subscription_id, id);
+CREATE INDEX idx_subscription_deliveries_due ON subscription_deliveries(next_attempt_at) WHERE status = 'PENDING';
+
+CREATE TABLE IF NOT EXISTS subscription_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL,
+    status_code INTEGER,
+    error TEXT,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_subscription_delivery_attempt_delivery
+        FOREIGN KEY(delivery_id)
+        REFERENCES subscription_deliveries(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_subscription_delivery_attempts_delivery_id ON subscription_delivery_attempts(delivery_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS notification_templates;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TYPE IF EXISTS notification_status;
+DROP TYPE IF EXISTS notification_mode;
+DROP TYPE IF EXISTS notification_channel;
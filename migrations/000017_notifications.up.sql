@@ -0,0 +1,54 @@
+CREATE TYPE notification_channel AS ENUM ('EMAIL', 'SLACK', 'WEBHOOK');
+CREATE TYPE notification_mode AS ENUM ('IMMEDIATE', 'DIGEST');
+CREATE TYPE notification_status AS ENUM ('PENDING', 'SENT', 'FAILED');
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    channel notification_channel NOT NULL,
+    address TEXT NOT NULL,
+    mode notification_mode NOT NULL DEFAULT 'IMMEDIATE',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    quiet_hours_start VARCHAR(5),
+    quiet_hours_end VARCHAR(5),
+    digest_time VARCHAR(5) NOT NULL DEFAULT '09:00',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_notification_preference_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS notification_templates (
+    kind VARCHAR(64) PRIMARY KEY,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    updated_by VARCHAR(255),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(64) NOT NULL,
+    pull_request_id VARCHAR(255) NOT NULL,
+    channel notification_channel NOT NULL,
+    address TEXT NOT NULL,
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status notification_status NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    deliver_after TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_notification_event_user UNIQUE (event_id, user_id),
+    CONSTRAINT fk_notification_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_notifications_due ON notifications(deliver_after) WHERE status = 'PENDING';
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS digest_subscriptions;
+DROP TYPE IF EXISTS digest_format;
+DROP TYPE IF EXISTS digest_kind;
@@ -0,0 +1,18 @@
+CREATE TYPE digest_kind AS ENUM ('DAILY', 'WEEKLY');
+CREATE TYPE digest_format AS ENUM ('MARKDOWN', 'HTML', 'TEXT');
+
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id VARCHAR(255) NOT NULL,
+    kind digest_kind NOT NULL,
+    format digest_format NOT NULL DEFAULT 'MARKDOWN',
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_sent_at TIMESTAMPTZ,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, kind),
+    CONSTRAINT fk_digest_subscription_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_digest_subscriptions_next_run_at ON digest_subscriptions(next_run_at);
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255),
+    team_id INT,
+    url TEXT,
+    categories TEXT[] NOT NULL DEFAULT '{}',
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    next_sync_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_synced_at TIMESTAMPTZ,
+    last_error TEXT,
+    CONSTRAINT calendar_feed_owner CHECK ((user_id IS NULL) <> (team_id IS NULL)),
+    CONSTRAINT fk_calendar_feed_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_calendar_feed_team
+        FOREIGN KEY(team_id)
+        REFERENCES teams(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_calendar_feeds_next_sync_at ON calendar_feeds(next_sync_at) WHERE url IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS absences (
+    feed_id BIGINT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    uid TEXT NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (feed_id, user_id, uid, starts_at),
+    CONSTRAINT fk_absence_feed
+        FOREIGN KEY(feed_id)
+        REFERENCES calendar_feeds(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_absence_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_absences_user_period ON absences(user_id, starts_at, ends_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS issue_tracker_jobs;
+DROP TABLE IF EXISTS pull_request_issues;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS pull_request_issues (
+    pull_request_id VARCHAR(255) NOT NULL,
+    issue_key VARCHAR(255) NOT NULL,
+    PRIMARY KEY (pull_request_id, issue_key),
+    CONSTRAINT fk_pull_request_issue_pull_request
+        FOREIGN KEY(pull_request_id)
+        REFERENCES pull_requests(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_pull_request_issues_issue_key ON pull_request_issues(issue_key);
+
+-- Covers only the default Jira-style pattern; with ISSUE_KEY_PATTERNS set,
+-- run `server backfill-issues` to relink existing PRs.
+INSERT INTO pull_request_issues (pull_request_id, issue_key)
+SELECT DISTINCT p.id, m[1]
+FROM pull_requests AS p, regexp_matches(p.name, '\m([A-Z][A-Z0-9_]+-[1-9][0-9]*)\M', 'g') AS m
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS issue_tracker_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    issue_key VARCHAR(255) NOT NULL,
+    pull_request_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, issue_key),
+    CONSTRAINT fk_issue_tracker_job_pull_request
+        FOREIGN KEY(pull_request_id)
+        REFERENCES pull_requests(id)
+        ON DELETE CASCADE
+);
+CREATE INDEX idx_issue_tracker_jobs_next_attempt_at ON issue_tracker_jobs(next_attempt_at);
@@ -0,0 +1,2 @@
+ALTER TABLE subscription_deliveries DROP CONSTRAINT IF EXISTS uq_subscription_delivery_event;
+ALTER TABLE subscription_deliveries DROP COLUMN IF EXISTS event_id;
@@ -0,0 +1,3 @@
+ALTER TABLE subscription_deliveries ADD COLUMN event_id BIGINT;
+ALTER TABLE subscription_deliveries
+    ADD CONSTRAINT uq_subscription_delivery_event UNIQUE (subscription_id, event_id);
@@ -0,0 +1,2 @@
+DELETE FROM signing_keys WHERE wrapped_key IS NOT NULL;
+ALTER TABLE signing_keys DROP COLUMN wrapped_key;
@@ -0,0 +1 @@
+ALTER TABLE signing_keys ADD COLUMN wrapped_key BYTEA;
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN on_vacation BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+ALTER TABLE outbox ADD COLUMN dead_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox(aggregate_id, id) WHERE published_at IS NULL AND dead_at IS NULL;
@@ -14,6 +14,24 @@ type PullRequestRepository struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields: ctx, id, actorID
+func (_m *PullRequestRepository) Close(ctx context.Context, id string, actorID string) error {
+	ret := _m.Called(ctx, id, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, pr
 func (_m *PullRequestRepository) Create(ctx context.Context, pr model.PullRequest) error {
 	ret := _m.Called(ctx, pr)
@@ -32,6 +50,36 @@ func (_m *PullRequestRepository) Create(ctx context.Context, pr model.PullReques
 	return r0
 }
 
+// GetByAuthorID provides a mock function with given fields: ctx, authorID
+func (_m *PullRequestRepository) GetByAuthorID(ctx context.Context, authorID string) ([]model.PullRequest, error) {
+	ret := _m.Called(ctx, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByAuthorID")
+	}
+
+	var r0 []model.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.PullRequest, error)); ok {
+		return rf(ctx, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.PullRequest); ok {
+		r0 = rf(ctx, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *PullRequestRepository) GetByID(ctx context.Context, id string) (*model.PullRequest, error) {
 	ret := _m.Called(ctx, id)
@@ -62,6 +110,36 @@ func (_m *PullRequestRepository) GetByID(ctx context.Context, id string) (*model
 	return r0, r1
 }
 
+// GetByIssueKey provides a mock function with given fields: ctx, issueKey
+func (_m *PullRequestRepository) GetByIssueKey(ctx context.Context, issueKey string) ([]model.PullRequest, error) {
+	ret := _m.Called(ctx, issueKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIssueKey")
+	}
+
+	var r0 []model.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.PullRequest, error)); ok {
+		return rf(ctx, issueKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.PullRequest); ok {
+		r0 = rf(ctx, issueKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, issueKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByReviewerID provides a mock function with given fields: ctx, reviewerID
 func (_m *PullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID string) ([]model.PullRequest, error) {
 	ret := _m.Called(ctx, reviewerID)
@@ -92,17 +170,77 @@ func (_m *PullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID
 	return r0, r1
 }
 
-// Merge provides a mock function with given fields: ctx, id
-func (_m *PullRequestRepository) Merge(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// ListNames provides a mock function with given fields: ctx
+func (_m *PullRequestRepository) ListNames(ctx context.Context) (map[string]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNames")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOpenIDs provides a mock function with given fields: ctx
+func (_m *PullRequestRepository) ListOpenIDs(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpenIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: ctx, id, actorID
+func (_m *PullRequestRepository) Merge(ctx context.Context, id string, actorID string) error {
+	ret := _m.Called(ctx, id, actorID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Merge")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, actorID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,17 +248,81 @@ func (_m *PullRequestRepository) Merge(ctx context.Context, id string) error {
 	return r0
 }
 
-// ReassignReviewer provides a mock function with given fields: ctx, prID, oldReviewerID, newReviewerID
-func (_m *PullRequestRepository) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) error {
-	ret := _m.Called(ctx, prID, oldReviewerID, newReviewerID)
+// ReassignReviewer provides a mock function with given fields: ctx, prID, oldReviewerID, newReviewerID, actorID
+func (_m *PullRequestRepository) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string, actorID string) error {
+	ret := _m.Called(ctx, prID, oldReviewerID, newReviewerID, actorID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReassignReviewer")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, prID, oldReviewerID, newReviewerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, prID, oldReviewerID, newReviewerID, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reopen provides a mock function with given fields: ctx, id, actorID
+func (_m *PullRequestRepository) Reopen(ctx context.Context, id string, actorID string) error {
+	ret := _m.Called(ctx, id, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceIssueKeys provides a mock function with given fields: ctx, issueKeys
+func (_m *PullRequestRepository) ReplaceIssueKeys(ctx context.Context, issueKeys map[string][]string) (int, error) {
+	ret := _m.Called(ctx, issueKeys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceIssueKeys")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string][]string) (int, error)); ok {
+		return rf(ctx, issueKeys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string][]string) int); ok {
+		r0 = rf(ctx, issueKeys)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, map[string][]string) error); ok {
+		r1 = rf(ctx, issueKeys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetVerdict provides a mock function with given fields: ctx, prID, reviewerID, verdict, actorID
+func (_m *PullRequestRepository) SetVerdict(ctx context.Context, prID string, reviewerID string, verdict model.Verdict, actorID string) error {
+	ret := _m.Called(ctx, prID, reviewerID, verdict, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetVerdict")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.Verdict, string) error); ok {
+		r0 = rf(ctx, prID, reviewerID, verdict, actorID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -14,24 +14,54 @@ type TeamRepository struct {
 	mock.Mock
 }
 
+// AddMember provides a mock function with given fields: ctx, teamName, member, actorID
+func (_m *TeamRepository) AddMember(ctx context.Context, teamName string, member model.User, actorID string) (*model.FullUserInfo, error) {
+	ret := _m.Called(ctx, teamName, member, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 *model.FullUserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.User, string) (*model.FullUserInfo, error)); ok {
+		return rf(ctx, teamName, member, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.User, string) *model.FullUserInfo); ok {
+		r0 = rf(ctx, teamName, member, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FullUserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.User, string) error); ok {
+		r1 = rf(ctx, teamName, member, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddTeamWithMembers provides a mock function with given fields: ctx, team, members
-func (_m *TeamRepository) AddTeamWithMembers(ctx context.Context, team model.Team, members []model.User) (*model.Team, error) {
+func (_m *TeamRepository) AddTeamWithMembers(ctx context.Context, team model.Team, members []model.User) (*model.TeamUpsert, error) {
 	ret := _m.Called(ctx, team, members)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddTeamWithMembers")
 	}
 
-	var r0 *model.Team
+	var r0 *model.TeamUpsert
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, model.Team, []model.User) (*model.Team, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.Team, []model.User) (*model.TeamUpsert, error)); ok {
 		return rf(ctx, team, members)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.Team, []model.User) *model.Team); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.Team, []model.User) *model.TeamUpsert); ok {
 		r0 = rf(ctx, team, members)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Team)
+			r0 = ret.Get(0).(*model.TeamUpsert)
 		}
 	}
 
@@ -44,6 +74,169 @@ func (_m *TeamRepository) AddTeamWithMembers(ctx context.Context, team model.Tea
 	return r0, r1
 }
 
+// ApplyImport provides a mock function with given fields: ctx, plan, actorID
+func (_m *TeamRepository) ApplyImport(ctx context.Context, plan model.ImportPlan, actorID string) error {
+	ret := _m.Called(ctx, plan, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ImportPlan, string) error); ok {
+		r0 = rf(ctx, plan, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApplyMembershipChange provides a mock function with given fields: ctx, plan, actorID
+func (_m *TeamRepository) ApplyMembershipChange(ctx context.Context, plan model.MembershipPlan, actorID string) (*model.FullUserInfo, error) {
+	ret := _m.Called(ctx, plan, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyMembershipChange")
+	}
+
+	var r0 *model.FullUserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.MembershipPlan, string) (*model.FullUserInfo, error)); ok {
+		return rf(ctx, plan, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.MembershipPlan, string) *model.FullUserInfo); ok {
+		r0 = rf(ctx, plan, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FullUserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.MembershipPlan, string) error); ok {
+		r1 = rf(ctx, plan, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Count provides a mock function with given fields: ctx, filter
+func (_m *TeamRepository) Count(ctx context.Context, filter model.TeamFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TeamFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, teamID, actorID
+func (_m *TeamRepository) Delete(ctx context.Context, teamID int, actorID string) error {
+	ret := _m.Called(ctx, teamID, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, teamID, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAncestors provides a mock function with given fields: ctx, teamID
+func (_m *TeamRepository) GetAncestors(ctx context.Context, teamID int) ([]model.Team, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAncestors")
+	}
+
+	var r0 []model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]model.Team, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.Team); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *TeamRepository) GetByID(ctx context.Context, id int) (*model.Team, []model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.Team
+	var r1 []model.User
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*model.Team, []model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Team); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) []model.User); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetByName provides a mock function with given fields: ctx, name
 func (_m *TeamRepository) GetByName(ctx context.Context, name string) (*model.Team, []model.User, error) {
 	ret := _m.Called(ctx, name)
@@ -83,6 +276,246 @@ func (_m *TeamRepository) GetByName(ctx context.Context, name string) (*model.Te
 	return r0, r1, r2
 }
 
+// GetChildren provides a mock function with given fields: ctx, teamID
+func (_m *TeamRepository) GetChildren(ctx context.Context, teamID int) ([]model.Team, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChildren")
+	}
+
+	var r0 []model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]model.Team, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.Team); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeletionReport provides a mock function with given fields: ctx, team
+func (_m *TeamRepository) GetDeletionReport(ctx context.Context, team model.Team) (*model.TeamDeletionReport, error) {
+	ret := _m.Called(ctx, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletionReport")
+	}
+
+	var r0 *model.TeamDeletionReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Team) (*model.TeamDeletionReport, error)); ok {
+		return rf(ctx, team)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Team) *model.TeamDeletionReport); ok {
+		r0 = rf(ctx, team)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamDeletionReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Team) error); ok {
+		r1 = rf(ctx, team)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *TeamRepository) List(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.TeamSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter) ([]model.TeamSummary, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter) []model.TeamSummary); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TeamFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rename provides a mock function with given fields: ctx, teamName, newName, actorID
+func (_m *TeamRepository) Rename(ctx context.Context, teamName string, newName string, actorID string) (*model.Team, error) {
+	ret := _m.Called(ctx, teamName, newName, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 *model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.Team, error)); ok {
+		return rf(ctx, teamName, newName, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Team); ok {
+		r0 = rf(ctx, teamName, newName, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, teamName, newName, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetArchived provides a mock function with given fields: ctx, teamName, archived, actorID
+func (_m *TeamRepository) SetArchived(ctx context.Context, teamName string, archived bool, actorID string) (*model.Team, error) {
+	ret := _m.Called(ctx, teamName, archived, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArchived")
+	}
+
+	var r0 *model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string) (*model.Team, error)); ok {
+		return rf(ctx, teamName, archived, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string) *model.Team); ok {
+		r0 = rf(ctx, teamName, archived, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, string) error); ok {
+		r1 = rf(ctx, teamName, archived, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetParent provides a mock function with given fields: ctx, teamName, parentName, actorID
+func (_m *TeamRepository) SetParent(ctx context.Context, teamName string, parentName string, actorID string) (*model.Team, error) {
+	ret := _m.Called(ctx, teamName, parentName, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParent")
+	}
+
+	var r0 *model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.Team, error)); ok {
+		return rf(ctx, teamName, parentName, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Team); ok {
+		r0 = rf(ctx, teamName, parentName, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, teamName, parentName, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPolicy provides a mock function with given fields: ctx, teamName, policy, actorID
+func (_m *TeamRepository) SetPolicy(ctx context.Context, teamName string, policy model.AssignmentPolicy, actorID string) (*model.Team, error) {
+	ret := _m.Called(ctx, teamName, policy, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPolicy")
+	}
+
+	var r0 *model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AssignmentPolicy, string) (*model.Team, error)); ok {
+		return rf(ctx, teamName, policy, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AssignmentPolicy, string) *model.Team); ok {
+		r0 = rf(ctx, teamName, policy, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.AssignmentPolicy, string) error); ok {
+		r1 = rf(ctx, teamName, policy, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSecondaryMember provides a mock function with given fields: ctx, teamName, userID, reviewable, actorID
+func (_m *TeamRepository) SetSecondaryMember(ctx context.Context, teamName string, userID string, reviewable bool, actorID string) (*model.TeamMembership, error) {
+	ret := _m.Called(ctx, teamName, userID, reviewable, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSecondaryMember")
+	}
+
+	var r0 *model.TeamMembership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, string) (*model.TeamMembership, error)); ok {
+		return rf(ctx, teamName, userID, reviewable, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, string) *model.TeamMembership); ok {
+		r0 = rf(ctx, teamName, userID, reviewable, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamMembership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, string) error); ok {
+		r1 = rf(ctx, teamName, userID, reviewable, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTeamRepository creates a new instance of TeamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamRepository(t interface {
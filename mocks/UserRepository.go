@@ -7,6 +7,8 @@ import (
 
 	model "github.com/DeadlyParkour777/pr-service/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -14,6 +16,64 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: ctx, filter
+func (_m *UserRepository) Count(ctx context.Context, filter model.UserFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, user
+func (_m *UserRepository) Create(ctx context.Context, user model.User) (*model.FullUserInfo, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.FullUserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) (*model.FullUserInfo, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) *model.FullUserInfo); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FullUserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetActiveTeamMembers provides a mock function with given fields: ctx, teamID, excludeUserID
 func (_m *UserRepository) GetActiveTeamMembers(ctx context.Context, teamID int, excludeUserID string) ([]model.User, error) {
 	ret := _m.Called(ctx, teamID, excludeUserID)
@@ -74,9 +134,175 @@ func (_m *UserRepository) GetByID(ctx context.Context, id string) (*model.FullUs
 	return r0, r1
 }
 
-// SetIsActive provides a mock function with given fields: ctx, id, isActive
-func (_m *UserRepository) SetIsActive(ctx context.Context, id string, isActive bool) (*model.FullUserInfo, error) {
-	ret := _m.Called(ctx, id, isActive)
+// GetCredentials provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetCredentials(ctx context.Context, id string) (*model.Credentials, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCredentials")
+	}
+
+	var r0 *model.Credentials
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Credentials, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Credentials); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Credentials)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberships provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetMemberships(ctx context.Context, id string) ([]model.TeamMembership, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMemberships")
+	}
+
+	var r0 []model.TeamMembership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.TeamMembership, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.TeamMembership); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamMembership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenReviewCount provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetOpenReviewCount(ctx context.Context, id string) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenReviewCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *UserRepository) List(ctx context.Context, filter model.UserFilter) ([]model.FullUserInfo, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.FullUserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) ([]model.FullUserInfo, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) []model.FullUserInfo); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FullUserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordLoginFailure provides a mock function with given fields: ctx, id, maxAttempts, lockout
+func (_m *UserRepository) RecordLoginFailure(ctx context.Context, id string, maxAttempts int, lockout time.Duration) (*model.Credentials, error) {
+	ret := _m.Called(ctx, id, maxAttempts, lockout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordLoginFailure")
+	}
+
+	var r0 *model.Credentials
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) (*model.Credentials, error)); ok {
+		return rf(ctx, id, maxAttempts, lockout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) *model.Credentials); ok {
+		r0 = rf(ctx, id, maxAttempts, lockout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Credentials)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Duration) error); ok {
+		r1 = rf(ctx, id, maxAttempts, lockout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetLoginFailures provides a mock function with given fields: ctx, id
+func (_m *UserRepository) ResetLoginFailures(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLoginFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetIsActive provides a mock function with given fields: ctx, id, isActive, actorID
+func (_m *UserRepository) SetIsActive(ctx context.Context, id string, isActive bool, actorID string) (*model.FullUserInfo, error) {
+	ret := _m.Called(ctx, id, isActive, actorID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetIsActive")
@@ -84,19 +310,19 @@ func (_m *UserRepository) SetIsActive(ctx context.Context, id string, isActive b
 
 	var r0 *model.FullUserInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.FullUserInfo, error)); ok {
-		return rf(ctx, id, isActive)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string) (*model.FullUserInfo, error)); ok {
+		return rf(ctx, id, isActive, actorID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.FullUserInfo); ok {
-		r0 = rf(ctx, id, isActive)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string) *model.FullUserInfo); ok {
+		r0 = rf(ctx, id, isActive, actorID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.FullUserInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-		r1 = rf(ctx, id, isActive)
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, string) error); ok {
+		r1 = rf(ctx, id, isActive, actorID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,6 +330,90 @@ func (_m *UserRepository) SetIsActive(ctx context.Context, id string, isActive b
 	return r0, r1
 }
 
+// SetPasswordHash provides a mock function with given fields: ctx, id, passwordHash, actorID
+func (_m *UserRepository) SetPasswordHash(ctx context.Context, id string, passwordHash string, actorID string) error {
+	ret := _m.Called(ctx, id, passwordHash, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, id, passwordHash, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetRole provides a mock function with given fields: ctx, id, role, actorID
+func (_m *UserRepository) SetRole(ctx context.Context, id string, role model.Role, actorID string) error {
+	ret := _m.Called(ctx, id, role, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Role, string) error); ok {
+		r0 = rf(ctx, id, role, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUsername provides a mock function with given fields: ctx, id, username
+func (_m *UserRepository) SetUsername(ctx context.Context, id string, username string) (*model.FullUserInfo, error) {
+	ret := _m.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUsername")
+	}
+
+	var r0 *model.FullUserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.FullUserInfo, error)); ok {
+		return rf(ctx, id, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.FullUserInfo); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FullUserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetVacation provides a mock function with given fields: ctx, id, onVacation
+func (_m *UserRepository) SetVacation(ctx context.Context, id string, onVacation bool) error {
+	ret := _m.Called(ctx, id, onVacation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetVacation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, id, onVacation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {